@@ -0,0 +1,160 @@
+package cityaq
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"math"
+	"sort"
+
+	rpc "github.com/ctessum/cityaq/cityaqrpc"
+	"github.com/ctessum/geom"
+)
+
+// sourceTypeBlend is a weighted mix of source types whose spatial
+// allocations are combined to allocate emissions.
+type sourceTypeBlend struct {
+	// components holds the source types in the mix, sorted by name,
+	// with weights that add up to one.
+	components []blendComponent
+
+	// id uniquely identifies the blend.
+	id string
+
+	// req is the request that the blend was created from.
+	req []*rpc.SourceTypeWeight
+}
+
+// blendComponent is a source type in a sourceTypeBlend.
+type blendComponent struct {
+	sourceType string
+	weight     float64
+}
+
+// requestSourceTypeBlend checks the given source type mix and converts it
+// to a sourceTypeBlend. It returns nil if mix is empty.
+func requestSourceTypeBlend(mix []*rpc.SourceTypeWeight) (*sourceTypeBlend, error) {
+	if len(mix) == 0 {
+		return nil, nil
+	}
+	o := &sourceTypeBlend{
+		components: make([]blendComponent, len(mix)),
+		req:        mix,
+	}
+	var sum float64
+	names := make(map[string]bool)
+	for i, w := range mix {
+		if w == nil || w.SourceType == "" {
+			return nil, fmt.Errorf("cityaq: source type mix entry %d is missing a source type", i)
+		}
+		if names[w.SourceType] {
+			return nil, fmt.Errorf("cityaq: source type %s is included more than once in the source type mix", w.SourceType)
+		}
+		names[w.SourceType] = true
+		if invalidFloat(w.Weight) || w.Weight <= 0 {
+			return nil, fmt.Errorf("cityaq: invalid weight %g for source type %s; it must be greater than zero", w.Weight, w.SourceType)
+		}
+		o.components[i] = blendComponent{sourceType: w.SourceType, weight: w.Weight}
+		sum += w.Weight
+	}
+	sort.Slice(o.components, func(i, j int) bool { return o.components[i].sourceType < o.components[j].sourceType })
+
+	h := sha256.New()
+	for i := range o.components {
+		o.components[i].weight /= sum
+		// Round the weights so that mixes that only differ
+		// by floating point error have the same ID.
+		fmt.Fprintf(h, "%q=%.6g\n", o.components[i].sourceType, o.components[i].weight)
+	}
+	o.id = hex.EncodeToString(h.Sum(nil)[:8])
+	return o, nil
+}
+
+// name returns the name of the receiver when it is used as a source type.
+func (b *sourceTypeBlend) name() string {
+	return "blend-" + b.id
+}
+
+// blendSourceType returns the source type that emissions allocated with
+// the given blend are treated as. All of the source types in the blend
+// must have the same allocation domain and stack parameters, and the
+// emissions grid uses the finest resolution and cell size of any of them.
+// The speciation of the blend is the weighted average of the speciation
+// of the source types, if all of them have speciation profiles.
+func (c *CityAQ) blendSourceType(b *sourceTypeBlend) (*sourceType, error) {
+	var o *sourceType
+	speciation := make(map[string]float64)
+	for _, comp := range b.components {
+		st, err := c.sourceType(comp.sourceType)
+		if err != nil {
+			return nil, err
+		}
+		if len(st.Speciation) == 0 {
+			speciation = nil
+		} else if speciation != nil {
+			for pol, frac := range st.Speciation {
+				speciation[pol] += frac * comp.weight
+			}
+		}
+		if o == nil {
+			o = &sourceType{
+				Name:             b.name(),
+				Label:            "Blend",
+				Domain:           st.Domain,
+				Resolution:       st.Resolution,
+				CellSize:         st.CellSize,
+				StackHeight:      st.StackHeight,
+				StackDiameter:    st.StackDiameter,
+				StackTemperature: st.StackTemperature,
+				StackVelocity:    st.StackVelocity,
+			}
+			continue
+		}
+		if st.Domain != o.Domain {
+			return nil, fmt.Errorf("cityaq: source types in a mix must have the same allocation domain, but %s is %s and %s is %s",
+				b.components[0].sourceType, o.Domain, st.Name, st.Domain)
+		}
+		if st.StackHeight != o.StackHeight || st.StackDiameter != o.StackDiameter ||
+			st.StackTemperature != o.StackTemperature || st.StackVelocity != o.StackVelocity {
+			return nil, fmt.Errorf("cityaq: source types in a mix must have the same stack parameters, but %s and %s differ",
+				b.components[0].sourceType, st.Name)
+		}
+		o.Resolution = math.Min(o.Resolution, st.Resolution)
+		o.CellSize = math.Min(o.CellSize, st.CellSize)
+	}
+	if len(speciation) > 0 {
+		o.Speciation = speciation
+	}
+	return o, nil
+}
+
+// blendEmissions allocates the given amounts of emissions within polygon
+// to the given grid using each of the source types in the given blend,
+// and combines them in proportion to their weights. gridName identifies
+// the grid for caching. If areaFallback is true, the emissions of source
+// types whose surrogates are zero throughout polygon are allocated in
+// proportion to area, and otherwise an error is returned for them. It
+// returns the gridded emissions of each pollutant and the source types
+// whose emissions were allocated in proportion to area.
+func (c *CityAQ) blendEmissions(b *sourceTypeBlend, polygon geom.Polygonal, gridName string, grid []geom.Polygonal, amounts []pollutantAmount, areaFallback bool) (map[rpc.Emission][]float64, []string, error) {
+	o := make(map[rpc.Emission][]float64)
+	for _, a := range amounts {
+		o[a.pollutant] = make([]float64, len(grid))
+	}
+	var fallbacks []string
+	for _, comp := range b.components {
+		e, fellBack, err := c.surrogateOrAreaEmissions(polygon, comp.sourceType, gridName, grid, amounts, areaFallback)
+		if err != nil {
+			return nil, nil, err
+		}
+		if fellBack {
+			fallbacks = append(fallbacks, comp.sourceType)
+		}
+		for pol, v := range e {
+			for i, vi := range v {
+				o[pol][i] += vi * comp.weight
+			}
+		}
+	}
+	return o, fallbacks, nil
+}
@@ -0,0 +1,141 @@
+package cityaq
+
+import (
+	"context"
+	"testing"
+
+	rpc "github.com/ctessum/cityaq/cityaqrpc"
+	"github.com/spatialmodel/inmap/emissions/aep/aeputil"
+	"gonum.org/v1/gonum/floats"
+)
+
+func TestRequestSourceTypeBlend(t *testing.T) {
+	b1, err := requestSourceTypeBlend([]*rpc.SourceTypeWeight{
+		{SourceType: "roadways_primary", Weight: 50},
+		{SourceType: "residential", Weight: 30},
+		{SourceType: "commercial", Weight: 20},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []blendComponent{
+		{sourceType: "commercial", weight: 0.2},
+		{sourceType: "residential", weight: 0.3},
+		{sourceType: "roadways_primary", weight: 0.5},
+	}
+	for i, comp := range b1.components {
+		if comp.sourceType != want[i].sourceType || !similar(comp.weight, want[i].weight, 1e-10) {
+			t.Errorf("component %d: have %+v, want %+v", i, comp, want[i])
+		}
+	}
+	b2, err := requestSourceTypeBlend([]*rpc.SourceTypeWeight{
+		{SourceType: "commercial", Weight: 0.2},
+		{SourceType: "roadways_primary", Weight: 0.5},
+		{SourceType: "residential", Weight: 0.3},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if b1.name() != b2.name() {
+		t.Errorf("equivalent blends have different names: %s != %s", b1.name(), b2.name())
+	}
+
+	if b, err := requestSourceTypeBlend(nil); b != nil || err != nil {
+		t.Errorf("empty mix: got %v, %v", b, err)
+	}
+	for name, mix := range map[string][]*rpc.SourceTypeWeight{
+		"no source type":  {{Weight: 1}},
+		"zero weight":     {{SourceType: "roadways", Weight: 0}},
+		"negative weight": {{SourceType: "roadways", Weight: 1}, {SourceType: "railways", Weight: -1}},
+		"duplicate":       {{SourceType: "roadways", Weight: 1}, {SourceType: "roadways", Weight: 1}},
+		"nil":             {nil},
+	} {
+		if _, err := requestSourceTypeBlend(mix); err == nil {
+			t.Errorf("%s: expected an error", name)
+		}
+	}
+}
+
+func TestCityAQ_blendSourceType(t *testing.T) {
+	c := &CityAQ{}
+	b, err := requestSourceTypeBlend([]*rpc.SourceTypeWeight{
+		{SourceType: "roadways", Weight: 1},
+		{SourceType: "residential", Weight: 1},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	st, err := c.blendSourceType(b)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if st.Name != b.name() || st.egugrid() || st.Resolution != defaultResolution[cityDomain] {
+		t.Errorf("wrong blended source type: %+v", st)
+	}
+
+	b, err = requestSourceTypeBlend([]*rpc.SourceTypeWeight{
+		{SourceType: "roadways", Weight: 1},
+		{SourceType: "electric_gen_egugrid", Weight: 1},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := c.blendSourceType(b); err == nil {
+		t.Error("expected an error for mixed allocation domains")
+	}
+
+	b, err = requestSourceTypeBlend([]*rpc.SourceTypeWeight{
+		{SourceType: "roadways", Weight: 1},
+		{SourceType: "roadway", Weight: 1},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := c.blendSourceType(b); err == nil {
+		t.Error("expected an error for an unknown source type")
+	}
+}
+
+func TestCityAQ_griddedEmissionsBlend(t *testing.T) {
+	c := &CityAQ{
+		CityGeomDir: "testdata/cities",
+		SpatialConfig: aeputil.SpatialConfig{
+			SrgSpecOSM:    "testdata/srgspec_osm.json",
+			SCCExactMatch: true,
+			GridRef:       []string{"testdata/gridref.txt"},
+			OutputSR:      "+proj=longlat",
+			InputSR:       "+proj=longlat",
+		},
+	}
+	emis := func(req *rpc.GriddedEmissionsRequest) []float64 {
+		req.CityName = "Accra Metropolitan"
+		req.Emission = rpc.Emission_PM2_5
+		e, err := c.GriddedEmissions(context.Background(), req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return e.Emissions
+	}
+	roadways := emis(&rpc.GriddedEmissionsRequest{SourceType: "roadways"})
+	residential := emis(&rpc.GriddedEmissionsRequest{SourceType: "residential"})
+	blend := emis(&rpc.GriddedEmissionsRequest{
+		SourceType: "ignored",
+		SourceTypeMix: []*rpc.SourceTypeWeight{
+			{SourceType: "roadways", Weight: 3},
+			{SourceType: "residential", Weight: 1},
+		},
+	})
+	if len(blend) != len(roadways) {
+		t.Fatalf("grid size: have %d, want %d", len(blend), len(roadways))
+	}
+	if sum := floats.Sum(blend); !similar(sum, 1.0e6, 1e-8) {
+		t.Errorf("total emissions: have %g, want %g", sum, 1.0e6)
+	}
+	for i, v := range blend {
+		want := 0.75*roadways[i] + 0.25*residential[i]
+		if !similar(v, want, 1e-8) {
+			t.Errorf("cell %d: have %g, want %g", i, v, want)
+			break
+		}
+	}
+}
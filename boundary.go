@@ -0,0 +1,364 @@
+package cityaq
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/ctessum/geom"
+	"github.com/ctessum/geom/encoding/geojson"
+	"github.com/ctessum/geom/encoding/shp"
+	"github.com/ctessum/geom/proj"
+)
+
+// boundaryFormat reads city boundaries from files in a particular format.
+type boundaryFormat struct {
+	// properties returns the attributes of the first
+	// feature in the file that has attributes.
+	properties func(path string) (map[string]interface{}, error)
+
+	// geometry returns the combined boundaries of all of the features
+	// in the file, with longitude and latitude coordinates. Each part
+	// of a multipart boundary is a separate polygon.
+	geometry func(path string) (geom.MultiPolygon, error)
+}
+
+// boundaryFormats holds the supported boundary file formats,
+// keyed by file extension. Support for additional formats can
+// be added by adding them here.
+var boundaryFormats = map[string]boundaryFormat{
+	".geojson": {properties: geojsonProperties, geometry: geojsonGeometry},
+	".shp":     {properties: shapefileProperties, geometry: shapefileGeometry},
+	".gpkg":    {properties: gpkgProperties, geometry: gpkgGeometry},
+	".kml":     {properties: kmlProperties, geometry: kmlGeometry},
+}
+
+// boundaryFormatOf returns the format of the given boundary file, and
+// whether the format is supported.
+func boundaryFormatOf(path string) (boundaryFormat, bool) {
+	f, ok := boundaryFormats[strings.ToLower(filepath.Ext(path))]
+	return f, ok
+}
+
+// boundaryProperties returns the attributes of the first feature
+// in the given boundary file that has attributes.
+func boundaryProperties(path string) (map[string]interface{}, error) {
+	f, ok := boundaryFormatOf(path)
+	if !ok {
+		return nil, fmt.Errorf("file %s: unsupported boundary file format", path)
+	}
+	return f.properties(path)
+}
+
+// boundaryVersion returns a hash of the contents of the given boundary
+// file, including the projection file of a shapefile, which changes
+// whenever the boundary changes.
+func boundaryVersion(path string) (string, error) {
+	files := []string{path}
+	if strings.ToLower(filepath.Ext(path)) == ".shp" {
+		files = append(files, strings.TrimSuffix(path, filepath.Ext(path))+".prj")
+	}
+	h := sha256.New()
+	for i, file := range files {
+		f, err := os.Open(file)
+		if os.IsNotExist(err) && i > 0 {
+			continue
+		} else if err != nil {
+			return "", fmt.Errorf("file %s: %v", path, err)
+		}
+		_, err = io.Copy(h, f)
+		f.Close()
+		if err != nil {
+			return "", fmt.Errorf("file %s: %v", path, err)
+		}
+	}
+	return hex.EncodeToString(h.Sum(nil)[:4]), nil
+}
+
+// cityGeometry returns the boundary of the requested city. Cities with
+// boundaries that are made up of several parts, such as islands or
+// exclaves, have one polygon for each part.
+func (c *CityAQ) cityGeometry(cityName string) (geom.MultiPolygon, error) {
+	path, err := c.catalog().path(cityName)
+	if err != nil {
+		return nil, err
+	}
+	f, ok := boundaryFormatOf(path)
+	if !ok {
+		return nil, fmt.Errorf("cityaq: file %s: unsupported boundary file format", path)
+	}
+	return f.geometry(path)
+}
+
+// appendPolygons appends the polygons in g to polys. Other types of
+// geometry, such as label points, are ignored.
+func appendPolygons(polys geom.MultiPolygon, g geom.Geom) geom.MultiPolygon {
+	switch t := g.(type) {
+	case geom.Polygon:
+		polys = append(polys, t)
+	case geom.MultiPolygon:
+		polys = append(polys, t...)
+	}
+	return polys
+}
+
+// splitRings splits a polygon whose rings may belong to several
+// separate parts, as in a shapefile polygon record, into one polygon
+// per part. Rings that are inside an odd number of other rings are
+// treated as holes in the smallest ring that contains them; the
+// other rings are treated as the outer rings of parts.
+func splitRings(p geom.Polygon) geom.MultiPolygon {
+	depth := make([]int, len(p))
+	for i, ring := range p {
+		if len(ring) == 0 {
+			continue
+		}
+		for k, other := range p {
+			if k != i && pointInRing(ring[0], other) {
+				depth[i]++
+			}
+		}
+	}
+	var o geom.MultiPolygon
+	part := make(map[int]int) // Index of part for each outer ring.
+	for i, ring := range p {
+		if len(ring) > 0 && depth[i]%2 == 0 {
+			part[i] = len(o)
+			o = append(o, geom.Polygon{ring})
+		}
+	}
+	for i, ring := range p {
+		if len(ring) == 0 || depth[i]%2 == 0 {
+			continue
+		}
+		// The outer ring that this hole belongs to is the
+		// one that contains it at one less depth.
+		for k, outer := range p {
+			if pi, ok := part[k]; ok && depth[k] == depth[i]-1 && pointInRing(ring[0], outer) {
+				o[pi] = append(o[pi], ring)
+				break
+			}
+		}
+	}
+	return o
+}
+
+// lonLatSR is the spatial reference that boundaries are converted to.
+var lonLatSR *proj.SR
+
+func init() {
+	var err error
+	lonLatSR, err = proj.Parse("+proj=longlat +datum=WGS84 +no_defs")
+	if err != nil {
+		panic(err)
+	}
+}
+
+// toLonLat converts the given polygons from spatial reference sr to
+// longitude and latitude. If sr is nil, the polygons are assumed to
+// already have longitude and latitude coordinates.
+func toLonLat(p geom.MultiPolygon, sr *proj.SR) (geom.MultiPolygon, error) {
+	if sr == nil || len(p) == 0 {
+		return p, nil
+	}
+	ct, err := sr.NewTransform(lonLatSR)
+	if err != nil {
+		return nil, fmt.Errorf("reprojecting boundary: %v", err)
+	}
+	g, err := p.Transform(ct)
+	if err != nil {
+		return nil, fmt.Errorf("reprojecting boundary: %v", err)
+	}
+	return g.(geom.MultiPolygon), nil
+}
+
+// epsgCode matches EPSG coordinate reference system names, for example
+// "EPSG:32630" or "urn:ogc:def:crs:EPSG::32630".
+var epsgCode = regexp.MustCompile(`(?i)^(?:urn:ogc:def:crs:)?EPSG:(?:[0-9.]*:)?([0-9]+)$`)
+
+// crsSR returns the spatial reference corresponding to the given
+// coordinate reference system name, or nil if it specifies longitude
+// and latitude. Only WGS 84 longitude-latitude, UTM, and web Mercator
+// reference systems are supported by name; other systems must be
+// specified with a .prj file or in a GeoPackage.
+func crsSR(name string) (*proj.SR, error) {
+	if name == "" || strings.EqualFold(name, "urn:ogc:def:crs:OGC:1.3:CRS84") || strings.EqualFold(name, "CRS84") {
+		return nil, nil
+	}
+	m := epsgCode.FindStringSubmatch(name)
+	if m == nil {
+		return nil, fmt.Errorf("unsupported coordinate reference system %q", name)
+	}
+	code, err := strconv.Atoi(m[1])
+	if err != nil {
+		return nil, fmt.Errorf("unsupported coordinate reference system %q", name)
+	}
+	var def string
+	switch {
+	case code == 4326:
+		return nil, nil
+	case code == 3857 || code == 900913:
+		def = "+proj=merc +a=6378137 +b=6378137 +lat_ts=0 +lon_0=0 +x_0=0 +y_0=0 +k=1 +units=m +no_defs"
+	case code >= 32601 && code <= 32660:
+		def = fmt.Sprintf("+proj=utm +zone=%d +datum=WGS84 +units=m +no_defs", code-32600)
+	case code >= 32701 && code <= 32760:
+		def = fmt.Sprintf("+proj=utm +zone=%d +south +datum=WGS84 +units=m +no_defs", code-32700)
+	default:
+		return nil, fmt.Errorf("unsupported coordinate reference system %q", name)
+	}
+	return proj.Parse(def)
+}
+
+// geojsonFile represents the contents of a GeoJSON FeatureCollection.
+type geojsonFile struct {
+	CRS *struct {
+		Properties struct {
+			Name string `json:"name"`
+		} `json:"properties"`
+	} `json:"crs"`
+	Features []struct {
+		Properties map[string]interface{} `json:"properties"`
+		Geometry   *geojson.Geometry      `json:"geometry"`
+	} `json:"features"`
+}
+
+func readGeoJSONFile(path string) (*geojsonFile, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	var data geojsonFile
+	if err := json.NewDecoder(f).Decode(&data); err != nil {
+		return nil, fmt.Errorf("file %s: %v", path, err)
+	}
+	return &data, nil
+}
+
+// geojsonProperties returns the properties of the first feature
+// in a GeoJSON file that has properties.
+func geojsonProperties(path string) (map[string]interface{}, error) {
+	data, err := readGeoJSONFile(path)
+	if err != nil {
+		return nil, err
+	}
+	for _, feat := range data.Features {
+		if feat.Properties != nil {
+			return feat.Properties, nil
+		}
+	}
+	return nil, fmt.Errorf("file %s: couldn't find feature properties", path)
+}
+
+// geojsonGeometry returns the boundary in a GeoJSON FeatureCollection.
+// If the file has a "crs" member, as allowed by the 2008 GeoJSON
+// specification, the boundary is converted to longitude and latitude.
+func geojsonGeometry(path string) (geom.MultiPolygon, error) {
+	data, err := readGeoJSONFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var sr *proj.SR
+	if data.CRS != nil {
+		if sr, err = crsSR(data.CRS.Properties.Name); err != nil {
+			return nil, fmt.Errorf("file %s: %v", path, err)
+		}
+	}
+	var polys geom.MultiPolygon
+	for _, ft := range data.Features {
+		if ft.Geometry == nil {
+			continue
+		}
+		g, err := geojson.FromGeoJSON(ft.Geometry)
+		if err != nil {
+			return nil, fmt.Errorf("file %s: %v", path, err)
+		}
+		polys = appendPolygons(polys, g)
+	}
+	polys, err = toLonLat(polys, sr)
+	if err != nil {
+		return nil, fmt.Errorf("file %s: %v", path, err)
+	}
+	return polys, nil
+}
+
+// shapefileProperties returns the attributes of the
+// first record in a shapefile.
+func shapefileProperties(path string) (map[string]interface{}, error) {
+	d, err := shp.NewDecoder(path)
+	if err != nil {
+		return nil, fmt.Errorf("file %s: %v", path, err)
+	}
+	defer d.Close()
+	var names []string
+	for _, f := range d.Fields() {
+		names = append(names, f.String())
+	}
+	_, fields, _ := d.DecodeRowFields(names...)
+	if err := d.Error(); err != nil {
+		return nil, fmt.Errorf("file %s: %v", path, err)
+	}
+	if len(fields) == 0 {
+		return nil, fmt.Errorf("file %s: couldn't find feature properties", path)
+	}
+	props := make(map[string]interface{}, len(fields))
+	for k, v := range fields {
+		props[k] = strings.TrimSpace(v)
+	}
+	return props, nil
+}
+
+// shapefileGeometry returns the boundary in a shapefile. If there
+// is a .prj file, the boundary is converted to longitude and latitude.
+func shapefileGeometry(path string) (geom.MultiPolygon, error) {
+	d, err := shp.NewDecoder(path)
+	if err != nil {
+		return nil, fmt.Errorf("file %s: %v", path, err)
+	}
+	defer d.Close()
+	var sr *proj.SR
+	prj := strings.TrimSuffix(path, filepath.Ext(path)) + ".prj"
+	if _, err := os.Stat(prj); err == nil {
+		if sr, err = readPRJ(prj); err != nil {
+			return nil, fmt.Errorf("file %s: %v", prj, err)
+		}
+	}
+	var polys geom.MultiPolygon
+	for {
+		g, _, more := d.DecodeRowFields()
+		if !more {
+			break
+		}
+		// Shapefile polygon records don't distinguish between
+		// the parts of multipart polygons and holes.
+		if p, ok := g.(geom.Polygon); ok {
+			g = splitRings(p)
+		}
+		polys = appendPolygons(polys, g)
+	}
+	if err := d.Error(); err != nil {
+		return nil, fmt.Errorf("file %s: %v", path, err)
+	}
+	polys, err = toLonLat(polys, sr)
+	if err != nil {
+		return nil, fmt.Errorf("file %s: %v", path, err)
+	}
+	return polys, nil
+}
+
+// readPRJ returns the spatial reference in the given .prj file.
+func readPRJ(path string) (*proj.SR, error) {
+	b, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return proj.Parse(string(b))
+}
@@ -0,0 +1,153 @@
+package cityaq
+
+import (
+	"context"
+	"io/ioutil"
+	"math"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	rpc "github.com/ctessum/cityaq/cityaqrpc"
+	"github.com/ctessum/geom"
+	"github.com/spatialmodel/inmap/emissions/aep/aeputil"
+)
+
+func TestCityAQ_boundaryFormats(t *testing.T) {
+	c := &CityAQ{CityGeomDir: "testdata/boundaries"}
+
+	cities, err := c.Cities(context.Background(), &rpc.CitiesRequest{Language: "fr"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	wantNames := []string{"Cape Coast", "Kumasi", "Tamale", "Tema"}
+	if !reflect.DeepEqual(cities.Names, wantNames) {
+		t.Errorf("names: %v != %v", cities.Names, wantNames)
+	}
+	wantCities := []*rpc.City{
+		{ID: "cape-coast", DisplayName: "Cape Coast"},
+		{ID: "kumasi", DisplayName: "Koumassi"},
+		{ID: "tamale", DisplayName: "Tamale"},
+		{ID: "tema", DisplayName: "Tema"},
+	}
+	if !reflect.DeepEqual(cities.Cities, wantCities) {
+		t.Errorf("cities: %v != %v", cities.Cities, wantCities)
+	}
+
+	tests := []struct {
+		city string
+		want geom.Bounds
+	}{
+		{city: "cape-coast", want: geom.Bounds{Min: geom.Point{X: -1.30, Y: 5.08}, Max: geom.Point{X: -1.20, Y: 5.15}}},
+		{city: "kumasi", want: geom.Bounds{Min: geom.Point{X: -1.68, Y: 6.62}, Max: geom.Point{X: -1.55, Y: 6.75}}},
+		{city: "tamale", want: geom.Bounds{Min: geom.Point{X: -0.90, Y: 9.36}, Max: geom.Point{X: -0.78, Y: 9.46}}},
+		{city: "tema", want: geom.Bounds{Min: geom.Point{X: -0.05, Y: 5.62}, Max: geom.Point{X: 0, Y: 5.70}}},
+	}
+	for _, test := range tests {
+		t.Run(test.city, func(t *testing.T) {
+			g, err := c.cityGeometry(test.city)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(g) != 1 || len(g[0]) != 1 || len(g[0][0]) != 5 {
+				t.Fatalf("wrong geometry: %v", g)
+			}
+			b := g.Bounds()
+			const tolerance = 1.e-5
+			if math.Abs(b.Min.X-test.want.Min.X) > tolerance || math.Abs(b.Min.Y-test.want.Min.Y) > tolerance ||
+				math.Abs(b.Max.X-test.want.Max.X) > tolerance || math.Abs(b.Max.Y-test.want.Max.Y) > tolerance {
+				t.Errorf("bounds: %v != %v", b, test.want)
+			}
+		})
+	}
+}
+
+func TestCRSSR(t *testing.T) {
+	for _, name := range []string{"", "urn:ogc:def:crs:OGC:1.3:CRS84", "EPSG:4326", "urn:ogc:def:crs:EPSG::4326"} {
+		sr, err := crsSR(name)
+		if err != nil || sr != nil {
+			t.Errorf("%s: should be longitude-latitude, got %v, %v", name, sr, err)
+		}
+	}
+	for _, name := range []string{"EPSG:32630", "urn:ogc:def:crs:EPSG::32733", "EPSG:3857"} {
+		sr, err := crsSR(name)
+		if err != nil || sr == nil {
+			t.Errorf("%s: should be projected, got %v, %v", name, sr, err)
+		}
+	}
+	for _, name := range []string{"EPSG:2236", "NAD27"} {
+		if _, err := crsSR(name); err == nil {
+			t.Errorf("%s: expected an error", name)
+		}
+	}
+}
+
+func TestCityAQ_multipartCity(t *testing.T) {
+	dir, err := ioutil.TempDir("", "cityaq_multipart")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	// An island city with a lake on the main island and a
+	// smaller island to the east.
+	const islands = `{"type":"FeatureCollection","features":[{"type":"Feature",
+"properties":{"name":"Islands"},
+"geometry":{"type":"MultiPolygon","coordinates":[
+[[[103.6,1.2],[104.0,1.2],[104.0,1.5],[103.6,1.5],[103.6,1.2]],
+[[103.7,1.3],[103.7,1.4],[103.8,1.4],[103.8,1.3],[103.7,1.3]]],
+[[[104.1,1.3],[104.2,1.3],[104.2,1.4],[104.1,1.4],[104.1,1.3]]]]}}]}`
+	if err := ioutil.WriteFile(filepath.Join(dir, "islands.geojson"), []byte(islands), 0644); err != nil {
+		t.Fatal(err)
+	}
+	c := &CityAQ{
+		CityGeomDir: dir,
+		SpatialConfig: aeputil.SpatialConfig{
+			SrgShapefileDirectory: "testdata",
+		},
+	}
+
+	g, err := c.CityGeometry(context.Background(), &rpc.CityGeometryRequest{CityName: "islands"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(g.Polygons) != 2 {
+		t.Fatalf("have %d parts, want 2", len(g.Polygons))
+	}
+	if len(g.Polygons[0].Paths) != 2 || len(g.Polygons[1].Paths) != 1 {
+		t.Errorf("wrong rings: %d and %d", len(g.Polygons[0].Paths), len(g.Polygons[1].Paths))
+	}
+
+	polys, err := c.cityGeometry("islands")
+	if err != nil {
+		t.Fatal(err)
+	}
+	// The lake is only subtracted from the main island.
+	wantArea := sphericalArea(geom.Polygon{polys[0][0]}) - sphericalArea(geom.Polygon{polys[0][1]}) +
+		sphericalArea(polys[1])
+	info, err := c.CityInfo(context.Background(), &rpc.CityInfoRequest{CityName: "islands"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if math.Abs(info.Area-wantArea) > 1.e-6 {
+		t.Errorf("area: %g != %g", info.Area, wantArea)
+	}
+}
+
+func TestSplitRings(t *testing.T) {
+	outer1 := geom.Path{{X: 0, Y: 0}, {X: 0, Y: 3}, {X: 3, Y: 3}, {X: 3, Y: 0}, {X: 0, Y: 0}}
+	hole1 := geom.Path{{X: 1, Y: 1}, {X: 2, Y: 1}, {X: 2, Y: 2}, {X: 1, Y: 2}, {X: 1, Y: 1}}
+	island := geom.Path{{X: 1.2, Y: 1.2}, {X: 1.2, Y: 1.8}, {X: 1.8, Y: 1.8}, {X: 1.8, Y: 1.2}, {X: 1.2, Y: 1.2}}
+	outer2 := geom.Path{{X: 5, Y: 0}, {X: 5, Y: 1}, {X: 6, Y: 1}, {X: 6, Y: 0}, {X: 5, Y: 0}}
+
+	have := splitRings(geom.Polygon{outer1, outer2, hole1, island})
+	want := geom.MultiPolygon{
+		{outer1, hole1},
+		{outer2},
+		{island},
+	}
+	if !reflect.DeepEqual(have, want) {
+		t.Errorf("%v != %v", have, want)
+	}
+}
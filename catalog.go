@@ -0,0 +1,366 @@
+package cityaq
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// cityCatalog is a registry of the city boundary files in a set of
+// directories. It is safe for concurrent use, and it can be refreshed
+// while it is in use to pick up files that have been added, changed,
+// or removed.
+type cityCatalog struct {
+	// dirs holds the directories to search for boundary files.
+	// The first directory must exist; the others are
+	// skipped if they do not exist.
+	dirs []string
+
+	// sync, if not nil, is called before each refresh to
+	// update the contents of the directories.
+	sync func() error
+
+	// refreshed, if not nil, is called after each refresh with
+	// the files that are in the catalog, so that information
+	// derived from files that have changed or been removed
+	// can be discarded.
+	refreshed func(files []cityFile)
+
+	mu     sync.RWMutex
+	loaded bool
+
+	// files holds information about each boundary file,
+	// keyed by file path.
+	files map[string]cityFile
+
+	// paths holds the path of the file containing the
+	// boundary of each city, keyed by city name.
+	paths map[string]string
+
+	// ids holds the path of the file containing the
+	// boundary of each city, keyed by city ID.
+	ids map[string]string
+
+	// problems holds the problems found during the last refresh,
+	// so that each problem is only logged once.
+	problems map[string]bool
+}
+
+// cityFile holds information about a city boundary file.
+type cityFile struct {
+	id      string
+	name    string
+	props   map[string]interface{}
+	modTime time.Time
+	size    int64
+
+	// version identifies the contents of the file, so that
+	// results derived from an earlier version of the boundary
+	// are not reused after the file changes.
+	version string
+}
+
+func newCityCatalog(dirs ...string) *cityCatalog {
+	return &cityCatalog{
+		dirs:  dirs,
+		files: make(map[string]cityFile),
+		paths: make(map[string]string),
+		ids:   make(map[string]string),
+	}
+}
+
+// catalog returns the city catalog for the receiver.
+func (c *CityAQ) catalog() *cityCatalog {
+	c.cityCatalogOnce.Do(func() {
+		dirs := []string{os.ExpandEnv(c.CityGeomDir)}
+		if dir := c.studyAreaDir(); dir != "" {
+			dirs = append(dirs, dir)
+		}
+		c.cityCatalog = newCityCatalog(dirs...)
+		c.cityCatalog.sync = c.syncStudyAreas
+		c.cityCatalog.refreshed = c.evictCityMeta
+	})
+	return c.cityCatalog
+}
+
+// load makes sure the catalog has been populated.
+// If the initial scan fails, it will be retried on the next call.
+func (cat *cityCatalog) load() error {
+	cat.mu.RLock()
+	loaded := cat.loaded
+	cat.mu.RUnlock()
+	if loaded {
+		return nil
+	}
+	err := cat.refresh()
+	cat.mu.RLock()
+	loaded = cat.loaded
+	cat.mu.RUnlock()
+	if !loaded {
+		return err
+	}
+	return nil
+}
+
+// refresh scans the catalog directories for city boundary files.
+// Files that are new or have changed since the last scan are read,
+// and files that no longer exist are removed from the catalog.
+// Files with problems are skipped and the problems are logged, so that
+// they don't prevent the remaining files from being registered; if a
+// previously valid file becomes invalid, its last valid version is
+// kept. An error is only returned if a directory can't be read.
+func (cat *cityCatalog) refresh() error {
+	type fileInfo struct {
+		path string
+		info os.FileInfo
+	}
+	var problems []string
+	if cat.sync != nil {
+		if err := cat.sync(); err != nil {
+			problems = append(problems, err.Error())
+		}
+	}
+
+	var found []fileInfo
+	for i, dir := range cat.dirs {
+		if i > 0 {
+			if _, err := os.Stat(dir); os.IsNotExist(err) {
+				continue
+			}
+		}
+		var dirFound []fileInfo
+		err := filepath.Walk(dir, func(path string, info os.FileInfo, err error) error {
+			if err != nil {
+				return err
+			}
+			if _, ok := boundaryFormatOf(path); info.IsDir() || !ok {
+				return nil
+			}
+			dirFound = append(dirFound, fileInfo{path: path, info: info})
+			return nil
+		})
+		if err != nil {
+			return fmt.Errorf("cityaq: reading city directory: %w", err)
+		}
+		// Sort within each directory so that cities in earlier
+		// directories take precedence over duplicates in later ones.
+		sort.Slice(dirFound, func(i, j int) bool { return dirFound[i].path < dirFound[j].path })
+		found = append(found, dirFound...)
+	}
+
+	cat.mu.RLock()
+	prev := cat.files
+	cat.mu.RUnlock()
+
+	files := make(map[string]cityFile, len(found))
+	paths := make(map[string]string, len(found))
+	ids := make(map[string]string, len(found))
+	for _, f := range found {
+		cf, ok := prev[f.path]
+		if !ok || !cf.modTime.Equal(f.info.ModTime()) || cf.size != f.info.Size() {
+			props, err := boundaryProperties(f.path)
+			var name, id, version string
+			if err == nil {
+				name, err = defaultCityName(props)
+				if err == nil {
+					id, err = cityID(props, name)
+				}
+				if err == nil {
+					version, err = boundaryVersion(f.path)
+				}
+				if err != nil {
+					err = fmt.Errorf("file %s: %v", f.path, err)
+				}
+			}
+			if err != nil {
+				problems = append(problems, err.Error())
+				if !ok {
+					continue
+				}
+			} else {
+				cf = cityFile{id: id, name: name, props: props, modTime: f.info.ModTime(), size: f.info.Size(), version: version}
+			}
+		}
+		if other, ok := paths[cf.name]; ok {
+			problems = append(problems, fmt.Sprintf("city %q is defined in both %s and %s", cf.name, other, f.path))
+			continue
+		}
+		if other, ok := ids[cf.id]; ok {
+			problems = append(problems, fmt.Sprintf("city ID %q is used by both %s and %s", cf.id, other, f.path))
+			continue
+		}
+		files[f.path] = cf
+		paths[cf.name] = f.path
+		ids[cf.id] = f.path
+	}
+
+	cat.mu.Lock()
+	cat.files = files
+	cat.paths = paths
+	cat.ids = ids
+	cat.loaded = true
+	logged := cat.problems
+	cat.problems = make(map[string]bool, len(problems))
+	for _, p := range problems {
+		if !logged[p] {
+			log.Printf("cityaq: problem loading cities: %s", p)
+		}
+		cat.problems[p] = true
+	}
+	cat.mu.Unlock()
+	if cat.refreshed != nil {
+		cat.refreshed(cat.cities())
+	}
+	return nil
+}
+
+// cities returns information about the cities in the catalog,
+// sorted by name.
+func (cat *cityCatalog) cities() []cityFile {
+	cat.mu.RLock()
+	defer cat.mu.RUnlock()
+	o := make([]cityFile, 0, len(cat.files))
+	for _, f := range cat.files {
+		o = append(o, f)
+	}
+	sort.Slice(o, func(i, j int) bool { return o[i].name < o[j].name })
+	return o
+}
+
+// lookup returns the location of and information about the
+// boundary file for the given city, which can be specified
+// either by its ID or by its default name.
+func (cat *cityCatalog) lookup(city string) (string, cityFile, error) {
+	if err := cat.load(); err != nil {
+		return "", cityFile{}, err
+	}
+	cat.mu.RLock()
+	defer cat.mu.RUnlock()
+	p, ok := cat.ids[city]
+	if !ok {
+		p, ok = cat.paths[city]
+		if !ok {
+			return "", cityFile{}, fmt.Errorf("cityaq: invalid city name %s", city)
+		}
+	}
+	return p, cat.files[p], nil
+}
+
+// path returns the location of the boundary file for the given city.
+func (cat *cityCatalog) path(city string) (string, error) {
+	p, _, err := cat.lookup(city)
+	return p, err
+}
+
+// id returns the ID of the given city.
+func (cat *cityCatalog) id(city string) (string, error) {
+	_, f, err := cat.lookup(city)
+	return f.id, err
+}
+
+// displayName returns the name of the given city in the requested language.
+func (cat *cityCatalog) displayName(city, lang string) (string, error) {
+	_, f, err := cat.lookup(city)
+	if err != nil {
+		return "", err
+	}
+	return localizedCityName(f.props, lang)
+}
+
+// cityIDProperty is the feature property that can be
+// used to explicitly set the ID of a city.
+const cityIDProperty = "cityaq_id"
+
+var validCityID = regexp.MustCompile("^[a-z0-9]+(-[a-z0-9]+)*$")
+
+// cityID returns the ID of a city with the given feature properties
+// and default name. If the properties contain a "cityaq_id" value, it is used
+// as the ID; otherwise the ID is derived from the default name. Setting the
+// ID explicitly allows the name of a city to change without changing its ID.
+func cityID(props map[string]interface{}, name string) (string, error) {
+	if idI, ok := props[cityIDProperty]; ok {
+		id, ok := idI.(string)
+		if !ok || !validCityID.MatchString(id) {
+			return "", fmt.Errorf("invalid %s %v: it must contain only lower-case letters, numbers, and single hyphens", cityIDProperty, idI)
+		}
+		return id, nil
+	}
+	return citySlug(name), nil
+}
+
+// citySlug returns a URL-safe identifier derived from the given
+// city name. Accented Latin letters are replaced with their unaccented
+// equivalents, and other characters that are not ASCII letters or numbers
+// are replaced with hyphens. If nothing remains, an identifier is
+// derived from a hash of the name.
+func citySlug(name string) string {
+	var b strings.Builder
+	hyphen := false
+	for _, r := range strings.ToLower(name) {
+		if f, ok := latinFold[r]; ok {
+			r = f
+		}
+		if (r >= 'a' && r <= 'z') || (r >= '0' && r <= '9') {
+			if hyphen && b.Len() > 0 {
+				b.WriteRune('-')
+			}
+			b.WriteRune(r)
+			hyphen = false
+		} else {
+			hyphen = true
+		}
+	}
+	if b.Len() == 0 {
+		h := sha256.Sum256([]byte(name))
+		return "city-" + hex.EncodeToString(h[:4])
+	}
+	return b.String()
+}
+
+// latinFold maps accented lower-case Latin letters to their
+// unaccented equivalents.
+var latinFold = map[rune]rune{
+	'à': 'a', 'á': 'a', 'â': 'a', 'ã': 'a', 'ä': 'a', 'å': 'a', 'ā': 'a', 'ă': 'a', 'ą': 'a',
+	'ç': 'c', 'ć': 'c', 'č': 'c',
+	'ď': 'd', 'đ': 'd',
+	'è': 'e', 'é': 'e', 'ê': 'e', 'ë': 'e', 'ē': 'e', 'ė': 'e', 'ę': 'e', 'ě': 'e',
+	'ğ': 'g',
+	'ì': 'i', 'í': 'i', 'î': 'i', 'ï': 'i', 'ī': 'i', 'į': 'i', 'ı': 'i',
+	'ł': 'l',
+	'ñ': 'n', 'ń': 'n', 'ň': 'n',
+	'ò': 'o', 'ó': 'o', 'ô': 'o', 'õ': 'o', 'ö': 'o', 'ø': 'o', 'ō': 'o', 'ő': 'o', 'ơ': 'o',
+	'ř': 'r',
+	'ś': 's', 'š': 's', 'ş': 's', 'ș': 's',
+	'ť': 't', 'ţ': 't', 'ț': 't',
+	'ù': 'u', 'ú': 'u', 'û': 'u', 'ü': 'u', 'ū': 'u', 'ů': 'u', 'ű': 'u', 'ư': 'u',
+	'ý': 'y', 'ÿ': 'y',
+	'ź': 'z', 'ż': 'z', 'ž': 'z',
+}
+
+// WatchCities checks the CityGeomDir directory for added, changed,
+// and removed city boundary files at the given interval until ctx
+// is cancelled, so that cities can be updated without restarting
+// the server. Problems with the boundary files are logged.
+func (c *CityAQ) WatchCities(ctx context.Context, interval time.Duration) {
+	t := time.NewTicker(interval)
+	defer t.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-t.C:
+			if err := c.catalog().refresh(); err != nil {
+				log.Println(err)
+			}
+		}
+	}
+}
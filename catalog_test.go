@@ -0,0 +1,280 @@
+package cityaq
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	rpc "github.com/ctessum/cityaq/cityaqrpc"
+)
+
+func copyFile(t *testing.T, src, dst string) {
+	b, err := ioutil.ReadFile(src)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := ioutil.WriteFile(dst, b, 0644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestCityAQ_citiesReload(t *testing.T) {
+	dir, err := ioutil.TempDir("", "cityaq_cities")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	accra := filepath.Join(dir, "accra_jurisdiction.geojson")
+	karachi := filepath.Join(dir, "karachi_jurisdiction.geojson")
+	copyFile(t, "testdata/cities/accra_jurisdiction.geojson", accra)
+
+	c := &CityAQ{CityGeomDir: dir}
+
+	checkCities := func(t *testing.T, want []string) {
+		cities, err := c.Cities(context.Background(), &rpc.CitiesRequest{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !reflect.DeepEqual(cities.Names, want) {
+			t.Errorf("%v != %v", cities.Names, want)
+		}
+	}
+
+	t.Run("initial", func(t *testing.T) {
+		checkCities(t, []string{"Accra Metropolitan"})
+	})
+
+	t.Run("added", func(t *testing.T) {
+		copyFile(t, "testdata/cities/karachi_jurisdiction.geojson", karachi)
+		checkCities(t, []string{"Accra Metropolitan", "ڪراچي Karachi"})
+		if _, err := c.cityGeometry("ڪراچي Karachi"); err != nil {
+			t.Fatal(err)
+		}
+	})
+
+	t.Run("changed", func(t *testing.T) {
+		// Replace the Karachi boundary with the Accra boundary
+		// under a different name.
+		b, err := ioutil.ReadFile(accra)
+		if err != nil {
+			t.Fatal(err)
+		}
+		b = []byte(strings.Replace(string(b), `"name": "Accra Metropolitan"`, `"name": "Accra 2"`, 1))
+		if err := ioutil.WriteFile(karachi, b, 0644); err != nil {
+			t.Fatal(err)
+		}
+		// Make sure the modification time changes.
+		future := time.Now().Add(time.Hour)
+		if err := os.Chtimes(karachi, future, future); err != nil {
+			t.Fatal(err)
+		}
+		checkCities(t, []string{"Accra 2", "Accra Metropolitan"})
+	})
+
+	t.Run("removed", func(t *testing.T) {
+		if err := os.Remove(karachi); err != nil {
+			t.Fatal(err)
+		}
+		checkCities(t, []string{"Accra Metropolitan"})
+		if _, err := c.cityGeometry("Accra 2"); err == nil {
+			t.Error("removed city should cause an error")
+		}
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		bad := filepath.Join(dir, "bad.geojson")
+		if err := ioutil.WriteFile(bad, []byte("{"), 0644); err != nil {
+			t.Fatal(err)
+		}
+		defer os.Remove(bad)
+		// The invalid file is skipped, and valid cities
+		// are still available.
+		checkCities(t, []string{"Accra Metropolitan"})
+		if _, err := c.cityGeometry("Accra Metropolitan"); err != nil {
+			t.Fatal(err)
+		}
+	})
+
+	t.Run("concurrent", func(t *testing.T) {
+		var wg sync.WaitGroup
+		for i := 0; i < 10; i++ {
+			wg.Add(2)
+			go func() {
+				defer wg.Done()
+				if _, err := c.Cities(context.Background(), &rpc.CitiesRequest{}); err != nil {
+					t.Error(err)
+				}
+			}()
+			go func() {
+				defer wg.Done()
+				if _, err := c.cityGeometry("Accra Metropolitan"); err != nil {
+					t.Error(err)
+				}
+			}()
+		}
+		wg.Wait()
+	})
+}
+
+func TestCityAQ_citiesMissingDir(t *testing.T) {
+	c := &CityAQ{CityGeomDir: "testdata/does_not_exist"}
+	if _, err := c.Cities(context.Background(), &rpc.CitiesRequest{}); err == nil {
+		t.Error("missing directory should cause an error")
+	}
+	if _, err := c.cityGeometry("Accra Metropolitan"); err == nil {
+		t.Error("missing directory should cause an error")
+	}
+}
+
+func TestCitySlug(t *testing.T) {
+	for name, want := range map[string]string{
+		"Accra Metropolitan": "accra-metropolitan",
+		"ڪراچي Karachi":      "karachi",
+		"Medellín":           "medellin",
+		"City of Johannesburg Metropolitan Municipality": "city-of-johannesburg-metropolitan-municipality",
+		"São Paulo":           "sao-paulo",
+		"  Ho Chi Minh City ": "ho-chi-minh-city",
+	} {
+		if have := citySlug(name); have != want {
+			t.Errorf("%s: %s != %s", name, have, want)
+		}
+	}
+	if a := citySlug("东京"); !strings.HasPrefix(a, "city-") {
+		t.Errorf("slug of non-Latin name should be derived from hash: %s", a)
+	}
+	if a, b := citySlug("东京"), citySlug("北京"); a == b {
+		t.Errorf("slugs of non-Latin names should differ: %s == %s", a, b)
+	}
+}
+
+func TestCityID(t *testing.T) {
+	id, err := cityID(map[string]interface{}{"cityaq_id": "accra"}, "Accra Metropolitan")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if id != "accra" {
+		t.Errorf("%s != accra", id)
+	}
+	for _, bad := range []interface{}{"Accra", "accra--1", "-accra", 1} {
+		if _, err := cityID(map[string]interface{}{"cityaq_id": bad}, "Accra"); err == nil {
+			t.Errorf("%v: should be invalid", bad)
+		}
+	}
+}
+
+func TestCityAQ_cityLookup(t *testing.T) {
+	c := &CityAQ{CityGeomDir: "testdata/cities"}
+	for _, city := range []string{"accra-metropolitan", "Accra Metropolitan"} {
+		id, err := c.catalog().id(city)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if id != "accra-metropolitan" {
+			t.Errorf("%s: %s != accra-metropolitan", city, id)
+		}
+	}
+	if _, err := c.catalog().id("accra"); err == nil {
+		t.Error("invalid city should cause an error")
+	}
+}
+
+func TestCityAQ_citiesDuplicateID(t *testing.T) {
+	dir, err := ioutil.TempDir("", "cityaq_cities")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	b, err := ioutil.ReadFile("testdata/cities/accra_jurisdiction.geojson")
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Different names that result in the same ID.
+	b1 := strings.Replace(string(b), `"name": "Accra Metropolitan"`, `"name": "Accra-Metropolitan"`, 1)
+	if err := ioutil.WriteFile(filepath.Join(dir, "a.geojson"), b, 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := ioutil.WriteFile(filepath.Join(dir, "b.geojson"), []byte(b1), 0644); err != nil {
+		t.Fatal(err)
+	}
+	c := &CityAQ{CityGeomDir: dir}
+	cities, err := c.Cities(context.Background(), &rpc.CitiesRequest{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	// The city with the duplicate ID is skipped.
+	if want := []string{"Accra Metropolitan"}; !reflect.DeepEqual(cities.Names, want) {
+		t.Errorf("%v != %v", cities.Names, want)
+	}
+}
+
+func TestCityAQ_boundaryVersion(t *testing.T) {
+	dir, err := ioutil.TempDir("", "cityaq_cities")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	accra := filepath.Join(dir, "accra_jurisdiction.geojson")
+	copyFile(t, "testdata/cities/accra_jurisdiction.geojson", accra)
+
+	c := &CityAQ{CityGeomDir: dir}
+	st := &sourceType{Name: "roadways"}
+	keys := func() (job, grid string) {
+		j, err := c.newConcentrationJob("accra-metropolitan", "roadways", rpc.SimulationType_CityMarginal, nil, nil, nil, 0, false, false)
+		if err != nil {
+			t.Fatal(err)
+		}
+		g, err := c.gridName("accra-metropolitan", st)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return j.Key(), g
+	}
+	job1, grid1 := keys()
+	_, f, err := c.catalog().lookup("accra-metropolitan")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if m := c.meta(f); m.err != nil {
+		t.Fatal(m.err)
+	}
+
+	// Change the boundary without changing the city.
+	b, err := ioutil.ReadFile(accra)
+	if err != nil {
+		t.Fatal(err)
+	}
+	b = []byte(strings.Replace(string(b), "5.5593271", "5.5593272", 1))
+	if err := ioutil.WriteFile(accra, b, 0644); err != nil {
+		t.Fatal(err)
+	}
+	future := time.Now().Add(time.Hour)
+	if err := os.Chtimes(accra, future, future); err != nil {
+		t.Fatal(err)
+	}
+	if err := c.catalog().refresh(); err != nil {
+		t.Fatal(err)
+	}
+
+	job2, grid2 := keys()
+	if job1 == job2 {
+		t.Errorf("concentration key should change with the boundary: %s", job1)
+	}
+	if grid1 == grid2 {
+		t.Errorf("grid name should change with the boundary: %s", grid1)
+	}
+	c.cityMetaMu.Lock()
+	n := len(c.cityMeta)
+	c.cityMetaMu.Unlock()
+	if n != 0 {
+		t.Errorf("city information for the old boundary should be evicted; %d entries remain", n)
+	}
+}
@@ -6,15 +6,13 @@ import (
 	"fmt"
 	"math"
 	"net/url"
-	"os"
-	"path/filepath"
 	"sort"
+	"strconv"
 	"strings"
 	"sync"
 
 	rpc "github.com/ctessum/cityaq/cityaqrpc"
 	"github.com/ctessum/geom"
-	"github.com/ctessum/geom/encoding/geojson"
 	"github.com/ctessum/geom/index/rtree"
 	"github.com/ctessum/requestcache/v4"
 	"github.com/spatialmodel/inmap/cloud"
@@ -24,11 +22,52 @@ import (
 // CityAQ estimates the air quality impacts of activities in cities.
 type CityAQ struct {
 	// CityGeomDir is the location of the directory that holds the
-	// GeoJSON geometries of cities.
+	// boundaries of cities, as GeoJSON, shapefile, GeoPackage,
+	// or KML files.
 	CityGeomDir string
 
 	aeputil.SpatialConfig
 
+	// SourceTypeFile is the path to a TOML or JSON file that describes
+	// the source types that emissions can be allocated to, usually kept
+	// alongside SrgSpecOSM. If it is empty, a built-in catalog of
+	// source types is used.
+	SourceTypeFile string
+
+	// OSMFile is the path to the OpenStreetMap PBF extract that custom
+	// surrogates are built from. If it is empty, the file used by
+	// the first surrogate in SrgSpecOSM is used.
+	OSMFile string
+
+	// GridProjection is the projection that emissions grids are built in:
+	// "lonlat" (the default) for cells that are square in degrees, or
+	// "utm" or "equalarea" for cells that are square in meters in the UTM
+	// zone or a Lambert azimuthal equal-area projection centered on the
+	// grid, so that cell areas do not depend on latitude. The cell size
+	// of projected grids is set by the CellSize of each source type.
+	GridProjection string
+
+	// TemporalRefFile and TemporalProFile are the paths to a temporal
+	// cross-reference file and a temporal profile file in SMOKE format,
+	// which specify how the emissions of each source type vary by month,
+	// day of the week, and hour of the day. Source type names are used in
+	// place of SCCs. If they are empty, emissions are constant over time.
+	// The profiles are in the local time of each city, so the boundaries
+	// of cities need a "timezone" property with their IANA time zone.
+	TemporalRefFile string
+	TemporalProFile string
+
+	// GridRegionFile is the path to a shapefile of electric grid or
+	// balancing-area regions. The emissions of source types in the
+	// "egugrid" domain are allocated within the region that contains
+	// the city. If it is empty, or none of the regions overlap the city,
+	// the smaller of the country or a circular buffer around the city
+	// is used instead. GridRegionNameField is the attribute that holds
+	// the name of each region; if it is empty, regions are named by
+	// record number.
+	GridRegionFile      string
+	GridRegionNameField string
+
 	// Location where temporary results should be stored.
 	CacheLoc    string
 	inmapClient *cloud.Client
@@ -49,51 +88,127 @@ type CityAQ struct {
 	// used for running simulations, for example "latest" or "v1.7.2".
 	Version string
 
-	// cityPaths holds the locations of the files containing the
+	// cityCatalog holds the locations of the files containing the
 	// boundaries of each city.
-	cityPaths         map[string]string
-	loadCityPathsOnce sync.Once
+	cityCatalog     *cityCatalog
+	cityCatalogOnce sync.Once
+
+	// studyAreaMu prevents concurrent registration
+	// of study areas with the same name.
+	studyAreaMu sync.Mutex
 
 	countries         *rtree.Rtree
+	countriesErr      error
 	loadCountriesOnce sync.Once
-	cloudSetupOnce    sync.Once
+
+	gridRegions         *rtree.Rtree
+	gridRegionsErr      error
+	loadGridRegionsOnce sync.Once
+	cloudSetupOnce      sync.Once
+
+	// cityMeta holds information about each city that
+	// is expensive to compute, keyed by cityMetaKey.
+	cityMeta   map[string]*cityMeta
+	cityMetaMu sync.Mutex
+
+	sourceTypeCatalog []*sourceType
+	sourceTypesErr    error
+	sourceTypesOnce   sync.Once
+
+	temporalProfileSet   *temporalProfiles
+	temporalProfilesErr  error
+	temporalProfilesOnce sync.Once
+
+	// cityIndex is a spatial index of the city boundaries.
+	cityIndex   *cityIndex
+	cityIndexMu sync.Mutex
 
 	cacheSetupOnce sync.Once
 	cache          *requestcache.Cache
+
+	// cacheKeys holds a description of the request associated
+	// with each cache key in this process, to check for collisions.
+	cacheKeys   map[string]string
+	cacheKeysMu sync.Mutex
 }
 
-// Cities returns the files in the CityGeomDir directory field of the receiver.
-func (c *CityAQ) Cities(ctx context.Context, _ *rpc.CitiesRequest) (*rpc.CitiesResponse, error) {
-	c.cityPaths = make(map[string]string)
-	r := new(rpc.CitiesResponse)
-	err := filepath.Walk(os.ExpandEnv(c.CityGeomDir), func(path string, info os.FileInfo, err error) error {
-		if err != nil {
-			return err
+// Cities returns the cities in the CityGeomDir directory field of the receiver.
+// The directory is rescanned on each call, so the result reflects any
+// boundary files that have been added, changed, or removed.
+// Cities are sorted by display name in the requested language. The
+// results can be filtered by name, country, and location, and split
+// into pages.
+func (c *CityAQ) Cities(ctx context.Context, req *rpc.CitiesRequest) (*rpc.CitiesResponse, error) {
+	cat := c.catalog()
+	if err := cat.refresh(); err != nil {
+		return nil, err
+	}
+	var bbox *geom.Bounds
+	if min, max := req.GetMin(), req.GetMax(); min != nil || max != nil {
+		if min == nil || max == nil || min.X > max.X || min.Y > max.Y {
+			return nil, fmt.Errorf("cityaq: invalid bounding box: Min and Max must both be specified, and Min must be south-west of Max")
 		}
-		if info.IsDir() || filepath.Ext(path) != ".geojson" {
-			return nil
+		bbox = &geom.Bounds{
+			Min: geom.Point{X: min.X, Y: min.Y},
+			Max: geom.Point{X: max.X, Y: max.Y},
 		}
-		name, err := c.geojsonName(path, "en")
+	}
+	if req.GetPageSize() < 0 {
+		return nil, fmt.Errorf("cityaq: invalid page size %d", req.GetPageSize())
+	}
+	offset, err := parsePageToken(req.GetPageToken())
+	if err != nil {
+		return nil, err
+	}
+
+	type match struct {
+		name string
+		city *rpc.City
+	}
+	var matches []match
+	for _, f := range cat.cities() {
+		displayName, err := localizedCityName(f.props, req.GetLanguage())
 		if err != nil {
-			return err
+			return nil, err
 		}
-		r.Names = append(r.Names, name)
-		c.cityPaths[name] = path
-		return nil
-	})
-	sort.Strings(r.Names)
-	return r, err
-}
-
-func (c *CityAQ) loadCityPaths() {
-	c.loadCityPathsOnce.Do(func() {
-		if c.cityPaths == nil {
-			_, err := c.Cities(context.Background(), nil)
-			if err != nil {
-				panic(err)
-			}
+		if !matchesQuery(req.GetQuery(), f.id, f.name, displayName) {
+			continue
+		}
+		m := c.meta(f)
+		if req.GetCountry() != "" && !strings.EqualFold(req.GetCountry(), m.country) {
+			continue
+		}
+		if bbox != nil && (m.err != nil || !boundsOverlap(bbox, m.bounds)) {
+			continue
 		}
+		matches = append(matches, match{
+			name: f.name,
+			city: &rpc.City{
+				ID:          f.id,
+				DisplayName: displayName,
+				Country:     m.country,
+				Region:      cityRegion(f.props),
+			},
+		})
+	}
+	sort.SliceStable(matches, func(i, j int) bool {
+		return matches[i].city.DisplayName < matches[j].city.DisplayName
 	})
+
+	r := &rpc.CitiesResponse{TotalSize: int32(len(matches))}
+	if offset > len(matches) {
+		offset = len(matches)
+	}
+	end := len(matches)
+	if req.GetPageSize() > 0 && offset+int(req.GetPageSize()) < end {
+		end = offset + int(req.GetPageSize())
+		r.NextPageToken = strconv.Itoa(end)
+	}
+	for _, m := range matches[offset:end] {
+		r.Names = append(r.Names, m.name)
+		r.Cities = append(r.Cities, m.city)
+	}
+	return r, nil
 }
 
 func (c *CityAQ) setupCache() {
@@ -122,16 +237,116 @@ func (c *CityAQ) setupCache() {
 
 // CityGeometry returns the geometry of the requested city.
 func (c *CityAQ) CityGeometry(ctx context.Context, req *rpc.CityGeometryRequest) (*rpc.CityGeometryResponse, error) {
-	polys, err := c.geojsonGeometry(req.CityName)
+	polys, err := c.cityGeometry(req.CityName)
+	if err != nil {
+		return nil, err
+	}
+	displayName, err := c.catalog().displayName(req.CityName, req.Language)
 	if err != nil {
 		return nil, err
 	}
 	o := &rpc.CityGeometryResponse{
-		Polygons: polygonsToRPC([]geom.Polygon{polys}),
+		Polygons:    polygonsToRPC(polys),
+		DisplayName: displayName,
 	}
 	return o, err
 }
 
+// CityInfo returns information about the boundary of the requested city.
+func (c *CityAQ) CityInfo(ctx context.Context, req *rpc.CityInfoRequest) (*rpc.CityInfoResponse, error) {
+	_, f, err := c.catalog().lookup(req.CityName)
+	if err != nil {
+		return nil, err
+	}
+	displayName, err := localizedCityName(f.props, req.Language)
+	if err != nil {
+		return nil, err
+	}
+	polys, err := c.cityGeometry(f.id)
+	if err != nil {
+		return nil, err
+	}
+	ctry, err := c.country(f.id)
+	if err != nil {
+		return nil, err
+	}
+	egugrid, err := c.egugridRegion(f.id)
+	if err != nil {
+		return nil, err
+	}
+	props, err := json.Marshal(f.props)
+	if err != nil {
+		return nil, err
+	}
+	var area float64
+	for _, poly := range polys {
+		area += sphericalArea(poly)
+	}
+	b := polys.Bounds()
+	centroid := polys.Centroid()
+	return &rpc.CityInfoResponse{
+		ID:          f.id,
+		DisplayName: displayName,
+		Area:        area,
+		Centroid:    &rpc.Point{X: centroid.X, Y: centroid.Y},
+		Min:         &rpc.Point{X: b.Min.X, Y: b.Min.Y},
+		Max:         &rpc.Point{X: b.Max.X, Y: b.Max.Y},
+		Country:     ctry.Name,
+		EGUGrid:     polygonsToRPC(egugrid.MultiPolygon),
+		Properties:  string(props),
+	}, nil
+}
+
+// sphericalArea returns the area in km² of the given polygon, where
+// coordinates are longitude and latitude in degrees, assuming a
+// spherical earth. Rings that are inside an odd number of other rings
+// are treated as holes.
+func sphericalArea(p geom.Polygon) float64 {
+	const r = 6371.0088 // Mean earth radius [km]
+	var area float64
+	for i, ring := range p {
+		if len(ring) < 3 {
+			continue
+		}
+		// Area of a spherical polygon, from
+		// Chamberlain and Duquette (2007), "Some algorithms for
+		// polygons on a sphere", JPL Publication 07-03.
+		var a float64
+		for j, p1 := range ring {
+			p2 := ring[(j+1)%len(ring)]
+			a += (p2.X - p1.X) * math.Pi / 180 *
+				(2 + math.Sin(p1.Y*math.Pi/180) + math.Sin(p2.Y*math.Pi/180))
+		}
+		a = math.Abs(a * r * r / 2)
+
+		var within int
+		for k, other := range p {
+			if k != i && pointInRing(ring[0], other) {
+				within++
+			}
+		}
+		if within%2 == 1 {
+			area -= a
+		} else {
+			area += a
+		}
+	}
+	return area
+}
+
+// pointInRing returns whether pt is inside of the given ring.
+func pointInRing(pt geom.Point, ring geom.Path) bool {
+	in := false
+	for i, j := 0, len(ring)-1; i < len(ring); j, i = i, i+1 {
+		pi, pj := ring[i], ring[j]
+		if (pi.Y > pt.Y) != (pj.Y > pt.Y) &&
+			pt.X < (pj.X-pi.X)*(pt.Y-pi.Y)/(pj.Y-pi.Y)+pi.X {
+			in = !in
+		}
+	}
+	return in
+}
+
 func polygonalsToRPC(polys []geom.Polygonal) []*rpc.Polygon {
 	o := make([]*rpc.Polygon, len(polys))
 	for i, poly := range polys {
@@ -164,141 +379,113 @@ func polygonsToRPC(polys []geom.Polygon) []*rpc.Polygon {
 	return o
 }
 
-// geojsonGeometry returns the geometry of the requested geojson file.
-func (c *CityAQ) geojsonGeometry(cityName string) (geom.Polygon, error) {
-	type gj struct {
-		Type     string `json:"type"`
-		Features []struct {
-			Type     string           `json:"type"`
-			Geometry geojson.Geometry `json:"geometry"`
-		} `json:"features"`
-	}
-
-	c.loadCityPaths()
-	path, ok := c.cityPaths[cityName]
-	if !ok {
-		return nil, fmt.Errorf("invalid city name %s", cityName)
-	}
-
-	f, err := os.Open(path)
-	if err != nil {
-		return nil, fmt.Errorf("opening city geojson file: %v", err)
-	}
-	dec := json.NewDecoder(f)
-	var data gj
-	if err := dec.Decode(&data); err != nil {
-		return nil, err
-	}
-
-	var polys geom.Polygon
-	for _, ft := range data.Features {
-		g, err := geojson.FromGeoJSON(&ft.Geometry)
-		if err != nil {
-			return nil, err
-		}
-		switch g.(type) {
-		case geom.Polygon:
-			polys = append(polys, g.(geom.Polygon)...)
-		case geom.MultiPolygon:
-			for _, poly := range g.(geom.MultiPolygon) {
-				polys = append(polys, poly...)
+// defaultCityName returns the default name of a city from its
+// feature properties.
+func defaultCityName(props map[string]interface{}) (string, error) {
+	for _, key := range []string{"c40_city_name", "name"} {
+		if name, ok := props[key]; ok {
+			nameStr, ok := name.(string)
+			if !ok || nameStr == "" {
+				return "", fmt.Errorf("invalid %s: %v", key, name)
 			}
+			return nameStr, nil
 		}
 	}
-	return polys, nil
+	return "", fmt.Errorf("missing c40_city_name or name property")
 }
 
-// geojsonName returns a city name (in the requested language)
-// from a GeoJSON file. (Language doesn't currently do anything)
-func (c *CityAQ) geojsonName(path, _ string) (string, error) {
-	type gj interface{}
-
-	f, err := os.Open(path)
-	if err != nil {
-		return "", err
-	}
-	dec := json.NewDecoder(f)
-	var data gj
-	if err := dec.Decode(&data); err != nil {
-		return "", fmt.Errorf("file %s: %v", path, err)
-	}
-	features := data.(map[string]interface{})["features"].([]interface{})
-	for _, feat := range features {
-		featmap, ok := feat.(map[string]interface{})
-		if !ok {
-			continue
-		}
-		props, ok := featmap["properties"]
-		if !ok {
-			continue
+// localizedCityName returns the name of a city in the requested language
+// from its feature properties, using OpenStreetMap-style
+// "name:<lang>" properties. If the name is not available in the requested
+// language, the name in the base language (for example "pt" for "pt-BR")
+// is used, and if that is not available either, the default name is used.
+func localizedCityName(props map[string]interface{}, lang string) (string, error) {
+	lang = strings.Replace(strings.TrimSpace(lang), "_", "-", -1)
+	if lang != "" {
+		candidates := []string{lang}
+		if i := strings.Index(lang, "-"); i > 0 {
+			candidates = append(candidates, lang[:i])
 		}
-		propmap, ok := props.(map[string]interface{})
-		if !ok {
-			continue
-		}
-		name, ok := propmap["c40_city_name"]
-		if !ok {
-			name, ok = propmap["name"]
-			if !ok {
-				return "", fmt.Errorf("file %s, missing name", path)
+		for _, l := range candidates {
+			for key, name := range props {
+				if !strings.EqualFold(key, "name:"+l) {
+					continue
+				}
+				if nameStr, ok := name.(string); ok && nameStr != "" {
+					return nameStr, nil
+				}
 			}
 		}
-		return name.(string), nil
 	}
-	return "", fmt.Errorf("couldn't find name in %v", data)
+	return defaultCityName(props)
 }
 
 // emissionsGrid returns the grid to be used for mapping gridded information about the requested city.
-// dx is grid cell edge length in degrees.
-func (c *CityAQ) emissionsGrid(cityName, sourceType string, dx float64) ([]geom.Polygonal, error) {
+// If GridProjection is set, the grid cells are square in that projection with
+// edge length st.CellSize meters; otherwise they are square in degrees with edge
+// length st.Resolution. Either way, the cells have longitude and latitude coordinates.
+func (c *CityAQ) emissionsGrid(cityName string, st *sourceType) ([]geom.Polygonal, error) {
+	projection, err := c.gridProjection()
+	if err != nil {
+		return nil, err
+	}
+	dx := st.Resolution
+	if projection != lonLatProjection {
+		dx = st.CellSize
+	}
 	if dx <= 0 {
 		return nil, fmt.Errorf("cityaq: emissions grid dx must be >0 but is %g", dx)
 	}
-	polygon, err := c.geojsonGeometry(cityName)
+	polygon, err := c.gridDomain(cityName, st)
 	if err != nil {
 		return nil, err
 	}
-	if egugridEmissions(sourceType) {
-		// Use EGU grid geometry instead of city.
-		country, err := c.countryOrGridBuffer(cityName)
-		if err != nil {
-			return nil, err
-		}
-		polygon = country.Polygon
-	}
 	b := polygon.Bounds()
 
 	if b.Min.X >= b.Max.X || b.Min.Y >= b.Max.Y {
-		return nil, fmt.Errorf("invalid emissionsGrid bounding box (%+v) for %s %s", b, cityName, sourceType)
-	}
-
-	var o []geom.Polygonal
-	const bufferFrac = 0.1
-	buffer := math.Sqrt((b.Max.X-b.Min.X)*(b.Max.Y-b.Min.Y)) * bufferFrac
-	b.Min.X -= buffer
-	b.Min.Y -= buffer
-	b.Max.X += buffer
-	b.Max.Y += buffer
-	b.Min.X = roundUnit(b.Min.X, dx)
-	b.Min.Y = roundUnit(b.Min.Y, dx)
-	b.Max.X = roundUnit(b.Max.X+dx/2, dx) // Round the max values up.
-	b.Max.Y = roundUnit(b.Max.Y+dx/2, dx) // Round the max values up.
-	for y := b.Min.Y; y < b.Max.Y+dx; y += dx {
-		for x := b.Min.X; x < b.Max.X+dx; x += dx {
-			o = append(o, geom.Polygon{
-				{
-					{X: x, Y: y}, {X: x + dx, Y: y}, {X: x + dx, Y: y + dx}, {X: x, Y: y + dx},
-				},
-			})
+		return nil, fmt.Errorf("invalid emissionsGrid bounding box (%+v) for %s %s", b, cityName, st.Name)
+	}
+
+	if projection != lonLatProjection {
+		return projectedGrid(polygon, projection, dx)
+	}
+	return gridCells(b, dx)
+}
+
+// gridDomain returns the area that the emissions of st are allocated
+// within for the requested city: the city itself, or for source types
+// in the "egugrid" domain, the electric grid region of the city.
+func (c *CityAQ) gridDomain(cityName string, st *sourceType) (geom.Polygonal, error) {
+	cityGeom, err := c.cityGeometry(cityName)
+	if err != nil {
+		return nil, err
+	}
+	if st.egugrid() {
+		// Use EGU grid geometry instead of city.
+		region, err := c.egugridRegion(cityName)
+		if err != nil {
+			return nil, err
 		}
+		return region.MultiPolygon, nil
 	}
-	return o, nil
+	return cityGeom, nil
 }
 
 // EmissionsGridBounds returns the bounds of the grid to be used for
-// mapping gridded information about the requested city.
+// mapping gridded information about the requested city. For source types
+// in the "egugrid" domain, these are the bounds of the grid region,
+// country, or buffer around the city that the emissions are allocated
+// within, as described in GriddedEmissions.
 func (c *CityAQ) EmissionsGridBounds(ctx context.Context, req *rpc.EmissionsGridBoundsRequest) (*rpc.EmissionsGridBoundsResponse, error) {
-	o, err := c.emissionsGrid(req.CityName, req.SourceType, mapResolution(req.SourceType, req.CityName))
+	st, err := c.sourceTypeOrSector(req.SourceType)
+	if err != nil {
+		return nil, err
+	}
+	st, err = c.gridSourceType(req.CityName, st, req.Resolution, req.AutoResolution)
+	if err != nil {
+		return nil, err
+	}
+	o, err := c.emissionsGrid(req.CityName, st)
 	if err != nil {
 		return nil, err
 	}
@@ -318,9 +505,12 @@ func (c *CityAQ) MapScale(ctx context.Context, req *rpc.MapScaleRequest) (*rpc.M
 	switch req.ImpactType {
 	case rpc.ImpactType_Emissions:
 		response, err := c.GriddedEmissions(ctx, &rpc.GriddedEmissionsRequest{
-			CityName:   req.CityName,
-			Emission:   req.Emission,
-			SourceType: req.SourceType,
+			CityName:       req.CityName,
+			Emission:       req.Emission,
+			SourceType:     req.SourceType,
+			SimulationType: req.SimulationType,
+			Resolution:     req.Resolution,
+			AutoResolution: req.AutoResolution,
 		})
 		if err != nil {
 			return nil, err
@@ -332,6 +522,8 @@ func (c *CityAQ) MapScale(ctx context.Context, req *rpc.MapScaleRequest) (*rpc.M
 			Emission:       req.Emission,
 			SourceType:     req.SourceType,
 			SimulationType: req.SimulationType,
+			Resolution:     req.Resolution,
+			AutoResolution: req.AutoResolution,
 		})
 		if err != nil {
 			return nil, err
@@ -8,6 +8,7 @@ import (
 	"os"
 	"reflect"
 	"testing"
+	"time"
 
 	"github.com/ctessum/cityaq/cityaqrpc"
 	rpc "github.com/ctessum/cityaq/cityaqrpc"
@@ -34,12 +35,71 @@ func TestCityAQ_Cities(t *testing.T) {
 			"Accra Metropolitan",
 			"ڪراچي Karachi",
 		},
+		Cities: []*rpc.City{
+			{ID: "accra-metropolitan", DisplayName: "Accra Metropolitan"},
+			{ID: "karachi", DisplayName: "ڪراچي Karachi", Country: "Pakistan", Region: "Asia"},
+		},
+		TotalSize: 2,
 	}
 	if !reflect.DeepEqual(want, cities) {
 		t.Errorf("%v != %v", cities, want)
 	}
 }
 
+func TestCityAQ_CitiesLanguage(t *testing.T) {
+	c := &CityAQ{
+		CityGeomDir: "testdata/cities",
+	}
+
+	for _, test := range []struct {
+		lang string
+		want []*rpc.City
+	}{
+		{
+			lang: "de",
+			want: []*rpc.City{
+				{ID: "accra-metropolitan", DisplayName: "Accra Metropolitan"},
+				{ID: "karachi", DisplayName: "Karatschi", Country: "Pakistan", Region: "Asia"},
+			},
+		},
+		{
+			lang: "en-US",
+			want: []*rpc.City{
+				{ID: "accra-metropolitan", DisplayName: "Accra Metropolitan"},
+				{ID: "karachi", DisplayName: "Karachi", Country: "Pakistan", Region: "Asia"},
+			},
+		},
+		{
+			lang: "xx",
+			want: []*rpc.City{
+				{ID: "accra-metropolitan", DisplayName: "Accra Metropolitan"},
+				{ID: "karachi", DisplayName: "ڪراچي Karachi", Country: "Pakistan", Region: "Asia"},
+			},
+		},
+	} {
+		t.Run(test.lang, func(t *testing.T) {
+			cities, err := c.Cities(context.Background(), &rpc.CitiesRequest{Language: test.lang})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(test.want, cities.Cities) {
+				t.Errorf("%v != %v", cities.Cities, test.want)
+			}
+		})
+	}
+
+	cityGeom, err := c.CityGeometry(context.Background(), &rpc.CityGeometryRequest{
+		CityName: "karachi",
+		Language: "de",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := "Karatschi"; cityGeom.DisplayName != want {
+		t.Errorf("%s != %s", cityGeom.DisplayName, want)
+	}
+}
+
 func TestCityAQ_CityGeometry(t *testing.T) {
 	r := &rpc.CityGeometryRequest{
 		CityName: "Accra Metropolitan",
@@ -62,6 +122,66 @@ func TestCityAQ_CityGeometry(t *testing.T) {
 	}
 }
 
+func TestCityAQ_CityInfo(t *testing.T) {
+	c := &CityAQ{
+		CityGeomDir: "testdata/cities",
+		SpatialConfig: aeputil.SpatialConfig{
+			SrgShapefileDirectory: "testdata",
+		},
+	}
+	info, err := c.CityInfo(context.Background(), &rpc.CityInfoRequest{
+		CityName: "accra-metropolitan",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if info.ID != "accra-metropolitan" {
+		t.Errorf("id: %s", info.ID)
+	}
+	if info.DisplayName != "Accra Metropolitan" {
+		t.Errorf("display name: %s", info.DisplayName)
+	}
+	if !similar(info.Area, 167.42084945663018, 1.0e-8) {
+		t.Errorf("area: %g", info.Area)
+	}
+	wantMin := &rpc.Point{X: -0.2843138, Y: 5.5150962}
+	wantMax := &rpc.Point{X: -0.1248164, Y: 5.6539649}
+	if !reflect.DeepEqual(info.Min, wantMin) || !reflect.DeepEqual(info.Max, wantMax) {
+		t.Errorf("bounds: %v, %v", info.Min, info.Max)
+	}
+	if info.Centroid.X < wantMin.X || info.Centroid.X > wantMax.X ||
+		info.Centroid.Y < wantMin.Y || info.Centroid.Y > wantMax.Y {
+		t.Errorf("centroid %v is outside of the bounding box", info.Centroid)
+	}
+	if info.Country != "Ghana" {
+		t.Errorf("country: %s", info.Country)
+	}
+	egugrid := polygonBounds(info.EGUGrid)
+	wantEGUGrid := &geom.Bounds{
+		Min: geom.Point{X: -3.24888920783991, Y: 4.72708272933966},
+		Max: geom.Point{X: 1.20277762413031, Y: 11.1556930541993},
+	}
+	if !reflect.DeepEqual(egugrid, wantEGUGrid) {
+		t.Errorf("egugrid bounds: %v != %v", egugrid, wantEGUGrid)
+	}
+	wantProps := `{"@id":"relation/1991850","admin_level":"6","boundary":"administrative","name":"Accra Metropolitan","source":"wikipedia","timezone":"Africa/Accra","type":"boundary","wikidata":"Q3761"}`
+	if info.Properties != wantProps {
+		t.Errorf("properties: %s != %s", info.Properties, wantProps)
+	}
+}
+
+func TestSphericalArea(t *testing.T) {
+	// A 1°x1° square at the equator with a 0.5°x0.5° hole.
+	p := geom.Polygon{
+		{{X: 0, Y: 0}, {X: 1, Y: 0}, {X: 1, Y: 1}, {X: 0, Y: 1}},
+		{{X: 0.25, Y: 0.25}, {X: 0.25, Y: 0.75}, {X: 0.75, Y: 0.75}, {X: 0.75, Y: 0.25}},
+	}
+	want := 9272.759185183495
+	if have := sphericalArea(p); !similar(have, want, 1.0e-6) {
+		t.Errorf("%g != %g", have, want)
+	}
+}
+
 func polygonBounds(polys []*rpc.Polygon) *geom.Bounds {
 	b := geom.NewBounds()
 	for _, poly := range polys {
@@ -162,6 +282,145 @@ func TestCityAQ_griddedEmissions(t *testing.T) {
 	}
 }
 
+func TestCityAQ_griddedEmissionsAmount(t *testing.T) {
+	c := &CityAQ{
+		CityGeomDir: "testdata/cities",
+		SpatialConfig: aeputil.SpatialConfig{
+			SrgSpecOSM:    "testdata/srgspec_osm.json",
+			SCCExactMatch: true,
+			GridRef:       []string{"testdata/gridref.txt"},
+			OutputSR:      "+proj=longlat",
+			InputSR:       "+proj=longlat",
+		},
+	}
+	req := &rpc.GriddedEmissionsRequest{
+		CityName:    "Accra Metropolitan",
+		Emission:    rpc.Emission_PM2_5,
+		SourceType:  "roadways",
+		Amount:      250,
+		AmountUnits: rpc.EmissionUnits_Tonnes,
+		Begin:       time.Date(2020, time.June, 1, 0, 0, 0, 0, time.UTC).Unix(),
+		End:         time.Date(2020, time.July, 1, 0, 0, 0, 0, time.UTC).Unix(),
+	}
+	emis, err := c.GriddedEmissions(context.Background(), req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	sum := floats.Sum(emis.Emissions)
+	want := 2.5e5
+	if !similar(sum, want, 1e-8) {
+		t.Errorf("have %g, want %g", sum, want)
+	}
+}
+
+func TestCityAQ_griddedEmissionsPollutants(t *testing.T) {
+	c := &CityAQ{
+		CityGeomDir: "testdata/cities",
+		SpatialConfig: aeputil.SpatialConfig{
+			SrgSpecOSM:    "testdata/srgspec_osm.json",
+			SCCExactMatch: true,
+			GridRef:       []string{"testdata/gridref.txt"},
+			OutputSR:      "+proj=longlat",
+			InputSR:       "+proj=longlat",
+		},
+	}
+	req := &rpc.GriddedEmissionsRequest{
+		CityName:   "Accra Metropolitan",
+		SourceType: "roadways",
+		Pollutants: []*rpc.PollutantAmount{
+			{Emission: rpc.Emission_NOx, Amount: 20, AmountUnits: rpc.EmissionUnits_Tonnes},
+			{Emission: rpc.Emission_PM2_5, Amount: 1, AmountUnits: rpc.EmissionUnits_Tonnes},
+		},
+	}
+	emis, err := c.GriddedEmissions(context.Background(), req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(emis.Emissions) != 0 {
+		t.Errorf("Emissions should be empty, but has length %d", len(emis.Emissions))
+	}
+	if len(emis.PollutantEmissions) != 2 {
+		t.Fatalf("wrong number of pollutants: %d", len(emis.PollutantEmissions))
+	}
+	nox, pm := emis.PollutantEmissions[0], emis.PollutantEmissions[1]
+	if nox.Emission != rpc.Emission_NOx || pm.Emission != rpc.Emission_PM2_5 {
+		t.Errorf("wrong pollutant order: %v, %v", nox.Emission, pm.Emission)
+	}
+	if sum := floats.Sum(nox.Emissions); !similar(sum, 2.0e4, 1e-8) {
+		t.Errorf("NOx: have %g, want %g", sum, 2.0e4)
+	}
+	if sum := floats.Sum(pm.Emissions); !similar(sum, 1.0e3, 1e-8) {
+		t.Errorf("PM2.5: have %g, want %g", sum, 1.0e3)
+	}
+	for i, v := range nox.Emissions {
+		if !similar(v, 20*pm.Emissions[i], 1e-8) {
+			t.Errorf("cell %d: NOx %g is not 20 times PM2.5 %g", i, v, pm.Emissions[i])
+			break
+		}
+	}
+
+	for _, pollutants := range [][]*rpc.PollutantAmount{
+		{{Emission: rpc.Emission_NOx}, {Emission: rpc.Emission_NOx}},
+		{{Emission: rpc.Emission_UNKNOWN_EMISSION}},
+		{nil},
+	} {
+		req.Pollutants = pollutants
+		if _, err := c.GriddedEmissions(context.Background(), req); err == nil {
+			t.Errorf("%v: expected an error", pollutants)
+		}
+	}
+}
+
+func TestRequestAmount(t *testing.T) {
+	begin := time.Date(2019, time.January, 1, 0, 0, 0, 0, time.UTC).Unix()
+	end := time.Date(2019, time.July, 2, 12, 0, 0, 0, time.UTC).Unix() // Half of a year.
+	tests := []struct {
+		name       string
+		amount     float64
+		units      rpc.EmissionUnits
+		begin, end int64
+		mass       float64
+		scale      float64
+		err        bool
+	}{
+		{name: "default", mass: 1.0e6, scale: 1},
+		{name: "kg", amount: 10, units: rpc.EmissionUnits_Kilograms, mass: 10, scale: 1.0e-5},
+		{name: "t", amount: 10, units: rpc.EmissionUnits_Tonnes, mass: 1.0e4, scale: 1.0e-2},
+		{name: "kt", amount: 10, units: rpc.EmissionUnits_Kilotonnes, mass: 1.0e7, scale: 10},
+		{
+			name: "Mg/yr", amount: 1000, units: rpc.EmissionUnits_MegagramsPerYear,
+			begin: begin, end: end, mass: 0.5e6, scale: 366.0 / 365,
+		},
+		{
+			name: "period", amount: 1, units: rpc.EmissionUnits_Kilotonnes,
+			begin: begin, end: end, mass: 1.0e6, scale: 2 * 366.0 / 365,
+		},
+		{name: "no units", amount: 1, err: true},
+		{name: "negative", amount: -1, units: rpc.EmissionUnits_Kilograms, err: true},
+		{name: "backwards", begin: end, end: begin, err: true},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			a, err := requestAmount(test.amount, test.units, test.begin, test.end)
+			if test.err {
+				if err == nil {
+					t.Error("expected an error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !similar(a.mass, test.mass, 1e-8) {
+				t.Errorf("mass: %g != %g", a.mass, test.mass)
+			}
+			if !similar(a.scale(), test.scale, 1e-8) {
+				t.Errorf("scale: %g != %g", a.scale(), test.scale)
+			}
+		})
+	}
+}
+
 func similar(a, b, tol float64) bool {
 	if math.Abs(a-b) > tol || 2*math.Abs(a-b)/(a+b) > tol {
 		return false
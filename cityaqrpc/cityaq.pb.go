@@ -31,6 +31,175 @@ const (
 // of the legacy proto package is being used.
 const _ = proto.ProtoPackageIsVersion4
 
+// TemporalResolution is the length of the time steps of
+// time-resolved emissions. Days and months are in the local
+// time of the city, including daylight saving time, from the IANA
+// time zone in the "timezone" property of its boundary.
+type TemporalResolution int32
+
+const (
+	TemporalResolution_UNKNOWN_TEMPORALRESOLUTION TemporalResolution = 0
+	TemporalResolution_Hourly                     TemporalResolution = 1
+	TemporalResolution_Daily                      TemporalResolution = 2
+	TemporalResolution_Monthly                    TemporalResolution = 3
+)
+
+// Enum value maps for TemporalResolution.
+var (
+	TemporalResolution_name = map[int32]string{
+		0: "UNKNOWN_TEMPORALRESOLUTION",
+		1: "Hourly",
+		2: "Daily",
+		3: "Monthly",
+	}
+	TemporalResolution_value = map[string]int32{
+		"UNKNOWN_TEMPORALRESOLUTION": 0,
+		"Hourly":                     1,
+		"Daily":                      2,
+		"Monthly":                    3,
+	}
+)
+
+func (x TemporalResolution) Enum() *TemporalResolution {
+	p := new(TemporalResolution)
+	*p = x
+	return p
+}
+
+func (x TemporalResolution) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (TemporalResolution) Descriptor() protoreflect.EnumDescriptor {
+	return file_cityaq_proto_enumTypes[0].Descriptor()
+}
+
+func (TemporalResolution) Type() protoreflect.EnumType {
+	return &file_cityaq_proto_enumTypes[0]
+}
+
+func (x TemporalResolution) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use TemporalResolution.Descriptor instead.
+func (TemporalResolution) EnumDescriptor() ([]byte, []int) {
+	return file_cityaq_proto_rawDescGZIP(), []int{0}
+}
+
+// SurrogateWeight specifies how spatial surrogate features are weighted.
+type SurrogateWeight int32
+
+const (
+	SurrogateWeight_UNKNOWN_SURROGATEWEIGHT SurrogateWeight = 0
+	// Length weights ways by their length. Nodes are not included.
+	SurrogateWeight_Length SurrogateWeight = 1
+	// Area weights closed ways by their area.
+	// Nodes and open ways are not included.
+	SurrogateWeight_Area SurrogateWeight = 2
+	// Count gives each node and way a weight of one,
+	// located at its center.
+	SurrogateWeight_Count SurrogateWeight = 3
+)
+
+// Enum value maps for SurrogateWeight.
+var (
+	SurrogateWeight_name = map[int32]string{
+		0: "UNKNOWN_SURROGATEWEIGHT",
+		1: "Length",
+		2: "Area",
+		3: "Count",
+	}
+	SurrogateWeight_value = map[string]int32{
+		"UNKNOWN_SURROGATEWEIGHT": 0,
+		"Length":                  1,
+		"Area":                    2,
+		"Count":                   3,
+	}
+)
+
+func (x SurrogateWeight) Enum() *SurrogateWeight {
+	p := new(SurrogateWeight)
+	*p = x
+	return p
+}
+
+func (x SurrogateWeight) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (SurrogateWeight) Descriptor() protoreflect.EnumDescriptor {
+	return file_cityaq_proto_enumTypes[1].Descriptor()
+}
+
+func (SurrogateWeight) Type() protoreflect.EnumType {
+	return &file_cityaq_proto_enumTypes[1]
+}
+
+func (x SurrogateWeight) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use SurrogateWeight.Descriptor instead.
+func (SurrogateWeight) EnumDescriptor() ([]byte, []int) {
+	return file_cityaq_proto_rawDescGZIP(), []int{1}
+}
+
+// AllocationDomain is an area that emissions are allocated within.
+type AllocationDomain int32
+
+const (
+	AllocationDomain_UNKNOWN_ALLOCATIONDOMAIN AllocationDomain = 0
+	// City is the city boundary.
+	AllocationDomain_City AllocationDomain = 1
+	// EGUGrid is the electric grid region that the city is in, if the
+	// server has a file of grid regions and one of them overlaps the city.
+	// Otherwise, it is the smaller of the country that the city is in or a
+	// buffer around the city the size of an average electric grid region.
+	AllocationDomain_EGUGrid AllocationDomain = 2
+)
+
+// Enum value maps for AllocationDomain.
+var (
+	AllocationDomain_name = map[int32]string{
+		0: "UNKNOWN_ALLOCATIONDOMAIN",
+		1: "City",
+		2: "EGUGrid",
+	}
+	AllocationDomain_value = map[string]int32{
+		"UNKNOWN_ALLOCATIONDOMAIN": 0,
+		"City":                     1,
+		"EGUGrid":                  2,
+	}
+)
+
+func (x AllocationDomain) Enum() *AllocationDomain {
+	p := new(AllocationDomain)
+	*p = x
+	return p
+}
+
+func (x AllocationDomain) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (AllocationDomain) Descriptor() protoreflect.EnumDescriptor {
+	return file_cityaq_proto_enumTypes[2].Descriptor()
+}
+
+func (AllocationDomain) Type() protoreflect.EnumType {
+	return &file_cityaq_proto_enumTypes[2]
+}
+
+func (x AllocationDomain) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use AllocationDomain.Descriptor instead.
+func (AllocationDomain) EnumDescriptor() ([]byte, []int) {
+	return file_cityaq_proto_rawDescGZIP(), []int{2}
+}
+
 type Emission int32
 
 const (
@@ -73,11 +242,11 @@ func (x Emission) String() string {
 }
 
 func (Emission) Descriptor() protoreflect.EnumDescriptor {
-	return file_cityaq_proto_enumTypes[0].Descriptor()
+	return file_cityaq_proto_enumTypes[3].Descriptor()
 }
 
 func (Emission) Type() protoreflect.EnumType {
-	return &file_cityaq_proto_enumTypes[0]
+	return &file_cityaq_proto_enumTypes[3]
 }
 
 func (x Emission) Number() protoreflect.EnumNumber {
@@ -86,7 +255,136 @@ func (x Emission) Number() protoreflect.EnumNumber {
 
 // Deprecated: Use Emission.Descriptor instead.
 func (Emission) EnumDescriptor() ([]byte, []int) {
-	return file_cityaq_proto_rawDescGZIP(), []int{0}
+	return file_cityaq_proto_rawDescGZIP(), []int{3}
+}
+
+// EmissionUnits are the units of an amount of emissions.
+type EmissionUnits int32
+
+const (
+	EmissionUnits_UNKNOWN_EMISSIONUNITS EmissionUnits = 0
+	// Kilograms, Tonnes, and Kilotonnes are the total mass
+	// emitted over the emissions period.
+	EmissionUnits_Kilograms  EmissionUnits = 1
+	EmissionUnits_Tonnes     EmissionUnits = 2
+	EmissionUnits_Kilotonnes EmissionUnits = 3
+	// MegagramsPerYear is the rate of emissions during the
+	// emissions period, where a year is 365 days long.
+	EmissionUnits_MegagramsPerYear EmissionUnits = 4
+)
+
+// Enum value maps for EmissionUnits.
+var (
+	EmissionUnits_name = map[int32]string{
+		0: "UNKNOWN_EMISSIONUNITS",
+		1: "Kilograms",
+		2: "Tonnes",
+		3: "Kilotonnes",
+		4: "MegagramsPerYear",
+	}
+	EmissionUnits_value = map[string]int32{
+		"UNKNOWN_EMISSIONUNITS": 0,
+		"Kilograms":             1,
+		"Tonnes":                2,
+		"Kilotonnes":            3,
+		"MegagramsPerYear":      4,
+	}
+)
+
+func (x EmissionUnits) Enum() *EmissionUnits {
+	p := new(EmissionUnits)
+	*p = x
+	return p
+}
+
+func (x EmissionUnits) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (EmissionUnits) Descriptor() protoreflect.EnumDescriptor {
+	return file_cityaq_proto_enumTypes[4].Descriptor()
+}
+
+func (EmissionUnits) Type() protoreflect.EnumType {
+	return &file_cityaq_proto_enumTypes[4]
+}
+
+func (x EmissionUnits) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use EmissionUnits.Descriptor instead.
+func (EmissionUnits) EnumDescriptor() ([]byte, []int) {
+	return file_cityaq_proto_rawDescGZIP(), []int{4}
+}
+
+// ExportFormat is a file format for exported gridded data. All formats
+// include the units of each variable and information about how the
+// data were created.
+type ExportFormat int32
+
+const (
+	ExportFormat_UNKNOWN_EXPORTFORMAT ExportFormat = 0
+	// GeoTIFF is a raster with one band per variable. It can only be
+	// used when the grid cells are aligned to a regular longitude-latitude
+	// raster; cells that are larger than the raster cells are split.
+	ExportFormat_GeoTIFF ExportFormat = 1
+	// NetCDF is a CF-compliant NetCDF file.
+	ExportFormat_NetCDF ExportFormat = 2
+	// Shapefile is a zipped ESRI shapefile.
+	ExportFormat_Shapefile ExportFormat = 3
+	// GeoJSON is a GeoJSON feature collection.
+	ExportFormat_GeoJSON ExportFormat = 4
+	// CSV is a comma-separated values file with the geometry of each grid
+	// cell in well-known text (WKT) format.
+	ExportFormat_CSV ExportFormat = 5
+)
+
+// Enum value maps for ExportFormat.
+var (
+	ExportFormat_name = map[int32]string{
+		0: "UNKNOWN_EXPORTFORMAT",
+		1: "GeoTIFF",
+		2: "NetCDF",
+		3: "Shapefile",
+		4: "GeoJSON",
+		5: "CSV",
+	}
+	ExportFormat_value = map[string]int32{
+		"UNKNOWN_EXPORTFORMAT": 0,
+		"GeoTIFF":              1,
+		"NetCDF":               2,
+		"Shapefile":            3,
+		"GeoJSON":              4,
+		"CSV":                  5,
+	}
+)
+
+func (x ExportFormat) Enum() *ExportFormat {
+	p := new(ExportFormat)
+	*p = x
+	return p
+}
+
+func (x ExportFormat) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (ExportFormat) Descriptor() protoreflect.EnumDescriptor {
+	return file_cityaq_proto_enumTypes[5].Descriptor()
+}
+
+func (ExportFormat) Type() protoreflect.EnumType {
+	return &file_cityaq_proto_enumTypes[5]
+}
+
+func (x ExportFormat) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use ExportFormat.Descriptor instead.
+func (ExportFormat) EnumDescriptor() ([]byte, []int) {
+	return file_cityaq_proto_rawDescGZIP(), []int{5}
 }
 
 type ImpactType int32
@@ -122,11 +420,11 @@ func (x ImpactType) String() string {
 }
 
 func (ImpactType) Descriptor() protoreflect.EnumDescriptor {
-	return file_cityaq_proto_enumTypes[1].Descriptor()
+	return file_cityaq_proto_enumTypes[6].Descriptor()
 }
 
 func (ImpactType) Type() protoreflect.EnumType {
-	return &file_cityaq_proto_enumTypes[1]
+	return &file_cityaq_proto_enumTypes[6]
 }
 
 func (x ImpactType) Number() protoreflect.EnumNumber {
@@ -135,7 +433,7 @@ func (x ImpactType) Number() protoreflect.EnumNumber {
 
 // Deprecated: Use ImpactType.Descriptor instead.
 func (ImpactType) EnumDescriptor() ([]byte, []int) {
-	return file_cityaq_proto_rawDescGZIP(), []int{1}
+	return file_cityaq_proto_rawDescGZIP(), []int{6}
 }
 
 type SimulationType int32
@@ -174,11 +472,11 @@ func (x SimulationType) String() string {
 }
 
 func (SimulationType) Descriptor() protoreflect.EnumDescriptor {
-	return file_cityaq_proto_enumTypes[2].Descriptor()
+	return file_cityaq_proto_enumTypes[7].Descriptor()
 }
 
 func (SimulationType) Type() protoreflect.EnumType {
-	return &file_cityaq_proto_enumTypes[2]
+	return &file_cityaq_proto_enumTypes[7]
 }
 
 func (x SimulationType) Number() protoreflect.EnumNumber {
@@ -187,13 +485,39 @@ func (x SimulationType) Number() protoreflect.EnumNumber {
 
 // Deprecated: Use SimulationType.Descriptor instead.
 func (SimulationType) EnumDescriptor() ([]byte, []int) {
-	return file_cityaq_proto_rawDescGZIP(), []int{2}
+	return file_cityaq_proto_rawDescGZIP(), []int{7}
 }
 
 type CitiesRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
+
+	// Language is the code of the language that city names should be
+	// displayed in, for example "es", "pt-BR", or "fr". If it is empty,
+	// or a name is not available in the requested language, the default
+	// name of each city is used.
+	Language string `protobuf:"bytes,1,opt,name=Language,proto3" json:"Language,omitempty"`
+	// Query, if specified, limits the results to cities whose ID,
+	// default name, or display name contains it, ignoring case and
+	// accents. Names that differ from the query by a small number of
+	// typing errors also match.
+	Query string `protobuf:"bytes,2,opt,name=Query,proto3" json:"Query,omitempty"`
+	// Country, if specified, limits the results to cities in the
+	// country with this name, ignoring case.
+	Country string `protobuf:"bytes,3,opt,name=Country,proto3" json:"Country,omitempty"`
+	// Min and Max, if specified, are the south-west and north-east
+	// corners of a longitude-latitude bounding box. Only cities whose
+	// boundaries overlap the box are returned.
+	Min *Point `protobuf:"bytes,4,opt,name=Min,proto3" json:"Min,omitempty"`
+	Max *Point `protobuf:"bytes,5,opt,name=Max,proto3" json:"Max,omitempty"`
+	// PageSize is the maximum number of cities to return. If it is
+	// zero, all matching cities are returned.
+	PageSize int32 `protobuf:"varint,6,opt,name=PageSize,proto3" json:"PageSize,omitempty"`
+	// PageToken is the NextPageToken from a previous response, which
+	// is used to request the next page of results. The other fields of
+	// the request must be the same as in the previous request.
+	PageToken string `protobuf:"bytes,7,opt,name=PageToken,proto3" json:"PageToken,omitempty"`
 }
 
 func (x *CitiesRequest) Reset() {
@@ -228,13 +552,71 @@ func (*CitiesRequest) Descriptor() ([]byte, []int) {
 	return file_cityaq_proto_rawDescGZIP(), []int{0}
 }
 
+func (x *CitiesRequest) GetLanguage() string {
+	if x != nil {
+		return x.Language
+	}
+	return ""
+}
+
+func (x *CitiesRequest) GetQuery() string {
+	if x != nil {
+		return x.Query
+	}
+	return ""
+}
+
+func (x *CitiesRequest) GetCountry() string {
+	if x != nil {
+		return x.Country
+	}
+	return ""
+}
+
+func (x *CitiesRequest) GetMin() *Point {
+	if x != nil {
+		return x.Min
+	}
+	return nil
+}
+
+func (x *CitiesRequest) GetMax() *Point {
+	if x != nil {
+		return x.Max
+	}
+	return nil
+}
+
+func (x *CitiesRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+func (x *CitiesRequest) GetPageToken() string {
+	if x != nil {
+		return x.PageToken
+	}
+	return ""
+}
+
 type CitiesResponse struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	// The names of the cities
+	// The default names of the cities, in the same order as Cities.
+	// Names can be used to specify cities in other requests, but IDs are
+	// preferred because they don't change when a city is renamed.
 	Names []string `protobuf:"bytes,1,rep,name=Names,proto3" json:"Names,omitempty"`
+	// Cities holds information about each city.
+	Cities []*City `protobuf:"bytes,2,rep,name=Cities,proto3" json:"Cities,omitempty"`
+	// NextPageToken can be used to request the next page of results.
+	// It is empty if there are no more results.
+	NextPageToken string `protobuf:"bytes,3,opt,name=NextPageToken,proto3" json:"NextPageToken,omitempty"`
+	// TotalSize is the total number of cities matching the request.
+	TotalSize int32 `protobuf:"varint,4,opt,name=TotalSize,proto3" json:"TotalSize,omitempty"`
 }
 
 func (x *CitiesResponse) Reset() {
@@ -276,16 +658,51 @@ func (x *CitiesResponse) GetNames() []string {
 	return nil
 }
 
-type CityGeometryRequest struct {
+func (x *CitiesResponse) GetCities() []*City {
+	if x != nil {
+		return x.Cities
+	}
+	return nil
+}
+
+func (x *CitiesResponse) GetNextPageToken() string {
+	if x != nil {
+		return x.NextPageToken
+	}
+	return ""
+}
+
+func (x *CitiesResponse) GetTotalSize() int32 {
+	if x != nil {
+		return x.TotalSize
+	}
+	return 0
+}
+
+// City identifies a city.
+type City struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	CityName string `protobuf:"bytes,1,opt,name=CityName,proto3" json:"CityName,omitempty"`
-}
-
-func (x *CityGeometryRequest) Reset() {
-	*x = CityGeometryRequest{}
+	// ID is the stable identifier of the city, which is used to
+	// specify the city in other requests.
+	ID string `protobuf:"bytes,1,opt,name=ID,proto3" json:"ID,omitempty"`
+	// DisplayName is the name of the city in the requested language.
+	DisplayName string `protobuf:"bytes,2,opt,name=DisplayName,proto3" json:"DisplayName,omitempty"`
+	// Country is the name of the country that the city is in, or an
+	// empty string if it is not known. It is given by the "country" or
+	// "is_in:country" property of the boundary file if present, and
+	// otherwise by the country that the boundary overlaps the most.
+	Country string `protobuf:"bytes,3,opt,name=Country,proto3" json:"Country,omitempty"`
+	// Region is the region of the world that the city is in, as given
+	// by the "region", "c40_region", or "is_in:continent" property of
+	// its boundary file, or an empty string if it is not known.
+	Region string `protobuf:"bytes,4,opt,name=Region,proto3" json:"Region,omitempty"`
+}
+
+func (x *City) Reset() {
+	*x = City{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_cityaq_proto_msgTypes[2]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -293,13 +710,13 @@ func (x *CityGeometryRequest) Reset() {
 	}
 }
 
-func (x *CityGeometryRequest) String() string {
+func (x *City) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*CityGeometryRequest) ProtoMessage() {}
+func (*City) ProtoMessage() {}
 
-func (x *CityGeometryRequest) ProtoReflect() protoreflect.Message {
+func (x *City) ProtoReflect() protoreflect.Message {
 	mi := &file_cityaq_proto_msgTypes[2]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -311,28 +728,52 @@ func (x *CityGeometryRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use CityGeometryRequest.ProtoReflect.Descriptor instead.
-func (*CityGeometryRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use City.ProtoReflect.Descriptor instead.
+func (*City) Descriptor() ([]byte, []int) {
 	return file_cityaq_proto_rawDescGZIP(), []int{2}
 }
 
-func (x *CityGeometryRequest) GetCityName() string {
+func (x *City) GetID() string {
 	if x != nil {
-		return x.CityName
+		return x.ID
 	}
 	return ""
 }
 
-type CityGeometryResponse struct {
+func (x *City) GetDisplayName() string {
+	if x != nil {
+		return x.DisplayName
+	}
+	return ""
+}
+
+func (x *City) GetCountry() string {
+	if x != nil {
+		return x.Country
+	}
+	return ""
+}
+
+func (x *City) GetRegion() string {
+	if x != nil {
+		return x.Region
+	}
+	return ""
+}
+
+type CityGeometryRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Polygons []*Polygon `protobuf:"bytes,1,rep,name=Polygons,proto3" json:"Polygons,omitempty"`
+	CityName string `protobuf:"bytes,1,opt,name=CityName,proto3" json:"CityName,omitempty"`
+	// Language is the code of the language that the city name should be
+	// displayed in. See CitiesRequest.Language.
+	Language string `protobuf:"bytes,2,opt,name=Language,proto3" json:"Language,omitempty"`
 }
 
-func (x *CityGeometryResponse) Reset() {
-	*x = CityGeometryResponse{}
+func (x *CityGeometryRequest) Reset() {
+	*x = CityGeometryRequest{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_cityaq_proto_msgTypes[3]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -340,13 +781,13 @@ func (x *CityGeometryResponse) Reset() {
 	}
 }
 
-func (x *CityGeometryResponse) String() string {
+func (x *CityGeometryRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*CityGeometryResponse) ProtoMessage() {}
+func (*CityGeometryRequest) ProtoMessage() {}
 
-func (x *CityGeometryResponse) ProtoReflect() protoreflect.Message {
+func (x *CityGeometryRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_cityaq_proto_msgTypes[3]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -358,28 +799,37 @@ func (x *CityGeometryResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use CityGeometryResponse.ProtoReflect.Descriptor instead.
-func (*CityGeometryResponse) Descriptor() ([]byte, []int) {
+// Deprecated: Use CityGeometryRequest.ProtoReflect.Descriptor instead.
+func (*CityGeometryRequest) Descriptor() ([]byte, []int) {
 	return file_cityaq_proto_rawDescGZIP(), []int{3}
 }
 
-func (x *CityGeometryResponse) GetPolygons() []*Polygon {
+func (x *CityGeometryRequest) GetCityName() string {
 	if x != nil {
-		return x.Polygons
+		return x.CityName
 	}
-	return nil
+	return ""
 }
 
-type Polygon struct {
+func (x *CityGeometryRequest) GetLanguage() string {
+	if x != nil {
+		return x.Language
+	}
+	return ""
+}
+
+type CityGeometryResponse struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Paths []*Path `protobuf:"bytes,1,rep,name=Paths,proto3" json:"Paths,omitempty"`
+	Polygons []*Polygon `protobuf:"bytes,1,rep,name=Polygons,proto3" json:"Polygons,omitempty"`
+	// DisplayName is the name of the city in the requested language.
+	DisplayName string `protobuf:"bytes,2,opt,name=DisplayName,proto3" json:"DisplayName,omitempty"`
 }
 
-func (x *Polygon) Reset() {
-	*x = Polygon{}
+func (x *CityGeometryResponse) Reset() {
+	*x = CityGeometryResponse{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_cityaq_proto_msgTypes[4]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -387,13 +837,13 @@ func (x *Polygon) Reset() {
 	}
 }
 
-func (x *Polygon) String() string {
+func (x *CityGeometryResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*Polygon) ProtoMessage() {}
+func (*CityGeometryResponse) ProtoMessage() {}
 
-func (x *Polygon) ProtoReflect() protoreflect.Message {
+func (x *CityGeometryResponse) ProtoReflect() protoreflect.Message {
 	mi := &file_cityaq_proto_msgTypes[4]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -405,28 +855,39 @@ func (x *Polygon) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use Polygon.ProtoReflect.Descriptor instead.
-func (*Polygon) Descriptor() ([]byte, []int) {
+// Deprecated: Use CityGeometryResponse.ProtoReflect.Descriptor instead.
+func (*CityGeometryResponse) Descriptor() ([]byte, []int) {
 	return file_cityaq_proto_rawDescGZIP(), []int{4}
 }
 
-func (x *Polygon) GetPaths() []*Path {
+func (x *CityGeometryResponse) GetPolygons() []*Polygon {
 	if x != nil {
-		return x.Paths
+		return x.Polygons
 	}
 	return nil
 }
 
-type Path struct {
+func (x *CityGeometryResponse) GetDisplayName() string {
+	if x != nil {
+		return x.DisplayName
+	}
+	return ""
+}
+
+type CitiesContainingRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
+	// Points are the longitude-latitude locations to look up.
 	Points []*Point `protobuf:"bytes,1,rep,name=Points,proto3" json:"Points,omitempty"`
+	// Language is the code of the language that city names should be
+	// displayed in, as in CitiesRequest.
+	Language string `protobuf:"bytes,2,opt,name=Language,proto3" json:"Language,omitempty"`
 }
 
-func (x *Path) Reset() {
-	*x = Path{}
+func (x *CitiesContainingRequest) Reset() {
+	*x = CitiesContainingRequest{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_cityaq_proto_msgTypes[5]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -434,13 +895,13 @@ func (x *Path) Reset() {
 	}
 }
 
-func (x *Path) String() string {
+func (x *CitiesContainingRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*Path) ProtoMessage() {}
+func (*CitiesContainingRequest) ProtoMessage() {}
 
-func (x *Path) ProtoReflect() protoreflect.Message {
+func (x *CitiesContainingRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_cityaq_proto_msgTypes[5]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -452,29 +913,37 @@ func (x *Path) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use Path.ProtoReflect.Descriptor instead.
-func (*Path) Descriptor() ([]byte, []int) {
+// Deprecated: Use CitiesContainingRequest.ProtoReflect.Descriptor instead.
+func (*CitiesContainingRequest) Descriptor() ([]byte, []int) {
 	return file_cityaq_proto_rawDescGZIP(), []int{5}
 }
 
-func (x *Path) GetPoints() []*Point {
+func (x *CitiesContainingRequest) GetPoints() []*Point {
 	if x != nil {
 		return x.Points
 	}
 	return nil
 }
 
-type Point struct {
+func (x *CitiesContainingRequest) GetLanguage() string {
+	if x != nil {
+		return x.Language
+	}
+	return ""
+}
+
+type CitiesContainingResponse struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	X float64 `protobuf:"fixed64,1,opt,name=X,proto3" json:"X,omitempty"`
-	Y float64 `protobuf:"fixed64,2,opt,name=Y,proto3" json:"Y,omitempty"`
+	// Points holds the cities containing each of the requested
+	// points, in the same order as the request.
+	Points []*PointCities `protobuf:"bytes,1,rep,name=Points,proto3" json:"Points,omitempty"`
 }
 
-func (x *Point) Reset() {
-	*x = Point{}
+func (x *CitiesContainingResponse) Reset() {
+	*x = CitiesContainingResponse{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_cityaq_proto_msgTypes[6]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -482,13 +951,13 @@ func (x *Point) Reset() {
 	}
 }
 
-func (x *Point) String() string {
+func (x *CitiesContainingResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*Point) ProtoMessage() {}
+func (*CitiesContainingResponse) ProtoMessage() {}
 
-func (x *Point) ProtoReflect() protoreflect.Message {
+func (x *CitiesContainingResponse) ProtoReflect() protoreflect.Message {
 	mi := &file_cityaq_proto_msgTypes[6]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -500,38 +969,33 @@ func (x *Point) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use Point.ProtoReflect.Descriptor instead.
-func (*Point) Descriptor() ([]byte, []int) {
+// Deprecated: Use CitiesContainingResponse.ProtoReflect.Descriptor instead.
+func (*CitiesContainingResponse) Descriptor() ([]byte, []int) {
 	return file_cityaq_proto_rawDescGZIP(), []int{6}
 }
 
-func (x *Point) GetX() float64 {
-	if x != nil {
-		return x.X
-	}
-	return 0
-}
-
-func (x *Point) GetY() float64 {
+func (x *CitiesContainingResponse) GetPoints() []*PointCities {
 	if x != nil {
-		return x.Y
+		return x.Points
 	}
-	return 0
+	return nil
 }
 
-type GriddedEmissionsRequest struct {
+// PointCities holds the cities that contain a point.
+type PointCities struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	CityName       string         `protobuf:"bytes,1,opt,name=CityName,proto3" json:"CityName,omitempty"`
-	SourceType     string         `protobuf:"bytes,2,opt,name=SourceType,proto3" json:"SourceType,omitempty"`
-	Emission       Emission       `protobuf:"varint,3,opt,name=Emission,proto3,enum=cityaqrpc.Emission" json:"Emission,omitempty"`
-	SimulationType SimulationType `protobuf:"varint,4,opt,name=SimulationType,proto3,enum=cityaqrpc.SimulationType" json:"SimulationType,omitempty"`
+	// Cities holds the cities whose boundaries contain the point,
+	// sorted by display name. It is empty if the point is not in
+	// any city. Boundaries of different cities can overlap, so a
+	// point can be in more than one city.
+	Cities []*City `protobuf:"bytes,1,rep,name=Cities,proto3" json:"Cities,omitempty"`
 }
 
-func (x *GriddedEmissionsRequest) Reset() {
-	*x = GriddedEmissionsRequest{}
+func (x *PointCities) Reset() {
+	*x = PointCities{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_cityaq_proto_msgTypes[7]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -539,13 +1003,13 @@ func (x *GriddedEmissionsRequest) Reset() {
 	}
 }
 
-func (x *GriddedEmissionsRequest) String() string {
+func (x *PointCities) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GriddedEmissionsRequest) ProtoMessage() {}
+func (*PointCities) ProtoMessage() {}
 
-func (x *GriddedEmissionsRequest) ProtoReflect() protoreflect.Message {
+func (x *PointCities) ProtoReflect() protoreflect.Message {
 	mi := &file_cityaq_proto_msgTypes[7]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -557,50 +1021,38 @@ func (x *GriddedEmissionsRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GriddedEmissionsRequest.ProtoReflect.Descriptor instead.
-func (*GriddedEmissionsRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use PointCities.ProtoReflect.Descriptor instead.
+func (*PointCities) Descriptor() ([]byte, []int) {
 	return file_cityaq_proto_rawDescGZIP(), []int{7}
 }
 
-func (x *GriddedEmissionsRequest) GetCityName() string {
-	if x != nil {
-		return x.CityName
-	}
-	return ""
-}
-
-func (x *GriddedEmissionsRequest) GetSourceType() string {
-	if x != nil {
-		return x.SourceType
-	}
-	return ""
-}
-
-func (x *GriddedEmissionsRequest) GetEmission() Emission {
+func (x *PointCities) GetCities() []*City {
 	if x != nil {
-		return x.Emission
-	}
-	return Emission_UNKNOWN_EMISSION
-}
-
-func (x *GriddedEmissionsRequest) GetSimulationType() SimulationType {
-	if x != nil {
-		return x.SimulationType
+		return x.Cities
 	}
-	return SimulationType_UNKNOWN_SIMULATIONTYPE
+	return nil
 }
 
-type GriddedEmissionsResponse struct {
+type RegisterStudyAreaRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Polygons  []*Polygon `protobuf:"bytes,1,rep,name=Polygons,proto3" json:"Polygons,omitempty"`
-	Emissions []float64  `protobuf:"fixed64,2,rep,packed,name=Emissions,proto3" json:"Emissions,omitempty"`
-}
-
-func (x *GriddedEmissionsResponse) Reset() {
-	*x = GriddedEmissionsResponse{}
+	// Name is the name of the study area.
+	Name string `protobuf:"bytes,1,opt,name=Name,proto3" json:"Name,omitempty"`
+	// GeoJSON is the boundary of the study area as a GeoJSON Polygon or
+	// MultiPolygon geometry, or a Feature or FeatureCollection containing
+	// such geometries, with longitude and latitude coordinates.
+	GeoJSON string `protobuf:"bytes,2,opt,name=GeoJSON,proto3" json:"GeoJSON,omitempty"`
+	// WKT is the boundary of the study area as a Well-Known Text POLYGON
+	// or MULTIPOLYGON with longitude and latitude coordinates. Only one of
+	// GeoJSON and WKT should be specified. The boundary can have at most
+	// 100,000 points.
+	WKT string `protobuf:"bytes,3,opt,name=WKT,proto3" json:"WKT,omitempty"`
+}
+
+func (x *RegisterStudyAreaRequest) Reset() {
+	*x = RegisterStudyAreaRequest{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_cityaq_proto_msgTypes[8]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -608,13 +1060,13 @@ func (x *GriddedEmissionsResponse) Reset() {
 	}
 }
 
-func (x *GriddedEmissionsResponse) String() string {
+func (x *RegisterStudyAreaRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GriddedEmissionsResponse) ProtoMessage() {}
+func (*RegisterStudyAreaRequest) ProtoMessage() {}
 
-func (x *GriddedEmissionsResponse) ProtoReflect() protoreflect.Message {
+func (x *RegisterStudyAreaRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_cityaq_proto_msgTypes[8]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -626,38 +1078,44 @@ func (x *GriddedEmissionsResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GriddedEmissionsResponse.ProtoReflect.Descriptor instead.
-func (*GriddedEmissionsResponse) Descriptor() ([]byte, []int) {
+// Deprecated: Use RegisterStudyAreaRequest.ProtoReflect.Descriptor instead.
+func (*RegisterStudyAreaRequest) Descriptor() ([]byte, []int) {
 	return file_cityaq_proto_rawDescGZIP(), []int{8}
 }
 
-func (x *GriddedEmissionsResponse) GetPolygons() []*Polygon {
+func (x *RegisterStudyAreaRequest) GetName() string {
 	if x != nil {
-		return x.Polygons
+		return x.Name
 	}
-	return nil
+	return ""
 }
 
-func (x *GriddedEmissionsResponse) GetEmissions() []float64 {
+func (x *RegisterStudyAreaRequest) GetGeoJSON() string {
 	if x != nil {
-		return x.Emissions
+		return x.GeoJSON
 	}
-	return nil
+	return ""
 }
 
-type GriddedConcentrationsRequest struct {
+func (x *RegisterStudyAreaRequest) GetWKT() string {
+	if x != nil {
+		return x.WKT
+	}
+	return ""
+}
+
+type RegisterStudyAreaResponse struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	CityName       string         `protobuf:"bytes,1,opt,name=CityName,proto3" json:"CityName,omitempty"`
-	SourceType     string         `protobuf:"bytes,2,opt,name=SourceType,proto3" json:"SourceType,omitempty"`
-	Emission       Emission       `protobuf:"varint,3,opt,name=Emission,proto3,enum=cityaqrpc.Emission" json:"Emission,omitempty"`
-	SimulationType SimulationType `protobuf:"varint,4,opt,name=SimulationType,proto3,enum=cityaqrpc.SimulationType" json:"SimulationType,omitempty"`
+	// ID is the identifier of the study area, which can be
+	// used to specify it in other requests.
+	ID string `protobuf:"bytes,1,opt,name=ID,proto3" json:"ID,omitempty"`
 }
 
-func (x *GriddedConcentrationsRequest) Reset() {
-	*x = GriddedConcentrationsRequest{}
+func (x *RegisterStudyAreaResponse) Reset() {
+	*x = RegisterStudyAreaResponse{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_cityaq_proto_msgTypes[9]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -665,13 +1123,13 @@ func (x *GriddedConcentrationsRequest) Reset() {
 	}
 }
 
-func (x *GriddedConcentrationsRequest) String() string {
+func (x *RegisterStudyAreaResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GriddedConcentrationsRequest) ProtoMessage() {}
+func (*RegisterStudyAreaResponse) ProtoMessage() {}
 
-func (x *GriddedConcentrationsRequest) ProtoReflect() protoreflect.Message {
+func (x *RegisterStudyAreaResponse) ProtoReflect() protoreflect.Message {
 	mi := &file_cityaq_proto_msgTypes[9]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -683,50 +1141,31 @@ func (x *GriddedConcentrationsRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GriddedConcentrationsRequest.ProtoReflect.Descriptor instead.
-func (*GriddedConcentrationsRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use RegisterStudyAreaResponse.ProtoReflect.Descriptor instead.
+func (*RegisterStudyAreaResponse) Descriptor() ([]byte, []int) {
 	return file_cityaq_proto_rawDescGZIP(), []int{9}
 }
 
-func (x *GriddedConcentrationsRequest) GetCityName() string {
-	if x != nil {
-		return x.CityName
-	}
-	return ""
-}
-
-func (x *GriddedConcentrationsRequest) GetSourceType() string {
+func (x *RegisterStudyAreaResponse) GetID() string {
 	if x != nil {
-		return x.SourceType
+		return x.ID
 	}
 	return ""
 }
 
-func (x *GriddedConcentrationsRequest) GetEmission() Emission {
-	if x != nil {
-		return x.Emission
-	}
-	return Emission_UNKNOWN_EMISSION
-}
-
-func (x *GriddedConcentrationsRequest) GetSimulationType() SimulationType {
-	if x != nil {
-		return x.SimulationType
-	}
-	return SimulationType_UNKNOWN_SIMULATIONTYPE
-}
-
-type GriddedConcentrationsResponse struct {
+type CityInfoRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Polygons       []*Polygon `protobuf:"bytes,1,rep,name=Polygons,proto3" json:"Polygons,omitempty"`
-	Concentrations []float64  `protobuf:"fixed64,2,rep,packed,name=Concentrations,proto3" json:"Concentrations,omitempty"`
+	CityName string `protobuf:"bytes,1,opt,name=CityName,proto3" json:"CityName,omitempty"`
+	// Language is the code of the language that the city name should be
+	// displayed in. See CitiesRequest.Language.
+	Language string `protobuf:"bytes,2,opt,name=Language,proto3" json:"Language,omitempty"`
 }
 
-func (x *GriddedConcentrationsResponse) Reset() {
-	*x = GriddedConcentrationsResponse{}
+func (x *CityInfoRequest) Reset() {
+	*x = CityInfoRequest{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_cityaq_proto_msgTypes[10]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -734,13 +1173,13 @@ func (x *GriddedConcentrationsResponse) Reset() {
 	}
 }
 
-func (x *GriddedConcentrationsResponse) String() string {
+func (x *CityInfoRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GriddedConcentrationsResponse) ProtoMessage() {}
+func (*CityInfoRequest) ProtoMessage() {}
 
-func (x *GriddedConcentrationsResponse) ProtoReflect() protoreflect.Message {
+func (x *CityInfoRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_cityaq_proto_msgTypes[10]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -752,38 +1191,55 @@ func (x *GriddedConcentrationsResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GriddedConcentrationsResponse.ProtoReflect.Descriptor instead.
-func (*GriddedConcentrationsResponse) Descriptor() ([]byte, []int) {
+// Deprecated: Use CityInfoRequest.ProtoReflect.Descriptor instead.
+func (*CityInfoRequest) Descriptor() ([]byte, []int) {
 	return file_cityaq_proto_rawDescGZIP(), []int{10}
 }
 
-func (x *GriddedConcentrationsResponse) GetPolygons() []*Polygon {
+func (x *CityInfoRequest) GetCityName() string {
 	if x != nil {
-		return x.Polygons
+		return x.CityName
 	}
-	return nil
+	return ""
 }
 
-func (x *GriddedConcentrationsResponse) GetConcentrations() []float64 {
+func (x *CityInfoRequest) GetLanguage() string {
 	if x != nil {
-		return x.Concentrations
+		return x.Language
 	}
-	return nil
+	return ""
 }
 
-type GriddedPopulationRequest struct {
+type CityInfoResponse struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	CityName       string         `protobuf:"bytes,1,opt,name=CityName,proto3" json:"CityName,omitempty"`
-	SourceType     string         `protobuf:"bytes,2,opt,name=SourceType,proto3" json:"SourceType,omitempty"`
-	Emission       Emission       `protobuf:"varint,3,opt,name=Emission,proto3,enum=cityaqrpc.Emission" json:"Emission,omitempty"`
-	SimulationType SimulationType `protobuf:"varint,4,opt,name=SimulationType,proto3,enum=cityaqrpc.SimulationType" json:"SimulationType,omitempty"`
-}
-
-func (x *GriddedPopulationRequest) Reset() {
-	*x = GriddedPopulationRequest{}
+	// ID is the stable identifier of the city.
+	ID string `protobuf:"bytes,1,opt,name=ID,proto3" json:"ID,omitempty"`
+	// DisplayName is the name of the city in the requested language.
+	DisplayName string `protobuf:"bytes,2,opt,name=DisplayName,proto3" json:"DisplayName,omitempty"`
+	// Area is the area of the city in square kilometers.
+	Area float64 `protobuf:"fixed64,3,opt,name=Area,proto3" json:"Area,omitempty"`
+	// Centroid is the centroid of the city boundary.
+	Centroid *Point `protobuf:"bytes,4,opt,name=Centroid,proto3" json:"Centroid,omitempty"`
+	// Min and Max are the lower-left and upper-right corners
+	// of the bounding box of the city boundary.
+	Min *Point `protobuf:"bytes,5,opt,name=Min,proto3" json:"Min,omitempty"`
+	Max *Point `protobuf:"bytes,6,opt,name=Max,proto3" json:"Max,omitempty"`
+	// Country is the name of the country that the city is in.
+	Country string `protobuf:"bytes,7,opt,name=Country,proto3" json:"Country,omitempty"`
+	// EGUGrid is the geometry that emissions from source types in the
+	// EGUGrid allocation domain, and from emissions inventory sectors
+	// with the suffix "_egugrid", are allocated to.
+	EGUGrid []*Polygon `protobuf:"bytes,8,rep,name=EGUGrid,proto3" json:"EGUGrid,omitempty"`
+	// Properties holds the properties of the city's GeoJSON feature,
+	// encoded as a JSON object.
+	Properties string `protobuf:"bytes,9,opt,name=Properties,proto3" json:"Properties,omitempty"`
+}
+
+func (x *CityInfoResponse) Reset() {
+	*x = CityInfoResponse{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_cityaq_proto_msgTypes[11]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -791,13 +1247,13 @@ func (x *GriddedPopulationRequest) Reset() {
 	}
 }
 
-func (x *GriddedPopulationRequest) String() string {
+func (x *CityInfoResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GriddedPopulationRequest) ProtoMessage() {}
+func (*CityInfoResponse) ProtoMessage() {}
 
-func (x *GriddedPopulationRequest) ProtoReflect() protoreflect.Message {
+func (x *CityInfoResponse) ProtoReflect() protoreflect.Message {
 	mi := &file_cityaq_proto_msgTypes[11]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -809,50 +1265,84 @@ func (x *GriddedPopulationRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GriddedPopulationRequest.ProtoReflect.Descriptor instead.
-func (*GriddedPopulationRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use CityInfoResponse.ProtoReflect.Descriptor instead.
+func (*CityInfoResponse) Descriptor() ([]byte, []int) {
 	return file_cityaq_proto_rawDescGZIP(), []int{11}
 }
 
-func (x *GriddedPopulationRequest) GetCityName() string {
+func (x *CityInfoResponse) GetID() string {
 	if x != nil {
-		return x.CityName
+		return x.ID
 	}
 	return ""
 }
 
-func (x *GriddedPopulationRequest) GetSourceType() string {
+func (x *CityInfoResponse) GetDisplayName() string {
 	if x != nil {
-		return x.SourceType
+		return x.DisplayName
 	}
 	return ""
 }
 
-func (x *GriddedPopulationRequest) GetEmission() Emission {
+func (x *CityInfoResponse) GetArea() float64 {
 	if x != nil {
-		return x.Emission
+		return x.Area
 	}
-	return Emission_UNKNOWN_EMISSION
+	return 0
 }
 
-func (x *GriddedPopulationRequest) GetSimulationType() SimulationType {
+func (x *CityInfoResponse) GetCentroid() *Point {
 	if x != nil {
-		return x.SimulationType
+		return x.Centroid
 	}
-	return SimulationType_UNKNOWN_SIMULATIONTYPE
+	return nil
 }
 
-type GriddedPopulationResponse struct {
+func (x *CityInfoResponse) GetMin() *Point {
+	if x != nil {
+		return x.Min
+	}
+	return nil
+}
+
+func (x *CityInfoResponse) GetMax() *Point {
+	if x != nil {
+		return x.Max
+	}
+	return nil
+}
+
+func (x *CityInfoResponse) GetCountry() string {
+	if x != nil {
+		return x.Country
+	}
+	return ""
+}
+
+func (x *CityInfoResponse) GetEGUGrid() []*Polygon {
+	if x != nil {
+		return x.EGUGrid
+	}
+	return nil
+}
+
+func (x *CityInfoResponse) GetProperties() string {
+	if x != nil {
+		return x.Properties
+	}
+	return ""
+}
+
+type Polygon struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Polygons   []*Polygon `protobuf:"bytes,1,rep,name=Polygons,proto3" json:"Polygons,omitempty"`
-	Population []float64  `protobuf:"fixed64,2,rep,packed,name=Population,proto3" json:"Population,omitempty"`
+	Paths []*Path `protobuf:"bytes,1,rep,name=Paths,proto3" json:"Paths,omitempty"`
 }
 
-func (x *GriddedPopulationResponse) Reset() {
-	*x = GriddedPopulationResponse{}
+func (x *Polygon) Reset() {
+	*x = Polygon{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_cityaq_proto_msgTypes[12]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -860,13 +1350,13 @@ func (x *GriddedPopulationResponse) Reset() {
 	}
 }
 
-func (x *GriddedPopulationResponse) String() string {
+func (x *Polygon) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GriddedPopulationResponse) ProtoMessage() {}
+func (*Polygon) ProtoMessage() {}
 
-func (x *GriddedPopulationResponse) ProtoReflect() protoreflect.Message {
+func (x *Polygon) ProtoReflect() protoreflect.Message {
 	mi := &file_cityaq_proto_msgTypes[12]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -878,53 +1368,2253 @@ func (x *GriddedPopulationResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GriddedPopulationResponse.ProtoReflect.Descriptor instead.
-func (*GriddedPopulationResponse) Descriptor() ([]byte, []int) {
+// Deprecated: Use Polygon.ProtoReflect.Descriptor instead.
+func (*Polygon) Descriptor() ([]byte, []int) {
 	return file_cityaq_proto_rawDescGZIP(), []int{12}
 }
 
-func (x *GriddedPopulationResponse) GetPolygons() []*Polygon {
+func (x *Polygon) GetPaths() []*Path {
 	if x != nil {
-		return x.Polygons
+		return x.Paths
 	}
 	return nil
 }
 
-func (x *GriddedPopulationResponse) GetPopulation() []float64 {
-	if x != nil {
-		return x.Population
-	}
+type Path struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Points []*Point `protobuf:"bytes,1,rep,name=Points,proto3" json:"Points,omitempty"`
+}
+
+func (x *Path) Reset() {
+	*x = Path{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_cityaq_proto_msgTypes[13]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Path) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Path) ProtoMessage() {}
+
+func (x *Path) ProtoReflect() protoreflect.Message {
+	mi := &file_cityaq_proto_msgTypes[13]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Path.ProtoReflect.Descriptor instead.
+func (*Path) Descriptor() ([]byte, []int) {
+	return file_cityaq_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *Path) GetPoints() []*Point {
+	if x != nil {
+		return x.Points
+	}
 	return nil
 }
 
-type ImpactSummaryRequest struct {
+type Point struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	CityName       string         `protobuf:"bytes,1,opt,name=CityName,proto3" json:"CityName,omitempty"`
-	SourceType     string         `protobuf:"bytes,2,opt,name=SourceType,proto3" json:"SourceType,omitempty"`
-	Emission       Emission       `protobuf:"varint,3,opt,name=Emission,proto3,enum=cityaqrpc.Emission" json:"Emission,omitempty"`
+	X float64 `protobuf:"fixed64,1,opt,name=X,proto3" json:"X,omitempty"`
+	Y float64 `protobuf:"fixed64,2,opt,name=Y,proto3" json:"Y,omitempty"`
+}
+
+func (x *Point) Reset() {
+	*x = Point{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_cityaq_proto_msgTypes[14]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Point) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Point) ProtoMessage() {}
+
+func (x *Point) ProtoReflect() protoreflect.Message {
+	mi := &file_cityaq_proto_msgTypes[14]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Point.ProtoReflect.Descriptor instead.
+func (*Point) Descriptor() ([]byte, []int) {
+	return file_cityaq_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *Point) GetX() float64 {
+	if x != nil {
+		return x.X
+	}
+	return 0
+}
+
+func (x *Point) GetY() float64 {
+	if x != nil {
+		return x.Y
+	}
+	return 0
+}
+
+type GriddedEmissionsRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	CityName string `protobuf:"bytes,1,opt,name=CityName,proto3" json:"CityName,omitempty"`
+	// SourceType is the name of a source type returned by SourceTypes,
+	// or for CityTotal and Total simulations, an emissions inventory
+	// sector. Sectors that are not source types are allocated within
+	// the city, or within the EGUGrid if their names have the suffix
+	// "_egugrid".
+	SourceType string   `protobuf:"bytes,2,opt,name=SourceType,proto3" json:"SourceType,omitempty"`
+	Emission   Emission `protobuf:"varint,3,opt,name=Emission,proto3,enum=cityaqrpc.Emission" json:"Emission,omitempty"`
+	// SimulationType, if it is CityTotal or Total, specifies that the
+	// emissions should be those of the emissions inventory sector
+	// SourceType that drive simulations of that type, in which case the
+	// amounts, CustomSurrogate, SourceTypeMix, and Speciate are ignored.
 	SimulationType SimulationType `protobuf:"varint,4,opt,name=SimulationType,proto3,enum=cityaqrpc.SimulationType" json:"SimulationType,omitempty"`
+	// Amount is the amount of emissions, in AmountUnits. If it is zero,
+	// 1 kilotonne of emissions is emitted over the emissions period.
+	Amount      float64       `protobuf:"fixed64,5,opt,name=Amount,proto3" json:"Amount,omitempty"`
+	AmountUnits EmissionUnits `protobuf:"varint,6,opt,name=AmountUnits,proto3,enum=cityaqrpc.EmissionUnits" json:"AmountUnits,omitempty"`
+	// Begin and End are the start and end of the emissions period, as
+	// Unix times in seconds. If both are zero, the emissions period is
+	// calendar year 2016.
+	Begin int64 `protobuf:"varint,7,opt,name=Begin,proto3" json:"Begin,omitempty"`
+	End   int64 `protobuf:"varint,8,opt,name=End,proto3" json:"End,omitempty"`
+	// Pollutants, if it is not empty, specifies the amounts of multiple
+	// pollutants to emit over the emissions period, in which case
+	// Emission, Amount, and AmountUnits are ignored.
+	Pollutants []*PollutantAmount `protobuf:"bytes,9,rep,name=Pollutants,proto3" json:"Pollutants,omitempty"`
+	// CustomSurrogate, if it is set, specifies a spatial surrogate to
+	// build from OpenStreetMap data and use instead of SourceType.
+	CustomSurrogate *CustomSurrogate `protobuf:"bytes,10,opt,name=CustomSurrogate,proto3" json:"CustomSurrogate,omitempty"`
+	// SourceTypeMix, if it is set, specifies a weighted mix of source
+	// types whose spatial allocations are blended to allocate the
+	// emissions, in which case SourceType is ignored.
+	SourceTypeMix []*SourceTypeWeight `protobuf:"bytes,11,rep,name=SourceTypeMix,proto3" json:"SourceTypeMix,omitempty"`
+	// Resolution, if it is greater than zero, is the emissions grid cell
+	// edge length, in degrees, or in meters if the server builds grids in
+	// a projection. If it is zero, the resolution of the source type is used.
+	Resolution float64 `protobuf:"fixed64,12,opt,name=Resolution,proto3" json:"Resolution,omitempty"`
+	// AutoResolution specifies that the emissions grid resolution should
+	// be chosen from the extent of the area that emissions are allocated
+	// within, so that grids for large and small cities have similar
+	// numbers of cells. Resolution must be zero if it is set.
+	AutoResolution bool `protobuf:"varint,13,opt,name=AutoResolution,proto3" json:"AutoResolution,omitempty"`
+	// TemporalResolution, if it is set, specifies that the response should
+	// include TimeSteps with the emissions in each time step of this length
+	// between WindowBegin and WindowEnd, allocated in time using the
+	// temporal profiles of the source type. An error is returned if the
+	// time steps would have more than 1,000,000 values in total over all
+	// of the grid cells and pollutants.
+	TemporalResolution TemporalResolution `protobuf:"varint,14,opt,name=TemporalResolution,proto3,enum=cityaqrpc.TemporalResolution" json:"TemporalResolution,omitempty"`
+	// WindowBegin and WindowEnd are the start and end of the time window
+	// for time-resolved emissions, as Unix times in seconds. The window
+	// must be within the emissions period. If both are zero, the window
+	// is the emissions period.
+	WindowBegin int64 `protobuf:"varint,15,opt,name=WindowBegin,proto3" json:"WindowBegin,omitempty"`
+	WindowEnd   int64 `protobuf:"varint,16,opt,name=WindowEnd,proto3" json:"WindowEnd,omitempty"`
+	// Speciate specifies that Amount is the total mass of all pollutants,
+	// which is split among the pollutants using the speciation profile of
+	// the source type, in which case Emission is ignored and Pollutants
+	// must not be set.
+	Speciate bool `protobuf:"varint,17,opt,name=Speciate,proto3" json:"Speciate,omitempty"`
+	// MassTolerance, if it is greater than zero, is the largest fraction
+	// of the requested mass of emissions that can be lost or gained when
+	// the emissions are allocated to the grid. If more is lost or gained,
+	// an error is returned instead of the emissions.
+	MassTolerance float64 `protobuf:"fixed64,18,opt,name=MassTolerance,proto3" json:"MassTolerance,omitempty"`
+	// AreaFallback specifies that the emissions of source types whose
+	// spatial surrogates are zero throughout the allocation domain should
+	// be allocated in proportion to area instead, which is reported in the
+	// Diagnostics. Otherwise, an error is returned for them.
+	AreaFallback bool `protobuf:"varint,19,opt,name=AreaFallback,proto3" json:"AreaFallback,omitempty"`
 }
 
-func (x *ImpactSummaryRequest) Reset() {
-	*x = ImpactSummaryRequest{}
+func (x *GriddedEmissionsRequest) Reset() {
+	*x = GriddedEmissionsRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_cityaq_proto_msgTypes[13]
+		mi := &file_cityaq_proto_msgTypes[15]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GriddedEmissionsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GriddedEmissionsRequest) ProtoMessage() {}
+
+func (x *GriddedEmissionsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_cityaq_proto_msgTypes[15]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GriddedEmissionsRequest.ProtoReflect.Descriptor instead.
+func (*GriddedEmissionsRequest) Descriptor() ([]byte, []int) {
+	return file_cityaq_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *GriddedEmissionsRequest) GetCityName() string {
+	if x != nil {
+		return x.CityName
+	}
+	return ""
+}
+
+func (x *GriddedEmissionsRequest) GetSourceType() string {
+	if x != nil {
+		return x.SourceType
+	}
+	return ""
+}
+
+func (x *GriddedEmissionsRequest) GetEmission() Emission {
+	if x != nil {
+		return x.Emission
+	}
+	return Emission_UNKNOWN_EMISSION
+}
+
+func (x *GriddedEmissionsRequest) GetSimulationType() SimulationType {
+	if x != nil {
+		return x.SimulationType
+	}
+	return SimulationType_UNKNOWN_SIMULATIONTYPE
+}
+
+func (x *GriddedEmissionsRequest) GetAmount() float64 {
+	if x != nil {
+		return x.Amount
+	}
+	return 0
+}
+
+func (x *GriddedEmissionsRequest) GetAmountUnits() EmissionUnits {
+	if x != nil {
+		return x.AmountUnits
+	}
+	return EmissionUnits_UNKNOWN_EMISSIONUNITS
+}
+
+func (x *GriddedEmissionsRequest) GetBegin() int64 {
+	if x != nil {
+		return x.Begin
+	}
+	return 0
+}
+
+func (x *GriddedEmissionsRequest) GetEnd() int64 {
+	if x != nil {
+		return x.End
+	}
+	return 0
+}
+
+func (x *GriddedEmissionsRequest) GetPollutants() []*PollutantAmount {
+	if x != nil {
+		return x.Pollutants
+	}
+	return nil
+}
+
+func (x *GriddedEmissionsRequest) GetCustomSurrogate() *CustomSurrogate {
+	if x != nil {
+		return x.CustomSurrogate
+	}
+	return nil
+}
+
+func (x *GriddedEmissionsRequest) GetSourceTypeMix() []*SourceTypeWeight {
+	if x != nil {
+		return x.SourceTypeMix
+	}
+	return nil
+}
+
+func (x *GriddedEmissionsRequest) GetResolution() float64 {
+	if x != nil {
+		return x.Resolution
+	}
+	return 0
+}
+
+func (x *GriddedEmissionsRequest) GetAutoResolution() bool {
+	if x != nil {
+		return x.AutoResolution
+	}
+	return false
+}
+
+func (x *GriddedEmissionsRequest) GetTemporalResolution() TemporalResolution {
+	if x != nil {
+		return x.TemporalResolution
+	}
+	return TemporalResolution_UNKNOWN_TEMPORALRESOLUTION
+}
+
+func (x *GriddedEmissionsRequest) GetWindowBegin() int64 {
+	if x != nil {
+		return x.WindowBegin
+	}
+	return 0
+}
+
+func (x *GriddedEmissionsRequest) GetWindowEnd() int64 {
+	if x != nil {
+		return x.WindowEnd
+	}
+	return 0
+}
+
+func (x *GriddedEmissionsRequest) GetSpeciate() bool {
+	if x != nil {
+		return x.Speciate
+	}
+	return false
+}
+
+func (x *GriddedEmissionsRequest) GetMassTolerance() float64 {
+	if x != nil {
+		return x.MassTolerance
+	}
+	return 0
+}
+
+func (x *GriddedEmissionsRequest) GetAreaFallback() bool {
+	if x != nil {
+		return x.AreaFallback
+	}
+	return false
+}
+
+// EmissionsTimeStep holds the emissions in a time step.
+type EmissionsTimeStep struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Begin and End are the start and end of the time step,
+	// as Unix times in seconds.
+	Begin int64 `protobuf:"varint,1,opt,name=Begin,proto3" json:"Begin,omitempty"`
+	End   int64 `protobuf:"varint,2,opt,name=End,proto3" json:"End,omitempty"`
+	// PollutantEmissions are the emissions of each of the requested
+	// pollutants within the time step, in kilograms.
+	PollutantEmissions []*PollutantEmissions `protobuf:"bytes,3,rep,name=PollutantEmissions,proto3" json:"PollutantEmissions,omitempty"`
+}
+
+func (x *EmissionsTimeStep) Reset() {
+	*x = EmissionsTimeStep{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_cityaq_proto_msgTypes[16]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *EmissionsTimeStep) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EmissionsTimeStep) ProtoMessage() {}
+
+func (x *EmissionsTimeStep) ProtoReflect() protoreflect.Message {
+	mi := &file_cityaq_proto_msgTypes[16]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EmissionsTimeStep.ProtoReflect.Descriptor instead.
+func (*EmissionsTimeStep) Descriptor() ([]byte, []int) {
+	return file_cityaq_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *EmissionsTimeStep) GetBegin() int64 {
+	if x != nil {
+		return x.Begin
+	}
+	return 0
+}
+
+func (x *EmissionsTimeStep) GetEnd() int64 {
+	if x != nil {
+		return x.End
+	}
+	return 0
+}
+
+func (x *EmissionsTimeStep) GetPollutantEmissions() []*PollutantEmissions {
+	if x != nil {
+		return x.PollutantEmissions
+	}
+	return nil
+}
+
+type GriddedEmissionsResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Polygons []*Polygon `protobuf:"bytes,1,rep,name=Polygons,proto3" json:"Polygons,omitempty"`
+	// Emissions are the emissions of the requested pollutant
+	// when Pollutants is not set in the request, or the total
+	// emissions of all pollutants when Speciate is set.
+	Emissions []float64 `protobuf:"fixed64,2,rep,packed,name=Emissions,proto3" json:"Emissions,omitempty"`
+	// PollutantEmissions are the emissions of each of the
+	// requested pollutants, in the order they were requested.
+	PollutantEmissions []*PollutantEmissions `protobuf:"bytes,3,rep,name=PollutantEmissions,proto3" json:"PollutantEmissions,omitempty"`
+	// TimeSteps are the emissions in each time step when
+	// TemporalResolution is set in the request.
+	TimeSteps []*EmissionsTimeStep `protobuf:"bytes,4,rep,name=TimeSteps,proto3" json:"TimeSteps,omitempty"`
+	// Diagnostics describe how well the mass of the emissions
+	// was conserved when it was allocated to the grid.
+	Diagnostics *EmissionsDiagnostics `protobuf:"bytes,5,opt,name=Diagnostics,proto3" json:"Diagnostics,omitempty"`
+}
+
+func (x *GriddedEmissionsResponse) Reset() {
+	*x = GriddedEmissionsResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_cityaq_proto_msgTypes[17]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GriddedEmissionsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GriddedEmissionsResponse) ProtoMessage() {}
+
+func (x *GriddedEmissionsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_cityaq_proto_msgTypes[17]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GriddedEmissionsResponse.ProtoReflect.Descriptor instead.
+func (*GriddedEmissionsResponse) Descriptor() ([]byte, []int) {
+	return file_cityaq_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *GriddedEmissionsResponse) GetPolygons() []*Polygon {
+	if x != nil {
+		return x.Polygons
+	}
+	return nil
+}
+
+func (x *GriddedEmissionsResponse) GetEmissions() []float64 {
+	if x != nil {
+		return x.Emissions
+	}
+	return nil
+}
+
+func (x *GriddedEmissionsResponse) GetPollutantEmissions() []*PollutantEmissions {
+	if x != nil {
+		return x.PollutantEmissions
+	}
+	return nil
+}
+
+func (x *GriddedEmissionsResponse) GetTimeSteps() []*EmissionsTimeStep {
+	if x != nil {
+		return x.TimeSteps
+	}
+	return nil
+}
+
+func (x *GriddedEmissionsResponse) GetDiagnostics() *EmissionsDiagnostics {
+	if x != nil {
+		return x.Diagnostics
+	}
+	return nil
+}
+
+// EmissionsDiagnostics describe the allocation of emissions to a grid.
+type EmissionsDiagnostics struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// RequestedMass is the total mass of all of the requested pollutants,
+	// in kilograms. For emissions inventory emissions, it is the mass of
+	// the inventory emissions within the grid, and for CityTotal
+	// simulations, within the city.
+	RequestedMass float64 `protobuf:"fixed64,1,opt,name=RequestedMass,proto3" json:"RequestedMass,omitempty"`
+	// AllocatedMass is the total mass of the emissions in the grid cells,
+	// in kilograms.
+	AllocatedMass float64 `protobuf:"fixed64,2,opt,name=AllocatedMass,proto3" json:"AllocatedMass,omitempty"`
+	// CityFraction is the fraction of the allocated mass that is in the
+	// city, assuming that emissions are evenly distributed within each
+	// grid cell.
+	CityFraction float64 `protobuf:"fixed64,3,opt,name=CityFraction,proto3" json:"CityFraction,omitempty"`
+	// LostFraction is the fraction of the requested mass that is not in
+	// the grid cells. It is negative if mass was gained.
+	LostFraction float64 `protobuf:"fixed64,4,opt,name=LostFraction,proto3" json:"LostFraction,omitempty"`
+	// SurrogateFallback is "area" if some of the emissions were allocated
+	// in proportion to area within the allocation domain because the
+	// spatial surrogate is zero throughout the domain and AreaFallback was
+	// set, or empty if the spatial surrogates were used.
+	SurrogateFallback string `protobuf:"bytes,5,opt,name=SurrogateFallback,proto3" json:"SurrogateFallback,omitempty"`
+	// FallbackSourceTypes are the source types whose spatial surrogates
+	// were replaced by SurrogateFallback.
+	FallbackSourceTypes []string `protobuf:"bytes,6,rep,name=FallbackSourceTypes,proto3" json:"FallbackSourceTypes,omitempty"`
+}
+
+func (x *EmissionsDiagnostics) Reset() {
+	*x = EmissionsDiagnostics{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_cityaq_proto_msgTypes[18]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *EmissionsDiagnostics) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EmissionsDiagnostics) ProtoMessage() {}
+
+func (x *EmissionsDiagnostics) ProtoReflect() protoreflect.Message {
+	mi := &file_cityaq_proto_msgTypes[18]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EmissionsDiagnostics.ProtoReflect.Descriptor instead.
+func (*EmissionsDiagnostics) Descriptor() ([]byte, []int) {
+	return file_cityaq_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *EmissionsDiagnostics) GetRequestedMass() float64 {
+	if x != nil {
+		return x.RequestedMass
+	}
+	return 0
+}
+
+func (x *EmissionsDiagnostics) GetAllocatedMass() float64 {
+	if x != nil {
+		return x.AllocatedMass
+	}
+	return 0
+}
+
+func (x *EmissionsDiagnostics) GetCityFraction() float64 {
+	if x != nil {
+		return x.CityFraction
+	}
+	return 0
+}
+
+func (x *EmissionsDiagnostics) GetLostFraction() float64 {
+	if x != nil {
+		return x.LostFraction
+	}
+	return 0
+}
+
+func (x *EmissionsDiagnostics) GetSurrogateFallback() string {
+	if x != nil {
+		return x.SurrogateFallback
+	}
+	return ""
+}
+
+func (x *EmissionsDiagnostics) GetFallbackSourceTypes() []string {
+	if x != nil {
+		return x.FallbackSourceTypes
+	}
+	return nil
+}
+
+// PollutantAmount is an amount of emissions of a pollutant.
+type PollutantAmount struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Emission Emission `protobuf:"varint,1,opt,name=Emission,proto3,enum=cityaqrpc.Emission" json:"Emission,omitempty"`
+	// Amount is the amount of emissions, in AmountUnits. If it is zero,
+	// 1 kilotonne of emissions is emitted over the emissions period.
+	Amount      float64       `protobuf:"fixed64,2,opt,name=Amount,proto3" json:"Amount,omitempty"`
+	AmountUnits EmissionUnits `protobuf:"varint,3,opt,name=AmountUnits,proto3,enum=cityaqrpc.EmissionUnits" json:"AmountUnits,omitempty"`
+}
+
+func (x *PollutantAmount) Reset() {
+	*x = PollutantAmount{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_cityaq_proto_msgTypes[19]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *PollutantAmount) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PollutantAmount) ProtoMessage() {}
+
+func (x *PollutantAmount) ProtoReflect() protoreflect.Message {
+	mi := &file_cityaq_proto_msgTypes[19]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PollutantAmount.ProtoReflect.Descriptor instead.
+func (*PollutantAmount) Descriptor() ([]byte, []int) {
+	return file_cityaq_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *PollutantAmount) GetEmission() Emission {
+	if x != nil {
+		return x.Emission
+	}
+	return Emission_UNKNOWN_EMISSION
+}
+
+func (x *PollutantAmount) GetAmount() float64 {
+	if x != nil {
+		return x.Amount
+	}
+	return 0
+}
+
+func (x *PollutantAmount) GetAmountUnits() EmissionUnits {
+	if x != nil {
+		return x.AmountUnits
+	}
+	return EmissionUnits_UNKNOWN_EMISSIONUNITS
+}
+
+// CustomSurrogate is a spatial surrogate that is built at request
+// time from the OpenStreetMap features that match a set of tags.
+// The surrogate is cached, so later requests with the same
+// surrogate do not need to build it again.
+type CustomSurrogate struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Tags are the tags that features must have to be included.
+	// A feature is included if it matches any of the tags.
+	Tags []*OSMTag `protobuf:"bytes,1,rep,name=Tags,proto3" json:"Tags,omitempty"`
+	// WeightBy specifies how features are weighted.
+	WeightBy SurrogateWeight `protobuf:"varint,2,opt,name=WeightBy,proto3,enum=cityaqrpc.SurrogateWeight" json:"WeightBy,omitempty"`
+}
+
+func (x *CustomSurrogate) Reset() {
+	*x = CustomSurrogate{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_cityaq_proto_msgTypes[20]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CustomSurrogate) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CustomSurrogate) ProtoMessage() {}
+
+func (x *CustomSurrogate) ProtoReflect() protoreflect.Message {
+	mi := &file_cityaq_proto_msgTypes[20]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CustomSurrogate.ProtoReflect.Descriptor instead.
+func (*CustomSurrogate) Descriptor() ([]byte, []int) {
+	return file_cityaq_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *CustomSurrogate) GetTags() []*OSMTag {
+	if x != nil {
+		return x.Tags
+	}
+	return nil
+}
+
+func (x *CustomSurrogate) GetWeightBy() SurrogateWeight {
+	if x != nil {
+		return x.WeightBy
+	}
+	return SurrogateWeight_UNKNOWN_SURROGATEWEIGHT
+}
+
+// OSMTag matches OpenStreetMap features with the tag Key and any of the
+// given Values, or any value if Values is empty.
+type OSMTag struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Key    string   `protobuf:"bytes,1,opt,name=Key,proto3" json:"Key,omitempty"`
+	Values []string `protobuf:"bytes,2,rep,name=Values,proto3" json:"Values,omitempty"`
+}
+
+func (x *OSMTag) Reset() {
+	*x = OSMTag{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_cityaq_proto_msgTypes[21]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *OSMTag) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OSMTag) ProtoMessage() {}
+
+func (x *OSMTag) ProtoReflect() protoreflect.Message {
+	mi := &file_cityaq_proto_msgTypes[21]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OSMTag.ProtoReflect.Descriptor instead.
+func (*OSMTag) Descriptor() ([]byte, []int) {
+	return file_cityaq_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *OSMTag) GetKey() string {
+	if x != nil {
+		return x.Key
+	}
+	return ""
+}
+
+func (x *OSMTag) GetValues() []string {
+	if x != nil {
+		return x.Values
+	}
+	return nil
+}
+
+// SourceTypeWeight is the weight of a source type in a mix of source
+// types. The weights of a mix do not need to add up to one; the
+// fraction of the emissions allocated using each source type is its
+// weight divided by the sum of the weights.
+type SourceTypeWeight struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	SourceType string  `protobuf:"bytes,1,opt,name=SourceType,proto3" json:"SourceType,omitempty"`
+	Weight     float64 `protobuf:"fixed64,2,opt,name=Weight,proto3" json:"Weight,omitempty"`
+}
+
+func (x *SourceTypeWeight) Reset() {
+	*x = SourceTypeWeight{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_cityaq_proto_msgTypes[22]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SourceTypeWeight) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SourceTypeWeight) ProtoMessage() {}
+
+func (x *SourceTypeWeight) ProtoReflect() protoreflect.Message {
+	mi := &file_cityaq_proto_msgTypes[22]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SourceTypeWeight.ProtoReflect.Descriptor instead.
+func (*SourceTypeWeight) Descriptor() ([]byte, []int) {
+	return file_cityaq_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *SourceTypeWeight) GetSourceType() string {
+	if x != nil {
+		return x.SourceType
+	}
+	return ""
+}
+
+func (x *SourceTypeWeight) GetWeight() float64 {
+	if x != nil {
+		return x.Weight
+	}
+	return 0
+}
+
+// PointSource is an emissions source with an elevated release.
+type PointSource struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Location is the longitude and latitude of the source.
+	Location *Point `protobuf:"bytes,1,opt,name=Location,proto3" json:"Location,omitempty"`
+	// PM2_5, VOC, NH3, NOx, and SOx are the annual
+	// emissions of each pollutant [kg/year].
+	PM2_5 float64 `protobuf:"fixed64,2,opt,name=PM2_5,json=PM25,proto3" json:"PM2_5,omitempty"`
+	VOC   float64 `protobuf:"fixed64,3,opt,name=VOC,proto3" json:"VOC,omitempty"`
+	NH3   float64 `protobuf:"fixed64,4,opt,name=NH3,proto3" json:"NH3,omitempty"`
+	NOx   float64 `protobuf:"fixed64,5,opt,name=NOx,proto3" json:"NOx,omitempty"`
+	SOx   float64 `protobuf:"fixed64,6,opt,name=SOx,proto3" json:"SOx,omitempty"`
+	// StackHeight is the height of the stack [m].
+	StackHeight float64 `protobuf:"fixed64,7,opt,name=StackHeight,proto3" json:"StackHeight,omitempty"`
+	// StackDiameter is the inside diameter of the stack [m].
+	StackDiameter float64 `protobuf:"fixed64,8,opt,name=StackDiameter,proto3" json:"StackDiameter,omitempty"`
+	// StackTemperature is the exit gas temperature [K].
+	StackTemperature float64 `protobuf:"fixed64,9,opt,name=StackTemperature,proto3" json:"StackTemperature,omitempty"`
+	// StackVelocity is the exit gas velocity [m/s].
+	StackVelocity float64 `protobuf:"fixed64,10,opt,name=StackVelocity,proto3" json:"StackVelocity,omitempty"`
+}
+
+func (x *PointSource) Reset() {
+	*x = PointSource{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_cityaq_proto_msgTypes[23]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *PointSource) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PointSource) ProtoMessage() {}
+
+func (x *PointSource) ProtoReflect() protoreflect.Message {
+	mi := &file_cityaq_proto_msgTypes[23]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PointSource.ProtoReflect.Descriptor instead.
+func (*PointSource) Descriptor() ([]byte, []int) {
+	return file_cityaq_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *PointSource) GetLocation() *Point {
+	if x != nil {
+		return x.Location
+	}
+	return nil
+}
+
+func (x *PointSource) GetPM2_5() float64 {
+	if x != nil {
+		return x.PM2_5
+	}
+	return 0
+}
+
+func (x *PointSource) GetVOC() float64 {
+	if x != nil {
+		return x.VOC
+	}
+	return 0
+}
+
+func (x *PointSource) GetNH3() float64 {
+	if x != nil {
+		return x.NH3
+	}
+	return 0
+}
+
+func (x *PointSource) GetNOx() float64 {
+	if x != nil {
+		return x.NOx
+	}
+	return 0
+}
+
+func (x *PointSource) GetSOx() float64 {
+	if x != nil {
+		return x.SOx
+	}
+	return 0
+}
+
+func (x *PointSource) GetStackHeight() float64 {
+	if x != nil {
+		return x.StackHeight
+	}
+	return 0
+}
+
+func (x *PointSource) GetStackDiameter() float64 {
+	if x != nil {
+		return x.StackDiameter
+	}
+	return 0
+}
+
+func (x *PointSource) GetStackTemperature() float64 {
+	if x != nil {
+		return x.StackTemperature
+	}
+	return 0
+}
+
+func (x *PointSource) GetStackVelocity() float64 {
+	if x != nil {
+		return x.StackVelocity
+	}
+	return 0
+}
+
+// PollutantEmissions are the gridded emissions of a pollutant.
+type PollutantEmissions struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Emission  Emission  `protobuf:"varint,1,opt,name=Emission,proto3,enum=cityaqrpc.Emission" json:"Emission,omitempty"`
+	Emissions []float64 `protobuf:"fixed64,2,rep,packed,name=Emissions,proto3" json:"Emissions,omitempty"`
+}
+
+func (x *PollutantEmissions) Reset() {
+	*x = PollutantEmissions{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_cityaq_proto_msgTypes[24]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *PollutantEmissions) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PollutantEmissions) ProtoMessage() {}
+
+func (x *PollutantEmissions) ProtoReflect() protoreflect.Message {
+	mi := &file_cityaq_proto_msgTypes[24]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PollutantEmissions.ProtoReflect.Descriptor instead.
+func (*PollutantEmissions) Descriptor() ([]byte, []int) {
+	return file_cityaq_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *PollutantEmissions) GetEmission() Emission {
+	if x != nil {
+		return x.Emission
+	}
+	return Emission_UNKNOWN_EMISSION
+}
+
+func (x *PollutantEmissions) GetEmissions() []float64 {
+	if x != nil {
+		return x.Emissions
+	}
+	return nil
+}
+
+type GriddedConcentrationsRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	CityName   string   `protobuf:"bytes,1,opt,name=CityName,proto3" json:"CityName,omitempty"`
+	SourceType string   `protobuf:"bytes,2,opt,name=SourceType,proto3" json:"SourceType,omitempty"`
+	Emission   Emission `protobuf:"varint,3,opt,name=Emission,proto3,enum=cityaqrpc.Emission" json:"Emission,omitempty"`
+	// SimulationType is the type of simulation. Amount, Begin, End,
+	// Pollutants, and Speciate can only be set for CityMarginal
+	// simulations, because the concentrations of CityTotal and Total
+	// simulations result from an emissions inventory.
+	SimulationType SimulationType `protobuf:"varint,4,opt,name=SimulationType,proto3,enum=cityaqrpc.SimulationType" json:"SimulationType,omitempty"`
+	// Amount is the amount of emissions, in AmountUnits. If it is zero,
+	// 1 kilotonne of emissions is emitted over the emissions period.
+	Amount      float64       `protobuf:"fixed64,5,opt,name=Amount,proto3" json:"Amount,omitempty"`
+	AmountUnits EmissionUnits `protobuf:"varint,6,opt,name=AmountUnits,proto3,enum=cityaqrpc.EmissionUnits" json:"AmountUnits,omitempty"`
+	// Begin and End are the start and end of the emissions period, as
+	// Unix times in seconds. If both are zero, the emissions period is
+	// calendar year 2016.
+	Begin int64 `protobuf:"varint,7,opt,name=Begin,proto3" json:"Begin,omitempty"`
+	End   int64 `protobuf:"varint,8,opt,name=End,proto3" json:"End,omitempty"`
+	// Pollutants, if it is not empty, specifies the amounts of multiple
+	// pollutants to emit over the emissions period, in which case
+	// Emission, Amount, and AmountUnits are ignored and the
+	// concentrations are the total PM2.5 resulting from all of them.
+	Pollutants []*PollutantAmount `protobuf:"bytes,9,rep,name=Pollutants,proto3" json:"Pollutants,omitempty"`
+	// PointSources, if it is not empty, specifies point sources
+	// whose emissions are simulated instead of the emissions of
+	// SourceType. The point sources are simulated in the air quality
+	// modeling domain of the city, so they must be located within it.
+	// SimulationType must be CityMarginal, Emission, Amount, AmountUnits,
+	// Pollutants, and Speciate must not be set, and SourceType, Begin,
+	// and End are ignored.
+	// The concentrations are the annual average total PM2.5 resulting
+	// from all of the point source emissions.
+	PointSources []*PointSource `protobuf:"bytes,10,rep,name=PointSources,proto3" json:"PointSources,omitempty"`
+	// CustomSurrogate, if it is set, specifies a spatial surrogate to
+	// build from OpenStreetMap data and use instead of SourceType.
+	CustomSurrogate *CustomSurrogate `protobuf:"bytes,11,opt,name=CustomSurrogate,proto3" json:"CustomSurrogate,omitempty"`
+	// SourceTypeMix, if it is set, specifies a weighted mix of source
+	// types whose spatial allocations are blended to allocate the
+	// emissions, in which case SourceType is ignored.
+	SourceTypeMix []*SourceTypeWeight `protobuf:"bytes,12,rep,name=SourceTypeMix,proto3" json:"SourceTypeMix,omitempty"`
+	// Resolution, if it is greater than zero, is the emissions grid cell
+	// edge length, in degrees, or in meters if the server builds grids in
+	// a projection. If it is zero, the resolution of the source type is used.
+	// Resolution only affects CityMarginal simulations.
+	Resolution float64 `protobuf:"fixed64,13,opt,name=Resolution,proto3" json:"Resolution,omitempty"`
+	// AutoResolution specifies that the emissions grid resolution should
+	// be chosen from the extent of the area that emissions are allocated
+	// within, so that grids for large and small cities have similar
+	// numbers of cells. Resolution must be zero if it is set.
+	AutoResolution bool `protobuf:"varint,14,opt,name=AutoResolution,proto3" json:"AutoResolution,omitempty"`
+	// Speciate specifies that Amount is the total mass of all pollutants,
+	// which is split among the pollutants using the speciation profile of
+	// the source type, in which case Emission is ignored and Pollutants
+	// must not be set.
+	Speciate bool `protobuf:"varint,15,opt,name=Speciate,proto3" json:"Speciate,omitempty"`
+	// AreaFallback specifies that, in CityMarginal simulations, the
+	// emissions of source types whose spatial surrogates are zero
+	// throughout the allocation domain should be allocated in proportion
+	// to area instead. Otherwise, an error is returned for them.
+	AreaFallback bool `protobuf:"varint,16,opt,name=AreaFallback,proto3" json:"AreaFallback,omitempty"`
+}
+
+func (x *GriddedConcentrationsRequest) Reset() {
+	*x = GriddedConcentrationsRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_cityaq_proto_msgTypes[25]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GriddedConcentrationsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GriddedConcentrationsRequest) ProtoMessage() {}
+
+func (x *GriddedConcentrationsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_cityaq_proto_msgTypes[25]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GriddedConcentrationsRequest.ProtoReflect.Descriptor instead.
+func (*GriddedConcentrationsRequest) Descriptor() ([]byte, []int) {
+	return file_cityaq_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *GriddedConcentrationsRequest) GetCityName() string {
+	if x != nil {
+		return x.CityName
+	}
+	return ""
+}
+
+func (x *GriddedConcentrationsRequest) GetSourceType() string {
+	if x != nil {
+		return x.SourceType
+	}
+	return ""
+}
+
+func (x *GriddedConcentrationsRequest) GetEmission() Emission {
+	if x != nil {
+		return x.Emission
+	}
+	return Emission_UNKNOWN_EMISSION
+}
+
+func (x *GriddedConcentrationsRequest) GetSimulationType() SimulationType {
+	if x != nil {
+		return x.SimulationType
+	}
+	return SimulationType_UNKNOWN_SIMULATIONTYPE
+}
+
+func (x *GriddedConcentrationsRequest) GetAmount() float64 {
+	if x != nil {
+		return x.Amount
+	}
+	return 0
+}
+
+func (x *GriddedConcentrationsRequest) GetAmountUnits() EmissionUnits {
+	if x != nil {
+		return x.AmountUnits
+	}
+	return EmissionUnits_UNKNOWN_EMISSIONUNITS
+}
+
+func (x *GriddedConcentrationsRequest) GetBegin() int64 {
+	if x != nil {
+		return x.Begin
+	}
+	return 0
+}
+
+func (x *GriddedConcentrationsRequest) GetEnd() int64 {
+	if x != nil {
+		return x.End
+	}
+	return 0
+}
+
+func (x *GriddedConcentrationsRequest) GetPollutants() []*PollutantAmount {
+	if x != nil {
+		return x.Pollutants
+	}
+	return nil
+}
+
+func (x *GriddedConcentrationsRequest) GetPointSources() []*PointSource {
+	if x != nil {
+		return x.PointSources
+	}
+	return nil
+}
+
+func (x *GriddedConcentrationsRequest) GetCustomSurrogate() *CustomSurrogate {
+	if x != nil {
+		return x.CustomSurrogate
+	}
+	return nil
+}
+
+func (x *GriddedConcentrationsRequest) GetSourceTypeMix() []*SourceTypeWeight {
+	if x != nil {
+		return x.SourceTypeMix
+	}
+	return nil
+}
+
+func (x *GriddedConcentrationsRequest) GetResolution() float64 {
+	if x != nil {
+		return x.Resolution
+	}
+	return 0
+}
+
+func (x *GriddedConcentrationsRequest) GetAutoResolution() bool {
+	if x != nil {
+		return x.AutoResolution
+	}
+	return false
+}
+
+func (x *GriddedConcentrationsRequest) GetSpeciate() bool {
+	if x != nil {
+		return x.Speciate
+	}
+	return false
+}
+
+func (x *GriddedConcentrationsRequest) GetAreaFallback() bool {
+	if x != nil {
+		return x.AreaFallback
+	}
+	return false
+}
+
+type GriddedConcentrationsResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Polygons       []*Polygon `protobuf:"bytes,1,rep,name=Polygons,proto3" json:"Polygons,omitempty"`
+	Concentrations []float64  `protobuf:"fixed64,2,rep,packed,name=Concentrations,proto3" json:"Concentrations,omitempty"`
+}
+
+func (x *GriddedConcentrationsResponse) Reset() {
+	*x = GriddedConcentrationsResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_cityaq_proto_msgTypes[26]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GriddedConcentrationsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GriddedConcentrationsResponse) ProtoMessage() {}
+
+func (x *GriddedConcentrationsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_cityaq_proto_msgTypes[26]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GriddedConcentrationsResponse.ProtoReflect.Descriptor instead.
+func (*GriddedConcentrationsResponse) Descriptor() ([]byte, []int) {
+	return file_cityaq_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *GriddedConcentrationsResponse) GetPolygons() []*Polygon {
+	if x != nil {
+		return x.Polygons
+	}
+	return nil
+}
+
+func (x *GriddedConcentrationsResponse) GetConcentrations() []float64 {
+	if x != nil {
+		return x.Concentrations
+	}
+	return nil
+}
+
+type GriddedPopulationRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	CityName       string         `protobuf:"bytes,1,opt,name=CityName,proto3" json:"CityName,omitempty"`
+	SourceType     string         `protobuf:"bytes,2,opt,name=SourceType,proto3" json:"SourceType,omitempty"`
+	Emission       Emission       `protobuf:"varint,3,opt,name=Emission,proto3,enum=cityaqrpc.Emission" json:"Emission,omitempty"`
+	SimulationType SimulationType `protobuf:"varint,4,opt,name=SimulationType,proto3,enum=cityaqrpc.SimulationType" json:"SimulationType,omitempty"`
+	// PointSources, if it is not empty, specifies point sources
+	// whose emissions are simulated instead of the emissions of
+	// SourceType. The point sources are simulated in the air quality
+	// modeling domain of the city, so they should be located near it.
+	// SimulationType must be CityMarginal.
+	PointSources []*PointSource `protobuf:"bytes,5,rep,name=PointSources,proto3" json:"PointSources,omitempty"`
+	// CustomSurrogate, if it is set, specifies a spatial surrogate to
+	// build from OpenStreetMap data and use instead of SourceType.
+	CustomSurrogate *CustomSurrogate `protobuf:"bytes,6,opt,name=CustomSurrogate,proto3" json:"CustomSurrogate,omitempty"`
+	// SourceTypeMix, if it is set, specifies a weighted mix of source
+	// types whose spatial allocations are blended to allocate the
+	// emissions, in which case SourceType is ignored.
+	SourceTypeMix []*SourceTypeWeight `protobuf:"bytes,7,rep,name=SourceTypeMix,proto3" json:"SourceTypeMix,omitempty"`
+	// Resolution, if it is greater than zero, is the emissions grid cell
+	// edge length, in degrees, or in meters if the server builds grids in
+	// a projection. If it is zero, the resolution of the source type is used.
+	// Resolution only affects CityMarginal simulations.
+	Resolution float64 `protobuf:"fixed64,8,opt,name=Resolution,proto3" json:"Resolution,omitempty"`
+	// AutoResolution specifies that the emissions grid resolution should
+	// be chosen from the extent of the area that emissions are allocated
+	// within, so that grids for large and small cities have similar
+	// numbers of cells. Resolution must be zero if it is set.
+	AutoResolution bool `protobuf:"varint,9,opt,name=AutoResolution,proto3" json:"AutoResolution,omitempty"`
+}
+
+func (x *GriddedPopulationRequest) Reset() {
+	*x = GriddedPopulationRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_cityaq_proto_msgTypes[27]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GriddedPopulationRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GriddedPopulationRequest) ProtoMessage() {}
+
+func (x *GriddedPopulationRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_cityaq_proto_msgTypes[27]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GriddedPopulationRequest.ProtoReflect.Descriptor instead.
+func (*GriddedPopulationRequest) Descriptor() ([]byte, []int) {
+	return file_cityaq_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *GriddedPopulationRequest) GetCityName() string {
+	if x != nil {
+		return x.CityName
+	}
+	return ""
+}
+
+func (x *GriddedPopulationRequest) GetSourceType() string {
+	if x != nil {
+		return x.SourceType
+	}
+	return ""
+}
+
+func (x *GriddedPopulationRequest) GetEmission() Emission {
+	if x != nil {
+		return x.Emission
+	}
+	return Emission_UNKNOWN_EMISSION
+}
+
+func (x *GriddedPopulationRequest) GetSimulationType() SimulationType {
+	if x != nil {
+		return x.SimulationType
+	}
+	return SimulationType_UNKNOWN_SIMULATIONTYPE
+}
+
+func (x *GriddedPopulationRequest) GetPointSources() []*PointSource {
+	if x != nil {
+		return x.PointSources
+	}
+	return nil
+}
+
+func (x *GriddedPopulationRequest) GetCustomSurrogate() *CustomSurrogate {
+	if x != nil {
+		return x.CustomSurrogate
+	}
+	return nil
+}
+
+func (x *GriddedPopulationRequest) GetSourceTypeMix() []*SourceTypeWeight {
+	if x != nil {
+		return x.SourceTypeMix
+	}
+	return nil
+}
+
+func (x *GriddedPopulationRequest) GetResolution() float64 {
+	if x != nil {
+		return x.Resolution
+	}
+	return 0
+}
+
+func (x *GriddedPopulationRequest) GetAutoResolution() bool {
+	if x != nil {
+		return x.AutoResolution
+	}
+	return false
+}
+
+type GriddedPopulationResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Polygons   []*Polygon `protobuf:"bytes,1,rep,name=Polygons,proto3" json:"Polygons,omitempty"`
+	Population []float64  `protobuf:"fixed64,2,rep,packed,name=Population,proto3" json:"Population,omitempty"`
+}
+
+func (x *GriddedPopulationResponse) Reset() {
+	*x = GriddedPopulationResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_cityaq_proto_msgTypes[28]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GriddedPopulationResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GriddedPopulationResponse) ProtoMessage() {}
+
+func (x *GriddedPopulationResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_cityaq_proto_msgTypes[28]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GriddedPopulationResponse.ProtoReflect.Descriptor instead.
+func (*GriddedPopulationResponse) Descriptor() ([]byte, []int) {
+	return file_cityaq_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *GriddedPopulationResponse) GetPolygons() []*Polygon {
+	if x != nil {
+		return x.Polygons
+	}
+	return nil
+}
+
+func (x *GriddedPopulationResponse) GetPopulation() []float64 {
+	if x != nil {
+		return x.Population
+	}
+	return nil
+}
+
+type ImpactSummaryRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	CityName   string   `protobuf:"bytes,1,opt,name=CityName,proto3" json:"CityName,omitempty"`
+	SourceType string   `protobuf:"bytes,2,opt,name=SourceType,proto3" json:"SourceType,omitempty"`
+	Emission   Emission `protobuf:"varint,3,opt,name=Emission,proto3,enum=cityaqrpc.Emission" json:"Emission,omitempty"`
+	// SimulationType is the type of simulation. Amount, Begin, End,
+	// and Speciate can only be set for CityMarginal simulations, because
+	// the impacts of CityTotal and Total simulations result from an
+	// emissions inventory.
+	SimulationType SimulationType `protobuf:"varint,4,opt,name=SimulationType,proto3,enum=cityaqrpc.SimulationType" json:"SimulationType,omitempty"`
+	// Amount is the amount of emissions, in AmountUnits. If it is zero,
+	// 1 kilotonne of emissions is emitted over the emissions period.
+	Amount      float64       `protobuf:"fixed64,5,opt,name=Amount,proto3" json:"Amount,omitempty"`
+	AmountUnits EmissionUnits `protobuf:"varint,6,opt,name=AmountUnits,proto3,enum=cityaqrpc.EmissionUnits" json:"AmountUnits,omitempty"`
+	// Begin and End are the start and end of the emissions period, as
+	// Unix times in seconds. If both are zero, the emissions period is
+	// calendar year 2016.
+	Begin int64 `protobuf:"varint,7,opt,name=Begin,proto3" json:"Begin,omitempty"`
+	End   int64 `protobuf:"varint,8,opt,name=End,proto3" json:"End,omitempty"`
+	// SourceTypeMix, if it is set, specifies a weighted mix of source
+	// types whose spatial allocations are blended to allocate the
+	// emissions, in which case SourceType is ignored.
+	SourceTypeMix []*SourceTypeWeight `protobuf:"bytes,9,rep,name=SourceTypeMix,proto3" json:"SourceTypeMix,omitempty"`
+	// Resolution, if it is greater than zero, is the emissions grid cell
+	// edge length, in degrees, or in meters if the server builds grids in
+	// a projection. If it is zero, the resolution of the source type is used.
+	// Resolution only affects CityMarginal simulations.
+	Resolution float64 `protobuf:"fixed64,10,opt,name=Resolution,proto3" json:"Resolution,omitempty"`
+	// AutoResolution specifies that the emissions grid resolution should
+	// be chosen from the extent of the area that emissions are allocated
+	// within, so that grids for large and small cities have similar
+	// numbers of cells. Resolution must be zero if it is set.
+	AutoResolution bool `protobuf:"varint,11,opt,name=AutoResolution,proto3" json:"AutoResolution,omitempty"`
+	// Speciate specifies that Amount is the total mass of all pollutants,
+	// which is split among the pollutants using the speciation profile of
+	// the source type, in which case Emission is ignored and Pollutants
+	// must not be set.
+	Speciate bool `protobuf:"varint,12,opt,name=Speciate,proto3" json:"Speciate,omitempty"`
+	// AreaFallback specifies that, in CityMarginal simulations, the
+	// emissions of source types whose spatial surrogates are zero
+	// throughout the allocation domain should be allocated in proportion
+	// to area instead. Otherwise, an error is returned for them.
+	AreaFallback bool `protobuf:"varint,13,opt,name=AreaFallback,proto3" json:"AreaFallback,omitempty"`
+}
+
+func (x *ImpactSummaryRequest) Reset() {
+	*x = ImpactSummaryRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_cityaq_proto_msgTypes[29]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ImpactSummaryRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ImpactSummaryRequest) ProtoMessage() {}
+
+func (x *ImpactSummaryRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_cityaq_proto_msgTypes[29]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ImpactSummaryRequest.ProtoReflect.Descriptor instead.
+func (*ImpactSummaryRequest) Descriptor() ([]byte, []int) {
+	return file_cityaq_proto_rawDescGZIP(), []int{29}
+}
+
+func (x *ImpactSummaryRequest) GetCityName() string {
+	if x != nil {
+		return x.CityName
+	}
+	return ""
+}
+
+func (x *ImpactSummaryRequest) GetSourceType() string {
+	if x != nil {
+		return x.SourceType
+	}
+	return ""
+}
+
+func (x *ImpactSummaryRequest) GetEmission() Emission {
+	if x != nil {
+		return x.Emission
+	}
+	return Emission_UNKNOWN_EMISSION
+}
+
+func (x *ImpactSummaryRequest) GetSimulationType() SimulationType {
+	if x != nil {
+		return x.SimulationType
+	}
+	return SimulationType_UNKNOWN_SIMULATIONTYPE
+}
+
+func (x *ImpactSummaryRequest) GetAmount() float64 {
+	if x != nil {
+		return x.Amount
+	}
+	return 0
+}
+
+func (x *ImpactSummaryRequest) GetAmountUnits() EmissionUnits {
+	if x != nil {
+		return x.AmountUnits
+	}
+	return EmissionUnits_UNKNOWN_EMISSIONUNITS
+}
+
+func (x *ImpactSummaryRequest) GetBegin() int64 {
+	if x != nil {
+		return x.Begin
+	}
+	return 0
+}
+
+func (x *ImpactSummaryRequest) GetEnd() int64 {
+	if x != nil {
+		return x.End
+	}
+	return 0
+}
+
+func (x *ImpactSummaryRequest) GetSourceTypeMix() []*SourceTypeWeight {
+	if x != nil {
+		return x.SourceTypeMix
+	}
+	return nil
+}
+
+func (x *ImpactSummaryRequest) GetResolution() float64 {
+	if x != nil {
+		return x.Resolution
+	}
+	return 0
+}
+
+func (x *ImpactSummaryRequest) GetAutoResolution() bool {
+	if x != nil {
+		return x.AutoResolution
+	}
+	return false
+}
+
+func (x *ImpactSummaryRequest) GetSpeciate() bool {
+	if x != nil {
+		return x.Speciate
+	}
+	return false
+}
+
+func (x *ImpactSummaryRequest) GetAreaFallback() bool {
+	if x != nil {
+		return x.AreaFallback
+	}
+	return false
+}
+
+type ImpactSummaryResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Population is the total population.
+	Population float64 `protobuf:"fixed64,1,opt,name=Population,proto3" json:"Population,omitempty"`
+	// CityPopulation is the total population in the city.
+	CityPopulation float64 `protobuf:"fixed64,2,opt,name=CityPopulation,proto3" json:"CityPopulation,omitempty"`
+	// CityExposure is the population-weighted average
+	// concentration in the city.
+	CityExposure float64 `protobuf:"fixed64,3,opt,name=CityExposure,proto3" json:"CityExposure,omitempty"`
+	// TotalExposure is the overall population-weighted average
+	// concentration.
+	TotalExposure float64 `protobuf:"fixed64,4,opt,name=TotalExposure,proto3" json:"TotalExposure,omitempty"`
+	// CityIF is the intake fraction in the city.
+	CityIF float64 `protobuf:"fixed64,5,opt,name=CityIF,proto3" json:"CityIF,omitempty"`
+	// TotalIF is the total intake fraction.
+	TotalIF float64 `protobuf:"fixed64,6,opt,name=TotalIF,proto3" json:"TotalIF,omitempty"`
+	// PollutantImpacts are the impacts of each of the pollutants
+	// that are emitted. The exposures of all the pollutants add up
+	// to the exposures above.
+	PollutantImpacts []*PollutantImpacts `protobuf:"bytes,7,rep,name=PollutantImpacts,proto3" json:"PollutantImpacts,omitempty"`
+}
+
+func (x *ImpactSummaryResponse) Reset() {
+	*x = ImpactSummaryResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_cityaq_proto_msgTypes[30]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ImpactSummaryResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ImpactSummaryResponse) ProtoMessage() {}
+
+func (x *ImpactSummaryResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_cityaq_proto_msgTypes[30]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ImpactSummaryResponse.ProtoReflect.Descriptor instead.
+func (*ImpactSummaryResponse) Descriptor() ([]byte, []int) {
+	return file_cityaq_proto_rawDescGZIP(), []int{30}
+}
+
+func (x *ImpactSummaryResponse) GetPopulation() float64 {
+	if x != nil {
+		return x.Population
+	}
+	return 0
+}
+
+func (x *ImpactSummaryResponse) GetCityPopulation() float64 {
+	if x != nil {
+		return x.CityPopulation
+	}
+	return 0
+}
+
+func (x *ImpactSummaryResponse) GetCityExposure() float64 {
+	if x != nil {
+		return x.CityExposure
+	}
+	return 0
+}
+
+func (x *ImpactSummaryResponse) GetTotalExposure() float64 {
+	if x != nil {
+		return x.TotalExposure
+	}
+	return 0
+}
+
+func (x *ImpactSummaryResponse) GetCityIF() float64 {
+	if x != nil {
+		return x.CityIF
+	}
+	return 0
+}
+
+func (x *ImpactSummaryResponse) GetTotalIF() float64 {
+	if x != nil {
+		return x.TotalIF
+	}
+	return 0
+}
+
+func (x *ImpactSummaryResponse) GetPollutantImpacts() []*PollutantImpacts {
+	if x != nil {
+		return x.PollutantImpacts
+	}
+	return nil
+}
+
+// PollutantImpacts are the impacts of the emissions of a pollutant.
+type PollutantImpacts struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Emission Emission `protobuf:"varint,1,opt,name=Emission,proto3,enum=cityaqrpc.Emission" json:"Emission,omitempty"`
+	// Amount is the mass of the pollutant emitted over
+	// the emissions period, in kilograms.
+	Amount float64 `protobuf:"fixed64,2,opt,name=Amount,proto3" json:"Amount,omitempty"`
+	// CityExposure and TotalExposure are the population-weighted
+	// average PM2.5 concentrations in the city and overall resulting
+	// from the emissions of the pollutant.
+	CityExposure  float64 `protobuf:"fixed64,3,opt,name=CityExposure,proto3" json:"CityExposure,omitempty"`
+	TotalExposure float64 `protobuf:"fixed64,4,opt,name=TotalExposure,proto3" json:"TotalExposure,omitempty"`
+	// CityIF and TotalIF are the intake fractions of
+	// the pollutant in the city and overall.
+	CityIF  float64 `protobuf:"fixed64,5,opt,name=CityIF,proto3" json:"CityIF,omitempty"`
+	TotalIF float64 `protobuf:"fixed64,6,opt,name=TotalIF,proto3" json:"TotalIF,omitempty"`
+}
+
+func (x *PollutantImpacts) Reset() {
+	*x = PollutantImpacts{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_cityaq_proto_msgTypes[31]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *PollutantImpacts) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PollutantImpacts) ProtoMessage() {}
+
+func (x *PollutantImpacts) ProtoReflect() protoreflect.Message {
+	mi := &file_cityaq_proto_msgTypes[31]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PollutantImpacts.ProtoReflect.Descriptor instead.
+func (*PollutantImpacts) Descriptor() ([]byte, []int) {
+	return file_cityaq_proto_rawDescGZIP(), []int{31}
+}
+
+func (x *PollutantImpacts) GetEmission() Emission {
+	if x != nil {
+		return x.Emission
+	}
+	return Emission_UNKNOWN_EMISSION
+}
+
+func (x *PollutantImpacts) GetAmount() float64 {
+	if x != nil {
+		return x.Amount
+	}
+	return 0
+}
+
+func (x *PollutantImpacts) GetCityExposure() float64 {
+	if x != nil {
+		return x.CityExposure
+	}
+	return 0
+}
+
+func (x *PollutantImpacts) GetTotalExposure() float64 {
+	if x != nil {
+		return x.TotalExposure
+	}
+	return 0
+}
+
+func (x *PollutantImpacts) GetCityIF() float64 {
+	if x != nil {
+		return x.CityIF
+	}
+	return 0
+}
+
+func (x *PollutantImpacts) GetTotalIF() float64 {
+	if x != nil {
+		return x.TotalIF
+	}
+	return 0
+}
+
+type EmissionsGridBoundsRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	CityName   string `protobuf:"bytes,1,opt,name=CityName,proto3" json:"CityName,omitempty"`
+	SourceType string `protobuf:"bytes,2,opt,name=SourceType,proto3" json:"SourceType,omitempty"`
+	// Resolution, if it is greater than zero, is the emissions grid cell
+	// edge length, in degrees, or in meters if the server builds grids in
+	// a projection. If it is zero, the resolution of the source type is used.
+	Resolution float64 `protobuf:"fixed64,3,opt,name=Resolution,proto3" json:"Resolution,omitempty"`
+	// AutoResolution specifies that the emissions grid resolution should
+	// be chosen from the extent of the area that emissions are allocated
+	// within, so that grids for large and small cities have similar
+	// numbers of cells. Resolution must be zero if it is set.
+	AutoResolution bool `protobuf:"varint,4,opt,name=AutoResolution,proto3" json:"AutoResolution,omitempty"`
+}
+
+func (x *EmissionsGridBoundsRequest) Reset() {
+	*x = EmissionsGridBoundsRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_cityaq_proto_msgTypes[32]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *EmissionsGridBoundsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EmissionsGridBoundsRequest) ProtoMessage() {}
+
+func (x *EmissionsGridBoundsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_cityaq_proto_msgTypes[32]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EmissionsGridBoundsRequest.ProtoReflect.Descriptor instead.
+func (*EmissionsGridBoundsRequest) Descriptor() ([]byte, []int) {
+	return file_cityaq_proto_rawDescGZIP(), []int{32}
+}
+
+func (x *EmissionsGridBoundsRequest) GetCityName() string {
+	if x != nil {
+		return x.CityName
+	}
+	return ""
+}
+
+func (x *EmissionsGridBoundsRequest) GetSourceType() string {
+	if x != nil {
+		return x.SourceType
+	}
+	return ""
+}
+
+func (x *EmissionsGridBoundsRequest) GetResolution() float64 {
+	if x != nil {
+		return x.Resolution
+	}
+	return 0
+}
+
+func (x *EmissionsGridBoundsRequest) GetAutoResolution() bool {
+	if x != nil {
+		return x.AutoResolution
+	}
+	return false
+}
+
+type EmissionsGridBoundsResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Min *Point `protobuf:"bytes,1,opt,name=Min,proto3" json:"Min,omitempty"`
+	Max *Point `protobuf:"bytes,2,opt,name=Max,proto3" json:"Max,omitempty"`
+}
+
+func (x *EmissionsGridBoundsResponse) Reset() {
+	*x = EmissionsGridBoundsResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_cityaq_proto_msgTypes[33]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *EmissionsGridBoundsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EmissionsGridBoundsResponse) ProtoMessage() {}
+
+func (x *EmissionsGridBoundsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_cityaq_proto_msgTypes[33]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EmissionsGridBoundsResponse.ProtoReflect.Descriptor instead.
+func (*EmissionsGridBoundsResponse) Descriptor() ([]byte, []int) {
+	return file_cityaq_proto_rawDescGZIP(), []int{33}
+}
+
+func (x *EmissionsGridBoundsResponse) GetMin() *Point {
+	if x != nil {
+		return x.Min
+	}
+	return nil
+}
+
+func (x *EmissionsGridBoundsResponse) GetMax() *Point {
+	if x != nil {
+		return x.Max
+	}
+	return nil
+}
+
+type EmissionsInventorySectorsRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *EmissionsInventorySectorsRequest) Reset() {
+	*x = EmissionsInventorySectorsRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_cityaq_proto_msgTypes[34]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *EmissionsInventorySectorsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EmissionsInventorySectorsRequest) ProtoMessage() {}
+
+func (x *EmissionsInventorySectorsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_cityaq_proto_msgTypes[34]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EmissionsInventorySectorsRequest.ProtoReflect.Descriptor instead.
+func (*EmissionsInventorySectorsRequest) Descriptor() ([]byte, []int) {
+	return file_cityaq_proto_rawDescGZIP(), []int{34}
+}
+
+type EmissionsInventorySectorsResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Sectors []string `protobuf:"bytes,1,rep,name=Sectors,proto3" json:"Sectors,omitempty"`
+}
+
+func (x *EmissionsInventorySectorsResponse) Reset() {
+	*x = EmissionsInventorySectorsResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_cityaq_proto_msgTypes[35]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *EmissionsInventorySectorsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EmissionsInventorySectorsResponse) ProtoMessage() {}
+
+func (x *EmissionsInventorySectorsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_cityaq_proto_msgTypes[35]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EmissionsInventorySectorsResponse.ProtoReflect.Descriptor instead.
+func (*EmissionsInventorySectorsResponse) Descriptor() ([]byte, []int) {
+	return file_cityaq_proto_rawDescGZIP(), []int{35}
+}
+
+func (x *EmissionsInventorySectorsResponse) GetSectors() []string {
+	if x != nil {
+		return x.Sectors
+	}
+	return nil
+}
+
+// SectorChange is a change in the emissions of an emissions inventory
+// sector within a city.
+type SectorChange struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Sector is the name of the sector, as returned by
+	// EmissionsInventorySectors.
+	Sector string `protobuf:"bytes,1,opt,name=Sector,proto3" json:"Sector,omitempty"`
+	// Percent is the change in the emissions of the sector, in percent.
+	// For example, -40 is a 40% reduction. It must be at least -100.
+	Percent float64 `protobuf:"fixed64,2,opt,name=Percent,proto3" json:"Percent,omitempty"`
+}
+
+func (x *SectorChange) Reset() {
+	*x = SectorChange{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_cityaq_proto_msgTypes[36]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SectorChange) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SectorChange) ProtoMessage() {}
+
+func (x *SectorChange) ProtoReflect() protoreflect.Message {
+	mi := &file_cityaq_proto_msgTypes[36]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SectorChange.ProtoReflect.Descriptor instead.
+func (*SectorChange) Descriptor() ([]byte, []int) {
+	return file_cityaq_proto_rawDescGZIP(), []int{36}
+}
+
+func (x *SectorChange) GetSector() string {
+	if x != nil {
+		return x.Sector
+	}
+	return ""
+}
+
+func (x *SectorChange) GetPercent() float64 {
+	if x != nil {
+		return x.Percent
+	}
+	return 0
+}
+
+type ReductionScenarioRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	CityName string `protobuf:"bytes,1,opt,name=CityName,proto3" json:"CityName,omitempty"`
+	// Changes are the changes in the emissions of each sector in the
+	// scenario. The baseline includes the emissions of all of the sectors,
+	// so a sector can be included with a zero change to make it part of
+	// the baseline, but each sector can only be included once.
+	Changes []*SectorChange `protobuf:"bytes,2,rep,name=Changes,proto3" json:"Changes,omitempty"`
+}
+
+func (x *ReductionScenarioRequest) Reset() {
+	*x = ReductionScenarioRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_cityaq_proto_msgTypes[37]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ReductionScenarioRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReductionScenarioRequest) ProtoMessage() {}
+
+func (x *ReductionScenarioRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_cityaq_proto_msgTypes[37]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReductionScenarioRequest.ProtoReflect.Descriptor instead.
+func (*ReductionScenarioRequest) Descriptor() ([]byte, []int) {
+	return file_cityaq_proto_rawDescGZIP(), []int{37}
+}
+
+func (x *ReductionScenarioRequest) GetCityName() string {
+	if x != nil {
+		return x.CityName
+	}
+	return ""
+}
+
+func (x *ReductionScenarioRequest) GetChanges() []*SectorChange {
+	if x != nil {
+		return x.Changes
+	}
+	return nil
+}
+
+type ReductionScenarioResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Polygons []*Polygon `protobuf:"bytes,1,rep,name=Polygons,proto3" json:"Polygons,omitempty"`
+	// BaselineConcentrations and ScenarioConcentrations are the total
+	// PM2.5 concentrations in each grid cell in the baseline and the
+	// scenario, in μg/m³.
+	BaselineConcentrations []float64 `protobuf:"fixed64,2,rep,packed,name=BaselineConcentrations,proto3" json:"BaselineConcentrations,omitempty"`
+	ScenarioConcentrations []float64 `protobuf:"fixed64,3,rep,packed,name=ScenarioConcentrations,proto3" json:"ScenarioConcentrations,omitempty"`
+	// ConcentrationChanges are the scenario concentrations minus the
+	// baseline concentrations.
+	ConcentrationChanges []float64 `protobuf:"fixed64,4,rep,packed,name=ConcentrationChanges,proto3" json:"ConcentrationChanges,omitempty"`
+	// Population is the population in each grid cell.
+	Population []float64 `protobuf:"fixed64,5,rep,packed,name=Population,proto3" json:"Population,omitempty"`
+	// BaselineCityExposure and BaselineTotalExposure are the
+	// population-weighted average baseline concentrations in the
+	// city and overall.
+	BaselineCityExposure  float64 `protobuf:"fixed64,6,opt,name=BaselineCityExposure,proto3" json:"BaselineCityExposure,omitempty"`
+	BaselineTotalExposure float64 `protobuf:"fixed64,7,opt,name=BaselineTotalExposure,proto3" json:"BaselineTotalExposure,omitempty"`
+	// CityExposureChange and TotalExposureChange are the changes in the
+	// population-weighted average concentrations in the city and overall.
+	CityExposureChange  float64 `protobuf:"fixed64,8,opt,name=CityExposureChange,proto3" json:"CityExposureChange,omitempty"`
+	TotalExposureChange float64 `protobuf:"fixed64,9,opt,name=TotalExposureChange,proto3" json:"TotalExposureChange,omitempty"`
+}
+
+func (x *ReductionScenarioResponse) Reset() {
+	*x = ReductionScenarioResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_cityaq_proto_msgTypes[38]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *ImpactSummaryRequest) String() string {
+func (x *ReductionScenarioResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ImpactSummaryRequest) ProtoMessage() {}
+func (*ReductionScenarioResponse) ProtoMessage() {}
 
-func (x *ImpactSummaryRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_cityaq_proto_msgTypes[13]
+func (x *ReductionScenarioResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_cityaq_proto_msgTypes[38]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -935,77 +3625,97 @@ func (x *ImpactSummaryRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ImpactSummaryRequest.ProtoReflect.Descriptor instead.
-func (*ImpactSummaryRequest) Descriptor() ([]byte, []int) {
-	return file_cityaq_proto_rawDescGZIP(), []int{13}
+// Deprecated: Use ReductionScenarioResponse.ProtoReflect.Descriptor instead.
+func (*ReductionScenarioResponse) Descriptor() ([]byte, []int) {
+	return file_cityaq_proto_rawDescGZIP(), []int{38}
 }
 
-func (x *ImpactSummaryRequest) GetCityName() string {
+func (x *ReductionScenarioResponse) GetPolygons() []*Polygon {
 	if x != nil {
-		return x.CityName
+		return x.Polygons
 	}
-	return ""
+	return nil
 }
 
-func (x *ImpactSummaryRequest) GetSourceType() string {
+func (x *ReductionScenarioResponse) GetBaselineConcentrations() []float64 {
 	if x != nil {
-		return x.SourceType
+		return x.BaselineConcentrations
 	}
-	return ""
+	return nil
 }
 
-func (x *ImpactSummaryRequest) GetEmission() Emission {
+func (x *ReductionScenarioResponse) GetScenarioConcentrations() []float64 {
 	if x != nil {
-		return x.Emission
+		return x.ScenarioConcentrations
 	}
-	return Emission_UNKNOWN_EMISSION
+	return nil
 }
 
-func (x *ImpactSummaryRequest) GetSimulationType() SimulationType {
+func (x *ReductionScenarioResponse) GetConcentrationChanges() []float64 {
 	if x != nil {
-		return x.SimulationType
+		return x.ConcentrationChanges
 	}
-	return SimulationType_UNKNOWN_SIMULATIONTYPE
+	return nil
 }
 
-type ImpactSummaryResponse struct {
+func (x *ReductionScenarioResponse) GetPopulation() []float64 {
+	if x != nil {
+		return x.Population
+	}
+	return nil
+}
+
+func (x *ReductionScenarioResponse) GetBaselineCityExposure() float64 {
+	if x != nil {
+		return x.BaselineCityExposure
+	}
+	return 0
+}
+
+func (x *ReductionScenarioResponse) GetBaselineTotalExposure() float64 {
+	if x != nil {
+		return x.BaselineTotalExposure
+	}
+	return 0
+}
+
+func (x *ReductionScenarioResponse) GetCityExposureChange() float64 {
+	if x != nil {
+		return x.CityExposureChange
+	}
+	return 0
+}
+
+func (x *ReductionScenarioResponse) GetTotalExposureChange() float64 {
+	if x != nil {
+		return x.TotalExposureChange
+	}
+	return 0
+}
+
+type SourceTypesRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
-
-	// Population is the total population.
-	Population float64 `protobuf:"fixed64,1,opt,name=Population,proto3" json:"Population,omitempty"`
-	// CityPopulation is the total population in the city.
-	CityPopulation float64 `protobuf:"fixed64,2,opt,name=CityPopulation,proto3" json:"CityPopulation,omitempty"`
-	// CityExposure is the population-weighted average
-	// concentration in the city.
-	CityExposure float64 `protobuf:"fixed64,3,opt,name=CityExposure,proto3" json:"CityExposure,omitempty"`
-	// TotalExposure is the overall population-weighted average
-	// concentration.
-	TotalExposure float64 `protobuf:"fixed64,4,opt,name=TotalExposure,proto3" json:"TotalExposure,omitempty"`
-	// CityIF is the intake fraction in the city.
-	CityIF float64 `protobuf:"fixed64,5,opt,name=CityIF,proto3" json:"CityIF,omitempty"`
-	// TotalIF is the total intake fraction.
-	TotalIF float64 `protobuf:"fixed64,6,opt,name=TotalIF,proto3" json:"TotalIF,omitempty"`
 }
 
-func (x *ImpactSummaryResponse) Reset() {
-	*x = ImpactSummaryResponse{}
+func (x *SourceTypesRequest) Reset() {
+	*x = SourceTypesRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_cityaq_proto_msgTypes[14]
+		mi := &file_cityaq_proto_msgTypes[39]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *ImpactSummaryResponse) String() string {
+func (x *SourceTypesRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ImpactSummaryResponse) ProtoMessage() {}
+func (*SourceTypesRequest) ProtoMessage() {}
 
-func (x *ImpactSummaryResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_cityaq_proto_msgTypes[14]
+func (x *SourceTypesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_cityaq_proto_msgTypes[39]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1016,79 +3726,107 @@ func (x *ImpactSummaryResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ImpactSummaryResponse.ProtoReflect.Descriptor instead.
-func (*ImpactSummaryResponse) Descriptor() ([]byte, []int) {
-	return file_cityaq_proto_rawDescGZIP(), []int{14}
+// Deprecated: Use SourceTypesRequest.ProtoReflect.Descriptor instead.
+func (*SourceTypesRequest) Descriptor() ([]byte, []int) {
+	return file_cityaq_proto_rawDescGZIP(), []int{39}
 }
 
-func (x *ImpactSummaryResponse) GetPopulation() float64 {
-	if x != nil {
-		return x.Population
-	}
-	return 0
+type SourceTypesResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	SourceTypes []*SourceType `protobuf:"bytes,1,rep,name=SourceTypes,proto3" json:"SourceTypes,omitempty"`
 }
 
-func (x *ImpactSummaryResponse) GetCityPopulation() float64 {
-	if x != nil {
-		return x.CityPopulation
+func (x *SourceTypesResponse) Reset() {
+	*x = SourceTypesResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_cityaq_proto_msgTypes[40]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
 	}
-	return 0
 }
 
-func (x *ImpactSummaryResponse) GetCityExposure() float64 {
-	if x != nil {
-		return x.CityExposure
-	}
-	return 0
+func (x *SourceTypesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
 }
 
-func (x *ImpactSummaryResponse) GetTotalExposure() float64 {
-	if x != nil {
-		return x.TotalExposure
+func (*SourceTypesResponse) ProtoMessage() {}
+
+func (x *SourceTypesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_cityaq_proto_msgTypes[40]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
 	}
-	return 0
+	return mi.MessageOf(x)
 }
 
-func (x *ImpactSummaryResponse) GetCityIF() float64 {
-	if x != nil {
-		return x.CityIF
-	}
-	return 0
+// Deprecated: Use SourceTypesResponse.ProtoReflect.Descriptor instead.
+func (*SourceTypesResponse) Descriptor() ([]byte, []int) {
+	return file_cityaq_proto_rawDescGZIP(), []int{40}
 }
 
-func (x *ImpactSummaryResponse) GetTotalIF() float64 {
+func (x *SourceTypesResponse) GetSourceTypes() []*SourceType {
 	if x != nil {
-		return x.TotalIF
+		return x.SourceTypes
 	}
-	return 0
+	return nil
 }
 
-type EmissionsGridBoundsRequest struct {
+// SourceType describes how the emissions of a source type
+// are allocated and released.
+type SourceType struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	CityName   string `protobuf:"bytes,1,opt,name=CityName,proto3" json:"CityName,omitempty"`
-	SourceType string `protobuf:"bytes,2,opt,name=SourceType,proto3" json:"SourceType,omitempty"`
-}
-
-func (x *EmissionsGridBoundsRequest) Reset() {
-	*x = EmissionsGridBoundsRequest{}
+	// Name is the name of the source type to use in requests.
+	Name string `protobuf:"bytes,1,opt,name=Name,proto3" json:"Name,omitempty"`
+	// Label and Description are for display to users.
+	Label       string `protobuf:"bytes,2,opt,name=Label,proto3" json:"Label,omitempty"`
+	Description string `protobuf:"bytes,3,opt,name=Description,proto3" json:"Description,omitempty"`
+	// Domain is the area that emissions are allocated within.
+	Domain AllocationDomain `protobuf:"varint,4,opt,name=Domain,proto3,enum=cityaqrpc.AllocationDomain" json:"Domain,omitempty"`
+	// Resolution is the emissions grid cell edge length in degrees.
+	Resolution float64 `protobuf:"fixed64,5,opt,name=Resolution,proto3" json:"Resolution,omitempty"`
+	// CellSize is the emissions grid cell edge length in meters
+	// when the server builds emissions grids in a projection.
+	CellSize float64 `protobuf:"fixed64,10,opt,name=CellSize,proto3" json:"CellSize,omitempty"`
+	// StackHeight [m], StackDiameter [m], StackTemperature [K], and
+	// StackVelocity [m/s] are the stack parameters of elevated
+	// releases. They are zero for ground-level releases.
+	StackHeight      float64 `protobuf:"fixed64,6,opt,name=StackHeight,proto3" json:"StackHeight,omitempty"`
+	StackDiameter    float64 `protobuf:"fixed64,7,opt,name=StackDiameter,proto3" json:"StackDiameter,omitempty"`
+	StackTemperature float64 `protobuf:"fixed64,8,opt,name=StackTemperature,proto3" json:"StackTemperature,omitempty"`
+	StackVelocity    float64 `protobuf:"fixed64,9,opt,name=StackVelocity,proto3" json:"StackVelocity,omitempty"`
+	// Speciation is the fraction of the total emissions mass of
+	// the source type that is each pollutant. It is empty if the
+	// source type does not have a speciation profile.
+	Speciation []*PollutantFraction `protobuf:"bytes,11,rep,name=Speciation,proto3" json:"Speciation,omitempty"`
+}
+
+func (x *SourceType) Reset() {
+	*x = SourceType{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_cityaq_proto_msgTypes[15]
+		mi := &file_cityaq_proto_msgTypes[41]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *EmissionsGridBoundsRequest) String() string {
+func (x *SourceType) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*EmissionsGridBoundsRequest) ProtoMessage() {}
+func (*SourceType) ProtoMessage() {}
 
-func (x *EmissionsGridBoundsRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_cityaq_proto_msgTypes[15]
+func (x *SourceType) ProtoReflect() protoreflect.Message {
+	mi := &file_cityaq_proto_msgTypes[41]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1099,51 +3837,116 @@ func (x *EmissionsGridBoundsRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use EmissionsGridBoundsRequest.ProtoReflect.Descriptor instead.
-func (*EmissionsGridBoundsRequest) Descriptor() ([]byte, []int) {
-	return file_cityaq_proto_rawDescGZIP(), []int{15}
+// Deprecated: Use SourceType.ProtoReflect.Descriptor instead.
+func (*SourceType) Descriptor() ([]byte, []int) {
+	return file_cityaq_proto_rawDescGZIP(), []int{41}
 }
 
-func (x *EmissionsGridBoundsRequest) GetCityName() string {
+func (x *SourceType) GetName() string {
 	if x != nil {
-		return x.CityName
+		return x.Name
 	}
 	return ""
 }
 
-func (x *EmissionsGridBoundsRequest) GetSourceType() string {
+func (x *SourceType) GetLabel() string {
 	if x != nil {
-		return x.SourceType
+		return x.Label
 	}
 	return ""
 }
 
-type EmissionsGridBoundsResponse struct {
+func (x *SourceType) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *SourceType) GetDomain() AllocationDomain {
+	if x != nil {
+		return x.Domain
+	}
+	return AllocationDomain_UNKNOWN_ALLOCATIONDOMAIN
+}
+
+func (x *SourceType) GetResolution() float64 {
+	if x != nil {
+		return x.Resolution
+	}
+	return 0
+}
+
+func (x *SourceType) GetCellSize() float64 {
+	if x != nil {
+		return x.CellSize
+	}
+	return 0
+}
+
+func (x *SourceType) GetStackHeight() float64 {
+	if x != nil {
+		return x.StackHeight
+	}
+	return 0
+}
+
+func (x *SourceType) GetStackDiameter() float64 {
+	if x != nil {
+		return x.StackDiameter
+	}
+	return 0
+}
+
+func (x *SourceType) GetStackTemperature() float64 {
+	if x != nil {
+		return x.StackTemperature
+	}
+	return 0
+}
+
+func (x *SourceType) GetStackVelocity() float64 {
+	if x != nil {
+		return x.StackVelocity
+	}
+	return 0
+}
+
+func (x *SourceType) GetSpeciation() []*PollutantFraction {
+	if x != nil {
+		return x.Speciation
+	}
+	return nil
+}
+
+// PollutantFraction is the fraction of a mass of emissions
+// that is a pollutant.
+type PollutantFraction struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Min *Point `protobuf:"bytes,1,opt,name=Min,proto3" json:"Min,omitempty"`
-	Max *Point `protobuf:"bytes,2,opt,name=Max,proto3" json:"Max,omitempty"`
+	Emission Emission `protobuf:"varint,1,opt,name=Emission,proto3,enum=cityaqrpc.Emission" json:"Emission,omitempty"`
+	Fraction float64  `protobuf:"fixed64,2,opt,name=Fraction,proto3" json:"Fraction,omitempty"`
 }
 
-func (x *EmissionsGridBoundsResponse) Reset() {
-	*x = EmissionsGridBoundsResponse{}
+func (x *PollutantFraction) Reset() {
+	*x = PollutantFraction{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_cityaq_proto_msgTypes[16]
+		mi := &file_cityaq_proto_msgTypes[42]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *EmissionsGridBoundsResponse) String() string {
+func (x *PollutantFraction) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*EmissionsGridBoundsResponse) ProtoMessage() {}
+func (*PollutantFraction) ProtoMessage() {}
 
-func (x *EmissionsGridBoundsResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_cityaq_proto_msgTypes[16]
+func (x *PollutantFraction) ProtoReflect() protoreflect.Message {
+	mi := &file_cityaq_proto_msgTypes[42]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1154,48 +3957,55 @@ func (x *EmissionsGridBoundsResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use EmissionsGridBoundsResponse.ProtoReflect.Descriptor instead.
-func (*EmissionsGridBoundsResponse) Descriptor() ([]byte, []int) {
-	return file_cityaq_proto_rawDescGZIP(), []int{16}
+// Deprecated: Use PollutantFraction.ProtoReflect.Descriptor instead.
+func (*PollutantFraction) Descriptor() ([]byte, []int) {
+	return file_cityaq_proto_rawDescGZIP(), []int{42}
 }
 
-func (x *EmissionsGridBoundsResponse) GetMin() *Point {
+func (x *PollutantFraction) GetEmission() Emission {
 	if x != nil {
-		return x.Min
+		return x.Emission
 	}
-	return nil
+	return Emission_UNKNOWN_EMISSION
 }
 
-func (x *EmissionsGridBoundsResponse) GetMax() *Point {
+func (x *PollutantFraction) GetFraction() float64 {
 	if x != nil {
-		return x.Max
+		return x.Fraction
 	}
-	return nil
+	return 0
 }
 
-type EmissionsInventorySectorsRequest struct {
+type ExportGriddedRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
+
+	// Exactly one of Emissions, Concentrations, and Population must be
+	// set, to specify the gridded data to export.
+	Emissions      *GriddedEmissionsRequest      `protobuf:"bytes,1,opt,name=Emissions,proto3" json:"Emissions,omitempty"`
+	Concentrations *GriddedConcentrationsRequest `protobuf:"bytes,2,opt,name=Concentrations,proto3" json:"Concentrations,omitempty"`
+	Population     *GriddedPopulationRequest     `protobuf:"bytes,3,opt,name=Population,proto3" json:"Population,omitempty"`
+	Format         ExportFormat                  `protobuf:"varint,4,opt,name=Format,proto3,enum=cityaqrpc.ExportFormat" json:"Format,omitempty"`
 }
 
-func (x *EmissionsInventorySectorsRequest) Reset() {
-	*x = EmissionsInventorySectorsRequest{}
+func (x *ExportGriddedRequest) Reset() {
+	*x = ExportGriddedRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_cityaq_proto_msgTypes[17]
+		mi := &file_cityaq_proto_msgTypes[43]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *EmissionsInventorySectorsRequest) String() string {
+func (x *ExportGriddedRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*EmissionsInventorySectorsRequest) ProtoMessage() {}
+func (*ExportGriddedRequest) ProtoMessage() {}
 
-func (x *EmissionsInventorySectorsRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_cityaq_proto_msgTypes[17]
+func (x *ExportGriddedRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_cityaq_proto_msgTypes[43]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1206,36 +4016,69 @@ func (x *EmissionsInventorySectorsRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use EmissionsInventorySectorsRequest.ProtoReflect.Descriptor instead.
-func (*EmissionsInventorySectorsRequest) Descriptor() ([]byte, []int) {
-	return file_cityaq_proto_rawDescGZIP(), []int{17}
+// Deprecated: Use ExportGriddedRequest.ProtoReflect.Descriptor instead.
+func (*ExportGriddedRequest) Descriptor() ([]byte, []int) {
+	return file_cityaq_proto_rawDescGZIP(), []int{43}
 }
 
-type EmissionsInventorySectorsResponse struct {
+func (x *ExportGriddedRequest) GetEmissions() *GriddedEmissionsRequest {
+	if x != nil {
+		return x.Emissions
+	}
+	return nil
+}
+
+func (x *ExportGriddedRequest) GetConcentrations() *GriddedConcentrationsRequest {
+	if x != nil {
+		return x.Concentrations
+	}
+	return nil
+}
+
+func (x *ExportGriddedRequest) GetPopulation() *GriddedPopulationRequest {
+	if x != nil {
+		return x.Population
+	}
+	return nil
+}
+
+func (x *ExportGriddedRequest) GetFormat() ExportFormat {
+	if x != nil {
+		return x.Format
+	}
+	return ExportFormat_UNKNOWN_EXPORTFORMAT
+}
+
+type ExportGriddedResponse struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Sectors []string `protobuf:"bytes,1,rep,name=Sectors,proto3" json:"Sectors,omitempty"`
+	// FileName is a suggested name for the file.
+	FileName string `protobuf:"bytes,1,opt,name=FileName,proto3" json:"FileName,omitempty"`
+	// ContentType is the MIME type of the file.
+	ContentType string `protobuf:"bytes,2,opt,name=ContentType,proto3" json:"ContentType,omitempty"`
+	// Data holds the contents of the file.
+	Data []byte `protobuf:"bytes,3,opt,name=Data,proto3" json:"Data,omitempty"`
 }
 
-func (x *EmissionsInventorySectorsResponse) Reset() {
-	*x = EmissionsInventorySectorsResponse{}
+func (x *ExportGriddedResponse) Reset() {
+	*x = ExportGriddedResponse{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_cityaq_proto_msgTypes[18]
+		mi := &file_cityaq_proto_msgTypes[44]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *EmissionsInventorySectorsResponse) String() string {
+func (x *ExportGriddedResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*EmissionsInventorySectorsResponse) ProtoMessage() {}
+func (*ExportGriddedResponse) ProtoMessage() {}
 
-func (x *EmissionsInventorySectorsResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_cityaq_proto_msgTypes[18]
+func (x *ExportGriddedResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_cityaq_proto_msgTypes[44]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1246,14 +4089,28 @@ func (x *EmissionsInventorySectorsResponse) ProtoReflect() protoreflect.Message
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use EmissionsInventorySectorsResponse.ProtoReflect.Descriptor instead.
-func (*EmissionsInventorySectorsResponse) Descriptor() ([]byte, []int) {
-	return file_cityaq_proto_rawDescGZIP(), []int{18}
+// Deprecated: Use ExportGriddedResponse.ProtoReflect.Descriptor instead.
+func (*ExportGriddedResponse) Descriptor() ([]byte, []int) {
+	return file_cityaq_proto_rawDescGZIP(), []int{44}
+}
+
+func (x *ExportGriddedResponse) GetFileName() string {
+	if x != nil {
+		return x.FileName
+	}
+	return ""
+}
+
+func (x *ExportGriddedResponse) GetContentType() string {
+	if x != nil {
+		return x.ContentType
+	}
+	return ""
 }
 
-func (x *EmissionsInventorySectorsResponse) GetSectors() []string {
+func (x *ExportGriddedResponse) GetData() []byte {
 	if x != nil {
-		return x.Sectors
+		return x.Data
 	}
 	return nil
 }
@@ -1268,12 +4125,21 @@ type MapScaleRequest struct {
 	Emission       Emission       `protobuf:"varint,3,opt,name=Emission,proto3,enum=cityaqrpc.Emission" json:"Emission,omitempty"`
 	SourceType     string         `protobuf:"bytes,4,opt,name=SourceType,proto3" json:"SourceType,omitempty"`
 	SimulationType SimulationType `protobuf:"varint,5,opt,name=SimulationType,proto3,enum=cityaqrpc.SimulationType" json:"SimulationType,omitempty"`
+	// Resolution, if it is greater than zero, is the emissions grid cell
+	// edge length, in degrees, or in meters if the server builds grids in
+	// a projection. If it is zero, the resolution of the source type is used.
+	Resolution float64 `protobuf:"fixed64,6,opt,name=Resolution,proto3" json:"Resolution,omitempty"`
+	// AutoResolution specifies that the emissions grid resolution should
+	// be chosen from the extent of the area that emissions are allocated
+	// within, so that grids for large and small cities have similar
+	// numbers of cells. Resolution must be zero if it is set.
+	AutoResolution bool `protobuf:"varint,7,opt,name=AutoResolution,proto3" json:"AutoResolution,omitempty"`
 }
 
 func (x *MapScaleRequest) Reset() {
 	*x = MapScaleRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_cityaq_proto_msgTypes[19]
+		mi := &file_cityaq_proto_msgTypes[45]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1286,7 +4152,7 @@ func (x *MapScaleRequest) String() string {
 func (*MapScaleRequest) ProtoMessage() {}
 
 func (x *MapScaleRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_cityaq_proto_msgTypes[19]
+	mi := &file_cityaq_proto_msgTypes[45]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1299,7 +4165,7 @@ func (x *MapScaleRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use MapScaleRequest.ProtoReflect.Descriptor instead.
 func (*MapScaleRequest) Descriptor() ([]byte, []int) {
-	return file_cityaq_proto_rawDescGZIP(), []int{19}
+	return file_cityaq_proto_rawDescGZIP(), []int{45}
 }
 
 func (x *MapScaleRequest) GetCityName() string {
@@ -1337,6 +4203,20 @@ func (x *MapScaleRequest) GetSimulationType() SimulationType {
 	return SimulationType_UNKNOWN_SIMULATIONTYPE
 }
 
+func (x *MapScaleRequest) GetResolution() float64 {
+	if x != nil {
+		return x.Resolution
+	}
+	return 0
+}
+
+func (x *MapScaleRequest) GetAutoResolution() bool {
+	if x != nil {
+		return x.AutoResolution
+	}
+	return false
+}
+
 type MapScaleResponse struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -1350,7 +4230,7 @@ type MapScaleResponse struct {
 func (x *MapScaleResponse) Reset() {
 	*x = MapScaleResponse{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_cityaq_proto_msgTypes[20]
+		mi := &file_cityaq_proto_msgTypes[46]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1363,7 +4243,7 @@ func (x *MapScaleResponse) String() string {
 func (*MapScaleResponse) ProtoMessage() {}
 
 func (x *MapScaleResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_cityaq_proto_msgTypes[20]
+	mi := &file_cityaq_proto_msgTypes[46]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1376,7 +4256,7 @@ func (x *MapScaleResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use MapScaleResponse.ProtoReflect.Descriptor instead.
 func (*MapScaleResponse) Descriptor() ([]byte, []int) {
-	return file_cityaq_proto_rawDescGZIP(), []int{20}
+	return file_cityaq_proto_rawDescGZIP(), []int{46}
 }
 
 func (x *MapScaleResponse) GetMin() float64 {
@@ -1404,28 +4284,254 @@ var File_cityaq_proto protoreflect.FileDescriptor
 
 var file_cityaq_proto_rawDesc = []byte{
 	0x0a, 0x0c, 0x63, 0x69, 0x74, 0x79, 0x61, 0x71, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x09,
-	0x63, 0x69, 0x74, 0x79, 0x61, 0x71, 0x72, 0x70, 0x63, 0x22, 0x0f, 0x0a, 0x0d, 0x43, 0x69, 0x74,
-	0x69, 0x65, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x22, 0x26, 0x0a, 0x0e, 0x43, 0x69,
+	0x63, 0x69, 0x74, 0x79, 0x61, 0x71, 0x72, 0x70, 0x63, 0x22, 0xdd, 0x01, 0x0a, 0x0d, 0x43, 0x69,
+	0x74, 0x69, 0x65, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1a, 0x0a, 0x08, 0x4c,
+	0x61, 0x6e, 0x67, 0x75, 0x61, 0x67, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x4c,
+	0x61, 0x6e, 0x67, 0x75, 0x61, 0x67, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x51, 0x75, 0x65, 0x72, 0x79,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x51, 0x75, 0x65, 0x72, 0x79, 0x12, 0x18, 0x0a,
+	0x07, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x72, 0x79, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07,
+	0x43, 0x6f, 0x75, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x22, 0x0a, 0x03, 0x4d, 0x69, 0x6e, 0x18, 0x04,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x10, 0x2e, 0x63, 0x69, 0x74, 0x79, 0x61, 0x71, 0x72, 0x70, 0x63,
+	0x2e, 0x50, 0x6f, 0x69, 0x6e, 0x74, 0x52, 0x03, 0x4d, 0x69, 0x6e, 0x12, 0x22, 0x0a, 0x03, 0x4d,
+	0x61, 0x78, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x10, 0x2e, 0x63, 0x69, 0x74, 0x79, 0x61,
+	0x71, 0x72, 0x70, 0x63, 0x2e, 0x50, 0x6f, 0x69, 0x6e, 0x74, 0x52, 0x03, 0x4d, 0x61, 0x78, 0x12,
+	0x1a, 0x0a, 0x08, 0x50, 0x61, 0x67, 0x65, 0x53, 0x69, 0x7a, 0x65, 0x18, 0x06, 0x20, 0x01, 0x28,
+	0x05, 0x52, 0x08, 0x50, 0x61, 0x67, 0x65, 0x53, 0x69, 0x7a, 0x65, 0x12, 0x1c, 0x0a, 0x09, 0x50,
+	0x61, 0x67, 0x65, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x18, 0x07, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09,
+	0x50, 0x61, 0x67, 0x65, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x22, 0x93, 0x01, 0x0a, 0x0e, 0x43, 0x69,
 	0x74, 0x69, 0x65, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x14, 0x0a, 0x05,
 	0x4e, 0x61, 0x6d, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x09, 0x52, 0x05, 0x4e, 0x61, 0x6d,
-	0x65, 0x73, 0x22, 0x31, 0x0a, 0x13, 0x43, 0x69, 0x74, 0x79, 0x47, 0x65, 0x6f, 0x6d, 0x65, 0x74,
-	0x72, 0x79, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1a, 0x0a, 0x08, 0x43, 0x69, 0x74,
-	0x79, 0x4e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x43, 0x69, 0x74,
-	0x79, 0x4e, 0x61, 0x6d, 0x65, 0x22, 0x46, 0x0a, 0x14, 0x43, 0x69, 0x74, 0x79, 0x47, 0x65, 0x6f,
-	0x6d, 0x65, 0x74, 0x72, 0x79, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x2e, 0x0a,
-	0x08, 0x50, 0x6f, 0x6c, 0x79, 0x67, 0x6f, 0x6e, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32,
-	0x12, 0x2e, 0x63, 0x69, 0x74, 0x79, 0x61, 0x71, 0x72, 0x70, 0x63, 0x2e, 0x50, 0x6f, 0x6c, 0x79,
-	0x67, 0x6f, 0x6e, 0x52, 0x08, 0x50, 0x6f, 0x6c, 0x79, 0x67, 0x6f, 0x6e, 0x73, 0x22, 0x30, 0x0a,
-	0x07, 0x50, 0x6f, 0x6c, 0x79, 0x67, 0x6f, 0x6e, 0x12, 0x25, 0x0a, 0x05, 0x50, 0x61, 0x74, 0x68,
-	0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x0f, 0x2e, 0x63, 0x69, 0x74, 0x79, 0x61, 0x71,
-	0x72, 0x70, 0x63, 0x2e, 0x50, 0x61, 0x74, 0x68, 0x52, 0x05, 0x50, 0x61, 0x74, 0x68, 0x73, 0x22,
-	0x30, 0x0a, 0x04, 0x50, 0x61, 0x74, 0x68, 0x12, 0x28, 0x0a, 0x06, 0x50, 0x6f, 0x69, 0x6e, 0x74,
-	0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x10, 0x2e, 0x63, 0x69, 0x74, 0x79, 0x61, 0x71,
-	0x72, 0x70, 0x63, 0x2e, 0x50, 0x6f, 0x69, 0x6e, 0x74, 0x52, 0x06, 0x50, 0x6f, 0x69, 0x6e, 0x74,
-	0x73, 0x22, 0x23, 0x0a, 0x05, 0x50, 0x6f, 0x69, 0x6e, 0x74, 0x12, 0x0c, 0x0a, 0x01, 0x58, 0x18,
-	0x01, 0x20, 0x01, 0x28, 0x01, 0x52, 0x01, 0x58, 0x12, 0x0c, 0x0a, 0x01, 0x59, 0x18, 0x02, 0x20,
-	0x01, 0x28, 0x01, 0x52, 0x01, 0x59, 0x22, 0xc9, 0x01, 0x0a, 0x17, 0x47, 0x72, 0x69, 0x64, 0x64,
-	0x65, 0x64, 0x45, 0x6d, 0x69, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x65, 0x73, 0x12, 0x27, 0x0a, 0x06, 0x43, 0x69, 0x74, 0x69, 0x65, 0x73, 0x18, 0x02, 0x20, 0x03,
+	0x28, 0x0b, 0x32, 0x0f, 0x2e, 0x63, 0x69, 0x74, 0x79, 0x61, 0x71, 0x72, 0x70, 0x63, 0x2e, 0x43,
+	0x69, 0x74, 0x79, 0x52, 0x06, 0x43, 0x69, 0x74, 0x69, 0x65, 0x73, 0x12, 0x24, 0x0a, 0x0d, 0x4e,
+	0x65, 0x78, 0x74, 0x50, 0x61, 0x67, 0x65, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x18, 0x03, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x0d, 0x4e, 0x65, 0x78, 0x74, 0x50, 0x61, 0x67, 0x65, 0x54, 0x6f, 0x6b, 0x65,
+	0x6e, 0x12, 0x1c, 0x0a, 0x09, 0x54, 0x6f, 0x74, 0x61, 0x6c, 0x53, 0x69, 0x7a, 0x65, 0x18, 0x04,
+	0x20, 0x01, 0x28, 0x05, 0x52, 0x09, 0x54, 0x6f, 0x74, 0x61, 0x6c, 0x53, 0x69, 0x7a, 0x65, 0x22,
+	0x6a, 0x0a, 0x04, 0x43, 0x69, 0x74, 0x79, 0x12, 0x0e, 0x0a, 0x02, 0x49, 0x44, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x02, 0x49, 0x44, 0x12, 0x20, 0x0a, 0x0b, 0x44, 0x69, 0x73, 0x70, 0x6c,
+	0x61, 0x79, 0x4e, 0x61, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x44, 0x69,
+	0x73, 0x70, 0x6c, 0x61, 0x79, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x43, 0x6f, 0x75,
+	0x6e, 0x74, 0x72, 0x79, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x43, 0x6f, 0x75, 0x6e,
+	0x74, 0x72, 0x79, 0x12, 0x16, 0x0a, 0x06, 0x52, 0x65, 0x67, 0x69, 0x6f, 0x6e, 0x18, 0x04, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x06, 0x52, 0x65, 0x67, 0x69, 0x6f, 0x6e, 0x22, 0x4d, 0x0a, 0x13, 0x43,
+	0x69, 0x74, 0x79, 0x47, 0x65, 0x6f, 0x6d, 0x65, 0x74, 0x72, 0x79, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x12, 0x1a, 0x0a, 0x08, 0x43, 0x69, 0x74, 0x79, 0x4e, 0x61, 0x6d, 0x65, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x43, 0x69, 0x74, 0x79, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x1a,
+	0x0a, 0x08, 0x4c, 0x61, 0x6e, 0x67, 0x75, 0x61, 0x67, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x08, 0x4c, 0x61, 0x6e, 0x67, 0x75, 0x61, 0x67, 0x65, 0x22, 0x68, 0x0a, 0x14, 0x43, 0x69,
+	0x74, 0x79, 0x47, 0x65, 0x6f, 0x6d, 0x65, 0x74, 0x72, 0x79, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
+	0x73, 0x65, 0x12, 0x2e, 0x0a, 0x08, 0x50, 0x6f, 0x6c, 0x79, 0x67, 0x6f, 0x6e, 0x73, 0x18, 0x01,
+	0x20, 0x03, 0x28, 0x0b, 0x32, 0x12, 0x2e, 0x63, 0x69, 0x74, 0x79, 0x61, 0x71, 0x72, 0x70, 0x63,
+	0x2e, 0x50, 0x6f, 0x6c, 0x79, 0x67, 0x6f, 0x6e, 0x52, 0x08, 0x50, 0x6f, 0x6c, 0x79, 0x67, 0x6f,
+	0x6e, 0x73, 0x12, 0x20, 0x0a, 0x0b, 0x44, 0x69, 0x73, 0x70, 0x6c, 0x61, 0x79, 0x4e, 0x61, 0x6d,
+	0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x44, 0x69, 0x73, 0x70, 0x6c, 0x61, 0x79,
+	0x4e, 0x61, 0x6d, 0x65, 0x22, 0x5f, 0x0a, 0x17, 0x43, 0x69, 0x74, 0x69, 0x65, 0x73, 0x43, 0x6f,
+	0x6e, 0x74, 0x61, 0x69, 0x6e, 0x69, 0x6e, 0x67, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12,
+	0x28, 0x0a, 0x06, 0x50, 0x6f, 0x69, 0x6e, 0x74, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32,
+	0x10, 0x2e, 0x63, 0x69, 0x74, 0x79, 0x61, 0x71, 0x72, 0x70, 0x63, 0x2e, 0x50, 0x6f, 0x69, 0x6e,
+	0x74, 0x52, 0x06, 0x50, 0x6f, 0x69, 0x6e, 0x74, 0x73, 0x12, 0x1a, 0x0a, 0x08, 0x4c, 0x61, 0x6e,
+	0x67, 0x75, 0x61, 0x67, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x4c, 0x61, 0x6e,
+	0x67, 0x75, 0x61, 0x67, 0x65, 0x22, 0x4a, 0x0a, 0x18, 0x43, 0x69, 0x74, 0x69, 0x65, 0x73, 0x43,
+	0x6f, 0x6e, 0x74, 0x61, 0x69, 0x6e, 0x69, 0x6e, 0x67, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
+	0x65, 0x12, 0x2e, 0x0a, 0x06, 0x50, 0x6f, 0x69, 0x6e, 0x74, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28,
+	0x0b, 0x32, 0x16, 0x2e, 0x63, 0x69, 0x74, 0x79, 0x61, 0x71, 0x72, 0x70, 0x63, 0x2e, 0x50, 0x6f,
+	0x69, 0x6e, 0x74, 0x43, 0x69, 0x74, 0x69, 0x65, 0x73, 0x52, 0x06, 0x50, 0x6f, 0x69, 0x6e, 0x74,
+	0x73, 0x22, 0x36, 0x0a, 0x0b, 0x50, 0x6f, 0x69, 0x6e, 0x74, 0x43, 0x69, 0x74, 0x69, 0x65, 0x73,
+	0x12, 0x27, 0x0a, 0x06, 0x43, 0x69, 0x74, 0x69, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b,
+	0x32, 0x0f, 0x2e, 0x63, 0x69, 0x74, 0x79, 0x61, 0x71, 0x72, 0x70, 0x63, 0x2e, 0x43, 0x69, 0x74,
+	0x79, 0x52, 0x06, 0x43, 0x69, 0x74, 0x69, 0x65, 0x73, 0x22, 0x5a, 0x0a, 0x18, 0x52, 0x65, 0x67,
+	0x69, 0x73, 0x74, 0x65, 0x72, 0x53, 0x74, 0x75, 0x64, 0x79, 0x41, 0x72, 0x65, 0x61, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x4e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x04, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x47, 0x65, 0x6f,
+	0x4a, 0x53, 0x4f, 0x4e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x47, 0x65, 0x6f, 0x4a,
+	0x53, 0x4f, 0x4e, 0x12, 0x10, 0x0a, 0x03, 0x57, 0x4b, 0x54, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x03, 0x57, 0x4b, 0x54, 0x22, 0x2b, 0x0a, 0x19, 0x52, 0x65, 0x67, 0x69, 0x73, 0x74, 0x65,
+	0x72, 0x53, 0x74, 0x75, 0x64, 0x79, 0x41, 0x72, 0x65, 0x61, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
+	0x73, 0x65, 0x12, 0x0e, 0x0a, 0x02, 0x49, 0x44, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02,
+	0x49, 0x44, 0x22, 0x49, 0x0a, 0x0f, 0x43, 0x69, 0x74, 0x79, 0x49, 0x6e, 0x66, 0x6f, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1a, 0x0a, 0x08, 0x43, 0x69, 0x74, 0x79, 0x4e, 0x61, 0x6d,
+	0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x43, 0x69, 0x74, 0x79, 0x4e, 0x61, 0x6d,
+	0x65, 0x12, 0x1a, 0x0a, 0x08, 0x4c, 0x61, 0x6e, 0x67, 0x75, 0x61, 0x67, 0x65, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x08, 0x4c, 0x61, 0x6e, 0x67, 0x75, 0x61, 0x67, 0x65, 0x22, 0xb6, 0x02,
+	0x0a, 0x10, 0x43, 0x69, 0x74, 0x79, 0x49, 0x6e, 0x66, 0x6f, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
+	0x73, 0x65, 0x12, 0x0e, 0x0a, 0x02, 0x49, 0x44, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02,
+	0x49, 0x44, 0x12, 0x20, 0x0a, 0x0b, 0x44, 0x69, 0x73, 0x70, 0x6c, 0x61, 0x79, 0x4e, 0x61, 0x6d,
+	0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x44, 0x69, 0x73, 0x70, 0x6c, 0x61, 0x79,
+	0x4e, 0x61, 0x6d, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x41, 0x72, 0x65, 0x61, 0x18, 0x03, 0x20, 0x01,
+	0x28, 0x01, 0x52, 0x04, 0x41, 0x72, 0x65, 0x61, 0x12, 0x2c, 0x0a, 0x08, 0x43, 0x65, 0x6e, 0x74,
+	0x72, 0x6f, 0x69, 0x64, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x10, 0x2e, 0x63, 0x69, 0x74,
+	0x79, 0x61, 0x71, 0x72, 0x70, 0x63, 0x2e, 0x50, 0x6f, 0x69, 0x6e, 0x74, 0x52, 0x08, 0x43, 0x65,
+	0x6e, 0x74, 0x72, 0x6f, 0x69, 0x64, 0x12, 0x22, 0x0a, 0x03, 0x4d, 0x69, 0x6e, 0x18, 0x05, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x10, 0x2e, 0x63, 0x69, 0x74, 0x79, 0x61, 0x71, 0x72, 0x70, 0x63, 0x2e,
+	0x50, 0x6f, 0x69, 0x6e, 0x74, 0x52, 0x03, 0x4d, 0x69, 0x6e, 0x12, 0x22, 0x0a, 0x03, 0x4d, 0x61,
+	0x78, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x10, 0x2e, 0x63, 0x69, 0x74, 0x79, 0x61, 0x71,
+	0x72, 0x70, 0x63, 0x2e, 0x50, 0x6f, 0x69, 0x6e, 0x74, 0x52, 0x03, 0x4d, 0x61, 0x78, 0x12, 0x18,
+	0x0a, 0x07, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x72, 0x79, 0x18, 0x07, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x07, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x2c, 0x0a, 0x07, 0x45, 0x47, 0x55, 0x47,
+	0x72, 0x69, 0x64, 0x18, 0x08, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x12, 0x2e, 0x63, 0x69, 0x74, 0x79,
+	0x61, 0x71, 0x72, 0x70, 0x63, 0x2e, 0x50, 0x6f, 0x6c, 0x79, 0x67, 0x6f, 0x6e, 0x52, 0x07, 0x45,
+	0x47, 0x55, 0x47, 0x72, 0x69, 0x64, 0x12, 0x1e, 0x0a, 0x0a, 0x50, 0x72, 0x6f, 0x70, 0x65, 0x72,
+	0x74, 0x69, 0x65, 0x73, 0x18, 0x09, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x50, 0x72, 0x6f, 0x70,
+	0x65, 0x72, 0x74, 0x69, 0x65, 0x73, 0x22, 0x30, 0x0a, 0x07, 0x50, 0x6f, 0x6c, 0x79, 0x67, 0x6f,
+	0x6e, 0x12, 0x25, 0x0a, 0x05, 0x50, 0x61, 0x74, 0x68, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b,
+	0x32, 0x0f, 0x2e, 0x63, 0x69, 0x74, 0x79, 0x61, 0x71, 0x72, 0x70, 0x63, 0x2e, 0x50, 0x61, 0x74,
+	0x68, 0x52, 0x05, 0x50, 0x61, 0x74, 0x68, 0x73, 0x22, 0x30, 0x0a, 0x04, 0x50, 0x61, 0x74, 0x68,
+	0x12, 0x28, 0x0a, 0x06, 0x50, 0x6f, 0x69, 0x6e, 0x74, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b,
+	0x32, 0x10, 0x2e, 0x63, 0x69, 0x74, 0x79, 0x61, 0x71, 0x72, 0x70, 0x63, 0x2e, 0x50, 0x6f, 0x69,
+	0x6e, 0x74, 0x52, 0x06, 0x50, 0x6f, 0x69, 0x6e, 0x74, 0x73, 0x22, 0x23, 0x0a, 0x05, 0x50, 0x6f,
+	0x69, 0x6e, 0x74, 0x12, 0x0c, 0x0a, 0x01, 0x58, 0x18, 0x01, 0x20, 0x01, 0x28, 0x01, 0x52, 0x01,
+	0x58, 0x12, 0x0c, 0x0a, 0x01, 0x59, 0x18, 0x02, 0x20, 0x01, 0x28, 0x01, 0x52, 0x01, 0x59, 0x22,
+	0xc7, 0x06, 0x0a, 0x17, 0x47, 0x72, 0x69, 0x64, 0x64, 0x65, 0x64, 0x45, 0x6d, 0x69, 0x73, 0x73,
+	0x69, 0x6f, 0x6e, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1a, 0x0a, 0x08, 0x43,
+	0x69, 0x74, 0x79, 0x4e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x43,
+	0x69, 0x74, 0x79, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x1e, 0x0a, 0x0a, 0x53, 0x6f, 0x75, 0x72, 0x63,
+	0x65, 0x54, 0x79, 0x70, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x53, 0x6f, 0x75,
+	0x72, 0x63, 0x65, 0x54, 0x79, 0x70, 0x65, 0x12, 0x2f, 0x0a, 0x08, 0x45, 0x6d, 0x69, 0x73, 0x73,
+	0x69, 0x6f, 0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x13, 0x2e, 0x63, 0x69, 0x74, 0x79,
+	0x61, 0x71, 0x72, 0x70, 0x63, 0x2e, 0x45, 0x6d, 0x69, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x52, 0x08,
+	0x45, 0x6d, 0x69, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x41, 0x0a, 0x0e, 0x53, 0x69, 0x6d, 0x75,
+	0x6c, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x54, 0x79, 0x70, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0e,
+	0x32, 0x19, 0x2e, 0x63, 0x69, 0x74, 0x79, 0x61, 0x71, 0x72, 0x70, 0x63, 0x2e, 0x53, 0x69, 0x6d,
+	0x75, 0x6c, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x54, 0x79, 0x70, 0x65, 0x52, 0x0e, 0x53, 0x69, 0x6d,
+	0x75, 0x6c, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x54, 0x79, 0x70, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x41,
+	0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x05, 0x20, 0x01, 0x28, 0x01, 0x52, 0x06, 0x41, 0x6d, 0x6f,
+	0x75, 0x6e, 0x74, 0x12, 0x3a, 0x0a, 0x0b, 0x41, 0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x55, 0x6e, 0x69,
+	0x74, 0x73, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x18, 0x2e, 0x63, 0x69, 0x74, 0x79, 0x61,
+	0x71, 0x72, 0x70, 0x63, 0x2e, 0x45, 0x6d, 0x69, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x55, 0x6e, 0x69,
+	0x74, 0x73, 0x52, 0x0b, 0x41, 0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x55, 0x6e, 0x69, 0x74, 0x73, 0x12,
+	0x14, 0x0a, 0x05, 0x42, 0x65, 0x67, 0x69, 0x6e, 0x18, 0x07, 0x20, 0x01, 0x28, 0x03, 0x52, 0x05,
+	0x42, 0x65, 0x67, 0x69, 0x6e, 0x12, 0x10, 0x0a, 0x03, 0x45, 0x6e, 0x64, 0x18, 0x08, 0x20, 0x01,
+	0x28, 0x03, 0x52, 0x03, 0x45, 0x6e, 0x64, 0x12, 0x3a, 0x0a, 0x0a, 0x50, 0x6f, 0x6c, 0x6c, 0x75,
+	0x74, 0x61, 0x6e, 0x74, 0x73, 0x18, 0x09, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x63, 0x69,
+	0x74, 0x79, 0x61, 0x71, 0x72, 0x70, 0x63, 0x2e, 0x50, 0x6f, 0x6c, 0x6c, 0x75, 0x74, 0x61, 0x6e,
+	0x74, 0x41, 0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x52, 0x0a, 0x50, 0x6f, 0x6c, 0x6c, 0x75, 0x74, 0x61,
+	0x6e, 0x74, 0x73, 0x12, 0x44, 0x0a, 0x0f, 0x43, 0x75, 0x73, 0x74, 0x6f, 0x6d, 0x53, 0x75, 0x72,
+	0x72, 0x6f, 0x67, 0x61, 0x74, 0x65, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x63,
+	0x69, 0x74, 0x79, 0x61, 0x71, 0x72, 0x70, 0x63, 0x2e, 0x43, 0x75, 0x73, 0x74, 0x6f, 0x6d, 0x53,
+	0x75, 0x72, 0x72, 0x6f, 0x67, 0x61, 0x74, 0x65, 0x52, 0x0f, 0x43, 0x75, 0x73, 0x74, 0x6f, 0x6d,
+	0x53, 0x75, 0x72, 0x72, 0x6f, 0x67, 0x61, 0x74, 0x65, 0x12, 0x41, 0x0a, 0x0d, 0x53, 0x6f, 0x75,
+	0x72, 0x63, 0x65, 0x54, 0x79, 0x70, 0x65, 0x4d, 0x69, 0x78, 0x18, 0x0b, 0x20, 0x03, 0x28, 0x0b,
+	0x32, 0x1b, 0x2e, 0x63, 0x69, 0x74, 0x79, 0x61, 0x71, 0x72, 0x70, 0x63, 0x2e, 0x53, 0x6f, 0x75,
+	0x72, 0x63, 0x65, 0x54, 0x79, 0x70, 0x65, 0x57, 0x65, 0x69, 0x67, 0x68, 0x74, 0x52, 0x0d, 0x53,
+	0x6f, 0x75, 0x72, 0x63, 0x65, 0x54, 0x79, 0x70, 0x65, 0x4d, 0x69, 0x78, 0x12, 0x1e, 0x0a, 0x0a,
+	0x52, 0x65, 0x73, 0x6f, 0x6c, 0x75, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x0c, 0x20, 0x01, 0x28, 0x01,
+	0x52, 0x0a, 0x52, 0x65, 0x73, 0x6f, 0x6c, 0x75, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x26, 0x0a, 0x0e,
+	0x41, 0x75, 0x74, 0x6f, 0x52, 0x65, 0x73, 0x6f, 0x6c, 0x75, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x0d,
+	0x20, 0x01, 0x28, 0x08, 0x52, 0x0e, 0x41, 0x75, 0x74, 0x6f, 0x52, 0x65, 0x73, 0x6f, 0x6c, 0x75,
+	0x74, 0x69, 0x6f, 0x6e, 0x12, 0x4d, 0x0a, 0x12, 0x54, 0x65, 0x6d, 0x70, 0x6f, 0x72, 0x61, 0x6c,
+	0x52, 0x65, 0x73, 0x6f, 0x6c, 0x75, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x0e, 0x20, 0x01, 0x28, 0x0e,
+	0x32, 0x1d, 0x2e, 0x63, 0x69, 0x74, 0x79, 0x61, 0x71, 0x72, 0x70, 0x63, 0x2e, 0x54, 0x65, 0x6d,
+	0x70, 0x6f, 0x72, 0x61, 0x6c, 0x52, 0x65, 0x73, 0x6f, 0x6c, 0x75, 0x74, 0x69, 0x6f, 0x6e, 0x52,
+	0x12, 0x54, 0x65, 0x6d, 0x70, 0x6f, 0x72, 0x61, 0x6c, 0x52, 0x65, 0x73, 0x6f, 0x6c, 0x75, 0x74,
+	0x69, 0x6f, 0x6e, 0x12, 0x20, 0x0a, 0x0b, 0x57, 0x69, 0x6e, 0x64, 0x6f, 0x77, 0x42, 0x65, 0x67,
+	0x69, 0x6e, 0x18, 0x0f, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0b, 0x57, 0x69, 0x6e, 0x64, 0x6f, 0x77,
+	0x42, 0x65, 0x67, 0x69, 0x6e, 0x12, 0x1c, 0x0a, 0x09, 0x57, 0x69, 0x6e, 0x64, 0x6f, 0x77, 0x45,
+	0x6e, 0x64, 0x18, 0x10, 0x20, 0x01, 0x28, 0x03, 0x52, 0x09, 0x57, 0x69, 0x6e, 0x64, 0x6f, 0x77,
+	0x45, 0x6e, 0x64, 0x12, 0x1a, 0x0a, 0x08, 0x53, 0x70, 0x65, 0x63, 0x69, 0x61, 0x74, 0x65, 0x18,
+	0x11, 0x20, 0x01, 0x28, 0x08, 0x52, 0x08, 0x53, 0x70, 0x65, 0x63, 0x69, 0x61, 0x74, 0x65, 0x12,
+	0x24, 0x0a, 0x0d, 0x4d, 0x61, 0x73, 0x73, 0x54, 0x6f, 0x6c, 0x65, 0x72, 0x61, 0x6e, 0x63, 0x65,
+	0x18, 0x12, 0x20, 0x01, 0x28, 0x01, 0x52, 0x0d, 0x4d, 0x61, 0x73, 0x73, 0x54, 0x6f, 0x6c, 0x65,
+	0x72, 0x61, 0x6e, 0x63, 0x65, 0x12, 0x22, 0x0a, 0x0c, 0x41, 0x72, 0x65, 0x61, 0x46, 0x61, 0x6c,
+	0x6c, 0x62, 0x61, 0x63, 0x6b, 0x18, 0x13, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0c, 0x41, 0x72, 0x65,
+	0x61, 0x46, 0x61, 0x6c, 0x6c, 0x62, 0x61, 0x63, 0x6b, 0x22, 0x8a, 0x01, 0x0a, 0x11, 0x45, 0x6d,
+	0x69, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x73, 0x54, 0x69, 0x6d, 0x65, 0x53, 0x74, 0x65, 0x70, 0x12,
+	0x14, 0x0a, 0x05, 0x42, 0x65, 0x67, 0x69, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x05,
+	0x42, 0x65, 0x67, 0x69, 0x6e, 0x12, 0x10, 0x0a, 0x03, 0x45, 0x6e, 0x64, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x03, 0x52, 0x03, 0x45, 0x6e, 0x64, 0x12, 0x4d, 0x0a, 0x12, 0x50, 0x6f, 0x6c, 0x6c, 0x75,
+	0x74, 0x61, 0x6e, 0x74, 0x45, 0x6d, 0x69, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x03, 0x20,
+	0x03, 0x28, 0x0b, 0x32, 0x1d, 0x2e, 0x63, 0x69, 0x74, 0x79, 0x61, 0x71, 0x72, 0x70, 0x63, 0x2e,
+	0x50, 0x6f, 0x6c, 0x6c, 0x75, 0x74, 0x61, 0x6e, 0x74, 0x45, 0x6d, 0x69, 0x73, 0x73, 0x69, 0x6f,
+	0x6e, 0x73, 0x52, 0x12, 0x50, 0x6f, 0x6c, 0x6c, 0x75, 0x74, 0x61, 0x6e, 0x74, 0x45, 0x6d, 0x69,
+	0x73, 0x73, 0x69, 0x6f, 0x6e, 0x73, 0x22, 0xb6, 0x02, 0x0a, 0x18, 0x47, 0x72, 0x69, 0x64, 0x64,
+	0x65, 0x64, 0x45, 0x6d, 0x69, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f,
+	0x6e, 0x73, 0x65, 0x12, 0x2e, 0x0a, 0x08, 0x50, 0x6f, 0x6c, 0x79, 0x67, 0x6f, 0x6e, 0x73, 0x18,
+	0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x12, 0x2e, 0x63, 0x69, 0x74, 0x79, 0x61, 0x71, 0x72, 0x70,
+	0x63, 0x2e, 0x50, 0x6f, 0x6c, 0x79, 0x67, 0x6f, 0x6e, 0x52, 0x08, 0x50, 0x6f, 0x6c, 0x79, 0x67,
+	0x6f, 0x6e, 0x73, 0x12, 0x1c, 0x0a, 0x09, 0x45, 0x6d, 0x69, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x73,
+	0x18, 0x02, 0x20, 0x03, 0x28, 0x01, 0x52, 0x09, 0x45, 0x6d, 0x69, 0x73, 0x73, 0x69, 0x6f, 0x6e,
+	0x73, 0x12, 0x4d, 0x0a, 0x12, 0x50, 0x6f, 0x6c, 0x6c, 0x75, 0x74, 0x61, 0x6e, 0x74, 0x45, 0x6d,
+	0x69, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1d, 0x2e,
+	0x63, 0x69, 0x74, 0x79, 0x61, 0x71, 0x72, 0x70, 0x63, 0x2e, 0x50, 0x6f, 0x6c, 0x6c, 0x75, 0x74,
+	0x61, 0x6e, 0x74, 0x45, 0x6d, 0x69, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x73, 0x52, 0x12, 0x50, 0x6f,
+	0x6c, 0x6c, 0x75, 0x74, 0x61, 0x6e, 0x74, 0x45, 0x6d, 0x69, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x73,
+	0x12, 0x3a, 0x0a, 0x09, 0x54, 0x69, 0x6d, 0x65, 0x53, 0x74, 0x65, 0x70, 0x73, 0x18, 0x04, 0x20,
+	0x03, 0x28, 0x0b, 0x32, 0x1c, 0x2e, 0x63, 0x69, 0x74, 0x79, 0x61, 0x71, 0x72, 0x70, 0x63, 0x2e,
+	0x45, 0x6d, 0x69, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x73, 0x54, 0x69, 0x6d, 0x65, 0x53, 0x74, 0x65,
+	0x70, 0x52, 0x09, 0x54, 0x69, 0x6d, 0x65, 0x53, 0x74, 0x65, 0x70, 0x73, 0x12, 0x41, 0x0a, 0x0b,
+	0x44, 0x69, 0x61, 0x67, 0x6e, 0x6f, 0x73, 0x74, 0x69, 0x63, 0x73, 0x18, 0x05, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x1f, 0x2e, 0x63, 0x69, 0x74, 0x79, 0x61, 0x71, 0x72, 0x70, 0x63, 0x2e, 0x45, 0x6d,
+	0x69, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x73, 0x44, 0x69, 0x61, 0x67, 0x6e, 0x6f, 0x73, 0x74, 0x69,
+	0x63, 0x73, 0x52, 0x0b, 0x44, 0x69, 0x61, 0x67, 0x6e, 0x6f, 0x73, 0x74, 0x69, 0x63, 0x73, 0x22,
+	0x8a, 0x02, 0x0a, 0x14, 0x45, 0x6d, 0x69, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x73, 0x44, 0x69, 0x61,
+	0x67, 0x6e, 0x6f, 0x73, 0x74, 0x69, 0x63, 0x73, 0x12, 0x24, 0x0a, 0x0d, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x65, 0x64, 0x4d, 0x61, 0x73, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x01, 0x52,
+	0x0d, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x65, 0x64, 0x4d, 0x61, 0x73, 0x73, 0x12, 0x24,
+	0x0a, 0x0d, 0x41, 0x6c, 0x6c, 0x6f, 0x63, 0x61, 0x74, 0x65, 0x64, 0x4d, 0x61, 0x73, 0x73, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x01, 0x52, 0x0d, 0x41, 0x6c, 0x6c, 0x6f, 0x63, 0x61, 0x74, 0x65, 0x64,
+	0x4d, 0x61, 0x73, 0x73, 0x12, 0x22, 0x0a, 0x0c, 0x43, 0x69, 0x74, 0x79, 0x46, 0x72, 0x61, 0x63,
+	0x74, 0x69, 0x6f, 0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x01, 0x52, 0x0c, 0x43, 0x69, 0x74, 0x79,
+	0x46, 0x72, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x22, 0x0a, 0x0c, 0x4c, 0x6f, 0x73, 0x74,
+	0x46, 0x72, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x04, 0x20, 0x01, 0x28, 0x01, 0x52, 0x0c,
+	0x4c, 0x6f, 0x73, 0x74, 0x46, 0x72, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x2c, 0x0a, 0x11,
+	0x53, 0x75, 0x72, 0x72, 0x6f, 0x67, 0x61, 0x74, 0x65, 0x46, 0x61, 0x6c, 0x6c, 0x62, 0x61, 0x63,
+	0x6b, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x11, 0x53, 0x75, 0x72, 0x72, 0x6f, 0x67, 0x61,
+	0x74, 0x65, 0x46, 0x61, 0x6c, 0x6c, 0x62, 0x61, 0x63, 0x6b, 0x12, 0x30, 0x0a, 0x13, 0x46, 0x61,
+	0x6c, 0x6c, 0x62, 0x61, 0x63, 0x6b, 0x53, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x54, 0x79, 0x70, 0x65,
+	0x73, 0x18, 0x06, 0x20, 0x03, 0x28, 0x09, 0x52, 0x13, 0x46, 0x61, 0x6c, 0x6c, 0x62, 0x61, 0x63,
+	0x6b, 0x53, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x54, 0x79, 0x70, 0x65, 0x73, 0x22, 0x96, 0x01, 0x0a,
+	0x0f, 0x50, 0x6f, 0x6c, 0x6c, 0x75, 0x74, 0x61, 0x6e, 0x74, 0x41, 0x6d, 0x6f, 0x75, 0x6e, 0x74,
+	0x12, 0x2f, 0x0a, 0x08, 0x45, 0x6d, 0x69, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x0e, 0x32, 0x13, 0x2e, 0x63, 0x69, 0x74, 0x79, 0x61, 0x71, 0x72, 0x70, 0x63, 0x2e, 0x45,
+	0x6d, 0x69, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x52, 0x08, 0x45, 0x6d, 0x69, 0x73, 0x73, 0x69, 0x6f,
+	0x6e, 0x12, 0x16, 0x0a, 0x06, 0x41, 0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x01, 0x52, 0x06, 0x41, 0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x3a, 0x0a, 0x0b, 0x41, 0x6d, 0x6f,
+	0x75, 0x6e, 0x74, 0x55, 0x6e, 0x69, 0x74, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x18,
+	0x2e, 0x63, 0x69, 0x74, 0x79, 0x61, 0x71, 0x72, 0x70, 0x63, 0x2e, 0x45, 0x6d, 0x69, 0x73, 0x73,
+	0x69, 0x6f, 0x6e, 0x55, 0x6e, 0x69, 0x74, 0x73, 0x52, 0x0b, 0x41, 0x6d, 0x6f, 0x75, 0x6e, 0x74,
+	0x55, 0x6e, 0x69, 0x74, 0x73, 0x22, 0x70, 0x0a, 0x0f, 0x43, 0x75, 0x73, 0x74, 0x6f, 0x6d, 0x53,
+	0x75, 0x72, 0x72, 0x6f, 0x67, 0x61, 0x74, 0x65, 0x12, 0x25, 0x0a, 0x04, 0x54, 0x61, 0x67, 0x73,
+	0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x11, 0x2e, 0x63, 0x69, 0x74, 0x79, 0x61, 0x71, 0x72,
+	0x70, 0x63, 0x2e, 0x4f, 0x53, 0x4d, 0x54, 0x61, 0x67, 0x52, 0x04, 0x54, 0x61, 0x67, 0x73, 0x12,
+	0x36, 0x0a, 0x08, 0x57, 0x65, 0x69, 0x67, 0x68, 0x74, 0x42, 0x79, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x0e, 0x32, 0x1a, 0x2e, 0x63, 0x69, 0x74, 0x79, 0x61, 0x71, 0x72, 0x70, 0x63, 0x2e, 0x53, 0x75,
+	0x72, 0x72, 0x6f, 0x67, 0x61, 0x74, 0x65, 0x57, 0x65, 0x69, 0x67, 0x68, 0x74, 0x52, 0x08, 0x57,
+	0x65, 0x69, 0x67, 0x68, 0x74, 0x42, 0x79, 0x22, 0x32, 0x0a, 0x06, 0x4f, 0x53, 0x4d, 0x54, 0x61,
+	0x67, 0x12, 0x10, 0x0a, 0x03, 0x4b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03,
+	0x4b, 0x65, 0x79, 0x12, 0x16, 0x0a, 0x06, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x73, 0x18, 0x02, 0x20,
+	0x03, 0x28, 0x09, 0x52, 0x06, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x73, 0x22, 0x4a, 0x0a, 0x10, 0x53,
+	0x6f, 0x75, 0x72, 0x63, 0x65, 0x54, 0x79, 0x70, 0x65, 0x57, 0x65, 0x69, 0x67, 0x68, 0x74, 0x12,
+	0x1e, 0x0a, 0x0a, 0x53, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x54, 0x79, 0x70, 0x65, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x0a, 0x53, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x54, 0x79, 0x70, 0x65, 0x12,
+	0x16, 0x0a, 0x06, 0x57, 0x65, 0x69, 0x67, 0x68, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x01, 0x52,
+	0x06, 0x57, 0x65, 0x69, 0x67, 0x68, 0x74, 0x22, 0xb2, 0x02, 0x0a, 0x0b, 0x50, 0x6f, 0x69, 0x6e,
+	0x74, 0x53, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x12, 0x2c, 0x0a, 0x08, 0x4c, 0x6f, 0x63, 0x61, 0x74,
+	0x69, 0x6f, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x10, 0x2e, 0x63, 0x69, 0x74, 0x79,
+	0x61, 0x71, 0x72, 0x70, 0x63, 0x2e, 0x50, 0x6f, 0x69, 0x6e, 0x74, 0x52, 0x08, 0x4c, 0x6f, 0x63,
+	0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x13, 0x0a, 0x05, 0x50, 0x4d, 0x32, 0x5f, 0x35, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x01, 0x52, 0x04, 0x50, 0x4d, 0x32, 0x35, 0x12, 0x10, 0x0a, 0x03, 0x56, 0x4f,
+	0x43, 0x18, 0x03, 0x20, 0x01, 0x28, 0x01, 0x52, 0x03, 0x56, 0x4f, 0x43, 0x12, 0x10, 0x0a, 0x03,
+	0x4e, 0x48, 0x33, 0x18, 0x04, 0x20, 0x01, 0x28, 0x01, 0x52, 0x03, 0x4e, 0x48, 0x33, 0x12, 0x10,
+	0x0a, 0x03, 0x4e, 0x4f, 0x78, 0x18, 0x05, 0x20, 0x01, 0x28, 0x01, 0x52, 0x03, 0x4e, 0x4f, 0x78,
+	0x12, 0x10, 0x0a, 0x03, 0x53, 0x4f, 0x78, 0x18, 0x06, 0x20, 0x01, 0x28, 0x01, 0x52, 0x03, 0x53,
+	0x4f, 0x78, 0x12, 0x20, 0x0a, 0x0b, 0x53, 0x74, 0x61, 0x63, 0x6b, 0x48, 0x65, 0x69, 0x67, 0x68,
+	0x74, 0x18, 0x07, 0x20, 0x01, 0x28, 0x01, 0x52, 0x0b, 0x53, 0x74, 0x61, 0x63, 0x6b, 0x48, 0x65,
+	0x69, 0x67, 0x68, 0x74, 0x12, 0x24, 0x0a, 0x0d, 0x53, 0x74, 0x61, 0x63, 0x6b, 0x44, 0x69, 0x61,
+	0x6d, 0x65, 0x74, 0x65, 0x72, 0x18, 0x08, 0x20, 0x01, 0x28, 0x01, 0x52, 0x0d, 0x53, 0x74, 0x61,
+	0x63, 0x6b, 0x44, 0x69, 0x61, 0x6d, 0x65, 0x74, 0x65, 0x72, 0x12, 0x2a, 0x0a, 0x10, 0x53, 0x74,
+	0x61, 0x63, 0x6b, 0x54, 0x65, 0x6d, 0x70, 0x65, 0x72, 0x61, 0x74, 0x75, 0x72, 0x65, 0x18, 0x09,
+	0x20, 0x01, 0x28, 0x01, 0x52, 0x10, 0x53, 0x74, 0x61, 0x63, 0x6b, 0x54, 0x65, 0x6d, 0x70, 0x65,
+	0x72, 0x61, 0x74, 0x75, 0x72, 0x65, 0x12, 0x24, 0x0a, 0x0d, 0x53, 0x74, 0x61, 0x63, 0x6b, 0x56,
+	0x65, 0x6c, 0x6f, 0x63, 0x69, 0x74, 0x79, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x01, 0x52, 0x0d, 0x53,
+	0x74, 0x61, 0x63, 0x6b, 0x56, 0x65, 0x6c, 0x6f, 0x63, 0x69, 0x74, 0x79, 0x22, 0x63, 0x0a, 0x12,
+	0x50, 0x6f, 0x6c, 0x6c, 0x75, 0x74, 0x61, 0x6e, 0x74, 0x45, 0x6d, 0x69, 0x73, 0x73, 0x69, 0x6f,
+	0x6e, 0x73, 0x12, 0x2f, 0x0a, 0x08, 0x45, 0x6d, 0x69, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x0e, 0x32, 0x13, 0x2e, 0x63, 0x69, 0x74, 0x79, 0x61, 0x71, 0x72, 0x70, 0x63,
+	0x2e, 0x45, 0x6d, 0x69, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x52, 0x08, 0x45, 0x6d, 0x69, 0x73, 0x73,
+	0x69, 0x6f, 0x6e, 0x12, 0x1c, 0x0a, 0x09, 0x45, 0x6d, 0x69, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x73,
+	0x18, 0x02, 0x20, 0x03, 0x28, 0x01, 0x52, 0x09, 0x45, 0x6d, 0x69, 0x73, 0x73, 0x69, 0x6f, 0x6e,
+	0x73, 0x22, 0xd3, 0x05, 0x0a, 0x1c, 0x47, 0x72, 0x69, 0x64, 0x64, 0x65, 0x64, 0x43, 0x6f, 0x6e,
+	0x63, 0x65, 0x6e, 0x74, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65,
 	0x73, 0x74, 0x12, 0x1a, 0x0a, 0x08, 0x43, 0x69, 0x74, 0x79, 0x4e, 0x61, 0x6d, 0x65, 0x18, 0x01,
 	0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x43, 0x69, 0x74, 0x79, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x1e,
 	0x0a, 0x0a, 0x53, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x54, 0x79, 0x70, 0x65, 0x18, 0x02, 0x20, 0x01,
@@ -1437,15 +4543,48 @@ var file_cityaq_proto_rawDesc = []byte{
 	0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x19, 0x2e, 0x63, 0x69, 0x74, 0x79, 0x61, 0x71,
 	0x72, 0x70, 0x63, 0x2e, 0x53, 0x69, 0x6d, 0x75, 0x6c, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x54, 0x79,
 	0x70, 0x65, 0x52, 0x0e, 0x53, 0x69, 0x6d, 0x75, 0x6c, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x54, 0x79,
-	0x70, 0x65, 0x22, 0x68, 0x0a, 0x18, 0x47, 0x72, 0x69, 0x64, 0x64, 0x65, 0x64, 0x45, 0x6d, 0x69,
-	0x73, 0x73, 0x69, 0x6f, 0x6e, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x2e,
-	0x0a, 0x08, 0x50, 0x6f, 0x6c, 0x79, 0x67, 0x6f, 0x6e, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b,
-	0x32, 0x12, 0x2e, 0x63, 0x69, 0x74, 0x79, 0x61, 0x71, 0x72, 0x70, 0x63, 0x2e, 0x50, 0x6f, 0x6c,
-	0x79, 0x67, 0x6f, 0x6e, 0x52, 0x08, 0x50, 0x6f, 0x6c, 0x79, 0x67, 0x6f, 0x6e, 0x73, 0x12, 0x1c,
-	0x0a, 0x09, 0x45, 0x6d, 0x69, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28,
-	0x01, 0x52, 0x09, 0x45, 0x6d, 0x69, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x73, 0x22, 0xce, 0x01, 0x0a,
-	0x1c, 0x47, 0x72, 0x69, 0x64, 0x64, 0x65, 0x64, 0x43, 0x6f, 0x6e, 0x63, 0x65, 0x6e, 0x74, 0x72,
-	0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1a, 0x0a,
+	0x70, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x41, 0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x05, 0x20, 0x01,
+	0x28, 0x01, 0x52, 0x06, 0x41, 0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x3a, 0x0a, 0x0b, 0x41, 0x6d,
+	0x6f, 0x75, 0x6e, 0x74, 0x55, 0x6e, 0x69, 0x74, 0x73, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0e, 0x32,
+	0x18, 0x2e, 0x63, 0x69, 0x74, 0x79, 0x61, 0x71, 0x72, 0x70, 0x63, 0x2e, 0x45, 0x6d, 0x69, 0x73,
+	0x73, 0x69, 0x6f, 0x6e, 0x55, 0x6e, 0x69, 0x74, 0x73, 0x52, 0x0b, 0x41, 0x6d, 0x6f, 0x75, 0x6e,
+	0x74, 0x55, 0x6e, 0x69, 0x74, 0x73, 0x12, 0x14, 0x0a, 0x05, 0x42, 0x65, 0x67, 0x69, 0x6e, 0x18,
+	0x07, 0x20, 0x01, 0x28, 0x03, 0x52, 0x05, 0x42, 0x65, 0x67, 0x69, 0x6e, 0x12, 0x10, 0x0a, 0x03,
+	0x45, 0x6e, 0x64, 0x18, 0x08, 0x20, 0x01, 0x28, 0x03, 0x52, 0x03, 0x45, 0x6e, 0x64, 0x12, 0x3a,
+	0x0a, 0x0a, 0x50, 0x6f, 0x6c, 0x6c, 0x75, 0x74, 0x61, 0x6e, 0x74, 0x73, 0x18, 0x09, 0x20, 0x03,
+	0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x63, 0x69, 0x74, 0x79, 0x61, 0x71, 0x72, 0x70, 0x63, 0x2e, 0x50,
+	0x6f, 0x6c, 0x6c, 0x75, 0x74, 0x61, 0x6e, 0x74, 0x41, 0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x52, 0x0a,
+	0x50, 0x6f, 0x6c, 0x6c, 0x75, 0x74, 0x61, 0x6e, 0x74, 0x73, 0x12, 0x3a, 0x0a, 0x0c, 0x50, 0x6f,
+	0x69, 0x6e, 0x74, 0x53, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x73, 0x18, 0x0a, 0x20, 0x03, 0x28, 0x0b,
+	0x32, 0x16, 0x2e, 0x63, 0x69, 0x74, 0x79, 0x61, 0x71, 0x72, 0x70, 0x63, 0x2e, 0x50, 0x6f, 0x69,
+	0x6e, 0x74, 0x53, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x52, 0x0c, 0x50, 0x6f, 0x69, 0x6e, 0x74, 0x53,
+	0x6f, 0x75, 0x72, 0x63, 0x65, 0x73, 0x12, 0x44, 0x0a, 0x0f, 0x43, 0x75, 0x73, 0x74, 0x6f, 0x6d,
+	0x53, 0x75, 0x72, 0x72, 0x6f, 0x67, 0x61, 0x74, 0x65, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x1a, 0x2e, 0x63, 0x69, 0x74, 0x79, 0x61, 0x71, 0x72, 0x70, 0x63, 0x2e, 0x43, 0x75, 0x73, 0x74,
+	0x6f, 0x6d, 0x53, 0x75, 0x72, 0x72, 0x6f, 0x67, 0x61, 0x74, 0x65, 0x52, 0x0f, 0x43, 0x75, 0x73,
+	0x74, 0x6f, 0x6d, 0x53, 0x75, 0x72, 0x72, 0x6f, 0x67, 0x61, 0x74, 0x65, 0x12, 0x41, 0x0a, 0x0d,
+	0x53, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x54, 0x79, 0x70, 0x65, 0x4d, 0x69, 0x78, 0x18, 0x0c, 0x20,
+	0x03, 0x28, 0x0b, 0x32, 0x1b, 0x2e, 0x63, 0x69, 0x74, 0x79, 0x61, 0x71, 0x72, 0x70, 0x63, 0x2e,
+	0x53, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x54, 0x79, 0x70, 0x65, 0x57, 0x65, 0x69, 0x67, 0x68, 0x74,
+	0x52, 0x0d, 0x53, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x54, 0x79, 0x70, 0x65, 0x4d, 0x69, 0x78, 0x12,
+	0x1e, 0x0a, 0x0a, 0x52, 0x65, 0x73, 0x6f, 0x6c, 0x75, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x0d, 0x20,
+	0x01, 0x28, 0x01, 0x52, 0x0a, 0x52, 0x65, 0x73, 0x6f, 0x6c, 0x75, 0x74, 0x69, 0x6f, 0x6e, 0x12,
+	0x26, 0x0a, 0x0e, 0x41, 0x75, 0x74, 0x6f, 0x52, 0x65, 0x73, 0x6f, 0x6c, 0x75, 0x74, 0x69, 0x6f,
+	0x6e, 0x18, 0x0e, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0e, 0x41, 0x75, 0x74, 0x6f, 0x52, 0x65, 0x73,
+	0x6f, 0x6c, 0x75, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x1a, 0x0a, 0x08, 0x53, 0x70, 0x65, 0x63, 0x69,
+	0x61, 0x74, 0x65, 0x18, 0x0f, 0x20, 0x01, 0x28, 0x08, 0x52, 0x08, 0x53, 0x70, 0x65, 0x63, 0x69,
+	0x61, 0x74, 0x65, 0x12, 0x22, 0x0a, 0x0c, 0x41, 0x72, 0x65, 0x61, 0x46, 0x61, 0x6c, 0x6c, 0x62,
+	0x61, 0x63, 0x6b, 0x18, 0x10, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0c, 0x41, 0x72, 0x65, 0x61, 0x46,
+	0x61, 0x6c, 0x6c, 0x62, 0x61, 0x63, 0x6b, 0x22, 0x77, 0x0a, 0x1d, 0x47, 0x72, 0x69, 0x64, 0x64,
+	0x65, 0x64, 0x43, 0x6f, 0x6e, 0x63, 0x65, 0x6e, 0x74, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73,
+	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x2e, 0x0a, 0x08, 0x50, 0x6f, 0x6c, 0x79,
+	0x67, 0x6f, 0x6e, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x12, 0x2e, 0x63, 0x69, 0x74,
+	0x79, 0x61, 0x71, 0x72, 0x70, 0x63, 0x2e, 0x50, 0x6f, 0x6c, 0x79, 0x67, 0x6f, 0x6e, 0x52, 0x08,
+	0x50, 0x6f, 0x6c, 0x79, 0x67, 0x6f, 0x6e, 0x73, 0x12, 0x26, 0x0a, 0x0e, 0x43, 0x6f, 0x6e, 0x63,
+	0x65, 0x6e, 0x74, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x01,
+	0x52, 0x0e, 0x43, 0x6f, 0x6e, 0x63, 0x65, 0x6e, 0x74, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73,
+	0x22, 0xd7, 0x03, 0x0a, 0x18, 0x47, 0x72, 0x69, 0x64, 0x64, 0x65, 0x64, 0x50, 0x6f, 0x70, 0x75,
+	0x6c, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1a, 0x0a,
 	0x08, 0x43, 0x69, 0x74, 0x79, 0x4e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
 	0x08, 0x43, 0x69, 0x74, 0x79, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x1e, 0x0a, 0x0a, 0x53, 0x6f, 0x75,
 	0x72, 0x63, 0x65, 0x54, 0x79, 0x70, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x53,
@@ -1456,169 +4595,379 @@ var file_cityaq_proto_rawDesc = []byte{
 	0x6d, 0x75, 0x6c, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x54, 0x79, 0x70, 0x65, 0x18, 0x04, 0x20, 0x01,
 	0x28, 0x0e, 0x32, 0x19, 0x2e, 0x63, 0x69, 0x74, 0x79, 0x61, 0x71, 0x72, 0x70, 0x63, 0x2e, 0x53,
 	0x69, 0x6d, 0x75, 0x6c, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x54, 0x79, 0x70, 0x65, 0x52, 0x0e, 0x53,
-	0x69, 0x6d, 0x75, 0x6c, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x54, 0x79, 0x70, 0x65, 0x22, 0x77, 0x0a,
-	0x1d, 0x47, 0x72, 0x69, 0x64, 0x64, 0x65, 0x64, 0x43, 0x6f, 0x6e, 0x63, 0x65, 0x6e, 0x74, 0x72,
-	0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x2e,
-	0x0a, 0x08, 0x50, 0x6f, 0x6c, 0x79, 0x67, 0x6f, 0x6e, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b,
-	0x32, 0x12, 0x2e, 0x63, 0x69, 0x74, 0x79, 0x61, 0x71, 0x72, 0x70, 0x63, 0x2e, 0x50, 0x6f, 0x6c,
-	0x79, 0x67, 0x6f, 0x6e, 0x52, 0x08, 0x50, 0x6f, 0x6c, 0x79, 0x67, 0x6f, 0x6e, 0x73, 0x12, 0x26,
-	0x0a, 0x0e, 0x43, 0x6f, 0x6e, 0x63, 0x65, 0x6e, 0x74, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73,
-	0x18, 0x02, 0x20, 0x03, 0x28, 0x01, 0x52, 0x0e, 0x43, 0x6f, 0x6e, 0x63, 0x65, 0x6e, 0x74, 0x72,
-	0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x22, 0xca, 0x01, 0x0a, 0x18, 0x47, 0x72, 0x69, 0x64, 0x64,
-	0x65, 0x64, 0x50, 0x6f, 0x70, 0x75, 0x6c, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x71, 0x75,
+	0x69, 0x6d, 0x75, 0x6c, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x54, 0x79, 0x70, 0x65, 0x12, 0x3a, 0x0a,
+	0x0c, 0x50, 0x6f, 0x69, 0x6e, 0x74, 0x53, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x73, 0x18, 0x05, 0x20,
+	0x03, 0x28, 0x0b, 0x32, 0x16, 0x2e, 0x63, 0x69, 0x74, 0x79, 0x61, 0x71, 0x72, 0x70, 0x63, 0x2e,
+	0x50, 0x6f, 0x69, 0x6e, 0x74, 0x53, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x52, 0x0c, 0x50, 0x6f, 0x69,
+	0x6e, 0x74, 0x53, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x73, 0x12, 0x44, 0x0a, 0x0f, 0x43, 0x75, 0x73,
+	0x74, 0x6f, 0x6d, 0x53, 0x75, 0x72, 0x72, 0x6f, 0x67, 0x61, 0x74, 0x65, 0x18, 0x06, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x63, 0x69, 0x74, 0x79, 0x61, 0x71, 0x72, 0x70, 0x63, 0x2e, 0x43,
+	0x75, 0x73, 0x74, 0x6f, 0x6d, 0x53, 0x75, 0x72, 0x72, 0x6f, 0x67, 0x61, 0x74, 0x65, 0x52, 0x0f,
+	0x43, 0x75, 0x73, 0x74, 0x6f, 0x6d, 0x53, 0x75, 0x72, 0x72, 0x6f, 0x67, 0x61, 0x74, 0x65, 0x12,
+	0x41, 0x0a, 0x0d, 0x53, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x54, 0x79, 0x70, 0x65, 0x4d, 0x69, 0x78,
+	0x18, 0x07, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1b, 0x2e, 0x63, 0x69, 0x74, 0x79, 0x61, 0x71, 0x72,
+	0x70, 0x63, 0x2e, 0x53, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x54, 0x79, 0x70, 0x65, 0x57, 0x65, 0x69,
+	0x67, 0x68, 0x74, 0x52, 0x0d, 0x53, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x54, 0x79, 0x70, 0x65, 0x4d,
+	0x69, 0x78, 0x12, 0x1e, 0x0a, 0x0a, 0x52, 0x65, 0x73, 0x6f, 0x6c, 0x75, 0x74, 0x69, 0x6f, 0x6e,
+	0x18, 0x08, 0x20, 0x01, 0x28, 0x01, 0x52, 0x0a, 0x52, 0x65, 0x73, 0x6f, 0x6c, 0x75, 0x74, 0x69,
+	0x6f, 0x6e, 0x12, 0x26, 0x0a, 0x0e, 0x41, 0x75, 0x74, 0x6f, 0x52, 0x65, 0x73, 0x6f, 0x6c, 0x75,
+	0x74, 0x69, 0x6f, 0x6e, 0x18, 0x09, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0e, 0x41, 0x75, 0x74, 0x6f,
+	0x52, 0x65, 0x73, 0x6f, 0x6c, 0x75, 0x74, 0x69, 0x6f, 0x6e, 0x22, 0x6b, 0x0a, 0x19, 0x47, 0x72,
+	0x69, 0x64, 0x64, 0x65, 0x64, 0x50, 0x6f, 0x70, 0x75, 0x6c, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52,
+	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x2e, 0x0a, 0x08, 0x50, 0x6f, 0x6c, 0x79, 0x67,
+	0x6f, 0x6e, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x12, 0x2e, 0x63, 0x69, 0x74, 0x79,
+	0x61, 0x71, 0x72, 0x70, 0x63, 0x2e, 0x50, 0x6f, 0x6c, 0x79, 0x67, 0x6f, 0x6e, 0x52, 0x08, 0x50,
+	0x6f, 0x6c, 0x79, 0x67, 0x6f, 0x6e, 0x73, 0x12, 0x1e, 0x0a, 0x0a, 0x50, 0x6f, 0x70, 0x75, 0x6c,
+	0x61, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x02, 0x20, 0x03, 0x28, 0x01, 0x52, 0x0a, 0x50, 0x6f, 0x70,
+	0x75, 0x6c, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x22, 0x8d, 0x04, 0x0a, 0x14, 0x49, 0x6d, 0x70, 0x61,
+	0x63, 0x74, 0x53, 0x75, 0x6d, 0x6d, 0x61, 0x72, 0x79, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x12, 0x1a, 0x0a, 0x08, 0x43, 0x69, 0x74, 0x79, 0x4e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x08, 0x43, 0x69, 0x74, 0x79, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x1e, 0x0a, 0x0a,
+	0x53, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x54, 0x79, 0x70, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x0a, 0x53, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x54, 0x79, 0x70, 0x65, 0x12, 0x2f, 0x0a, 0x08,
+	0x45, 0x6d, 0x69, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x13,
+	0x2e, 0x63, 0x69, 0x74, 0x79, 0x61, 0x71, 0x72, 0x70, 0x63, 0x2e, 0x45, 0x6d, 0x69, 0x73, 0x73,
+	0x69, 0x6f, 0x6e, 0x52, 0x08, 0x45, 0x6d, 0x69, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x41, 0x0a,
+	0x0e, 0x53, 0x69, 0x6d, 0x75, 0x6c, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x54, 0x79, 0x70, 0x65, 0x18,
+	0x04, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x19, 0x2e, 0x63, 0x69, 0x74, 0x79, 0x61, 0x71, 0x72, 0x70,
+	0x63, 0x2e, 0x53, 0x69, 0x6d, 0x75, 0x6c, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x54, 0x79, 0x70, 0x65,
+	0x52, 0x0e, 0x53, 0x69, 0x6d, 0x75, 0x6c, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x54, 0x79, 0x70, 0x65,
+	0x12, 0x16, 0x0a, 0x06, 0x41, 0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x05, 0x20, 0x01, 0x28, 0x01,
+	0x52, 0x06, 0x41, 0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x3a, 0x0a, 0x0b, 0x41, 0x6d, 0x6f, 0x75,
+	0x6e, 0x74, 0x55, 0x6e, 0x69, 0x74, 0x73, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x18, 0x2e,
+	0x63, 0x69, 0x74, 0x79, 0x61, 0x71, 0x72, 0x70, 0x63, 0x2e, 0x45, 0x6d, 0x69, 0x73, 0x73, 0x69,
+	0x6f, 0x6e, 0x55, 0x6e, 0x69, 0x74, 0x73, 0x52, 0x0b, 0x41, 0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x55,
+	0x6e, 0x69, 0x74, 0x73, 0x12, 0x14, 0x0a, 0x05, 0x42, 0x65, 0x67, 0x69, 0x6e, 0x18, 0x07, 0x20,
+	0x01, 0x28, 0x03, 0x52, 0x05, 0x42, 0x65, 0x67, 0x69, 0x6e, 0x12, 0x10, 0x0a, 0x03, 0x45, 0x6e,
+	0x64, 0x18, 0x08, 0x20, 0x01, 0x28, 0x03, 0x52, 0x03, 0x45, 0x6e, 0x64, 0x12, 0x41, 0x0a, 0x0d,
+	0x53, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x54, 0x79, 0x70, 0x65, 0x4d, 0x69, 0x78, 0x18, 0x09, 0x20,
+	0x03, 0x28, 0x0b, 0x32, 0x1b, 0x2e, 0x63, 0x69, 0x74, 0x79, 0x61, 0x71, 0x72, 0x70, 0x63, 0x2e,
+	0x53, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x54, 0x79, 0x70, 0x65, 0x57, 0x65, 0x69, 0x67, 0x68, 0x74,
+	0x52, 0x0d, 0x53, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x54, 0x79, 0x70, 0x65, 0x4d, 0x69, 0x78, 0x12,
+	0x1e, 0x0a, 0x0a, 0x52, 0x65, 0x73, 0x6f, 0x6c, 0x75, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x0a, 0x20,
+	0x01, 0x28, 0x01, 0x52, 0x0a, 0x52, 0x65, 0x73, 0x6f, 0x6c, 0x75, 0x74, 0x69, 0x6f, 0x6e, 0x12,
+	0x26, 0x0a, 0x0e, 0x41, 0x75, 0x74, 0x6f, 0x52, 0x65, 0x73, 0x6f, 0x6c, 0x75, 0x74, 0x69, 0x6f,
+	0x6e, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0e, 0x41, 0x75, 0x74, 0x6f, 0x52, 0x65, 0x73,
+	0x6f, 0x6c, 0x75, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x1a, 0x0a, 0x08, 0x53, 0x70, 0x65, 0x63, 0x69,
+	0x61, 0x74, 0x65, 0x18, 0x0c, 0x20, 0x01, 0x28, 0x08, 0x52, 0x08, 0x53, 0x70, 0x65, 0x63, 0x69,
+	0x61, 0x74, 0x65, 0x12, 0x22, 0x0a, 0x0c, 0x41, 0x72, 0x65, 0x61, 0x46, 0x61, 0x6c, 0x6c, 0x62,
+	0x61, 0x63, 0x6b, 0x18, 0x0d, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0c, 0x41, 0x72, 0x65, 0x61, 0x46,
+	0x61, 0x6c, 0x6c, 0x62, 0x61, 0x63, 0x6b, 0x22, 0xa4, 0x02, 0x0a, 0x15, 0x49, 0x6d, 0x70, 0x61,
+	0x63, 0x74, 0x53, 0x75, 0x6d, 0x6d, 0x61, 0x72, 0x79, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
+	0x65, 0x12, 0x1e, 0x0a, 0x0a, 0x50, 0x6f, 0x70, 0x75, 0x6c, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x01, 0x52, 0x0a, 0x50, 0x6f, 0x70, 0x75, 0x6c, 0x61, 0x74, 0x69, 0x6f,
+	0x6e, 0x12, 0x26, 0x0a, 0x0e, 0x43, 0x69, 0x74, 0x79, 0x50, 0x6f, 0x70, 0x75, 0x6c, 0x61, 0x74,
+	0x69, 0x6f, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x01, 0x52, 0x0e, 0x43, 0x69, 0x74, 0x79, 0x50,
+	0x6f, 0x70, 0x75, 0x6c, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x22, 0x0a, 0x0c, 0x43, 0x69, 0x74,
+	0x79, 0x45, 0x78, 0x70, 0x6f, 0x73, 0x75, 0x72, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x01, 0x52,
+	0x0c, 0x43, 0x69, 0x74, 0x79, 0x45, 0x78, 0x70, 0x6f, 0x73, 0x75, 0x72, 0x65, 0x12, 0x24, 0x0a,
+	0x0d, 0x54, 0x6f, 0x74, 0x61, 0x6c, 0x45, 0x78, 0x70, 0x6f, 0x73, 0x75, 0x72, 0x65, 0x18, 0x04,
+	0x20, 0x01, 0x28, 0x01, 0x52, 0x0d, 0x54, 0x6f, 0x74, 0x61, 0x6c, 0x45, 0x78, 0x70, 0x6f, 0x73,
+	0x75, 0x72, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x43, 0x69, 0x74, 0x79, 0x49, 0x46, 0x18, 0x05, 0x20,
+	0x01, 0x28, 0x01, 0x52, 0x06, 0x43, 0x69, 0x74, 0x79, 0x49, 0x46, 0x12, 0x18, 0x0a, 0x07, 0x54,
+	0x6f, 0x74, 0x61, 0x6c, 0x49, 0x46, 0x18, 0x06, 0x20, 0x01, 0x28, 0x01, 0x52, 0x07, 0x54, 0x6f,
+	0x74, 0x61, 0x6c, 0x49, 0x46, 0x12, 0x47, 0x0a, 0x10, 0x50, 0x6f, 0x6c, 0x6c, 0x75, 0x74, 0x61,
+	0x6e, 0x74, 0x49, 0x6d, 0x70, 0x61, 0x63, 0x74, 0x73, 0x18, 0x07, 0x20, 0x03, 0x28, 0x0b, 0x32,
+	0x1b, 0x2e, 0x63, 0x69, 0x74, 0x79, 0x61, 0x71, 0x72, 0x70, 0x63, 0x2e, 0x50, 0x6f, 0x6c, 0x6c,
+	0x75, 0x74, 0x61, 0x6e, 0x74, 0x49, 0x6d, 0x70, 0x61, 0x63, 0x74, 0x73, 0x52, 0x10, 0x50, 0x6f,
+	0x6c, 0x6c, 0x75, 0x74, 0x61, 0x6e, 0x74, 0x49, 0x6d, 0x70, 0x61, 0x63, 0x74, 0x73, 0x22, 0xd7,
+	0x01, 0x0a, 0x10, 0x50, 0x6f, 0x6c, 0x6c, 0x75, 0x74, 0x61, 0x6e, 0x74, 0x49, 0x6d, 0x70, 0x61,
+	0x63, 0x74, 0x73, 0x12, 0x2f, 0x0a, 0x08, 0x45, 0x6d, 0x69, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x13, 0x2e, 0x63, 0x69, 0x74, 0x79, 0x61, 0x71, 0x72, 0x70,
+	0x63, 0x2e, 0x45, 0x6d, 0x69, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x52, 0x08, 0x45, 0x6d, 0x69, 0x73,
+	0x73, 0x69, 0x6f, 0x6e, 0x12, 0x16, 0x0a, 0x06, 0x41, 0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x01, 0x52, 0x06, 0x41, 0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x22, 0x0a, 0x0c,
+	0x43, 0x69, 0x74, 0x79, 0x45, 0x78, 0x70, 0x6f, 0x73, 0x75, 0x72, 0x65, 0x18, 0x03, 0x20, 0x01,
+	0x28, 0x01, 0x52, 0x0c, 0x43, 0x69, 0x74, 0x79, 0x45, 0x78, 0x70, 0x6f, 0x73, 0x75, 0x72, 0x65,
+	0x12, 0x24, 0x0a, 0x0d, 0x54, 0x6f, 0x74, 0x61, 0x6c, 0x45, 0x78, 0x70, 0x6f, 0x73, 0x75, 0x72,
+	0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x01, 0x52, 0x0d, 0x54, 0x6f, 0x74, 0x61, 0x6c, 0x45, 0x78,
+	0x70, 0x6f, 0x73, 0x75, 0x72, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x43, 0x69, 0x74, 0x79, 0x49, 0x46,
+	0x18, 0x05, 0x20, 0x01, 0x28, 0x01, 0x52, 0x06, 0x43, 0x69, 0x74, 0x79, 0x49, 0x46, 0x12, 0x18,
+	0x0a, 0x07, 0x54, 0x6f, 0x74, 0x61, 0x6c, 0x49, 0x46, 0x18, 0x06, 0x20, 0x01, 0x28, 0x01, 0x52,
+	0x07, 0x54, 0x6f, 0x74, 0x61, 0x6c, 0x49, 0x46, 0x22, 0xa0, 0x01, 0x0a, 0x1a, 0x45, 0x6d, 0x69,
+	0x73, 0x73, 0x69, 0x6f, 0x6e, 0x73, 0x47, 0x72, 0x69, 0x64, 0x42, 0x6f, 0x75, 0x6e, 0x64, 0x73,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1a, 0x0a, 0x08, 0x43, 0x69, 0x74, 0x79, 0x4e,
+	0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x43, 0x69, 0x74, 0x79, 0x4e,
+	0x61, 0x6d, 0x65, 0x12, 0x1e, 0x0a, 0x0a, 0x53, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x54, 0x79, 0x70,
+	0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x53, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x54,
+	0x79, 0x70, 0x65, 0x12, 0x1e, 0x0a, 0x0a, 0x52, 0x65, 0x73, 0x6f, 0x6c, 0x75, 0x74, 0x69, 0x6f,
+	0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x01, 0x52, 0x0a, 0x52, 0x65, 0x73, 0x6f, 0x6c, 0x75, 0x74,
+	0x69, 0x6f, 0x6e, 0x12, 0x26, 0x0a, 0x0e, 0x41, 0x75, 0x74, 0x6f, 0x52, 0x65, 0x73, 0x6f, 0x6c,
+	0x75, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x04, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0e, 0x41, 0x75, 0x74,
+	0x6f, 0x52, 0x65, 0x73, 0x6f, 0x6c, 0x75, 0x74, 0x69, 0x6f, 0x6e, 0x22, 0x65, 0x0a, 0x1b, 0x45,
+	0x6d, 0x69, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x73, 0x47, 0x72, 0x69, 0x64, 0x42, 0x6f, 0x75, 0x6e,
+	0x64, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x22, 0x0a, 0x03, 0x4d, 0x69,
+	0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x10, 0x2e, 0x63, 0x69, 0x74, 0x79, 0x61, 0x71,
+	0x72, 0x70, 0x63, 0x2e, 0x50, 0x6f, 0x69, 0x6e, 0x74, 0x52, 0x03, 0x4d, 0x69, 0x6e, 0x12, 0x22,
+	0x0a, 0x03, 0x4d, 0x61, 0x78, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x10, 0x2e, 0x63, 0x69,
+	0x74, 0x79, 0x61, 0x71, 0x72, 0x70, 0x63, 0x2e, 0x50, 0x6f, 0x69, 0x6e, 0x74, 0x52, 0x03, 0x4d,
+	0x61, 0x78, 0x22, 0x22, 0x0a, 0x20, 0x45, 0x6d, 0x69, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x73, 0x49,
+	0x6e, 0x76, 0x65, 0x6e, 0x74, 0x6f, 0x72, 0x79, 0x53, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x73, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x22, 0x3d, 0x0a, 0x21, 0x45, 0x6d, 0x69, 0x73, 0x73, 0x69,
+	0x6f, 0x6e, 0x73, 0x49, 0x6e, 0x76, 0x65, 0x6e, 0x74, 0x6f, 0x72, 0x79, 0x53, 0x65, 0x63, 0x74,
+	0x6f, 0x72, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x53,
+	0x65, 0x63, 0x74, 0x6f, 0x72, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x09, 0x52, 0x07, 0x53, 0x65,
+	0x63, 0x74, 0x6f, 0x72, 0x73, 0x22, 0x40, 0x0a, 0x0c, 0x53, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x43,
+	0x68, 0x61, 0x6e, 0x67, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x53, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x53, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x12, 0x18, 0x0a,
+	0x07, 0x50, 0x65, 0x72, 0x63, 0x65, 0x6e, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x01, 0x52, 0x07,
+	0x50, 0x65, 0x72, 0x63, 0x65, 0x6e, 0x74, 0x22, 0x69, 0x0a, 0x18, 0x52, 0x65, 0x64, 0x75, 0x63,
+	0x74, 0x69, 0x6f, 0x6e, 0x53, 0x63, 0x65, 0x6e, 0x61, 0x72, 0x69, 0x6f, 0x52, 0x65, 0x71, 0x75,
 	0x65, 0x73, 0x74, 0x12, 0x1a, 0x0a, 0x08, 0x43, 0x69, 0x74, 0x79, 0x4e, 0x61, 0x6d, 0x65, 0x18,
 	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x43, 0x69, 0x74, 0x79, 0x4e, 0x61, 0x6d, 0x65, 0x12,
-	0x1e, 0x0a, 0x0a, 0x53, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x54, 0x79, 0x70, 0x65, 0x18, 0x02, 0x20,
-	0x01, 0x28, 0x09, 0x52, 0x0a, 0x53, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x54, 0x79, 0x70, 0x65, 0x12,
-	0x2f, 0x0a, 0x08, 0x45, 0x6d, 0x69, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x03, 0x20, 0x01, 0x28,
-	0x0e, 0x32, 0x13, 0x2e, 0x63, 0x69, 0x74, 0x79, 0x61, 0x71, 0x72, 0x70, 0x63, 0x2e, 0x45, 0x6d,
-	0x69, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x52, 0x08, 0x45, 0x6d, 0x69, 0x73, 0x73, 0x69, 0x6f, 0x6e,
-	0x12, 0x41, 0x0a, 0x0e, 0x53, 0x69, 0x6d, 0x75, 0x6c, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x54, 0x79,
-	0x70, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x19, 0x2e, 0x63, 0x69, 0x74, 0x79, 0x61,
-	0x71, 0x72, 0x70, 0x63, 0x2e, 0x53, 0x69, 0x6d, 0x75, 0x6c, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x54,
-	0x79, 0x70, 0x65, 0x52, 0x0e, 0x53, 0x69, 0x6d, 0x75, 0x6c, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x54,
-	0x79, 0x70, 0x65, 0x22, 0x6b, 0x0a, 0x19, 0x47, 0x72, 0x69, 0x64, 0x64, 0x65, 0x64, 0x50, 0x6f,
-	0x70, 0x75, 0x6c, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
+	0x31, 0x0a, 0x07, 0x43, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b,
+	0x32, 0x17, 0x2e, 0x63, 0x69, 0x74, 0x79, 0x61, 0x71, 0x72, 0x70, 0x63, 0x2e, 0x53, 0x65, 0x63,
+	0x74, 0x6f, 0x72, 0x43, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x52, 0x07, 0x43, 0x68, 0x61, 0x6e, 0x67,
+	0x65, 0x73, 0x22, 0xdb, 0x03, 0x0a, 0x19, 0x52, 0x65, 0x64, 0x75, 0x63, 0x74, 0x69, 0x6f, 0x6e,
+	0x53, 0x63, 0x65, 0x6e, 0x61, 0x72, 0x69, 0x6f, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
 	0x12, 0x2e, 0x0a, 0x08, 0x50, 0x6f, 0x6c, 0x79, 0x67, 0x6f, 0x6e, 0x73, 0x18, 0x01, 0x20, 0x03,
 	0x28, 0x0b, 0x32, 0x12, 0x2e, 0x63, 0x69, 0x74, 0x79, 0x61, 0x71, 0x72, 0x70, 0x63, 0x2e, 0x50,
 	0x6f, 0x6c, 0x79, 0x67, 0x6f, 0x6e, 0x52, 0x08, 0x50, 0x6f, 0x6c, 0x79, 0x67, 0x6f, 0x6e, 0x73,
-	0x12, 0x1e, 0x0a, 0x0a, 0x50, 0x6f, 0x70, 0x75, 0x6c, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x02,
-	0x20, 0x03, 0x28, 0x01, 0x52, 0x0a, 0x50, 0x6f, 0x70, 0x75, 0x6c, 0x61, 0x74, 0x69, 0x6f, 0x6e,
-	0x22, 0xc6, 0x01, 0x0a, 0x14, 0x49, 0x6d, 0x70, 0x61, 0x63, 0x74, 0x53, 0x75, 0x6d, 0x6d, 0x61,
-	0x72, 0x79, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1a, 0x0a, 0x08, 0x43, 0x69, 0x74,
-	0x79, 0x4e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x43, 0x69, 0x74,
-	0x79, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x1e, 0x0a, 0x0a, 0x53, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x54,
-	0x79, 0x70, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x53, 0x6f, 0x75, 0x72, 0x63,
-	0x65, 0x54, 0x79, 0x70, 0x65, 0x12, 0x2f, 0x0a, 0x08, 0x45, 0x6d, 0x69, 0x73, 0x73, 0x69, 0x6f,
-	0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x13, 0x2e, 0x63, 0x69, 0x74, 0x79, 0x61, 0x71,
-	0x72, 0x70, 0x63, 0x2e, 0x45, 0x6d, 0x69, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x52, 0x08, 0x45, 0x6d,
-	0x69, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x41, 0x0a, 0x0e, 0x53, 0x69, 0x6d, 0x75, 0x6c, 0x61,
-	0x74, 0x69, 0x6f, 0x6e, 0x54, 0x79, 0x70, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x19,
-	0x2e, 0x63, 0x69, 0x74, 0x79, 0x61, 0x71, 0x72, 0x70, 0x63, 0x2e, 0x53, 0x69, 0x6d, 0x75, 0x6c,
-	0x61, 0x74, 0x69, 0x6f, 0x6e, 0x54, 0x79, 0x70, 0x65, 0x52, 0x0e, 0x53, 0x69, 0x6d, 0x75, 0x6c,
-	0x61, 0x74, 0x69, 0x6f, 0x6e, 0x54, 0x79, 0x70, 0x65, 0x22, 0xdb, 0x01, 0x0a, 0x15, 0x49, 0x6d,
-	0x70, 0x61, 0x63, 0x74, 0x53, 0x75, 0x6d, 0x6d, 0x61, 0x72, 0x79, 0x52, 0x65, 0x73, 0x70, 0x6f,
-	0x6e, 0x73, 0x65, 0x12, 0x1e, 0x0a, 0x0a, 0x50, 0x6f, 0x70, 0x75, 0x6c, 0x61, 0x74, 0x69, 0x6f,
-	0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x01, 0x52, 0x0a, 0x50, 0x6f, 0x70, 0x75, 0x6c, 0x61, 0x74,
-	0x69, 0x6f, 0x6e, 0x12, 0x26, 0x0a, 0x0e, 0x43, 0x69, 0x74, 0x79, 0x50, 0x6f, 0x70, 0x75, 0x6c,
-	0x61, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x01, 0x52, 0x0e, 0x43, 0x69, 0x74,
-	0x79, 0x50, 0x6f, 0x70, 0x75, 0x6c, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x22, 0x0a, 0x0c, 0x43,
-	0x69, 0x74, 0x79, 0x45, 0x78, 0x70, 0x6f, 0x73, 0x75, 0x72, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28,
-	0x01, 0x52, 0x0c, 0x43, 0x69, 0x74, 0x79, 0x45, 0x78, 0x70, 0x6f, 0x73, 0x75, 0x72, 0x65, 0x12,
-	0x24, 0x0a, 0x0d, 0x54, 0x6f, 0x74, 0x61, 0x6c, 0x45, 0x78, 0x70, 0x6f, 0x73, 0x75, 0x72, 0x65,
-	0x18, 0x04, 0x20, 0x01, 0x28, 0x01, 0x52, 0x0d, 0x54, 0x6f, 0x74, 0x61, 0x6c, 0x45, 0x78, 0x70,
-	0x6f, 0x73, 0x75, 0x72, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x43, 0x69, 0x74, 0x79, 0x49, 0x46, 0x18,
-	0x05, 0x20, 0x01, 0x28, 0x01, 0x52, 0x06, 0x43, 0x69, 0x74, 0x79, 0x49, 0x46, 0x12, 0x18, 0x0a,
-	0x07, 0x54, 0x6f, 0x74, 0x61, 0x6c, 0x49, 0x46, 0x18, 0x06, 0x20, 0x01, 0x28, 0x01, 0x52, 0x07,
-	0x54, 0x6f, 0x74, 0x61, 0x6c, 0x49, 0x46, 0x22, 0x58, 0x0a, 0x1a, 0x45, 0x6d, 0x69, 0x73, 0x73,
-	0x69, 0x6f, 0x6e, 0x73, 0x47, 0x72, 0x69, 0x64, 0x42, 0x6f, 0x75, 0x6e, 0x64, 0x73, 0x52, 0x65,
-	0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1a, 0x0a, 0x08, 0x43, 0x69, 0x74, 0x79, 0x4e, 0x61, 0x6d,
-	0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x43, 0x69, 0x74, 0x79, 0x4e, 0x61, 0x6d,
-	0x65, 0x12, 0x1e, 0x0a, 0x0a, 0x53, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x54, 0x79, 0x70, 0x65, 0x18,
-	0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x53, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x54, 0x79, 0x70,
-	0x65, 0x22, 0x65, 0x0a, 0x1b, 0x45, 0x6d, 0x69, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x73, 0x47, 0x72,
-	0x69, 0x64, 0x42, 0x6f, 0x75, 0x6e, 0x64, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
-	0x12, 0x22, 0x0a, 0x03, 0x4d, 0x69, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x10, 0x2e,
-	0x63, 0x69, 0x74, 0x79, 0x61, 0x71, 0x72, 0x70, 0x63, 0x2e, 0x50, 0x6f, 0x69, 0x6e, 0x74, 0x52,
-	0x03, 0x4d, 0x69, 0x6e, 0x12, 0x22, 0x0a, 0x03, 0x4d, 0x61, 0x78, 0x18, 0x02, 0x20, 0x01, 0x28,
-	0x0b, 0x32, 0x10, 0x2e, 0x63, 0x69, 0x74, 0x79, 0x61, 0x71, 0x72, 0x70, 0x63, 0x2e, 0x50, 0x6f,
-	0x69, 0x6e, 0x74, 0x52, 0x03, 0x4d, 0x61, 0x78, 0x22, 0x22, 0x0a, 0x20, 0x45, 0x6d, 0x69, 0x73,
-	0x73, 0x69, 0x6f, 0x6e, 0x73, 0x49, 0x6e, 0x76, 0x65, 0x6e, 0x74, 0x6f, 0x72, 0x79, 0x53, 0x65,
-	0x63, 0x74, 0x6f, 0x72, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x22, 0x3d, 0x0a, 0x21,
-	0x45, 0x6d, 0x69, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x73, 0x49, 0x6e, 0x76, 0x65, 0x6e, 0x74, 0x6f,
-	0x72, 0x79, 0x53, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
-	0x65, 0x12, 0x18, 0x0a, 0x07, 0x53, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x73, 0x18, 0x01, 0x20, 0x03,
-	0x28, 0x09, 0x52, 0x07, 0x53, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x73, 0x22, 0xf8, 0x01, 0x0a, 0x0f,
-	0x4d, 0x61, 0x70, 0x53, 0x63, 0x61, 0x6c, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12,
-	0x1a, 0x0a, 0x08, 0x43, 0x69, 0x74, 0x79, 0x4e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28,
-	0x09, 0x52, 0x08, 0x43, 0x69, 0x74, 0x79, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x35, 0x0a, 0x0a, 0x49,
-	0x6d, 0x70, 0x61, 0x63, 0x74, 0x54, 0x79, 0x70, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0e, 0x32,
-	0x15, 0x2e, 0x63, 0x69, 0x74, 0x79, 0x61, 0x71, 0x72, 0x70, 0x63, 0x2e, 0x49, 0x6d, 0x70, 0x61,
-	0x63, 0x74, 0x54, 0x79, 0x70, 0x65, 0x52, 0x0a, 0x49, 0x6d, 0x70, 0x61, 0x63, 0x74, 0x54, 0x79,
-	0x70, 0x65, 0x12, 0x2f, 0x0a, 0x08, 0x45, 0x6d, 0x69, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x03,
-	0x20, 0x01, 0x28, 0x0e, 0x32, 0x13, 0x2e, 0x63, 0x69, 0x74, 0x79, 0x61, 0x71, 0x72, 0x70, 0x63,
-	0x2e, 0x45, 0x6d, 0x69, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x52, 0x08, 0x45, 0x6d, 0x69, 0x73, 0x73,
-	0x69, 0x6f, 0x6e, 0x12, 0x1e, 0x0a, 0x0a, 0x53, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x54, 0x79, 0x70,
-	0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x53, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x54,
-	0x79, 0x70, 0x65, 0x12, 0x41, 0x0a, 0x0e, 0x53, 0x69, 0x6d, 0x75, 0x6c, 0x61, 0x74, 0x69, 0x6f,
-	0x6e, 0x54, 0x79, 0x70, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x19, 0x2e, 0x63, 0x69,
-	0x74, 0x79, 0x61, 0x71, 0x72, 0x70, 0x63, 0x2e, 0x53, 0x69, 0x6d, 0x75, 0x6c, 0x61, 0x74, 0x69,
-	0x6f, 0x6e, 0x54, 0x79, 0x70, 0x65, 0x52, 0x0e, 0x53, 0x69, 0x6d, 0x75, 0x6c, 0x61, 0x74, 0x69,
-	0x6f, 0x6e, 0x54, 0x79, 0x70, 0x65, 0x22, 0x4c, 0x0a, 0x10, 0x4d, 0x61, 0x70, 0x53, 0x63, 0x61,
-	0x6c, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x10, 0x0a, 0x03, 0x4d, 0x69,
-	0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x01, 0x52, 0x03, 0x4d, 0x69, 0x6e, 0x12, 0x10, 0x0a, 0x03,
-	0x4d, 0x61, 0x78, 0x18, 0x02, 0x20, 0x01, 0x28, 0x01, 0x52, 0x03, 0x4d, 0x61, 0x78, 0x12, 0x14,
-	0x0a, 0x05, 0x43, 0x75, 0x74, 0x50, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x01, 0x52, 0x05, 0x43,
-	0x75, 0x74, 0x50, 0x74, 0x2a, 0x4f, 0x0a, 0x08, 0x45, 0x6d, 0x69, 0x73, 0x73, 0x69, 0x6f, 0x6e,
-	0x12, 0x14, 0x0a, 0x10, 0x55, 0x4e, 0x4b, 0x4e, 0x4f, 0x57, 0x4e, 0x5f, 0x45, 0x4d, 0x49, 0x53,
-	0x53, 0x49, 0x4f, 0x4e, 0x10, 0x00, 0x12, 0x09, 0x0a, 0x05, 0x50, 0x4d, 0x32, 0x5f, 0x35, 0x10,
-	0x01, 0x12, 0x07, 0x0a, 0x03, 0x4e, 0x48, 0x33, 0x10, 0x02, 0x12, 0x07, 0x0a, 0x03, 0x4e, 0x4f,
-	0x78, 0x10, 0x03, 0x12, 0x07, 0x0a, 0x03, 0x53, 0x4f, 0x78, 0x10, 0x04, 0x12, 0x07, 0x0a, 0x03,
-	0x56, 0x4f, 0x43, 0x10, 0x05, 0x2a, 0x47, 0x0a, 0x0a, 0x49, 0x6d, 0x70, 0x61, 0x63, 0x74, 0x54,
-	0x79, 0x70, 0x65, 0x12, 0x16, 0x0a, 0x12, 0x55, 0x4e, 0x4b, 0x4e, 0x4f, 0x57, 0x4e, 0x5f, 0x49,
-	0x4d, 0x50, 0x41, 0x43, 0x54, 0x54, 0x59, 0x50, 0x45, 0x10, 0x00, 0x12, 0x0d, 0x0a, 0x09, 0x45,
-	0x6d, 0x69, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x73, 0x10, 0x01, 0x12, 0x12, 0x0a, 0x0e, 0x43, 0x6f,
-	0x6e, 0x63, 0x65, 0x6e, 0x74, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x10, 0x02, 0x2a, 0x58,
-	0x0a, 0x0e, 0x53, 0x69, 0x6d, 0x75, 0x6c, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x54, 0x79, 0x70, 0x65,
-	0x12, 0x1a, 0x0a, 0x16, 0x55, 0x4e, 0x4b, 0x4e, 0x4f, 0x57, 0x4e, 0x5f, 0x53, 0x49, 0x4d, 0x55,
-	0x4c, 0x41, 0x54, 0x49, 0x4f, 0x4e, 0x54, 0x59, 0x50, 0x45, 0x10, 0x00, 0x12, 0x09, 0x0a, 0x05,
-	0x54, 0x6f, 0x74, 0x61, 0x6c, 0x10, 0x01, 0x12, 0x0d, 0x0a, 0x09, 0x43, 0x69, 0x74, 0x79, 0x54,
-	0x6f, 0x74, 0x61, 0x6c, 0x10, 0x02, 0x12, 0x10, 0x0a, 0x0c, 0x43, 0x69, 0x74, 0x79, 0x4d, 0x61,
-	0x72, 0x67, 0x69, 0x6e, 0x61, 0x6c, 0x10, 0x03, 0x32, 0xca, 0x06, 0x0a, 0x06, 0x43, 0x69, 0x74,
-	0x79, 0x41, 0x51, 0x12, 0x3f, 0x0a, 0x06, 0x43, 0x69, 0x74, 0x69, 0x65, 0x73, 0x12, 0x18, 0x2e,
-	0x63, 0x69, 0x74, 0x79, 0x61, 0x71, 0x72, 0x70, 0x63, 0x2e, 0x43, 0x69, 0x74, 0x69, 0x65, 0x73,
-	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x19, 0x2e, 0x63, 0x69, 0x74, 0x79, 0x61, 0x71,
-	0x72, 0x70, 0x63, 0x2e, 0x43, 0x69, 0x74, 0x69, 0x65, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
-	0x73, 0x65, 0x22, 0x00, 0x12, 0x51, 0x0a, 0x0c, 0x43, 0x69, 0x74, 0x79, 0x47, 0x65, 0x6f, 0x6d,
-	0x65, 0x74, 0x72, 0x79, 0x12, 0x1e, 0x2e, 0x63, 0x69, 0x74, 0x79, 0x61, 0x71, 0x72, 0x70, 0x63,
-	0x2e, 0x43, 0x69, 0x74, 0x79, 0x47, 0x65, 0x6f, 0x6d, 0x65, 0x74, 0x72, 0x79, 0x52, 0x65, 0x71,
-	0x75, 0x65, 0x73, 0x74, 0x1a, 0x1f, 0x2e, 0x63, 0x69, 0x74, 0x79, 0x61, 0x71, 0x72, 0x70, 0x63,
-	0x2e, 0x43, 0x69, 0x74, 0x79, 0x47, 0x65, 0x6f, 0x6d, 0x65, 0x74, 0x72, 0x79, 0x52, 0x65, 0x73,
-	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x5d, 0x0a, 0x10, 0x47, 0x72, 0x69, 0x64, 0x64,
-	0x65, 0x64, 0x45, 0x6d, 0x69, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x22, 0x2e, 0x63, 0x69,
-	0x74, 0x79, 0x61, 0x71, 0x72, 0x70, 0x63, 0x2e, 0x47, 0x72, 0x69, 0x64, 0x64, 0x65, 0x64, 0x45,
-	0x6d, 0x69, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a,
-	0x23, 0x2e, 0x63, 0x69, 0x74, 0x79, 0x61, 0x71, 0x72, 0x70, 0x63, 0x2e, 0x47, 0x72, 0x69, 0x64,
-	0x64, 0x65, 0x64, 0x45, 0x6d, 0x69, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x73, 0x52, 0x65, 0x73, 0x70,
-	0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x66, 0x0a, 0x13, 0x45, 0x6d, 0x69, 0x73, 0x73, 0x69,
-	0x6f, 0x6e, 0x73, 0x47, 0x72, 0x69, 0x64, 0x42, 0x6f, 0x75, 0x6e, 0x64, 0x73, 0x12, 0x25, 0x2e,
-	0x63, 0x69, 0x74, 0x79, 0x61, 0x71, 0x72, 0x70, 0x63, 0x2e, 0x45, 0x6d, 0x69, 0x73, 0x73, 0x69,
-	0x6f, 0x6e, 0x73, 0x47, 0x72, 0x69, 0x64, 0x42, 0x6f, 0x75, 0x6e, 0x64, 0x73, 0x52, 0x65, 0x71,
-	0x75, 0x65, 0x73, 0x74, 0x1a, 0x26, 0x2e, 0x63, 0x69, 0x74, 0x79, 0x61, 0x71, 0x72, 0x70, 0x63,
+	0x12, 0x36, 0x0a, 0x16, 0x42, 0x61, 0x73, 0x65, 0x6c, 0x69, 0x6e, 0x65, 0x43, 0x6f, 0x6e, 0x63,
+	0x65, 0x6e, 0x74, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x01,
+	0x52, 0x16, 0x42, 0x61, 0x73, 0x65, 0x6c, 0x69, 0x6e, 0x65, 0x43, 0x6f, 0x6e, 0x63, 0x65, 0x6e,
+	0x74, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x36, 0x0a, 0x16, 0x53, 0x63, 0x65, 0x6e,
+	0x61, 0x72, 0x69, 0x6f, 0x43, 0x6f, 0x6e, 0x63, 0x65, 0x6e, 0x74, 0x72, 0x61, 0x74, 0x69, 0x6f,
+	0x6e, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x01, 0x52, 0x16, 0x53, 0x63, 0x65, 0x6e, 0x61, 0x72,
+	0x69, 0x6f, 0x43, 0x6f, 0x6e, 0x63, 0x65, 0x6e, 0x74, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73,
+	0x12, 0x32, 0x0a, 0x14, 0x43, 0x6f, 0x6e, 0x63, 0x65, 0x6e, 0x74, 0x72, 0x61, 0x74, 0x69, 0x6f,
+	0x6e, 0x43, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x73, 0x18, 0x04, 0x20, 0x03, 0x28, 0x01, 0x52, 0x14,
+	0x43, 0x6f, 0x6e, 0x63, 0x65, 0x6e, 0x74, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x43, 0x68, 0x61,
+	0x6e, 0x67, 0x65, 0x73, 0x12, 0x1e, 0x0a, 0x0a, 0x50, 0x6f, 0x70, 0x75, 0x6c, 0x61, 0x74, 0x69,
+	0x6f, 0x6e, 0x18, 0x05, 0x20, 0x03, 0x28, 0x01, 0x52, 0x0a, 0x50, 0x6f, 0x70, 0x75, 0x6c, 0x61,
+	0x74, 0x69, 0x6f, 0x6e, 0x12, 0x32, 0x0a, 0x14, 0x42, 0x61, 0x73, 0x65, 0x6c, 0x69, 0x6e, 0x65,
+	0x43, 0x69, 0x74, 0x79, 0x45, 0x78, 0x70, 0x6f, 0x73, 0x75, 0x72, 0x65, 0x18, 0x06, 0x20, 0x01,
+	0x28, 0x01, 0x52, 0x14, 0x42, 0x61, 0x73, 0x65, 0x6c, 0x69, 0x6e, 0x65, 0x43, 0x69, 0x74, 0x79,
+	0x45, 0x78, 0x70, 0x6f, 0x73, 0x75, 0x72, 0x65, 0x12, 0x34, 0x0a, 0x15, 0x42, 0x61, 0x73, 0x65,
+	0x6c, 0x69, 0x6e, 0x65, 0x54, 0x6f, 0x74, 0x61, 0x6c, 0x45, 0x78, 0x70, 0x6f, 0x73, 0x75, 0x72,
+	0x65, 0x18, 0x07, 0x20, 0x01, 0x28, 0x01, 0x52, 0x15, 0x42, 0x61, 0x73, 0x65, 0x6c, 0x69, 0x6e,
+	0x65, 0x54, 0x6f, 0x74, 0x61, 0x6c, 0x45, 0x78, 0x70, 0x6f, 0x73, 0x75, 0x72, 0x65, 0x12, 0x2e,
+	0x0a, 0x12, 0x43, 0x69, 0x74, 0x79, 0x45, 0x78, 0x70, 0x6f, 0x73, 0x75, 0x72, 0x65, 0x43, 0x68,
+	0x61, 0x6e, 0x67, 0x65, 0x18, 0x08, 0x20, 0x01, 0x28, 0x01, 0x52, 0x12, 0x43, 0x69, 0x74, 0x79,
+	0x45, 0x78, 0x70, 0x6f, 0x73, 0x75, 0x72, 0x65, 0x43, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x12, 0x30,
+	0x0a, 0x13, 0x54, 0x6f, 0x74, 0x61, 0x6c, 0x45, 0x78, 0x70, 0x6f, 0x73, 0x75, 0x72, 0x65, 0x43,
+	0x68, 0x61, 0x6e, 0x67, 0x65, 0x18, 0x09, 0x20, 0x01, 0x28, 0x01, 0x52, 0x13, 0x54, 0x6f, 0x74,
+	0x61, 0x6c, 0x45, 0x78, 0x70, 0x6f, 0x73, 0x75, 0x72, 0x65, 0x43, 0x68, 0x61, 0x6e, 0x67, 0x65,
+	0x22, 0x14, 0x0a, 0x12, 0x53, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x54, 0x79, 0x70, 0x65, 0x73, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x22, 0x4e, 0x0a, 0x13, 0x53, 0x6f, 0x75, 0x72, 0x63, 0x65,
+	0x54, 0x79, 0x70, 0x65, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x37, 0x0a,
+	0x0b, 0x53, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x54, 0x79, 0x70, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03,
+	0x28, 0x0b, 0x32, 0x15, 0x2e, 0x63, 0x69, 0x74, 0x79, 0x61, 0x71, 0x72, 0x70, 0x63, 0x2e, 0x53,
+	0x6f, 0x75, 0x72, 0x63, 0x65, 0x54, 0x79, 0x70, 0x65, 0x52, 0x0b, 0x53, 0x6f, 0x75, 0x72, 0x63,
+	0x65, 0x54, 0x79, 0x70, 0x65, 0x73, 0x22, 0xa1, 0x03, 0x0a, 0x0a, 0x53, 0x6f, 0x75, 0x72, 0x63,
+	0x65, 0x54, 0x79, 0x70, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x4e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x04, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x4c, 0x61, 0x62,
+	0x65, 0x6c, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x4c, 0x61, 0x62, 0x65, 0x6c, 0x12,
+	0x20, 0x0a, 0x0b, 0x44, 0x65, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x03,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x44, 0x65, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f,
+	0x6e, 0x12, 0x33, 0x0a, 0x06, 0x44, 0x6f, 0x6d, 0x61, 0x69, 0x6e, 0x18, 0x04, 0x20, 0x01, 0x28,
+	0x0e, 0x32, 0x1b, 0x2e, 0x63, 0x69, 0x74, 0x79, 0x61, 0x71, 0x72, 0x70, 0x63, 0x2e, 0x41, 0x6c,
+	0x6c, 0x6f, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x44, 0x6f, 0x6d, 0x61, 0x69, 0x6e, 0x52, 0x06,
+	0x44, 0x6f, 0x6d, 0x61, 0x69, 0x6e, 0x12, 0x1e, 0x0a, 0x0a, 0x52, 0x65, 0x73, 0x6f, 0x6c, 0x75,
+	0x74, 0x69, 0x6f, 0x6e, 0x18, 0x05, 0x20, 0x01, 0x28, 0x01, 0x52, 0x0a, 0x52, 0x65, 0x73, 0x6f,
+	0x6c, 0x75, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x1a, 0x0a, 0x08, 0x43, 0x65, 0x6c, 0x6c, 0x53, 0x69,
+	0x7a, 0x65, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x01, 0x52, 0x08, 0x43, 0x65, 0x6c, 0x6c, 0x53, 0x69,
+	0x7a, 0x65, 0x12, 0x20, 0x0a, 0x0b, 0x53, 0x74, 0x61, 0x63, 0x6b, 0x48, 0x65, 0x69, 0x67, 0x68,
+	0x74, 0x18, 0x06, 0x20, 0x01, 0x28, 0x01, 0x52, 0x0b, 0x53, 0x74, 0x61, 0x63, 0x6b, 0x48, 0x65,
+	0x69, 0x67, 0x68, 0x74, 0x12, 0x24, 0x0a, 0x0d, 0x53, 0x74, 0x61, 0x63, 0x6b, 0x44, 0x69, 0x61,
+	0x6d, 0x65, 0x74, 0x65, 0x72, 0x18, 0x07, 0x20, 0x01, 0x28, 0x01, 0x52, 0x0d, 0x53, 0x74, 0x61,
+	0x63, 0x6b, 0x44, 0x69, 0x61, 0x6d, 0x65, 0x74, 0x65, 0x72, 0x12, 0x2a, 0x0a, 0x10, 0x53, 0x74,
+	0x61, 0x63, 0x6b, 0x54, 0x65, 0x6d, 0x70, 0x65, 0x72, 0x61, 0x74, 0x75, 0x72, 0x65, 0x18, 0x08,
+	0x20, 0x01, 0x28, 0x01, 0x52, 0x10, 0x53, 0x74, 0x61, 0x63, 0x6b, 0x54, 0x65, 0x6d, 0x70, 0x65,
+	0x72, 0x61, 0x74, 0x75, 0x72, 0x65, 0x12, 0x24, 0x0a, 0x0d, 0x53, 0x74, 0x61, 0x63, 0x6b, 0x56,
+	0x65, 0x6c, 0x6f, 0x63, 0x69, 0x74, 0x79, 0x18, 0x09, 0x20, 0x01, 0x28, 0x01, 0x52, 0x0d, 0x53,
+	0x74, 0x61, 0x63, 0x6b, 0x56, 0x65, 0x6c, 0x6f, 0x63, 0x69, 0x74, 0x79, 0x12, 0x3c, 0x0a, 0x0a,
+	0x53, 0x70, 0x65, 0x63, 0x69, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x0b, 0x20, 0x03, 0x28, 0x0b,
+	0x32, 0x1c, 0x2e, 0x63, 0x69, 0x74, 0x79, 0x61, 0x71, 0x72, 0x70, 0x63, 0x2e, 0x50, 0x6f, 0x6c,
+	0x6c, 0x75, 0x74, 0x61, 0x6e, 0x74, 0x46, 0x72, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x0a,
+	0x53, 0x70, 0x65, 0x63, 0x69, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x22, 0x60, 0x0a, 0x11, 0x50, 0x6f,
+	0x6c, 0x6c, 0x75, 0x74, 0x61, 0x6e, 0x74, 0x46, 0x72, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x12,
+	0x2f, 0x0a, 0x08, 0x45, 0x6d, 0x69, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x0e, 0x32, 0x13, 0x2e, 0x63, 0x69, 0x74, 0x79, 0x61, 0x71, 0x72, 0x70, 0x63, 0x2e, 0x45, 0x6d,
+	0x69, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x52, 0x08, 0x45, 0x6d, 0x69, 0x73, 0x73, 0x69, 0x6f, 0x6e,
+	0x12, 0x1a, 0x0a, 0x08, 0x46, 0x72, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x01, 0x52, 0x08, 0x46, 0x72, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x22, 0x9f, 0x02, 0x0a,
+	0x14, 0x45, 0x78, 0x70, 0x6f, 0x72, 0x74, 0x47, 0x72, 0x69, 0x64, 0x64, 0x65, 0x64, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x40, 0x0a, 0x09, 0x45, 0x6d, 0x69, 0x73, 0x73, 0x69, 0x6f,
+	0x6e, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x22, 0x2e, 0x63, 0x69, 0x74, 0x79, 0x61,
+	0x71, 0x72, 0x70, 0x63, 0x2e, 0x47, 0x72, 0x69, 0x64, 0x64, 0x65, 0x64, 0x45, 0x6d, 0x69, 0x73,
+	0x73, 0x69, 0x6f, 0x6e, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x52, 0x09, 0x45, 0x6d,
+	0x69, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x4f, 0x0a, 0x0e, 0x43, 0x6f, 0x6e, 0x63, 0x65,
+	0x6e, 0x74, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x27, 0x2e, 0x63, 0x69, 0x74, 0x79, 0x61, 0x71, 0x72, 0x70, 0x63, 0x2e, 0x47, 0x72, 0x69, 0x64,
+	0x64, 0x65, 0x64, 0x43, 0x6f, 0x6e, 0x63, 0x65, 0x6e, 0x74, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e,
+	0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x52, 0x0e, 0x43, 0x6f, 0x6e, 0x63, 0x65, 0x6e,
+	0x74, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x43, 0x0a, 0x0a, 0x50, 0x6f, 0x70, 0x75,
+	0x6c, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x23, 0x2e, 0x63,
+	0x69, 0x74, 0x79, 0x61, 0x71, 0x72, 0x70, 0x63, 0x2e, 0x47, 0x72, 0x69, 0x64, 0x64, 0x65, 0x64,
+	0x50, 0x6f, 0x70, 0x75, 0x6c, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x52, 0x0a, 0x50, 0x6f, 0x70, 0x75, 0x6c, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x2f, 0x0a,
+	0x06, 0x46, 0x6f, 0x72, 0x6d, 0x61, 0x74, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x17, 0x2e,
+	0x63, 0x69, 0x74, 0x79, 0x61, 0x71, 0x72, 0x70, 0x63, 0x2e, 0x45, 0x78, 0x70, 0x6f, 0x72, 0x74,
+	0x46, 0x6f, 0x72, 0x6d, 0x61, 0x74, 0x52, 0x06, 0x46, 0x6f, 0x72, 0x6d, 0x61, 0x74, 0x22, 0x69,
+	0x0a, 0x15, 0x45, 0x78, 0x70, 0x6f, 0x72, 0x74, 0x47, 0x72, 0x69, 0x64, 0x64, 0x65, 0x64, 0x52,
+	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x1a, 0x0a, 0x08, 0x46, 0x69, 0x6c, 0x65, 0x4e,
+	0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x46, 0x69, 0x6c, 0x65, 0x4e,
+	0x61, 0x6d, 0x65, 0x12, 0x20, 0x0a, 0x0b, 0x43, 0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74, 0x54, 0x79,
+	0x70, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x43, 0x6f, 0x6e, 0x74, 0x65, 0x6e,
+	0x74, 0x54, 0x79, 0x70, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x44, 0x61, 0x74, 0x61, 0x18, 0x03, 0x20,
+	0x01, 0x28, 0x0c, 0x52, 0x04, 0x44, 0x61, 0x74, 0x61, 0x22, 0xc0, 0x02, 0x0a, 0x0f, 0x4d, 0x61,
+	0x70, 0x53, 0x63, 0x61, 0x6c, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1a, 0x0a,
+	0x08, 0x43, 0x69, 0x74, 0x79, 0x4e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x08, 0x43, 0x69, 0x74, 0x79, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x35, 0x0a, 0x0a, 0x49, 0x6d, 0x70,
+	0x61, 0x63, 0x74, 0x54, 0x79, 0x70, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x15, 0x2e,
+	0x63, 0x69, 0x74, 0x79, 0x61, 0x71, 0x72, 0x70, 0x63, 0x2e, 0x49, 0x6d, 0x70, 0x61, 0x63, 0x74,
+	0x54, 0x79, 0x70, 0x65, 0x52, 0x0a, 0x49, 0x6d, 0x70, 0x61, 0x63, 0x74, 0x54, 0x79, 0x70, 0x65,
+	0x12, 0x2f, 0x0a, 0x08, 0x45, 0x6d, 0x69, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x03, 0x20, 0x01,
+	0x28, 0x0e, 0x32, 0x13, 0x2e, 0x63, 0x69, 0x74, 0x79, 0x61, 0x71, 0x72, 0x70, 0x63, 0x2e, 0x45,
+	0x6d, 0x69, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x52, 0x08, 0x45, 0x6d, 0x69, 0x73, 0x73, 0x69, 0x6f,
+	0x6e, 0x12, 0x1e, 0x0a, 0x0a, 0x53, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x54, 0x79, 0x70, 0x65, 0x18,
+	0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x53, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x54, 0x79, 0x70,
+	0x65, 0x12, 0x41, 0x0a, 0x0e, 0x53, 0x69, 0x6d, 0x75, 0x6c, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x54,
+	0x79, 0x70, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x19, 0x2e, 0x63, 0x69, 0x74, 0x79,
+	0x61, 0x71, 0x72, 0x70, 0x63, 0x2e, 0x53, 0x69, 0x6d, 0x75, 0x6c, 0x61, 0x74, 0x69, 0x6f, 0x6e,
+	0x54, 0x79, 0x70, 0x65, 0x52, 0x0e, 0x53, 0x69, 0x6d, 0x75, 0x6c, 0x61, 0x74, 0x69, 0x6f, 0x6e,
+	0x54, 0x79, 0x70, 0x65, 0x12, 0x1e, 0x0a, 0x0a, 0x52, 0x65, 0x73, 0x6f, 0x6c, 0x75, 0x74, 0x69,
+	0x6f, 0x6e, 0x18, 0x06, 0x20, 0x01, 0x28, 0x01, 0x52, 0x0a, 0x52, 0x65, 0x73, 0x6f, 0x6c, 0x75,
+	0x74, 0x69, 0x6f, 0x6e, 0x12, 0x26, 0x0a, 0x0e, 0x41, 0x75, 0x74, 0x6f, 0x52, 0x65, 0x73, 0x6f,
+	0x6c, 0x75, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x07, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0e, 0x41, 0x75,
+	0x74, 0x6f, 0x52, 0x65, 0x73, 0x6f, 0x6c, 0x75, 0x74, 0x69, 0x6f, 0x6e, 0x22, 0x4c, 0x0a, 0x10,
+	0x4d, 0x61, 0x70, 0x53, 0x63, 0x61, 0x6c, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
+	0x12, 0x10, 0x0a, 0x03, 0x4d, 0x69, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x01, 0x52, 0x03, 0x4d,
+	0x69, 0x6e, 0x12, 0x10, 0x0a, 0x03, 0x4d, 0x61, 0x78, 0x18, 0x02, 0x20, 0x01, 0x28, 0x01, 0x52,
+	0x03, 0x4d, 0x61, 0x78, 0x12, 0x14, 0x0a, 0x05, 0x43, 0x75, 0x74, 0x50, 0x74, 0x18, 0x03, 0x20,
+	0x01, 0x28, 0x01, 0x52, 0x05, 0x43, 0x75, 0x74, 0x50, 0x74, 0x2a, 0x58, 0x0a, 0x12, 0x54, 0x65,
+	0x6d, 0x70, 0x6f, 0x72, 0x61, 0x6c, 0x52, 0x65, 0x73, 0x6f, 0x6c, 0x75, 0x74, 0x69, 0x6f, 0x6e,
+	0x12, 0x1e, 0x0a, 0x1a, 0x55, 0x4e, 0x4b, 0x4e, 0x4f, 0x57, 0x4e, 0x5f, 0x54, 0x45, 0x4d, 0x50,
+	0x4f, 0x52, 0x41, 0x4c, 0x52, 0x45, 0x53, 0x4f, 0x4c, 0x55, 0x54, 0x49, 0x4f, 0x4e, 0x10, 0x00,
+	0x12, 0x0a, 0x0a, 0x06, 0x48, 0x6f, 0x75, 0x72, 0x6c, 0x79, 0x10, 0x01, 0x12, 0x09, 0x0a, 0x05,
+	0x44, 0x61, 0x69, 0x6c, 0x79, 0x10, 0x02, 0x12, 0x0b, 0x0a, 0x07, 0x4d, 0x6f, 0x6e, 0x74, 0x68,
+	0x6c, 0x79, 0x10, 0x03, 0x2a, 0x4f, 0x0a, 0x0f, 0x53, 0x75, 0x72, 0x72, 0x6f, 0x67, 0x61, 0x74,
+	0x65, 0x57, 0x65, 0x69, 0x67, 0x68, 0x74, 0x12, 0x1b, 0x0a, 0x17, 0x55, 0x4e, 0x4b, 0x4e, 0x4f,
+	0x57, 0x4e, 0x5f, 0x53, 0x55, 0x52, 0x52, 0x4f, 0x47, 0x41, 0x54, 0x45, 0x57, 0x45, 0x49, 0x47,
+	0x48, 0x54, 0x10, 0x00, 0x12, 0x0a, 0x0a, 0x06, 0x4c, 0x65, 0x6e, 0x67, 0x74, 0x68, 0x10, 0x01,
+	0x12, 0x08, 0x0a, 0x04, 0x41, 0x72, 0x65, 0x61, 0x10, 0x02, 0x12, 0x09, 0x0a, 0x05, 0x43, 0x6f,
+	0x75, 0x6e, 0x74, 0x10, 0x03, 0x2a, 0x47, 0x0a, 0x10, 0x41, 0x6c, 0x6c, 0x6f, 0x63, 0x61, 0x74,
+	0x69, 0x6f, 0x6e, 0x44, 0x6f, 0x6d, 0x61, 0x69, 0x6e, 0x12, 0x1c, 0x0a, 0x18, 0x55, 0x4e, 0x4b,
+	0x4e, 0x4f, 0x57, 0x4e, 0x5f, 0x41, 0x4c, 0x4c, 0x4f, 0x43, 0x41, 0x54, 0x49, 0x4f, 0x4e, 0x44,
+	0x4f, 0x4d, 0x41, 0x49, 0x4e, 0x10, 0x00, 0x12, 0x08, 0x0a, 0x04, 0x43, 0x69, 0x74, 0x79, 0x10,
+	0x01, 0x12, 0x0b, 0x0a, 0x07, 0x45, 0x47, 0x55, 0x47, 0x72, 0x69, 0x64, 0x10, 0x02, 0x2a, 0x4f,
+	0x0a, 0x08, 0x45, 0x6d, 0x69, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x14, 0x0a, 0x10, 0x55, 0x4e,
+	0x4b, 0x4e, 0x4f, 0x57, 0x4e, 0x5f, 0x45, 0x4d, 0x49, 0x53, 0x53, 0x49, 0x4f, 0x4e, 0x10, 0x00,
+	0x12, 0x09, 0x0a, 0x05, 0x50, 0x4d, 0x32, 0x5f, 0x35, 0x10, 0x01, 0x12, 0x07, 0x0a, 0x03, 0x4e,
+	0x48, 0x33, 0x10, 0x02, 0x12, 0x07, 0x0a, 0x03, 0x4e, 0x4f, 0x78, 0x10, 0x03, 0x12, 0x07, 0x0a,
+	0x03, 0x53, 0x4f, 0x78, 0x10, 0x04, 0x12, 0x07, 0x0a, 0x03, 0x56, 0x4f, 0x43, 0x10, 0x05, 0x2a,
+	0x6b, 0x0a, 0x0d, 0x45, 0x6d, 0x69, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x55, 0x6e, 0x69, 0x74, 0x73,
+	0x12, 0x19, 0x0a, 0x15, 0x55, 0x4e, 0x4b, 0x4e, 0x4f, 0x57, 0x4e, 0x5f, 0x45, 0x4d, 0x49, 0x53,
+	0x53, 0x49, 0x4f, 0x4e, 0x55, 0x4e, 0x49, 0x54, 0x53, 0x10, 0x00, 0x12, 0x0d, 0x0a, 0x09, 0x4b,
+	0x69, 0x6c, 0x6f, 0x67, 0x72, 0x61, 0x6d, 0x73, 0x10, 0x01, 0x12, 0x0a, 0x0a, 0x06, 0x54, 0x6f,
+	0x6e, 0x6e, 0x65, 0x73, 0x10, 0x02, 0x12, 0x0e, 0x0a, 0x0a, 0x4b, 0x69, 0x6c, 0x6f, 0x74, 0x6f,
+	0x6e, 0x6e, 0x65, 0x73, 0x10, 0x03, 0x12, 0x14, 0x0a, 0x10, 0x4d, 0x65, 0x67, 0x61, 0x67, 0x72,
+	0x61, 0x6d, 0x73, 0x50, 0x65, 0x72, 0x59, 0x65, 0x61, 0x72, 0x10, 0x04, 0x2a, 0x66, 0x0a, 0x0c,
+	0x45, 0x78, 0x70, 0x6f, 0x72, 0x74, 0x46, 0x6f, 0x72, 0x6d, 0x61, 0x74, 0x12, 0x18, 0x0a, 0x14,
+	0x55, 0x4e, 0x4b, 0x4e, 0x4f, 0x57, 0x4e, 0x5f, 0x45, 0x58, 0x50, 0x4f, 0x52, 0x54, 0x46, 0x4f,
+	0x52, 0x4d, 0x41, 0x54, 0x10, 0x00, 0x12, 0x0b, 0x0a, 0x07, 0x47, 0x65, 0x6f, 0x54, 0x49, 0x46,
+	0x46, 0x10, 0x01, 0x12, 0x0a, 0x0a, 0x06, 0x4e, 0x65, 0x74, 0x43, 0x44, 0x46, 0x10, 0x02, 0x12,
+	0x0d, 0x0a, 0x09, 0x53, 0x68, 0x61, 0x70, 0x65, 0x66, 0x69, 0x6c, 0x65, 0x10, 0x03, 0x12, 0x0b,
+	0x0a, 0x07, 0x47, 0x65, 0x6f, 0x4a, 0x53, 0x4f, 0x4e, 0x10, 0x04, 0x12, 0x07, 0x0a, 0x03, 0x43,
+	0x53, 0x56, 0x10, 0x05, 0x2a, 0x47, 0x0a, 0x0a, 0x49, 0x6d, 0x70, 0x61, 0x63, 0x74, 0x54, 0x79,
+	0x70, 0x65, 0x12, 0x16, 0x0a, 0x12, 0x55, 0x4e, 0x4b, 0x4e, 0x4f, 0x57, 0x4e, 0x5f, 0x49, 0x4d,
+	0x50, 0x41, 0x43, 0x54, 0x54, 0x59, 0x50, 0x45, 0x10, 0x00, 0x12, 0x0d, 0x0a, 0x09, 0x45, 0x6d,
+	0x69, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x73, 0x10, 0x01, 0x12, 0x12, 0x0a, 0x0e, 0x43, 0x6f, 0x6e,
+	0x63, 0x65, 0x6e, 0x74, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x10, 0x02, 0x2a, 0x58, 0x0a,
+	0x0e, 0x53, 0x69, 0x6d, 0x75, 0x6c, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x54, 0x79, 0x70, 0x65, 0x12,
+	0x1a, 0x0a, 0x16, 0x55, 0x4e, 0x4b, 0x4e, 0x4f, 0x57, 0x4e, 0x5f, 0x53, 0x49, 0x4d, 0x55, 0x4c,
+	0x41, 0x54, 0x49, 0x4f, 0x4e, 0x54, 0x59, 0x50, 0x45, 0x10, 0x00, 0x12, 0x09, 0x0a, 0x05, 0x54,
+	0x6f, 0x74, 0x61, 0x6c, 0x10, 0x01, 0x12, 0x0d, 0x0a, 0x09, 0x43, 0x69, 0x74, 0x79, 0x54, 0x6f,
+	0x74, 0x61, 0x6c, 0x10, 0x02, 0x12, 0x10, 0x0a, 0x0c, 0x43, 0x69, 0x74, 0x79, 0x4d, 0x61, 0x72,
+	0x67, 0x69, 0x6e, 0x61, 0x6c, 0x10, 0x03, 0x32, 0xda, 0x0a, 0x0a, 0x06, 0x43, 0x69, 0x74, 0x79,
+	0x41, 0x51, 0x12, 0x3f, 0x0a, 0x06, 0x43, 0x69, 0x74, 0x69, 0x65, 0x73, 0x12, 0x18, 0x2e, 0x63,
+	0x69, 0x74, 0x79, 0x61, 0x71, 0x72, 0x70, 0x63, 0x2e, 0x43, 0x69, 0x74, 0x69, 0x65, 0x73, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x19, 0x2e, 0x63, 0x69, 0x74, 0x79, 0x61, 0x71, 0x72,
+	0x70, 0x63, 0x2e, 0x43, 0x69, 0x74, 0x69, 0x65, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
+	0x65, 0x22, 0x00, 0x12, 0x51, 0x0a, 0x0c, 0x43, 0x69, 0x74, 0x79, 0x47, 0x65, 0x6f, 0x6d, 0x65,
+	0x74, 0x72, 0x79, 0x12, 0x1e, 0x2e, 0x63, 0x69, 0x74, 0x79, 0x61, 0x71, 0x72, 0x70, 0x63, 0x2e,
+	0x43, 0x69, 0x74, 0x79, 0x47, 0x65, 0x6f, 0x6d, 0x65, 0x74, 0x72, 0x79, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x1a, 0x1f, 0x2e, 0x63, 0x69, 0x74, 0x79, 0x61, 0x71, 0x72, 0x70, 0x63, 0x2e,
+	0x43, 0x69, 0x74, 0x79, 0x47, 0x65, 0x6f, 0x6d, 0x65, 0x74, 0x72, 0x79, 0x52, 0x65, 0x73, 0x70,
+	0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x45, 0x0a, 0x08, 0x43, 0x69, 0x74, 0x79, 0x49, 0x6e,
+	0x66, 0x6f, 0x12, 0x1a, 0x2e, 0x63, 0x69, 0x74, 0x79, 0x61, 0x71, 0x72, 0x70, 0x63, 0x2e, 0x43,
+	0x69, 0x74, 0x79, 0x49, 0x6e, 0x66, 0x6f, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1b,
+	0x2e, 0x63, 0x69, 0x74, 0x79, 0x61, 0x71, 0x72, 0x70, 0x63, 0x2e, 0x43, 0x69, 0x74, 0x79, 0x49,
+	0x6e, 0x66, 0x6f, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x5d, 0x0a,
+	0x10, 0x43, 0x69, 0x74, 0x69, 0x65, 0x73, 0x43, 0x6f, 0x6e, 0x74, 0x61, 0x69, 0x6e, 0x69, 0x6e,
+	0x67, 0x12, 0x22, 0x2e, 0x63, 0x69, 0x74, 0x79, 0x61, 0x71, 0x72, 0x70, 0x63, 0x2e, 0x43, 0x69,
+	0x74, 0x69, 0x65, 0x73, 0x43, 0x6f, 0x6e, 0x74, 0x61, 0x69, 0x6e, 0x69, 0x6e, 0x67, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x23, 0x2e, 0x63, 0x69, 0x74, 0x79, 0x61, 0x71, 0x72, 0x70,
+	0x63, 0x2e, 0x43, 0x69, 0x74, 0x69, 0x65, 0x73, 0x43, 0x6f, 0x6e, 0x74, 0x61, 0x69, 0x6e, 0x69,
+	0x6e, 0x67, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x60, 0x0a, 0x11,
+	0x52, 0x65, 0x67, 0x69, 0x73, 0x74, 0x65, 0x72, 0x53, 0x74, 0x75, 0x64, 0x79, 0x41, 0x72, 0x65,
+	0x61, 0x12, 0x23, 0x2e, 0x63, 0x69, 0x74, 0x79, 0x61, 0x71, 0x72, 0x70, 0x63, 0x2e, 0x52, 0x65,
+	0x67, 0x69, 0x73, 0x74, 0x65, 0x72, 0x53, 0x74, 0x75, 0x64, 0x79, 0x41, 0x72, 0x65, 0x61, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x24, 0x2e, 0x63, 0x69, 0x74, 0x79, 0x61, 0x71, 0x72,
+	0x70, 0x63, 0x2e, 0x52, 0x65, 0x67, 0x69, 0x73, 0x74, 0x65, 0x72, 0x53, 0x74, 0x75, 0x64, 0x79,
+	0x41, 0x72, 0x65, 0x61, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x5d,
+	0x0a, 0x10, 0x47, 0x72, 0x69, 0x64, 0x64, 0x65, 0x64, 0x45, 0x6d, 0x69, 0x73, 0x73, 0x69, 0x6f,
+	0x6e, 0x73, 0x12, 0x22, 0x2e, 0x63, 0x69, 0x74, 0x79, 0x61, 0x71, 0x72, 0x70, 0x63, 0x2e, 0x47,
+	0x72, 0x69, 0x64, 0x64, 0x65, 0x64, 0x45, 0x6d, 0x69, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x73, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x23, 0x2e, 0x63, 0x69, 0x74, 0x79, 0x61, 0x71, 0x72,
+	0x70, 0x63, 0x2e, 0x47, 0x72, 0x69, 0x64, 0x64, 0x65, 0x64, 0x45, 0x6d, 0x69, 0x73, 0x73, 0x69,
+	0x6f, 0x6e, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x66, 0x0a,
+	0x13, 0x45, 0x6d, 0x69, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x73, 0x47, 0x72, 0x69, 0x64, 0x42, 0x6f,
+	0x75, 0x6e, 0x64, 0x73, 0x12, 0x25, 0x2e, 0x63, 0x69, 0x74, 0x79, 0x61, 0x71, 0x72, 0x70, 0x63,
 	0x2e, 0x45, 0x6d, 0x69, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x73, 0x47, 0x72, 0x69, 0x64, 0x42, 0x6f,
-	0x75, 0x6e, 0x64, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x6c,
-	0x0a, 0x15, 0x47, 0x72, 0x69, 0x64, 0x64, 0x65, 0x64, 0x43, 0x6f, 0x6e, 0x63, 0x65, 0x6e, 0x74,
-	0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x27, 0x2e, 0x63, 0x69, 0x74, 0x79, 0x61, 0x71,
+	0x75, 0x6e, 0x64, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x26, 0x2e, 0x63, 0x69,
+	0x74, 0x79, 0x61, 0x71, 0x72, 0x70, 0x63, 0x2e, 0x45, 0x6d, 0x69, 0x73, 0x73, 0x69, 0x6f, 0x6e,
+	0x73, 0x47, 0x72, 0x69, 0x64, 0x42, 0x6f, 0x75, 0x6e, 0x64, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f,
+	0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x6c, 0x0a, 0x15, 0x47, 0x72, 0x69, 0x64, 0x64, 0x65, 0x64,
+	0x43, 0x6f, 0x6e, 0x63, 0x65, 0x6e, 0x74, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x27,
+	0x2e, 0x63, 0x69, 0x74, 0x79, 0x61, 0x71, 0x72, 0x70, 0x63, 0x2e, 0x47, 0x72, 0x69, 0x64, 0x64,
+	0x65, 0x64, 0x43, 0x6f, 0x6e, 0x63, 0x65, 0x6e, 0x74, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x28, 0x2e, 0x63, 0x69, 0x74, 0x79, 0x61, 0x71,
 	0x72, 0x70, 0x63, 0x2e, 0x47, 0x72, 0x69, 0x64, 0x64, 0x65, 0x64, 0x43, 0x6f, 0x6e, 0x63, 0x65,
-	0x6e, 0x74, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
-	0x1a, 0x28, 0x2e, 0x63, 0x69, 0x74, 0x79, 0x61, 0x71, 0x72, 0x70, 0x63, 0x2e, 0x47, 0x72, 0x69,
-	0x64, 0x64, 0x65, 0x64, 0x43, 0x6f, 0x6e, 0x63, 0x65, 0x6e, 0x74, 0x72, 0x61, 0x74, 0x69, 0x6f,
-	0x6e, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x45, 0x0a, 0x08,
-	0x4d, 0x61, 0x70, 0x53, 0x63, 0x61, 0x6c, 0x65, 0x12, 0x1a, 0x2e, 0x63, 0x69, 0x74, 0x79, 0x61,
-	0x71, 0x72, 0x70, 0x63, 0x2e, 0x4d, 0x61, 0x70, 0x53, 0x63, 0x61, 0x6c, 0x65, 0x52, 0x65, 0x71,
-	0x75, 0x65, 0x73, 0x74, 0x1a, 0x1b, 0x2e, 0x63, 0x69, 0x74, 0x79, 0x61, 0x71, 0x72, 0x70, 0x63,
-	0x2e, 0x4d, 0x61, 0x70, 0x53, 0x63, 0x61, 0x6c, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
-	0x65, 0x22, 0x00, 0x12, 0x60, 0x0a, 0x11, 0x47, 0x72, 0x69, 0x64, 0x64, 0x65, 0x64, 0x50, 0x6f,
-	0x70, 0x75, 0x6c, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x23, 0x2e, 0x63, 0x69, 0x74, 0x79, 0x61,
-	0x71, 0x72, 0x70, 0x63, 0x2e, 0x47, 0x72, 0x69, 0x64, 0x64, 0x65, 0x64, 0x50, 0x6f, 0x70, 0x75,
-	0x6c, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x24, 0x2e,
-	0x63, 0x69, 0x74, 0x79, 0x61, 0x71, 0x72, 0x70, 0x63, 0x2e, 0x47, 0x72, 0x69, 0x64, 0x64, 0x65,
-	0x64, 0x50, 0x6f, 0x70, 0x75, 0x6c, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x73, 0x70, 0x6f,
-	0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x54, 0x0a, 0x0d, 0x49, 0x6d, 0x70, 0x61, 0x63, 0x74, 0x53,
-	0x75, 0x6d, 0x6d, 0x61, 0x72, 0x79, 0x12, 0x1f, 0x2e, 0x63, 0x69, 0x74, 0x79, 0x61, 0x71, 0x72,
-	0x70, 0x63, 0x2e, 0x49, 0x6d, 0x70, 0x61, 0x63, 0x74, 0x53, 0x75, 0x6d, 0x6d, 0x61, 0x72, 0x79,
-	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x20, 0x2e, 0x63, 0x69, 0x74, 0x79, 0x61, 0x71,
-	0x72, 0x70, 0x63, 0x2e, 0x49, 0x6d, 0x70, 0x61, 0x63, 0x74, 0x53, 0x75, 0x6d, 0x6d, 0x61, 0x72,
-	0x79, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x78, 0x0a, 0x19, 0x45,
-	0x6d, 0x69, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x73, 0x49, 0x6e, 0x76, 0x65, 0x6e, 0x74, 0x6f, 0x72,
-	0x79, 0x53, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x73, 0x12, 0x2b, 0x2e, 0x63, 0x69, 0x74, 0x79, 0x61,
-	0x71, 0x72, 0x70, 0x63, 0x2e, 0x45, 0x6d, 0x69, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x73, 0x49, 0x6e,
-	0x76, 0x65, 0x6e, 0x74, 0x6f, 0x72, 0x79, 0x53, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x73, 0x52, 0x65,
-	0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x2c, 0x2e, 0x63, 0x69, 0x74, 0x79, 0x61, 0x71, 0x72, 0x70,
-	0x63, 0x2e, 0x45, 0x6d, 0x69, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x73, 0x49, 0x6e, 0x76, 0x65, 0x6e,
-	0x74, 0x6f, 0x72, 0x79, 0x53, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f,
-	0x6e, 0x73, 0x65, 0x22, 0x00, 0x42, 0x0b, 0x5a, 0x09, 0x63, 0x69, 0x74, 0x79, 0x61, 0x71, 0x72,
-	0x70, 0x63, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+	0x6e, 0x74, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
+	0x65, 0x22, 0x00, 0x12, 0x45, 0x0a, 0x08, 0x4d, 0x61, 0x70, 0x53, 0x63, 0x61, 0x6c, 0x65, 0x12,
+	0x1a, 0x2e, 0x63, 0x69, 0x74, 0x79, 0x61, 0x71, 0x72, 0x70, 0x63, 0x2e, 0x4d, 0x61, 0x70, 0x53,
+	0x63, 0x61, 0x6c, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1b, 0x2e, 0x63, 0x69,
+	0x74, 0x79, 0x61, 0x71, 0x72, 0x70, 0x63, 0x2e, 0x4d, 0x61, 0x70, 0x53, 0x63, 0x61, 0x6c, 0x65,
+	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x60, 0x0a, 0x11, 0x47, 0x72,
+	0x69, 0x64, 0x64, 0x65, 0x64, 0x50, 0x6f, 0x70, 0x75, 0x6c, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12,
+	0x23, 0x2e, 0x63, 0x69, 0x74, 0x79, 0x61, 0x71, 0x72, 0x70, 0x63, 0x2e, 0x47, 0x72, 0x69, 0x64,
+	0x64, 0x65, 0x64, 0x50, 0x6f, 0x70, 0x75, 0x6c, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x1a, 0x24, 0x2e, 0x63, 0x69, 0x74, 0x79, 0x61, 0x71, 0x72, 0x70, 0x63,
+	0x2e, 0x47, 0x72, 0x69, 0x64, 0x64, 0x65, 0x64, 0x50, 0x6f, 0x70, 0x75, 0x6c, 0x61, 0x74, 0x69,
+	0x6f, 0x6e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x54, 0x0a, 0x0d,
+	0x49, 0x6d, 0x70, 0x61, 0x63, 0x74, 0x53, 0x75, 0x6d, 0x6d, 0x61, 0x72, 0x79, 0x12, 0x1f, 0x2e,
+	0x63, 0x69, 0x74, 0x79, 0x61, 0x71, 0x72, 0x70, 0x63, 0x2e, 0x49, 0x6d, 0x70, 0x61, 0x63, 0x74,
+	0x53, 0x75, 0x6d, 0x6d, 0x61, 0x72, 0x79, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x20,
+	0x2e, 0x63, 0x69, 0x74, 0x79, 0x61, 0x71, 0x72, 0x70, 0x63, 0x2e, 0x49, 0x6d, 0x70, 0x61, 0x63,
+	0x74, 0x53, 0x75, 0x6d, 0x6d, 0x61, 0x72, 0x79, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
+	0x22, 0x00, 0x12, 0x78, 0x0a, 0x19, 0x45, 0x6d, 0x69, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x73, 0x49,
+	0x6e, 0x76, 0x65, 0x6e, 0x74, 0x6f, 0x72, 0x79, 0x53, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x73, 0x12,
+	0x2b, 0x2e, 0x63, 0x69, 0x74, 0x79, 0x61, 0x71, 0x72, 0x70, 0x63, 0x2e, 0x45, 0x6d, 0x69, 0x73,
+	0x73, 0x69, 0x6f, 0x6e, 0x73, 0x49, 0x6e, 0x76, 0x65, 0x6e, 0x74, 0x6f, 0x72, 0x79, 0x53, 0x65,
+	0x63, 0x74, 0x6f, 0x72, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x2c, 0x2e, 0x63,
+	0x69, 0x74, 0x79, 0x61, 0x71, 0x72, 0x70, 0x63, 0x2e, 0x45, 0x6d, 0x69, 0x73, 0x73, 0x69, 0x6f,
+	0x6e, 0x73, 0x49, 0x6e, 0x76, 0x65, 0x6e, 0x74, 0x6f, 0x72, 0x79, 0x53, 0x65, 0x63, 0x74, 0x6f,
+	0x72, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x4e, 0x0a, 0x0b,
+	0x53, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x54, 0x79, 0x70, 0x65, 0x73, 0x12, 0x1d, 0x2e, 0x63, 0x69,
+	0x74, 0x79, 0x61, 0x71, 0x72, 0x70, 0x63, 0x2e, 0x53, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x54, 0x79,
+	0x70, 0x65, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1e, 0x2e, 0x63, 0x69, 0x74,
+	0x79, 0x61, 0x71, 0x72, 0x70, 0x63, 0x2e, 0x53, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x54, 0x79, 0x70,
+	0x65, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x54, 0x0a, 0x0d,
+	0x45, 0x78, 0x70, 0x6f, 0x72, 0x74, 0x47, 0x72, 0x69, 0x64, 0x64, 0x65, 0x64, 0x12, 0x1f, 0x2e,
+	0x63, 0x69, 0x74, 0x79, 0x61, 0x71, 0x72, 0x70, 0x63, 0x2e, 0x45, 0x78, 0x70, 0x6f, 0x72, 0x74,
+	0x47, 0x72, 0x69, 0x64, 0x64, 0x65, 0x64, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x20,
+	0x2e, 0x63, 0x69, 0x74, 0x79, 0x61, 0x71, 0x72, 0x70, 0x63, 0x2e, 0x45, 0x78, 0x70, 0x6f, 0x72,
+	0x74, 0x47, 0x72, 0x69, 0x64, 0x64, 0x65, 0x64, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
+	0x22, 0x00, 0x12, 0x60, 0x0a, 0x11, 0x52, 0x65, 0x64, 0x75, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x53,
+	0x63, 0x65, 0x6e, 0x61, 0x72, 0x69, 0x6f, 0x12, 0x23, 0x2e, 0x63, 0x69, 0x74, 0x79, 0x61, 0x71,
+	0x72, 0x70, 0x63, 0x2e, 0x52, 0x65, 0x64, 0x75, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x53, 0x63, 0x65,
+	0x6e, 0x61, 0x72, 0x69, 0x6f, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x24, 0x2e, 0x63,
+	0x69, 0x74, 0x79, 0x61, 0x71, 0x72, 0x70, 0x63, 0x2e, 0x52, 0x65, 0x64, 0x75, 0x63, 0x74, 0x69,
+	0x6f, 0x6e, 0x53, 0x63, 0x65, 0x6e, 0x61, 0x72, 0x69, 0x6f, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
+	0x73, 0x65, 0x22, 0x00, 0x42, 0x0b, 0x5a, 0x09, 0x63, 0x69, 0x74, 0x79, 0x61, 0x71, 0x72, 0x70,
+	0x63, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
 }
 
 var (
@@ -1633,77 +4982,167 @@ func file_cityaq_proto_rawDescGZIP() []byte {
 	return file_cityaq_proto_rawDescData
 }
 
-var file_cityaq_proto_enumTypes = make([]protoimpl.EnumInfo, 3)
-var file_cityaq_proto_msgTypes = make([]protoimpl.MessageInfo, 21)
+var file_cityaq_proto_enumTypes = make([]protoimpl.EnumInfo, 8)
+var file_cityaq_proto_msgTypes = make([]protoimpl.MessageInfo, 47)
 var file_cityaq_proto_goTypes = []interface{}{
-	(Emission)(0),                             // 0: cityaqrpc.Emission
-	(ImpactType)(0),                           // 1: cityaqrpc.ImpactType
-	(SimulationType)(0),                       // 2: cityaqrpc.SimulationType
-	(*CitiesRequest)(nil),                     // 3: cityaqrpc.CitiesRequest
-	(*CitiesResponse)(nil),                    // 4: cityaqrpc.CitiesResponse
-	(*CityGeometryRequest)(nil),               // 5: cityaqrpc.CityGeometryRequest
-	(*CityGeometryResponse)(nil),              // 6: cityaqrpc.CityGeometryResponse
-	(*Polygon)(nil),                           // 7: cityaqrpc.Polygon
-	(*Path)(nil),                              // 8: cityaqrpc.Path
-	(*Point)(nil),                             // 9: cityaqrpc.Point
-	(*GriddedEmissionsRequest)(nil),           // 10: cityaqrpc.GriddedEmissionsRequest
-	(*GriddedEmissionsResponse)(nil),          // 11: cityaqrpc.GriddedEmissionsResponse
-	(*GriddedConcentrationsRequest)(nil),      // 12: cityaqrpc.GriddedConcentrationsRequest
-	(*GriddedConcentrationsResponse)(nil),     // 13: cityaqrpc.GriddedConcentrationsResponse
-	(*GriddedPopulationRequest)(nil),          // 14: cityaqrpc.GriddedPopulationRequest
-	(*GriddedPopulationResponse)(nil),         // 15: cityaqrpc.GriddedPopulationResponse
-	(*ImpactSummaryRequest)(nil),              // 16: cityaqrpc.ImpactSummaryRequest
-	(*ImpactSummaryResponse)(nil),             // 17: cityaqrpc.ImpactSummaryResponse
-	(*EmissionsGridBoundsRequest)(nil),        // 18: cityaqrpc.EmissionsGridBoundsRequest
-	(*EmissionsGridBoundsResponse)(nil),       // 19: cityaqrpc.EmissionsGridBoundsResponse
-	(*EmissionsInventorySectorsRequest)(nil),  // 20: cityaqrpc.EmissionsInventorySectorsRequest
-	(*EmissionsInventorySectorsResponse)(nil), // 21: cityaqrpc.EmissionsInventorySectorsResponse
-	(*MapScaleRequest)(nil),                   // 22: cityaqrpc.MapScaleRequest
-	(*MapScaleResponse)(nil),                  // 23: cityaqrpc.MapScaleResponse
+	(TemporalResolution)(0),                   // 0: cityaqrpc.TemporalResolution
+	(SurrogateWeight)(0),                      // 1: cityaqrpc.SurrogateWeight
+	(AllocationDomain)(0),                     // 2: cityaqrpc.AllocationDomain
+	(Emission)(0),                             // 3: cityaqrpc.Emission
+	(EmissionUnits)(0),                        // 4: cityaqrpc.EmissionUnits
+	(ExportFormat)(0),                         // 5: cityaqrpc.ExportFormat
+	(ImpactType)(0),                           // 6: cityaqrpc.ImpactType
+	(SimulationType)(0),                       // 7: cityaqrpc.SimulationType
+	(*CitiesRequest)(nil),                     // 8: cityaqrpc.CitiesRequest
+	(*CitiesResponse)(nil),                    // 9: cityaqrpc.CitiesResponse
+	(*City)(nil),                              // 10: cityaqrpc.City
+	(*CityGeometryRequest)(nil),               // 11: cityaqrpc.CityGeometryRequest
+	(*CityGeometryResponse)(nil),              // 12: cityaqrpc.CityGeometryResponse
+	(*CitiesContainingRequest)(nil),           // 13: cityaqrpc.CitiesContainingRequest
+	(*CitiesContainingResponse)(nil),          // 14: cityaqrpc.CitiesContainingResponse
+	(*PointCities)(nil),                       // 15: cityaqrpc.PointCities
+	(*RegisterStudyAreaRequest)(nil),          // 16: cityaqrpc.RegisterStudyAreaRequest
+	(*RegisterStudyAreaResponse)(nil),         // 17: cityaqrpc.RegisterStudyAreaResponse
+	(*CityInfoRequest)(nil),                   // 18: cityaqrpc.CityInfoRequest
+	(*CityInfoResponse)(nil),                  // 19: cityaqrpc.CityInfoResponse
+	(*Polygon)(nil),                           // 20: cityaqrpc.Polygon
+	(*Path)(nil),                              // 21: cityaqrpc.Path
+	(*Point)(nil),                             // 22: cityaqrpc.Point
+	(*GriddedEmissionsRequest)(nil),           // 23: cityaqrpc.GriddedEmissionsRequest
+	(*EmissionsTimeStep)(nil),                 // 24: cityaqrpc.EmissionsTimeStep
+	(*GriddedEmissionsResponse)(nil),          // 25: cityaqrpc.GriddedEmissionsResponse
+	(*EmissionsDiagnostics)(nil),              // 26: cityaqrpc.EmissionsDiagnostics
+	(*PollutantAmount)(nil),                   // 27: cityaqrpc.PollutantAmount
+	(*CustomSurrogate)(nil),                   // 28: cityaqrpc.CustomSurrogate
+	(*OSMTag)(nil),                            // 29: cityaqrpc.OSMTag
+	(*SourceTypeWeight)(nil),                  // 30: cityaqrpc.SourceTypeWeight
+	(*PointSource)(nil),                       // 31: cityaqrpc.PointSource
+	(*PollutantEmissions)(nil),                // 32: cityaqrpc.PollutantEmissions
+	(*GriddedConcentrationsRequest)(nil),      // 33: cityaqrpc.GriddedConcentrationsRequest
+	(*GriddedConcentrationsResponse)(nil),     // 34: cityaqrpc.GriddedConcentrationsResponse
+	(*GriddedPopulationRequest)(nil),          // 35: cityaqrpc.GriddedPopulationRequest
+	(*GriddedPopulationResponse)(nil),         // 36: cityaqrpc.GriddedPopulationResponse
+	(*ImpactSummaryRequest)(nil),              // 37: cityaqrpc.ImpactSummaryRequest
+	(*ImpactSummaryResponse)(nil),             // 38: cityaqrpc.ImpactSummaryResponse
+	(*PollutantImpacts)(nil),                  // 39: cityaqrpc.PollutantImpacts
+	(*EmissionsGridBoundsRequest)(nil),        // 40: cityaqrpc.EmissionsGridBoundsRequest
+	(*EmissionsGridBoundsResponse)(nil),       // 41: cityaqrpc.EmissionsGridBoundsResponse
+	(*EmissionsInventorySectorsRequest)(nil),  // 42: cityaqrpc.EmissionsInventorySectorsRequest
+	(*EmissionsInventorySectorsResponse)(nil), // 43: cityaqrpc.EmissionsInventorySectorsResponse
+	(*SectorChange)(nil),                      // 44: cityaqrpc.SectorChange
+	(*ReductionScenarioRequest)(nil),          // 45: cityaqrpc.ReductionScenarioRequest
+	(*ReductionScenarioResponse)(nil),         // 46: cityaqrpc.ReductionScenarioResponse
+	(*SourceTypesRequest)(nil),                // 47: cityaqrpc.SourceTypesRequest
+	(*SourceTypesResponse)(nil),               // 48: cityaqrpc.SourceTypesResponse
+	(*SourceType)(nil),                        // 49: cityaqrpc.SourceType
+	(*PollutantFraction)(nil),                 // 50: cityaqrpc.PollutantFraction
+	(*ExportGriddedRequest)(nil),              // 51: cityaqrpc.ExportGriddedRequest
+	(*ExportGriddedResponse)(nil),             // 52: cityaqrpc.ExportGriddedResponse
+	(*MapScaleRequest)(nil),                   // 53: cityaqrpc.MapScaleRequest
+	(*MapScaleResponse)(nil),                  // 54: cityaqrpc.MapScaleResponse
 }
 var file_cityaq_proto_depIdxs = []int32{
-	7,  // 0: cityaqrpc.CityGeometryResponse.Polygons:type_name -> cityaqrpc.Polygon
-	8,  // 1: cityaqrpc.Polygon.Paths:type_name -> cityaqrpc.Path
-	9,  // 2: cityaqrpc.Path.Points:type_name -> cityaqrpc.Point
-	0,  // 3: cityaqrpc.GriddedEmissionsRequest.Emission:type_name -> cityaqrpc.Emission
-	2,  // 4: cityaqrpc.GriddedEmissionsRequest.SimulationType:type_name -> cityaqrpc.SimulationType
-	7,  // 5: cityaqrpc.GriddedEmissionsResponse.Polygons:type_name -> cityaqrpc.Polygon
-	0,  // 6: cityaqrpc.GriddedConcentrationsRequest.Emission:type_name -> cityaqrpc.Emission
-	2,  // 7: cityaqrpc.GriddedConcentrationsRequest.SimulationType:type_name -> cityaqrpc.SimulationType
-	7,  // 8: cityaqrpc.GriddedConcentrationsResponse.Polygons:type_name -> cityaqrpc.Polygon
-	0,  // 9: cityaqrpc.GriddedPopulationRequest.Emission:type_name -> cityaqrpc.Emission
-	2,  // 10: cityaqrpc.GriddedPopulationRequest.SimulationType:type_name -> cityaqrpc.SimulationType
-	7,  // 11: cityaqrpc.GriddedPopulationResponse.Polygons:type_name -> cityaqrpc.Polygon
-	0,  // 12: cityaqrpc.ImpactSummaryRequest.Emission:type_name -> cityaqrpc.Emission
-	2,  // 13: cityaqrpc.ImpactSummaryRequest.SimulationType:type_name -> cityaqrpc.SimulationType
-	9,  // 14: cityaqrpc.EmissionsGridBoundsResponse.Min:type_name -> cityaqrpc.Point
-	9,  // 15: cityaqrpc.EmissionsGridBoundsResponse.Max:type_name -> cityaqrpc.Point
-	1,  // 16: cityaqrpc.MapScaleRequest.ImpactType:type_name -> cityaqrpc.ImpactType
-	0,  // 17: cityaqrpc.MapScaleRequest.Emission:type_name -> cityaqrpc.Emission
-	2,  // 18: cityaqrpc.MapScaleRequest.SimulationType:type_name -> cityaqrpc.SimulationType
-	3,  // 19: cityaqrpc.CityAQ.Cities:input_type -> cityaqrpc.CitiesRequest
-	5,  // 20: cityaqrpc.CityAQ.CityGeometry:input_type -> cityaqrpc.CityGeometryRequest
-	10, // 21: cityaqrpc.CityAQ.GriddedEmissions:input_type -> cityaqrpc.GriddedEmissionsRequest
-	18, // 22: cityaqrpc.CityAQ.EmissionsGridBounds:input_type -> cityaqrpc.EmissionsGridBoundsRequest
-	12, // 23: cityaqrpc.CityAQ.GriddedConcentrations:input_type -> cityaqrpc.GriddedConcentrationsRequest
-	22, // 24: cityaqrpc.CityAQ.MapScale:input_type -> cityaqrpc.MapScaleRequest
-	14, // 25: cityaqrpc.CityAQ.GriddedPopulation:input_type -> cityaqrpc.GriddedPopulationRequest
-	16, // 26: cityaqrpc.CityAQ.ImpactSummary:input_type -> cityaqrpc.ImpactSummaryRequest
-	20, // 27: cityaqrpc.CityAQ.EmissionsInventorySectors:input_type -> cityaqrpc.EmissionsInventorySectorsRequest
-	4,  // 28: cityaqrpc.CityAQ.Cities:output_type -> cityaqrpc.CitiesResponse
-	6,  // 29: cityaqrpc.CityAQ.CityGeometry:output_type -> cityaqrpc.CityGeometryResponse
-	11, // 30: cityaqrpc.CityAQ.GriddedEmissions:output_type -> cityaqrpc.GriddedEmissionsResponse
-	19, // 31: cityaqrpc.CityAQ.EmissionsGridBounds:output_type -> cityaqrpc.EmissionsGridBoundsResponse
-	13, // 32: cityaqrpc.CityAQ.GriddedConcentrations:output_type -> cityaqrpc.GriddedConcentrationsResponse
-	23, // 33: cityaqrpc.CityAQ.MapScale:output_type -> cityaqrpc.MapScaleResponse
-	15, // 34: cityaqrpc.CityAQ.GriddedPopulation:output_type -> cityaqrpc.GriddedPopulationResponse
-	17, // 35: cityaqrpc.CityAQ.ImpactSummary:output_type -> cityaqrpc.ImpactSummaryResponse
-	21, // 36: cityaqrpc.CityAQ.EmissionsInventorySectors:output_type -> cityaqrpc.EmissionsInventorySectorsResponse
-	28, // [28:37] is the sub-list for method output_type
-	19, // [19:28] is the sub-list for method input_type
-	19, // [19:19] is the sub-list for extension type_name
-	19, // [19:19] is the sub-list for extension extendee
-	0,  // [0:19] is the sub-list for field type_name
+	22, // 0: cityaqrpc.CitiesRequest.Min:type_name -> cityaqrpc.Point
+	22, // 1: cityaqrpc.CitiesRequest.Max:type_name -> cityaqrpc.Point
+	10, // 2: cityaqrpc.CitiesResponse.Cities:type_name -> cityaqrpc.City
+	20, // 3: cityaqrpc.CityGeometryResponse.Polygons:type_name -> cityaqrpc.Polygon
+	22, // 4: cityaqrpc.CitiesContainingRequest.Points:type_name -> cityaqrpc.Point
+	15, // 5: cityaqrpc.CitiesContainingResponse.Points:type_name -> cityaqrpc.PointCities
+	10, // 6: cityaqrpc.PointCities.Cities:type_name -> cityaqrpc.City
+	22, // 7: cityaqrpc.CityInfoResponse.Centroid:type_name -> cityaqrpc.Point
+	22, // 8: cityaqrpc.CityInfoResponse.Min:type_name -> cityaqrpc.Point
+	22, // 9: cityaqrpc.CityInfoResponse.Max:type_name -> cityaqrpc.Point
+	20, // 10: cityaqrpc.CityInfoResponse.EGUGrid:type_name -> cityaqrpc.Polygon
+	21, // 11: cityaqrpc.Polygon.Paths:type_name -> cityaqrpc.Path
+	22, // 12: cityaqrpc.Path.Points:type_name -> cityaqrpc.Point
+	3,  // 13: cityaqrpc.GriddedEmissionsRequest.Emission:type_name -> cityaqrpc.Emission
+	7,  // 14: cityaqrpc.GriddedEmissionsRequest.SimulationType:type_name -> cityaqrpc.SimulationType
+	4,  // 15: cityaqrpc.GriddedEmissionsRequest.AmountUnits:type_name -> cityaqrpc.EmissionUnits
+	27, // 16: cityaqrpc.GriddedEmissionsRequest.Pollutants:type_name -> cityaqrpc.PollutantAmount
+	28, // 17: cityaqrpc.GriddedEmissionsRequest.CustomSurrogate:type_name -> cityaqrpc.CustomSurrogate
+	30, // 18: cityaqrpc.GriddedEmissionsRequest.SourceTypeMix:type_name -> cityaqrpc.SourceTypeWeight
+	0,  // 19: cityaqrpc.GriddedEmissionsRequest.TemporalResolution:type_name -> cityaqrpc.TemporalResolution
+	32, // 20: cityaqrpc.EmissionsTimeStep.PollutantEmissions:type_name -> cityaqrpc.PollutantEmissions
+	20, // 21: cityaqrpc.GriddedEmissionsResponse.Polygons:type_name -> cityaqrpc.Polygon
+	32, // 22: cityaqrpc.GriddedEmissionsResponse.PollutantEmissions:type_name -> cityaqrpc.PollutantEmissions
+	24, // 23: cityaqrpc.GriddedEmissionsResponse.TimeSteps:type_name -> cityaqrpc.EmissionsTimeStep
+	26, // 24: cityaqrpc.GriddedEmissionsResponse.Diagnostics:type_name -> cityaqrpc.EmissionsDiagnostics
+	3,  // 25: cityaqrpc.PollutantAmount.Emission:type_name -> cityaqrpc.Emission
+	4,  // 26: cityaqrpc.PollutantAmount.AmountUnits:type_name -> cityaqrpc.EmissionUnits
+	29, // 27: cityaqrpc.CustomSurrogate.Tags:type_name -> cityaqrpc.OSMTag
+	1,  // 28: cityaqrpc.CustomSurrogate.WeightBy:type_name -> cityaqrpc.SurrogateWeight
+	22, // 29: cityaqrpc.PointSource.Location:type_name -> cityaqrpc.Point
+	3,  // 30: cityaqrpc.PollutantEmissions.Emission:type_name -> cityaqrpc.Emission
+	3,  // 31: cityaqrpc.GriddedConcentrationsRequest.Emission:type_name -> cityaqrpc.Emission
+	7,  // 32: cityaqrpc.GriddedConcentrationsRequest.SimulationType:type_name -> cityaqrpc.SimulationType
+	4,  // 33: cityaqrpc.GriddedConcentrationsRequest.AmountUnits:type_name -> cityaqrpc.EmissionUnits
+	27, // 34: cityaqrpc.GriddedConcentrationsRequest.Pollutants:type_name -> cityaqrpc.PollutantAmount
+	31, // 35: cityaqrpc.GriddedConcentrationsRequest.PointSources:type_name -> cityaqrpc.PointSource
+	28, // 36: cityaqrpc.GriddedConcentrationsRequest.CustomSurrogate:type_name -> cityaqrpc.CustomSurrogate
+	30, // 37: cityaqrpc.GriddedConcentrationsRequest.SourceTypeMix:type_name -> cityaqrpc.SourceTypeWeight
+	20, // 38: cityaqrpc.GriddedConcentrationsResponse.Polygons:type_name -> cityaqrpc.Polygon
+	3,  // 39: cityaqrpc.GriddedPopulationRequest.Emission:type_name -> cityaqrpc.Emission
+	7,  // 40: cityaqrpc.GriddedPopulationRequest.SimulationType:type_name -> cityaqrpc.SimulationType
+	31, // 41: cityaqrpc.GriddedPopulationRequest.PointSources:type_name -> cityaqrpc.PointSource
+	28, // 42: cityaqrpc.GriddedPopulationRequest.CustomSurrogate:type_name -> cityaqrpc.CustomSurrogate
+	30, // 43: cityaqrpc.GriddedPopulationRequest.SourceTypeMix:type_name -> cityaqrpc.SourceTypeWeight
+	20, // 44: cityaqrpc.GriddedPopulationResponse.Polygons:type_name -> cityaqrpc.Polygon
+	3,  // 45: cityaqrpc.ImpactSummaryRequest.Emission:type_name -> cityaqrpc.Emission
+	7,  // 46: cityaqrpc.ImpactSummaryRequest.SimulationType:type_name -> cityaqrpc.SimulationType
+	4,  // 47: cityaqrpc.ImpactSummaryRequest.AmountUnits:type_name -> cityaqrpc.EmissionUnits
+	30, // 48: cityaqrpc.ImpactSummaryRequest.SourceTypeMix:type_name -> cityaqrpc.SourceTypeWeight
+	39, // 49: cityaqrpc.ImpactSummaryResponse.PollutantImpacts:type_name -> cityaqrpc.PollutantImpacts
+	3,  // 50: cityaqrpc.PollutantImpacts.Emission:type_name -> cityaqrpc.Emission
+	22, // 51: cityaqrpc.EmissionsGridBoundsResponse.Min:type_name -> cityaqrpc.Point
+	22, // 52: cityaqrpc.EmissionsGridBoundsResponse.Max:type_name -> cityaqrpc.Point
+	44, // 53: cityaqrpc.ReductionScenarioRequest.Changes:type_name -> cityaqrpc.SectorChange
+	20, // 54: cityaqrpc.ReductionScenarioResponse.Polygons:type_name -> cityaqrpc.Polygon
+	49, // 55: cityaqrpc.SourceTypesResponse.SourceTypes:type_name -> cityaqrpc.SourceType
+	2,  // 56: cityaqrpc.SourceType.Domain:type_name -> cityaqrpc.AllocationDomain
+	50, // 57: cityaqrpc.SourceType.Speciation:type_name -> cityaqrpc.PollutantFraction
+	3,  // 58: cityaqrpc.PollutantFraction.Emission:type_name -> cityaqrpc.Emission
+	23, // 59: cityaqrpc.ExportGriddedRequest.Emissions:type_name -> cityaqrpc.GriddedEmissionsRequest
+	33, // 60: cityaqrpc.ExportGriddedRequest.Concentrations:type_name -> cityaqrpc.GriddedConcentrationsRequest
+	35, // 61: cityaqrpc.ExportGriddedRequest.Population:type_name -> cityaqrpc.GriddedPopulationRequest
+	5,  // 62: cityaqrpc.ExportGriddedRequest.Format:type_name -> cityaqrpc.ExportFormat
+	6,  // 63: cityaqrpc.MapScaleRequest.ImpactType:type_name -> cityaqrpc.ImpactType
+	3,  // 64: cityaqrpc.MapScaleRequest.Emission:type_name -> cityaqrpc.Emission
+	7,  // 65: cityaqrpc.MapScaleRequest.SimulationType:type_name -> cityaqrpc.SimulationType
+	8,  // 66: cityaqrpc.CityAQ.Cities:input_type -> cityaqrpc.CitiesRequest
+	11, // 67: cityaqrpc.CityAQ.CityGeometry:input_type -> cityaqrpc.CityGeometryRequest
+	18, // 68: cityaqrpc.CityAQ.CityInfo:input_type -> cityaqrpc.CityInfoRequest
+	13, // 69: cityaqrpc.CityAQ.CitiesContaining:input_type -> cityaqrpc.CitiesContainingRequest
+	16, // 70: cityaqrpc.CityAQ.RegisterStudyArea:input_type -> cityaqrpc.RegisterStudyAreaRequest
+	23, // 71: cityaqrpc.CityAQ.GriddedEmissions:input_type -> cityaqrpc.GriddedEmissionsRequest
+	40, // 72: cityaqrpc.CityAQ.EmissionsGridBounds:input_type -> cityaqrpc.EmissionsGridBoundsRequest
+	33, // 73: cityaqrpc.CityAQ.GriddedConcentrations:input_type -> cityaqrpc.GriddedConcentrationsRequest
+	53, // 74: cityaqrpc.CityAQ.MapScale:input_type -> cityaqrpc.MapScaleRequest
+	35, // 75: cityaqrpc.CityAQ.GriddedPopulation:input_type -> cityaqrpc.GriddedPopulationRequest
+	37, // 76: cityaqrpc.CityAQ.ImpactSummary:input_type -> cityaqrpc.ImpactSummaryRequest
+	42, // 77: cityaqrpc.CityAQ.EmissionsInventorySectors:input_type -> cityaqrpc.EmissionsInventorySectorsRequest
+	47, // 78: cityaqrpc.CityAQ.SourceTypes:input_type -> cityaqrpc.SourceTypesRequest
+	51, // 79: cityaqrpc.CityAQ.ExportGridded:input_type -> cityaqrpc.ExportGriddedRequest
+	45, // 80: cityaqrpc.CityAQ.ReductionScenario:input_type -> cityaqrpc.ReductionScenarioRequest
+	9,  // 81: cityaqrpc.CityAQ.Cities:output_type -> cityaqrpc.CitiesResponse
+	12, // 82: cityaqrpc.CityAQ.CityGeometry:output_type -> cityaqrpc.CityGeometryResponse
+	19, // 83: cityaqrpc.CityAQ.CityInfo:output_type -> cityaqrpc.CityInfoResponse
+	14, // 84: cityaqrpc.CityAQ.CitiesContaining:output_type -> cityaqrpc.CitiesContainingResponse
+	17, // 85: cityaqrpc.CityAQ.RegisterStudyArea:output_type -> cityaqrpc.RegisterStudyAreaResponse
+	25, // 86: cityaqrpc.CityAQ.GriddedEmissions:output_type -> cityaqrpc.GriddedEmissionsResponse
+	41, // 87: cityaqrpc.CityAQ.EmissionsGridBounds:output_type -> cityaqrpc.EmissionsGridBoundsResponse
+	34, // 88: cityaqrpc.CityAQ.GriddedConcentrations:output_type -> cityaqrpc.GriddedConcentrationsResponse
+	54, // 89: cityaqrpc.CityAQ.MapScale:output_type -> cityaqrpc.MapScaleResponse
+	36, // 90: cityaqrpc.CityAQ.GriddedPopulation:output_type -> cityaqrpc.GriddedPopulationResponse
+	38, // 91: cityaqrpc.CityAQ.ImpactSummary:output_type -> cityaqrpc.ImpactSummaryResponse
+	43, // 92: cityaqrpc.CityAQ.EmissionsInventorySectors:output_type -> cityaqrpc.EmissionsInventorySectorsResponse
+	48, // 93: cityaqrpc.CityAQ.SourceTypes:output_type -> cityaqrpc.SourceTypesResponse
+	52, // 94: cityaqrpc.CityAQ.ExportGridded:output_type -> cityaqrpc.ExportGriddedResponse
+	46, // 95: cityaqrpc.CityAQ.ReductionScenario:output_type -> cityaqrpc.ReductionScenarioResponse
+	81, // [81:96] is the sub-list for method output_type
+	66, // [66:81] is the sub-list for method input_type
+	66, // [66:66] is the sub-list for extension type_name
+	66, // [66:66] is the sub-list for extension extendee
+	0,  // [0:66] is the sub-list for field type_name
 }
 
 func init() { file_cityaq_proto_init() }
@@ -1724,8 +5163,236 @@ func file_cityaq_proto_init() {
 				return nil
 			}
 		}
-		file_cityaq_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*CitiesResponse); i {
+		file_cityaq_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CitiesResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_cityaq_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*City); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_cityaq_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CityGeometryRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_cityaq_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CityGeometryResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_cityaq_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CitiesContainingRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_cityaq_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CitiesContainingResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_cityaq_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*PointCities); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_cityaq_proto_msgTypes[8].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RegisterStudyAreaRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_cityaq_proto_msgTypes[9].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RegisterStudyAreaResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_cityaq_proto_msgTypes[10].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CityInfoRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_cityaq_proto_msgTypes[11].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CityInfoResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_cityaq_proto_msgTypes[12].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Polygon); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_cityaq_proto_msgTypes[13].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Path); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_cityaq_proto_msgTypes[14].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Point); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_cityaq_proto_msgTypes[15].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GriddedEmissionsRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_cityaq_proto_msgTypes[16].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*EmissionsTimeStep); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_cityaq_proto_msgTypes[17].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GriddedEmissionsResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_cityaq_proto_msgTypes[18].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*EmissionsDiagnostics); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_cityaq_proto_msgTypes[19].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*PollutantAmount); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_cityaq_proto_msgTypes[20].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CustomSurrogate); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1736,8 +5403,8 @@ func file_cityaq_proto_init() {
 				return nil
 			}
 		}
-		file_cityaq_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*CityGeometryRequest); i {
+		file_cityaq_proto_msgTypes[21].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*OSMTag); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1748,8 +5415,8 @@ func file_cityaq_proto_init() {
 				return nil
 			}
 		}
-		file_cityaq_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*CityGeometryResponse); i {
+		file_cityaq_proto_msgTypes[22].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SourceTypeWeight); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1760,8 +5427,8 @@ func file_cityaq_proto_init() {
 				return nil
 			}
 		}
-		file_cityaq_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*Polygon); i {
+		file_cityaq_proto_msgTypes[23].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*PointSource); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1772,8 +5439,8 @@ func file_cityaq_proto_init() {
 				return nil
 			}
 		}
-		file_cityaq_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*Path); i {
+		file_cityaq_proto_msgTypes[24].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*PollutantEmissions); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1784,8 +5451,8 @@ func file_cityaq_proto_init() {
 				return nil
 			}
 		}
-		file_cityaq_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*Point); i {
+		file_cityaq_proto_msgTypes[25].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GriddedConcentrationsRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1796,8 +5463,8 @@ func file_cityaq_proto_init() {
 				return nil
 			}
 		}
-		file_cityaq_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*GriddedEmissionsRequest); i {
+		file_cityaq_proto_msgTypes[26].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GriddedConcentrationsResponse); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1808,8 +5475,8 @@ func file_cityaq_proto_init() {
 				return nil
 			}
 		}
-		file_cityaq_proto_msgTypes[8].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*GriddedEmissionsResponse); i {
+		file_cityaq_proto_msgTypes[27].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GriddedPopulationRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1820,8 +5487,8 @@ func file_cityaq_proto_init() {
 				return nil
 			}
 		}
-		file_cityaq_proto_msgTypes[9].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*GriddedConcentrationsRequest); i {
+		file_cityaq_proto_msgTypes[28].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GriddedPopulationResponse); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1832,8 +5499,8 @@ func file_cityaq_proto_init() {
 				return nil
 			}
 		}
-		file_cityaq_proto_msgTypes[10].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*GriddedConcentrationsResponse); i {
+		file_cityaq_proto_msgTypes[29].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ImpactSummaryRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1844,8 +5511,8 @@ func file_cityaq_proto_init() {
 				return nil
 			}
 		}
-		file_cityaq_proto_msgTypes[11].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*GriddedPopulationRequest); i {
+		file_cityaq_proto_msgTypes[30].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ImpactSummaryResponse); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1856,8 +5523,8 @@ func file_cityaq_proto_init() {
 				return nil
 			}
 		}
-		file_cityaq_proto_msgTypes[12].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*GriddedPopulationResponse); i {
+		file_cityaq_proto_msgTypes[31].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*PollutantImpacts); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1868,8 +5535,8 @@ func file_cityaq_proto_init() {
 				return nil
 			}
 		}
-		file_cityaq_proto_msgTypes[13].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*ImpactSummaryRequest); i {
+		file_cityaq_proto_msgTypes[32].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*EmissionsGridBoundsRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1880,8 +5547,8 @@ func file_cityaq_proto_init() {
 				return nil
 			}
 		}
-		file_cityaq_proto_msgTypes[14].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*ImpactSummaryResponse); i {
+		file_cityaq_proto_msgTypes[33].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*EmissionsGridBoundsResponse); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1892,8 +5559,8 @@ func file_cityaq_proto_init() {
 				return nil
 			}
 		}
-		file_cityaq_proto_msgTypes[15].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*EmissionsGridBoundsRequest); i {
+		file_cityaq_proto_msgTypes[34].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*EmissionsInventorySectorsRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1904,8 +5571,8 @@ func file_cityaq_proto_init() {
 				return nil
 			}
 		}
-		file_cityaq_proto_msgTypes[16].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*EmissionsGridBoundsResponse); i {
+		file_cityaq_proto_msgTypes[35].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*EmissionsInventorySectorsResponse); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1916,8 +5583,8 @@ func file_cityaq_proto_init() {
 				return nil
 			}
 		}
-		file_cityaq_proto_msgTypes[17].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*EmissionsInventorySectorsRequest); i {
+		file_cityaq_proto_msgTypes[36].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SectorChange); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1928,8 +5595,8 @@ func file_cityaq_proto_init() {
 				return nil
 			}
 		}
-		file_cityaq_proto_msgTypes[18].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*EmissionsInventorySectorsResponse); i {
+		file_cityaq_proto_msgTypes[37].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ReductionScenarioRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1940,7 +5607,91 @@ func file_cityaq_proto_init() {
 				return nil
 			}
 		}
-		file_cityaq_proto_msgTypes[19].Exporter = func(v interface{}, i int) interface{} {
+		file_cityaq_proto_msgTypes[38].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ReductionScenarioResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_cityaq_proto_msgTypes[39].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SourceTypesRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_cityaq_proto_msgTypes[40].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SourceTypesResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_cityaq_proto_msgTypes[41].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SourceType); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_cityaq_proto_msgTypes[42].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*PollutantFraction); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_cityaq_proto_msgTypes[43].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ExportGriddedRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_cityaq_proto_msgTypes[44].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ExportGriddedResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_cityaq_proto_msgTypes[45].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*MapScaleRequest); i {
 			case 0:
 				return &v.state
@@ -1952,7 +5703,7 @@ func file_cityaq_proto_init() {
 				return nil
 			}
 		}
-		file_cityaq_proto_msgTypes[20].Exporter = func(v interface{}, i int) interface{} {
+		file_cityaq_proto_msgTypes[46].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*MapScaleResponse); i {
 			case 0:
 				return &v.state
@@ -1970,8 +5721,8 @@ func file_cityaq_proto_init() {
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_cityaq_proto_rawDesc,
-			NumEnums:      3,
-			NumMessages:   21,
+			NumEnums:      8,
+			NumMessages:   47,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
@@ -2002,10 +5753,27 @@ type CityAQClient interface {
 	Cities(ctx context.Context, in *CitiesRequest, opts ...grpc.CallOption) (*CitiesResponse, error)
 	// CityGeometry returns the boundary of the specified city.
 	CityGeometry(ctx context.Context, in *CityGeometryRequest, opts ...grpc.CallOption) (*CityGeometryResponse, error)
-	// GriddedEmissions returns the distribution within the city of
-	// 1 kilotonne of emissions.
+	// CityInfo returns information about the boundary of the specified city.
+	CityInfo(ctx context.Context, in *CityInfoRequest, opts ...grpc.CallOption) (*CityInfoResponse, error)
+	// CitiesContaining returns the cities whose boundaries contain
+	// each of the given points.
+	CitiesContaining(ctx context.Context, in *CitiesContainingRequest, opts ...grpc.CallOption) (*CitiesContainingResponse, error)
+	// RegisterStudyArea stores a custom study area boundary so that it can
+	// be used in other requests in the same way as a city.
+	RegisterStudyArea(ctx context.Context, in *RegisterStudyAreaRequest, opts ...grpc.CallOption) (*RegisterStudyAreaResponse, error)
+	// GriddedEmissions returns the distribution within the city of the
+	// requested amount of emissions, in kilograms emitted over the
+	// emissions period. By default, 1 kilotonne of emissions is distributed.
+	// For CityTotal and Total simulations, it returns the emissions
+	// inventory emissions of the SourceType sector instead.
 	GriddedEmissions(ctx context.Context, in *GriddedEmissionsRequest, opts ...grpc.CallOption) (*GriddedEmissionsResponse, error)
 	// EmissionsGridBounds returns the bounds of the grid used in GriddedEmissions.
+	// For source types in the "egugrid" allocation domain, the grid covers
+	// the region of the server's grid region file that the city is in, or,
+	// if there is none, the country that the city is in, or, if the country
+	// is larger than an average US NERC region, the part of the country
+	// within a 5.4 degree radius buffer around the city. For other source
+	// types, it covers the city.
 	EmissionsGridBounds(ctx context.Context, in *EmissionsGridBoundsRequest, opts ...grpc.CallOption) (*EmissionsGridBoundsResponse, error)
 	// GriddedConcentrations returns the concentrations resulting from the
 	// corresponding GriddedEmissions.
@@ -2019,6 +5787,17 @@ type CityAQClient interface {
 	// ImpactSummary returns a summary of the impacts from the given request.
 	ImpactSummary(ctx context.Context, in *ImpactSummaryRequest, opts ...grpc.CallOption) (*ImpactSummaryResponse, error)
 	EmissionsInventorySectors(ctx context.Context, in *EmissionsInventorySectorsRequest, opts ...grpc.CallOption) (*EmissionsInventorySectorsResponse, error)
+	// SourceTypes returns the source types that emissions can be
+	// allocated to in CityMarginal simulations.
+	SourceTypes(ctx context.Context, in *SourceTypesRequest, opts ...grpc.CallOption) (*SourceTypesResponse, error)
+	// ExportGridded returns gridded emissions, concentrations, or
+	// population as a file in a format that GIS software can read.
+	ExportGridded(ctx context.Context, in *ExportGriddedRequest, opts ...grpc.CallOption) (*ExportGriddedResponse, error)
+	// ReductionScenario returns the changes in PM2.5 concentrations and
+	// exposure that result from reducing or increasing the emissions of
+	// emissions inventory sectors within a city by given percentages,
+	// compared to a baseline CityTotal simulation of the same sectors.
+	ReductionScenario(ctx context.Context, in *ReductionScenarioRequest, opts ...grpc.CallOption) (*ReductionScenarioResponse, error)
 }
 
 type cityAQClient struct {
@@ -2047,6 +5826,33 @@ func (c *cityAQClient) CityGeometry(ctx context.Context, in *CityGeometryRequest
 	return out, nil
 }
 
+func (c *cityAQClient) CityInfo(ctx context.Context, in *CityInfoRequest, opts ...grpc.CallOption) (*CityInfoResponse, error) {
+	out := new(CityInfoResponse)
+	err := c.cc.Invoke(ctx, "/cityaqrpc.CityAQ/CityInfo", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *cityAQClient) CitiesContaining(ctx context.Context, in *CitiesContainingRequest, opts ...grpc.CallOption) (*CitiesContainingResponse, error) {
+	out := new(CitiesContainingResponse)
+	err := c.cc.Invoke(ctx, "/cityaqrpc.CityAQ/CitiesContaining", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *cityAQClient) RegisterStudyArea(ctx context.Context, in *RegisterStudyAreaRequest, opts ...grpc.CallOption) (*RegisterStudyAreaResponse, error) {
+	out := new(RegisterStudyAreaResponse)
+	err := c.cc.Invoke(ctx, "/cityaqrpc.CityAQ/RegisterStudyArea", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *cityAQClient) GriddedEmissions(ctx context.Context, in *GriddedEmissionsRequest, opts ...grpc.CallOption) (*GriddedEmissionsResponse, error) {
 	out := new(GriddedEmissionsResponse)
 	err := c.cc.Invoke(ctx, "/cityaqrpc.CityAQ/GriddedEmissions", in, out, opts...)
@@ -2110,16 +5916,60 @@ func (c *cityAQClient) EmissionsInventorySectors(ctx context.Context, in *Emissi
 	return out, nil
 }
 
+func (c *cityAQClient) SourceTypes(ctx context.Context, in *SourceTypesRequest, opts ...grpc.CallOption) (*SourceTypesResponse, error) {
+	out := new(SourceTypesResponse)
+	err := c.cc.Invoke(ctx, "/cityaqrpc.CityAQ/SourceTypes", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *cityAQClient) ExportGridded(ctx context.Context, in *ExportGriddedRequest, opts ...grpc.CallOption) (*ExportGriddedResponse, error) {
+	out := new(ExportGriddedResponse)
+	err := c.cc.Invoke(ctx, "/cityaqrpc.CityAQ/ExportGridded", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *cityAQClient) ReductionScenario(ctx context.Context, in *ReductionScenarioRequest, opts ...grpc.CallOption) (*ReductionScenarioResponse, error) {
+	out := new(ReductionScenarioResponse)
+	err := c.cc.Invoke(ctx, "/cityaqrpc.CityAQ/ReductionScenario", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // CityAQServer is the server API for CityAQ service.
 type CityAQServer interface {
 	// Cities returns the available cities.
 	Cities(context.Context, *CitiesRequest) (*CitiesResponse, error)
 	// CityGeometry returns the boundary of the specified city.
 	CityGeometry(context.Context, *CityGeometryRequest) (*CityGeometryResponse, error)
-	// GriddedEmissions returns the distribution within the city of
-	// 1 kilotonne of emissions.
+	// CityInfo returns information about the boundary of the specified city.
+	CityInfo(context.Context, *CityInfoRequest) (*CityInfoResponse, error)
+	// CitiesContaining returns the cities whose boundaries contain
+	// each of the given points.
+	CitiesContaining(context.Context, *CitiesContainingRequest) (*CitiesContainingResponse, error)
+	// RegisterStudyArea stores a custom study area boundary so that it can
+	// be used in other requests in the same way as a city.
+	RegisterStudyArea(context.Context, *RegisterStudyAreaRequest) (*RegisterStudyAreaResponse, error)
+	// GriddedEmissions returns the distribution within the city of the
+	// requested amount of emissions, in kilograms emitted over the
+	// emissions period. By default, 1 kilotonne of emissions is distributed.
+	// For CityTotal and Total simulations, it returns the emissions
+	// inventory emissions of the SourceType sector instead.
 	GriddedEmissions(context.Context, *GriddedEmissionsRequest) (*GriddedEmissionsResponse, error)
 	// EmissionsGridBounds returns the bounds of the grid used in GriddedEmissions.
+	// For source types in the "egugrid" allocation domain, the grid covers
+	// the region of the server's grid region file that the city is in, or,
+	// if there is none, the country that the city is in, or, if the country
+	// is larger than an average US NERC region, the part of the country
+	// within a 5.4 degree radius buffer around the city. For other source
+	// types, it covers the city.
 	EmissionsGridBounds(context.Context, *EmissionsGridBoundsRequest) (*EmissionsGridBoundsResponse, error)
 	// GriddedConcentrations returns the concentrations resulting from the
 	// corresponding GriddedEmissions.
@@ -2133,6 +5983,17 @@ type CityAQServer interface {
 	// ImpactSummary returns a summary of the impacts from the given request.
 	ImpactSummary(context.Context, *ImpactSummaryRequest) (*ImpactSummaryResponse, error)
 	EmissionsInventorySectors(context.Context, *EmissionsInventorySectorsRequest) (*EmissionsInventorySectorsResponse, error)
+	// SourceTypes returns the source types that emissions can be
+	// allocated to in CityMarginal simulations.
+	SourceTypes(context.Context, *SourceTypesRequest) (*SourceTypesResponse, error)
+	// ExportGridded returns gridded emissions, concentrations, or
+	// population as a file in a format that GIS software can read.
+	ExportGridded(context.Context, *ExportGriddedRequest) (*ExportGriddedResponse, error)
+	// ReductionScenario returns the changes in PM2.5 concentrations and
+	// exposure that result from reducing or increasing the emissions of
+	// emissions inventory sectors within a city by given percentages,
+	// compared to a baseline CityTotal simulation of the same sectors.
+	ReductionScenario(context.Context, *ReductionScenarioRequest) (*ReductionScenarioResponse, error)
 }
 
 // UnimplementedCityAQServer can be embedded to have forward compatible implementations.
@@ -2145,6 +6006,15 @@ func (*UnimplementedCityAQServer) Cities(context.Context, *CitiesRequest) (*Citi
 func (*UnimplementedCityAQServer) CityGeometry(context.Context, *CityGeometryRequest) (*CityGeometryResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method CityGeometry not implemented")
 }
+func (*UnimplementedCityAQServer) CityInfo(context.Context, *CityInfoRequest) (*CityInfoResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CityInfo not implemented")
+}
+func (*UnimplementedCityAQServer) CitiesContaining(context.Context, *CitiesContainingRequest) (*CitiesContainingResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CitiesContaining not implemented")
+}
+func (*UnimplementedCityAQServer) RegisterStudyArea(context.Context, *RegisterStudyAreaRequest) (*RegisterStudyAreaResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RegisterStudyArea not implemented")
+}
 func (*UnimplementedCityAQServer) GriddedEmissions(context.Context, *GriddedEmissionsRequest) (*GriddedEmissionsResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GriddedEmissions not implemented")
 }
@@ -2166,6 +6036,15 @@ func (*UnimplementedCityAQServer) ImpactSummary(context.Context, *ImpactSummaryR
 func (*UnimplementedCityAQServer) EmissionsInventorySectors(context.Context, *EmissionsInventorySectorsRequest) (*EmissionsInventorySectorsResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method EmissionsInventorySectors not implemented")
 }
+func (*UnimplementedCityAQServer) SourceTypes(context.Context, *SourceTypesRequest) (*SourceTypesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SourceTypes not implemented")
+}
+func (*UnimplementedCityAQServer) ExportGridded(context.Context, *ExportGriddedRequest) (*ExportGriddedResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ExportGridded not implemented")
+}
+func (*UnimplementedCityAQServer) ReductionScenario(context.Context, *ReductionScenarioRequest) (*ReductionScenarioResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ReductionScenario not implemented")
+}
 
 func RegisterCityAQServer(s *grpc.Server, srv CityAQServer) {
 	s.RegisterService(&_CityAQ_serviceDesc, srv)
@@ -2207,6 +6086,60 @@ func _CityAQ_CityGeometry_Handler(srv interface{}, ctx context.Context, dec func
 	return interceptor(ctx, in, info, handler)
 }
 
+func _CityAQ_CityInfo_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CityInfoRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CityAQServer).CityInfo(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/cityaqrpc.CityAQ/CityInfo",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CityAQServer).CityInfo(ctx, req.(*CityInfoRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CityAQ_CitiesContaining_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CitiesContainingRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CityAQServer).CitiesContaining(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/cityaqrpc.CityAQ/CitiesContaining",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CityAQServer).CitiesContaining(ctx, req.(*CitiesContainingRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CityAQ_RegisterStudyArea_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RegisterStudyAreaRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CityAQServer).RegisterStudyArea(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/cityaqrpc.CityAQ/RegisterStudyArea",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CityAQServer).RegisterStudyArea(ctx, req.(*RegisterStudyAreaRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _CityAQ_GriddedEmissions_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(GriddedEmissionsRequest)
 	if err := dec(in); err != nil {
@@ -2333,6 +6266,60 @@ func _CityAQ_EmissionsInventorySectors_Handler(srv interface{}, ctx context.Cont
 	return interceptor(ctx, in, info, handler)
 }
 
+func _CityAQ_SourceTypes_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SourceTypesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CityAQServer).SourceTypes(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/cityaqrpc.CityAQ/SourceTypes",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CityAQServer).SourceTypes(ctx, req.(*SourceTypesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CityAQ_ExportGridded_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ExportGriddedRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CityAQServer).ExportGridded(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/cityaqrpc.CityAQ/ExportGridded",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CityAQServer).ExportGridded(ctx, req.(*ExportGriddedRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CityAQ_ReductionScenario_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReductionScenarioRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CityAQServer).ReductionScenario(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/cityaqrpc.CityAQ/ReductionScenario",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CityAQServer).ReductionScenario(ctx, req.(*ReductionScenarioRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 var _CityAQ_serviceDesc = grpc.ServiceDesc{
 	ServiceName: "cityaqrpc.CityAQ",
 	HandlerType: (*CityAQServer)(nil),
@@ -2345,6 +6332,18 @@ var _CityAQ_serviceDesc = grpc.ServiceDesc{
 			MethodName: "CityGeometry",
 			Handler:    _CityAQ_CityGeometry_Handler,
 		},
+		{
+			MethodName: "CityInfo",
+			Handler:    _CityAQ_CityInfo_Handler,
+		},
+		{
+			MethodName: "CitiesContaining",
+			Handler:    _CityAQ_CitiesContaining_Handler,
+		},
+		{
+			MethodName: "RegisterStudyArea",
+			Handler:    _CityAQ_RegisterStudyArea_Handler,
+		},
 		{
 			MethodName: "GriddedEmissions",
 			Handler:    _CityAQ_GriddedEmissions_Handler,
@@ -2373,6 +6372,18 @@ var _CityAQ_serviceDesc = grpc.ServiceDesc{
 			MethodName: "EmissionsInventorySectors",
 			Handler:    _CityAQ_EmissionsInventorySectors_Handler,
 		},
+		{
+			MethodName: "SourceTypes",
+			Handler:    _CityAQ_SourceTypes_Handler,
+		},
+		{
+			MethodName: "ExportGridded",
+			Handler:    _CityAQ_ExportGridded_Handler,
+		},
+		{
+			MethodName: "ReductionScenario",
+			Handler:    _CityAQ_ReductionScenario_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "cityaq.proto",
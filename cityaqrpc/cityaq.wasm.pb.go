@@ -25,6 +25,106 @@ var _ = math.Inf
 // proto package needs to be updated.
 const _ = proto.ProtoPackageIsVersion2 // please upgrade the proto package
 
+// TemporalResolution is the length of the time steps of
+// time-resolved emissions. Days and months are in the local
+// time of the city, including daylight saving time, from the IANA
+// time zone in the "timezone" property of its boundary.
+type TemporalResolution int32
+
+const (
+	TemporalResolution_UNKNOWN_TEMPORALRESOLUTION TemporalResolution = 0
+	TemporalResolution_Hourly                     TemporalResolution = 1
+	TemporalResolution_Daily                      TemporalResolution = 2
+	TemporalResolution_Monthly                    TemporalResolution = 3
+)
+
+var TemporalResolution_name = map[int32]string{
+	0: "UNKNOWN_TEMPORALRESOLUTION",
+	1: "Hourly",
+	2: "Daily",
+	3: "Monthly",
+}
+var TemporalResolution_value = map[string]int32{
+	"UNKNOWN_TEMPORALRESOLUTION": 0,
+	"Hourly":                     1,
+	"Daily":                      2,
+	"Monthly":                    3,
+}
+
+func (x TemporalResolution) String() string {
+	return proto.EnumName(TemporalResolution_name, int32(x))
+}
+func (TemporalResolution) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_cityaq_067e2154450e2bec, []int{0}
+}
+
+// SurrogateWeight specifies how spatial surrogate features are weighted.
+type SurrogateWeight int32
+
+const (
+	SurrogateWeight_UNKNOWN_SURROGATEWEIGHT SurrogateWeight = 0
+	// Length weights ways by their length. Nodes are not included.
+	SurrogateWeight_Length SurrogateWeight = 1
+	// Area weights closed ways by their area.
+	// Nodes and open ways are not included.
+	SurrogateWeight_Area SurrogateWeight = 2
+	// Count gives each node and way a weight of one,
+	// located at its center.
+	SurrogateWeight_Count SurrogateWeight = 3
+)
+
+var SurrogateWeight_name = map[int32]string{
+	0: "UNKNOWN_SURROGATEWEIGHT",
+	1: "Length",
+	2: "Area",
+	3: "Count",
+}
+var SurrogateWeight_value = map[string]int32{
+	"UNKNOWN_SURROGATEWEIGHT": 0,
+	"Length":                  1,
+	"Area":                    2,
+	"Count":                   3,
+}
+
+func (x SurrogateWeight) String() string {
+	return proto.EnumName(SurrogateWeight_name, int32(x))
+}
+func (SurrogateWeight) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_cityaq_067e2154450e2bec, []int{1}
+}
+
+// AllocationDomain is an area that emissions are allocated within.
+type AllocationDomain int32
+
+const (
+	AllocationDomain_UNKNOWN_ALLOCATIONDOMAIN AllocationDomain = 0
+	// City is the city boundary.
+	AllocationDomain_City AllocationDomain = 1
+	// EGUGrid is the electric grid region that the city is in, if the
+	// server has a file of grid regions and one of them overlaps the city.
+	// Otherwise, it is the smaller of the country that the city is in or a
+	// buffer around the city the size of an average electric grid region.
+	AllocationDomain_EGUGrid AllocationDomain = 2
+)
+
+var AllocationDomain_name = map[int32]string{
+	0: "UNKNOWN_ALLOCATIONDOMAIN",
+	1: "City",
+	2: "EGUGrid",
+}
+var AllocationDomain_value = map[string]int32{
+	"UNKNOWN_ALLOCATIONDOMAIN": 0,
+	"City":                     1,
+	"EGUGrid":                  2,
+}
+
+func (x AllocationDomain) String() string {
+	return proto.EnumName(AllocationDomain_name, int32(x))
+}
+func (AllocationDomain) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_cityaq_067e2154450e2bec, []int{2}
+}
+
 type Emission int32
 
 const (
@@ -57,7 +157,90 @@ func (x Emission) String() string {
 	return proto.EnumName(Emission_name, int32(x))
 }
 func (Emission) EnumDescriptor() ([]byte, []int) {
-	return fileDescriptor_cityaq_5d59c783e971c48c, []int{0}
+	return fileDescriptor_cityaq_067e2154450e2bec, []int{3}
+}
+
+// EmissionUnits are the units of an amount of emissions.
+type EmissionUnits int32
+
+const (
+	EmissionUnits_UNKNOWN_EMISSIONUNITS EmissionUnits = 0
+	// Kilograms, Tonnes, and Kilotonnes are the total mass
+	// emitted over the emissions period.
+	EmissionUnits_Kilograms  EmissionUnits = 1
+	EmissionUnits_Tonnes     EmissionUnits = 2
+	EmissionUnits_Kilotonnes EmissionUnits = 3
+	// MegagramsPerYear is the rate of emissions during the
+	// emissions period, where a year is 365 days long.
+	EmissionUnits_MegagramsPerYear EmissionUnits = 4
+)
+
+var EmissionUnits_name = map[int32]string{
+	0: "UNKNOWN_EMISSIONUNITS",
+	1: "Kilograms",
+	2: "Tonnes",
+	3: "Kilotonnes",
+	4: "MegagramsPerYear",
+}
+var EmissionUnits_value = map[string]int32{
+	"UNKNOWN_EMISSIONUNITS": 0,
+	"Kilograms":             1,
+	"Tonnes":                2,
+	"Kilotonnes":            3,
+	"MegagramsPerYear":      4,
+}
+
+func (x EmissionUnits) String() string {
+	return proto.EnumName(EmissionUnits_name, int32(x))
+}
+func (EmissionUnits) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_cityaq_067e2154450e2bec, []int{4}
+}
+
+// ExportFormat is a file format for exported gridded data. All formats
+// include the units of each variable and information about how the
+// data were created.
+type ExportFormat int32
+
+const (
+	ExportFormat_UNKNOWN_EXPORTFORMAT ExportFormat = 0
+	// GeoTIFF is a raster with one band per variable. It can only be
+	// used when the grid cells are aligned to a regular longitude-latitude
+	// raster; cells that are larger than the raster cells are split.
+	ExportFormat_GeoTIFF ExportFormat = 1
+	// NetCDF is a CF-compliant NetCDF file.
+	ExportFormat_NetCDF ExportFormat = 2
+	// Shapefile is a zipped ESRI shapefile.
+	ExportFormat_Shapefile ExportFormat = 3
+	// GeoJSON is a GeoJSON feature collection.
+	ExportFormat_GeoJSON ExportFormat = 4
+	// CSV is a comma-separated values file with the geometry of each grid
+	// cell in well-known text (WKT) format.
+	ExportFormat_CSV ExportFormat = 5
+)
+
+var ExportFormat_name = map[int32]string{
+	0: "UNKNOWN_EXPORTFORMAT",
+	1: "GeoTIFF",
+	2: "NetCDF",
+	3: "Shapefile",
+	4: "GeoJSON",
+	5: "CSV",
+}
+var ExportFormat_value = map[string]int32{
+	"UNKNOWN_EXPORTFORMAT": 0,
+	"GeoTIFF":              1,
+	"NetCDF":               2,
+	"Shapefile":            3,
+	"GeoJSON":              4,
+	"CSV":                  5,
+}
+
+func (x ExportFormat) String() string {
+	return proto.EnumName(ExportFormat_name, int32(x))
+}
+func (ExportFormat) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_cityaq_067e2154450e2bec, []int{5}
 }
 
 type ImpactType int32
@@ -83,7 +266,7 @@ func (x ImpactType) String() string {
 	return proto.EnumName(ImpactType_name, int32(x))
 }
 func (ImpactType) EnumDescriptor() ([]byte, []int) {
-	return fileDescriptor_cityaq_5d59c783e971c48c, []int{1}
+	return fileDescriptor_cityaq_067e2154450e2bec, []int{6}
 }
 
 type SimulationType int32
@@ -112,10 +295,35 @@ func (x SimulationType) String() string {
 	return proto.EnumName(SimulationType_name, int32(x))
 }
 func (SimulationType) EnumDescriptor() ([]byte, []int) {
-	return fileDescriptor_cityaq_5d59c783e971c48c, []int{2}
+	return fileDescriptor_cityaq_067e2154450e2bec, []int{7}
 }
 
 type CitiesRequest struct {
+	// Language is the code of the language that city names should be
+	// displayed in, for example "es", "pt-BR", or "fr". If it is empty,
+	// or a name is not available in the requested language, the default
+	// name of each city is used.
+	Language string `protobuf:"bytes,1,opt,name=Language,proto3" json:"Language,omitempty"`
+	// Query, if specified, limits the results to cities whose ID,
+	// default name, or display name contains it, ignoring case and
+	// accents. Names that differ from the query by a small number of
+	// typing errors also match.
+	Query string `protobuf:"bytes,2,opt,name=Query,proto3" json:"Query,omitempty"`
+	// Country, if specified, limits the results to cities in the
+	// country with this name, ignoring case.
+	Country string `protobuf:"bytes,3,opt,name=Country,proto3" json:"Country,omitempty"`
+	// Min and Max, if specified, are the south-west and north-east
+	// corners of a longitude-latitude bounding box. Only cities whose
+	// boundaries overlap the box are returned.
+	Min *Point `protobuf:"bytes,4,opt,name=Min,proto3" json:"Min,omitempty"`
+	Max *Point `protobuf:"bytes,5,opt,name=Max,proto3" json:"Max,omitempty"`
+	// PageSize is the maximum number of cities to return. If it is
+	// zero, all matching cities are returned.
+	PageSize int32 `protobuf:"varint,6,opt,name=PageSize,proto3" json:"PageSize,omitempty"`
+	// PageToken is the NextPageToken from a previous response, which
+	// is used to request the next page of results. The other fields of
+	// the request must be the same as in the previous request.
+	PageToken            string   `protobuf:"bytes,7,opt,name=PageToken,proto3" json:"PageToken,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -125,7 +333,7 @@ func (m *CitiesRequest) Reset()         { *m = CitiesRequest{} }
 func (m *CitiesRequest) String() string { return proto.CompactTextString(m) }
 func (*CitiesRequest) ProtoMessage()    {}
 func (*CitiesRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_cityaq_5d59c783e971c48c, []int{0}
+	return fileDescriptor_cityaq_067e2154450e2bec, []int{0}
 }
 func (m *CitiesRequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_CitiesRequest.Unmarshal(m, b)
@@ -145,9 +353,67 @@ func (m *CitiesRequest) XXX_DiscardUnknown() {
 
 var xxx_messageInfo_CitiesRequest proto.InternalMessageInfo
 
+func (m *CitiesRequest) GetLanguage() string {
+	if m != nil {
+		return m.Language
+	}
+	return ""
+}
+
+func (m *CitiesRequest) GetQuery() string {
+	if m != nil {
+		return m.Query
+	}
+	return ""
+}
+
+func (m *CitiesRequest) GetCountry() string {
+	if m != nil {
+		return m.Country
+	}
+	return ""
+}
+
+func (m *CitiesRequest) GetMin() *Point {
+	if m != nil {
+		return m.Min
+	}
+	return nil
+}
+
+func (m *CitiesRequest) GetMax() *Point {
+	if m != nil {
+		return m.Max
+	}
+	return nil
+}
+
+func (m *CitiesRequest) GetPageSize() int32 {
+	if m != nil {
+		return m.PageSize
+	}
+	return 0
+}
+
+func (m *CitiesRequest) GetPageToken() string {
+	if m != nil {
+		return m.PageToken
+	}
+	return ""
+}
+
 type CitiesResponse struct {
-	// The names of the cities
-	Names                []string `protobuf:"bytes,1,rep,name=Names,proto3" json:"Names,omitempty"`
+	// The default names of the cities, in the same order as Cities.
+	// Names can be used to specify cities in other requests, but IDs are
+	// preferred because they don't change when a city is renamed.
+	Names []string `protobuf:"bytes,1,rep,name=Names,proto3" json:"Names,omitempty"`
+	// Cities holds information about each city.
+	Cities []*City `protobuf:"bytes,2,rep,name=Cities,proto3" json:"Cities,omitempty"`
+	// NextPageToken can be used to request the next page of results.
+	// It is empty if there are no more results.
+	NextPageToken string `protobuf:"bytes,3,opt,name=NextPageToken,proto3" json:"NextPageToken,omitempty"`
+	// TotalSize is the total number of cities matching the request.
+	TotalSize            int32    `protobuf:"varint,4,opt,name=TotalSize,proto3" json:"TotalSize,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -157,7 +423,7 @@ func (m *CitiesResponse) Reset()         { *m = CitiesResponse{} }
 func (m *CitiesResponse) String() string { return proto.CompactTextString(m) }
 func (*CitiesResponse) ProtoMessage()    {}
 func (*CitiesResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_cityaq_5d59c783e971c48c, []int{1}
+	return fileDescriptor_cityaq_067e2154450e2bec, []int{1}
 }
 func (m *CitiesResponse) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_CitiesResponse.Unmarshal(m, b)
@@ -184,8 +450,105 @@ func (m *CitiesResponse) GetNames() []string {
 	return nil
 }
 
+func (m *CitiesResponse) GetCities() []*City {
+	if m != nil {
+		return m.Cities
+	}
+	return nil
+}
+
+func (m *CitiesResponse) GetNextPageToken() string {
+	if m != nil {
+		return m.NextPageToken
+	}
+	return ""
+}
+
+func (m *CitiesResponse) GetTotalSize() int32 {
+	if m != nil {
+		return m.TotalSize
+	}
+	return 0
+}
+
+// City identifies a city.
+type City struct {
+	// ID is the stable identifier of the city, which is used to
+	// specify the city in other requests.
+	ID string `protobuf:"bytes,1,opt,name=ID,proto3" json:"ID,omitempty"`
+	// DisplayName is the name of the city in the requested language.
+	DisplayName string `protobuf:"bytes,2,opt,name=DisplayName,proto3" json:"DisplayName,omitempty"`
+	// Country is the name of the country that the city is in, or an
+	// empty string if it is not known. It is given by the "country" or
+	// "is_in:country" property of the boundary file if present, and
+	// otherwise by the country that the boundary overlaps the most.
+	Country string `protobuf:"bytes,3,opt,name=Country,proto3" json:"Country,omitempty"`
+	// Region is the region of the world that the city is in, as given
+	// by the "region", "c40_region", or "is_in:continent" property of
+	// its boundary file, or an empty string if it is not known.
+	Region               string   `protobuf:"bytes,4,opt,name=Region,proto3" json:"Region,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *City) Reset()         { *m = City{} }
+func (m *City) String() string { return proto.CompactTextString(m) }
+func (*City) ProtoMessage()    {}
+func (*City) Descriptor() ([]byte, []int) {
+	return fileDescriptor_cityaq_067e2154450e2bec, []int{2}
+}
+func (m *City) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_City.Unmarshal(m, b)
+}
+func (m *City) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_City.Marshal(b, m, deterministic)
+}
+func (dst *City) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_City.Merge(dst, src)
+}
+func (m *City) XXX_Size() int {
+	return xxx_messageInfo_City.Size(m)
+}
+func (m *City) XXX_DiscardUnknown() {
+	xxx_messageInfo_City.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_City proto.InternalMessageInfo
+
+func (m *City) GetID() string {
+	if m != nil {
+		return m.ID
+	}
+	return ""
+}
+
+func (m *City) GetDisplayName() string {
+	if m != nil {
+		return m.DisplayName
+	}
+	return ""
+}
+
+func (m *City) GetCountry() string {
+	if m != nil {
+		return m.Country
+	}
+	return ""
+}
+
+func (m *City) GetRegion() string {
+	if m != nil {
+		return m.Region
+	}
+	return ""
+}
+
 type CityGeometryRequest struct {
-	CityName             string   `protobuf:"bytes,1,opt,name=CityName,proto3" json:"CityName,omitempty"`
+	CityName string `protobuf:"bytes,1,opt,name=CityName,proto3" json:"CityName,omitempty"`
+	// Language is the code of the language that the city name should be
+	// displayed in. See CitiesRequest.Language.
+	Language             string   `protobuf:"bytes,2,opt,name=Language,proto3" json:"Language,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -195,7 +558,7 @@ func (m *CityGeometryRequest) Reset()         { *m = CityGeometryRequest{} }
 func (m *CityGeometryRequest) String() string { return proto.CompactTextString(m) }
 func (*CityGeometryRequest) ProtoMessage()    {}
 func (*CityGeometryRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_cityaq_5d59c783e971c48c, []int{2}
+	return fileDescriptor_cityaq_067e2154450e2bec, []int{3}
 }
 func (m *CityGeometryRequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_CityGeometryRequest.Unmarshal(m, b)
@@ -222,18 +585,27 @@ func (m *CityGeometryRequest) GetCityName() string {
 	return ""
 }
 
+func (m *CityGeometryRequest) GetLanguage() string {
+	if m != nil {
+		return m.Language
+	}
+	return ""
+}
+
 type CityGeometryResponse struct {
-	Polygons             []*Polygon `protobuf:"bytes,1,rep,name=Polygons,proto3" json:"Polygons,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}   `json:"-"`
-	XXX_unrecognized     []byte     `json:"-"`
-	XXX_sizecache        int32      `json:"-"`
+	Polygons []*Polygon `protobuf:"bytes,1,rep,name=Polygons,proto3" json:"Polygons,omitempty"`
+	// DisplayName is the name of the city in the requested language.
+	DisplayName          string   `protobuf:"bytes,2,opt,name=DisplayName,proto3" json:"DisplayName,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
 func (m *CityGeometryResponse) Reset()         { *m = CityGeometryResponse{} }
 func (m *CityGeometryResponse) String() string { return proto.CompactTextString(m) }
 func (*CityGeometryResponse) ProtoMessage()    {}
 func (*CityGeometryResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_cityaq_5d59c783e971c48c, []int{3}
+	return fileDescriptor_cityaq_067e2154450e2bec, []int{4}
 }
 func (m *CityGeometryResponse) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_CityGeometryResponse.Unmarshal(m, b)
@@ -260,172 +632,641 @@ func (m *CityGeometryResponse) GetPolygons() []*Polygon {
 	return nil
 }
 
-type Polygon struct {
-	Paths                []*Path  `protobuf:"bytes,1,rep,name=Paths,proto3" json:"Paths,omitempty"`
+func (m *CityGeometryResponse) GetDisplayName() string {
+	if m != nil {
+		return m.DisplayName
+	}
+	return ""
+}
+
+type CitiesContainingRequest struct {
+	// Points are the longitude-latitude locations to look up.
+	Points []*Point `protobuf:"bytes,1,rep,name=Points,proto3" json:"Points,omitempty"`
+	// Language is the code of the language that city names should be
+	// displayed in, as in CitiesRequest.
+	Language             string   `protobuf:"bytes,2,opt,name=Language,proto3" json:"Language,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *Polygon) Reset()         { *m = Polygon{} }
-func (m *Polygon) String() string { return proto.CompactTextString(m) }
-func (*Polygon) ProtoMessage()    {}
-func (*Polygon) Descriptor() ([]byte, []int) {
-	return fileDescriptor_cityaq_5d59c783e971c48c, []int{4}
+func (m *CitiesContainingRequest) Reset()         { *m = CitiesContainingRequest{} }
+func (m *CitiesContainingRequest) String() string { return proto.CompactTextString(m) }
+func (*CitiesContainingRequest) ProtoMessage()    {}
+func (*CitiesContainingRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_cityaq_067e2154450e2bec, []int{5}
 }
-func (m *Polygon) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_Polygon.Unmarshal(m, b)
+func (m *CitiesContainingRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CitiesContainingRequest.Unmarshal(m, b)
 }
-func (m *Polygon) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_Polygon.Marshal(b, m, deterministic)
+func (m *CitiesContainingRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CitiesContainingRequest.Marshal(b, m, deterministic)
 }
-func (dst *Polygon) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_Polygon.Merge(dst, src)
+func (dst *CitiesContainingRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CitiesContainingRequest.Merge(dst, src)
 }
-func (m *Polygon) XXX_Size() int {
-	return xxx_messageInfo_Polygon.Size(m)
+func (m *CitiesContainingRequest) XXX_Size() int {
+	return xxx_messageInfo_CitiesContainingRequest.Size(m)
 }
-func (m *Polygon) XXX_DiscardUnknown() {
-	xxx_messageInfo_Polygon.DiscardUnknown(m)
+func (m *CitiesContainingRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_CitiesContainingRequest.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_Polygon proto.InternalMessageInfo
+var xxx_messageInfo_CitiesContainingRequest proto.InternalMessageInfo
 
-func (m *Polygon) GetPaths() []*Path {
+func (m *CitiesContainingRequest) GetPoints() []*Point {
 	if m != nil {
-		return m.Paths
+		return m.Points
 	}
 	return nil
 }
 
-type Path struct {
-	Points               []*Point `protobuf:"bytes,1,rep,name=Points,proto3" json:"Points,omitempty"`
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
+func (m *CitiesContainingRequest) GetLanguage() string {
+	if m != nil {
+		return m.Language
+	}
+	return ""
 }
 
-func (m *Path) Reset()         { *m = Path{} }
-func (m *Path) String() string { return proto.CompactTextString(m) }
-func (*Path) ProtoMessage()    {}
-func (*Path) Descriptor() ([]byte, []int) {
-	return fileDescriptor_cityaq_5d59c783e971c48c, []int{5}
+type CitiesContainingResponse struct {
+	// Points holds the cities containing each of the requested
+	// points, in the same order as the request.
+	Points               []*PointCities `protobuf:"bytes,1,rep,name=Points,proto3" json:"Points,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}       `json:"-"`
+	XXX_unrecognized     []byte         `json:"-"`
+	XXX_sizecache        int32          `json:"-"`
 }
-func (m *Path) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_Path.Unmarshal(m, b)
+
+func (m *CitiesContainingResponse) Reset()         { *m = CitiesContainingResponse{} }
+func (m *CitiesContainingResponse) String() string { return proto.CompactTextString(m) }
+func (*CitiesContainingResponse) ProtoMessage()    {}
+func (*CitiesContainingResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_cityaq_067e2154450e2bec, []int{6}
 }
-func (m *Path) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_Path.Marshal(b, m, deterministic)
+func (m *CitiesContainingResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CitiesContainingResponse.Unmarshal(m, b)
 }
-func (dst *Path) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_Path.Merge(dst, src)
+func (m *CitiesContainingResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CitiesContainingResponse.Marshal(b, m, deterministic)
 }
-func (m *Path) XXX_Size() int {
-	return xxx_messageInfo_Path.Size(m)
+func (dst *CitiesContainingResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CitiesContainingResponse.Merge(dst, src)
 }
-func (m *Path) XXX_DiscardUnknown() {
-	xxx_messageInfo_Path.DiscardUnknown(m)
+func (m *CitiesContainingResponse) XXX_Size() int {
+	return xxx_messageInfo_CitiesContainingResponse.Size(m)
+}
+func (m *CitiesContainingResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_CitiesContainingResponse.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_Path proto.InternalMessageInfo
+var xxx_messageInfo_CitiesContainingResponse proto.InternalMessageInfo
 
-func (m *Path) GetPoints() []*Point {
+func (m *CitiesContainingResponse) GetPoints() []*PointCities {
 	if m != nil {
 		return m.Points
 	}
 	return nil
 }
 
-type Point struct {
-	X                    float64  `protobuf:"fixed64,1,opt,name=X,proto3" json:"X,omitempty"`
-	Y                    float64  `protobuf:"fixed64,2,opt,name=Y,proto3" json:"Y,omitempty"`
+// PointCities holds the cities that contain a point.
+type PointCities struct {
+	// Cities holds the cities whose boundaries contain the point,
+	// sorted by display name. It is empty if the point is not in
+	// any city. Boundaries of different cities can overlap, so a
+	// point can be in more than one city.
+	Cities               []*City  `protobuf:"bytes,1,rep,name=Cities,proto3" json:"Cities,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *Point) Reset()         { *m = Point{} }
-func (m *Point) String() string { return proto.CompactTextString(m) }
-func (*Point) ProtoMessage()    {}
-func (*Point) Descriptor() ([]byte, []int) {
-	return fileDescriptor_cityaq_5d59c783e971c48c, []int{6}
+func (m *PointCities) Reset()         { *m = PointCities{} }
+func (m *PointCities) String() string { return proto.CompactTextString(m) }
+func (*PointCities) ProtoMessage()    {}
+func (*PointCities) Descriptor() ([]byte, []int) {
+	return fileDescriptor_cityaq_067e2154450e2bec, []int{7}
 }
-func (m *Point) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_Point.Unmarshal(m, b)
+func (m *PointCities) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_PointCities.Unmarshal(m, b)
 }
-func (m *Point) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_Point.Marshal(b, m, deterministic)
+func (m *PointCities) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_PointCities.Marshal(b, m, deterministic)
 }
-func (dst *Point) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_Point.Merge(dst, src)
+func (dst *PointCities) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PointCities.Merge(dst, src)
 }
-func (m *Point) XXX_Size() int {
-	return xxx_messageInfo_Point.Size(m)
+func (m *PointCities) XXX_Size() int {
+	return xxx_messageInfo_PointCities.Size(m)
 }
-func (m *Point) XXX_DiscardUnknown() {
-	xxx_messageInfo_Point.DiscardUnknown(m)
+func (m *PointCities) XXX_DiscardUnknown() {
+	xxx_messageInfo_PointCities.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_Point proto.InternalMessageInfo
-
-func (m *Point) GetX() float64 {
-	if m != nil {
-		return m.X
-	}
-	return 0
-}
+var xxx_messageInfo_PointCities proto.InternalMessageInfo
 
-func (m *Point) GetY() float64 {
+func (m *PointCities) GetCities() []*City {
 	if m != nil {
-		return m.Y
+		return m.Cities
 	}
-	return 0
+	return nil
 }
 
-type GriddedEmissionsRequest struct {
-	CityName             string         `protobuf:"bytes,1,opt,name=CityName,proto3" json:"CityName,omitempty"`
-	SourceType           string         `protobuf:"bytes,2,opt,name=SourceType,proto3" json:"SourceType,omitempty"`
-	Emission             Emission       `protobuf:"varint,3,opt,name=Emission,proto3,enum=cityaqrpc.Emission" json:"Emission,omitempty"`
-	SimulationType       SimulationType `protobuf:"varint,4,opt,name=SimulationType,proto3,enum=cityaqrpc.SimulationType" json:"SimulationType,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}       `json:"-"`
-	XXX_unrecognized     []byte         `json:"-"`
-	XXX_sizecache        int32          `json:"-"`
+type RegisterStudyAreaRequest struct {
+	// Name is the name of the study area.
+	Name string `protobuf:"bytes,1,opt,name=Name,proto3" json:"Name,omitempty"`
+	// GeoJSON is the boundary of the study area as a GeoJSON Polygon or
+	// MultiPolygon geometry, or a Feature or FeatureCollection containing
+	// such geometries, with longitude and latitude coordinates.
+	GeoJSON string `protobuf:"bytes,2,opt,name=GeoJSON,proto3" json:"GeoJSON,omitempty"`
+	// WKT is the boundary of the study area as a Well-Known Text POLYGON
+	// or MULTIPOLYGON with longitude and latitude coordinates. Only one of
+	// GeoJSON and WKT should be specified. The boundary can have at most
+	// 100,000 points.
+	WKT                  string   `protobuf:"bytes,3,opt,name=WKT,proto3" json:"WKT,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *GriddedEmissionsRequest) Reset()         { *m = GriddedEmissionsRequest{} }
-func (m *GriddedEmissionsRequest) String() string { return proto.CompactTextString(m) }
-func (*GriddedEmissionsRequest) ProtoMessage()    {}
-func (*GriddedEmissionsRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_cityaq_5d59c783e971c48c, []int{7}
+func (m *RegisterStudyAreaRequest) Reset()         { *m = RegisterStudyAreaRequest{} }
+func (m *RegisterStudyAreaRequest) String() string { return proto.CompactTextString(m) }
+func (*RegisterStudyAreaRequest) ProtoMessage()    {}
+func (*RegisterStudyAreaRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_cityaq_067e2154450e2bec, []int{8}
 }
-func (m *GriddedEmissionsRequest) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_GriddedEmissionsRequest.Unmarshal(m, b)
+func (m *RegisterStudyAreaRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RegisterStudyAreaRequest.Unmarshal(m, b)
 }
-func (m *GriddedEmissionsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_GriddedEmissionsRequest.Marshal(b, m, deterministic)
+func (m *RegisterStudyAreaRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RegisterStudyAreaRequest.Marshal(b, m, deterministic)
 }
-func (dst *GriddedEmissionsRequest) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_GriddedEmissionsRequest.Merge(dst, src)
+func (dst *RegisterStudyAreaRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RegisterStudyAreaRequest.Merge(dst, src)
 }
-func (m *GriddedEmissionsRequest) XXX_Size() int {
-	return xxx_messageInfo_GriddedEmissionsRequest.Size(m)
+func (m *RegisterStudyAreaRequest) XXX_Size() int {
+	return xxx_messageInfo_RegisterStudyAreaRequest.Size(m)
 }
-func (m *GriddedEmissionsRequest) XXX_DiscardUnknown() {
-	xxx_messageInfo_GriddedEmissionsRequest.DiscardUnknown(m)
+func (m *RegisterStudyAreaRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_RegisterStudyAreaRequest.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_GriddedEmissionsRequest proto.InternalMessageInfo
+var xxx_messageInfo_RegisterStudyAreaRequest proto.InternalMessageInfo
 
-func (m *GriddedEmissionsRequest) GetCityName() string {
+func (m *RegisterStudyAreaRequest) GetName() string {
 	if m != nil {
-		return m.CityName
+		return m.Name
 	}
 	return ""
 }
 
-func (m *GriddedEmissionsRequest) GetSourceType() string {
+func (m *RegisterStudyAreaRequest) GetGeoJSON() string {
 	if m != nil {
-		return m.SourceType
+		return m.GeoJSON
+	}
+	return ""
+}
+
+func (m *RegisterStudyAreaRequest) GetWKT() string {
+	if m != nil {
+		return m.WKT
+	}
+	return ""
+}
+
+type RegisterStudyAreaResponse struct {
+	// ID is the identifier of the study area, which can be
+	// used to specify it in other requests.
+	ID                   string   `protobuf:"bytes,1,opt,name=ID,proto3" json:"ID,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *RegisterStudyAreaResponse) Reset()         { *m = RegisterStudyAreaResponse{} }
+func (m *RegisterStudyAreaResponse) String() string { return proto.CompactTextString(m) }
+func (*RegisterStudyAreaResponse) ProtoMessage()    {}
+func (*RegisterStudyAreaResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_cityaq_067e2154450e2bec, []int{9}
+}
+func (m *RegisterStudyAreaResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RegisterStudyAreaResponse.Unmarshal(m, b)
+}
+func (m *RegisterStudyAreaResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RegisterStudyAreaResponse.Marshal(b, m, deterministic)
+}
+func (dst *RegisterStudyAreaResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RegisterStudyAreaResponse.Merge(dst, src)
+}
+func (m *RegisterStudyAreaResponse) XXX_Size() int {
+	return xxx_messageInfo_RegisterStudyAreaResponse.Size(m)
+}
+func (m *RegisterStudyAreaResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_RegisterStudyAreaResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RegisterStudyAreaResponse proto.InternalMessageInfo
+
+func (m *RegisterStudyAreaResponse) GetID() string {
+	if m != nil {
+		return m.ID
+	}
+	return ""
+}
+
+type CityInfoRequest struct {
+	CityName string `protobuf:"bytes,1,opt,name=CityName,proto3" json:"CityName,omitempty"`
+	// Language is the code of the language that the city name should be
+	// displayed in. See CitiesRequest.Language.
+	Language             string   `protobuf:"bytes,2,opt,name=Language,proto3" json:"Language,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *CityInfoRequest) Reset()         { *m = CityInfoRequest{} }
+func (m *CityInfoRequest) String() string { return proto.CompactTextString(m) }
+func (*CityInfoRequest) ProtoMessage()    {}
+func (*CityInfoRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_cityaq_067e2154450e2bec, []int{10}
+}
+func (m *CityInfoRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CityInfoRequest.Unmarshal(m, b)
+}
+func (m *CityInfoRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CityInfoRequest.Marshal(b, m, deterministic)
+}
+func (dst *CityInfoRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CityInfoRequest.Merge(dst, src)
+}
+func (m *CityInfoRequest) XXX_Size() int {
+	return xxx_messageInfo_CityInfoRequest.Size(m)
+}
+func (m *CityInfoRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_CityInfoRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CityInfoRequest proto.InternalMessageInfo
+
+func (m *CityInfoRequest) GetCityName() string {
+	if m != nil {
+		return m.CityName
+	}
+	return ""
+}
+
+func (m *CityInfoRequest) GetLanguage() string {
+	if m != nil {
+		return m.Language
+	}
+	return ""
+}
+
+type CityInfoResponse struct {
+	// ID is the stable identifier of the city.
+	ID string `protobuf:"bytes,1,opt,name=ID,proto3" json:"ID,omitempty"`
+	// DisplayName is the name of the city in the requested language.
+	DisplayName string `protobuf:"bytes,2,opt,name=DisplayName,proto3" json:"DisplayName,omitempty"`
+	// Area is the area of the city in square kilometers.
+	Area float64 `protobuf:"fixed64,3,opt,name=Area,proto3" json:"Area,omitempty"`
+	// Centroid is the centroid of the city boundary.
+	Centroid *Point `protobuf:"bytes,4,opt,name=Centroid,proto3" json:"Centroid,omitempty"`
+	// Min and Max are the lower-left and upper-right corners
+	// of the bounding box of the city boundary.
+	Min *Point `protobuf:"bytes,5,opt,name=Min,proto3" json:"Min,omitempty"`
+	Max *Point `protobuf:"bytes,6,opt,name=Max,proto3" json:"Max,omitempty"`
+	// Country is the name of the country that the city is in.
+	Country string `protobuf:"bytes,7,opt,name=Country,proto3" json:"Country,omitempty"`
+	// EGUGrid is the geometry that emissions from source types in the
+	// EGUGrid allocation domain, and from emissions inventory sectors
+	// with the suffix "_egugrid", are allocated to.
+	EGUGrid []*Polygon `protobuf:"bytes,8,rep,name=EGUGrid,proto3" json:"EGUGrid,omitempty"`
+	// Properties holds the properties of the city's GeoJSON feature,
+	// encoded as a JSON object.
+	Properties           string   `protobuf:"bytes,9,opt,name=Properties,proto3" json:"Properties,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *CityInfoResponse) Reset()         { *m = CityInfoResponse{} }
+func (m *CityInfoResponse) String() string { return proto.CompactTextString(m) }
+func (*CityInfoResponse) ProtoMessage()    {}
+func (*CityInfoResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_cityaq_067e2154450e2bec, []int{11}
+}
+func (m *CityInfoResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CityInfoResponse.Unmarshal(m, b)
+}
+func (m *CityInfoResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CityInfoResponse.Marshal(b, m, deterministic)
+}
+func (dst *CityInfoResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CityInfoResponse.Merge(dst, src)
+}
+func (m *CityInfoResponse) XXX_Size() int {
+	return xxx_messageInfo_CityInfoResponse.Size(m)
+}
+func (m *CityInfoResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_CityInfoResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CityInfoResponse proto.InternalMessageInfo
+
+func (m *CityInfoResponse) GetID() string {
+	if m != nil {
+		return m.ID
+	}
+	return ""
+}
+
+func (m *CityInfoResponse) GetDisplayName() string {
+	if m != nil {
+		return m.DisplayName
+	}
+	return ""
+}
+
+func (m *CityInfoResponse) GetArea() float64 {
+	if m != nil {
+		return m.Area
+	}
+	return 0
+}
+
+func (m *CityInfoResponse) GetCentroid() *Point {
+	if m != nil {
+		return m.Centroid
+	}
+	return nil
+}
+
+func (m *CityInfoResponse) GetMin() *Point {
+	if m != nil {
+		return m.Min
+	}
+	return nil
+}
+
+func (m *CityInfoResponse) GetMax() *Point {
+	if m != nil {
+		return m.Max
+	}
+	return nil
+}
+
+func (m *CityInfoResponse) GetCountry() string {
+	if m != nil {
+		return m.Country
+	}
+	return ""
+}
+
+func (m *CityInfoResponse) GetEGUGrid() []*Polygon {
+	if m != nil {
+		return m.EGUGrid
+	}
+	return nil
+}
+
+func (m *CityInfoResponse) GetProperties() string {
+	if m != nil {
+		return m.Properties
+	}
+	return ""
+}
+
+type Polygon struct {
+	Paths                []*Path  `protobuf:"bytes,1,rep,name=Paths,proto3" json:"Paths,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *Polygon) Reset()         { *m = Polygon{} }
+func (m *Polygon) String() string { return proto.CompactTextString(m) }
+func (*Polygon) ProtoMessage()    {}
+func (*Polygon) Descriptor() ([]byte, []int) {
+	return fileDescriptor_cityaq_067e2154450e2bec, []int{12}
+}
+func (m *Polygon) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Polygon.Unmarshal(m, b)
+}
+func (m *Polygon) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Polygon.Marshal(b, m, deterministic)
+}
+func (dst *Polygon) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Polygon.Merge(dst, src)
+}
+func (m *Polygon) XXX_Size() int {
+	return xxx_messageInfo_Polygon.Size(m)
+}
+func (m *Polygon) XXX_DiscardUnknown() {
+	xxx_messageInfo_Polygon.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Polygon proto.InternalMessageInfo
+
+func (m *Polygon) GetPaths() []*Path {
+	if m != nil {
+		return m.Paths
+	}
+	return nil
+}
+
+type Path struct {
+	Points               []*Point `protobuf:"bytes,1,rep,name=Points,proto3" json:"Points,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *Path) Reset()         { *m = Path{} }
+func (m *Path) String() string { return proto.CompactTextString(m) }
+func (*Path) ProtoMessage()    {}
+func (*Path) Descriptor() ([]byte, []int) {
+	return fileDescriptor_cityaq_067e2154450e2bec, []int{13}
+}
+func (m *Path) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Path.Unmarshal(m, b)
+}
+func (m *Path) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Path.Marshal(b, m, deterministic)
+}
+func (dst *Path) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Path.Merge(dst, src)
+}
+func (m *Path) XXX_Size() int {
+	return xxx_messageInfo_Path.Size(m)
+}
+func (m *Path) XXX_DiscardUnknown() {
+	xxx_messageInfo_Path.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Path proto.InternalMessageInfo
+
+func (m *Path) GetPoints() []*Point {
+	if m != nil {
+		return m.Points
+	}
+	return nil
+}
+
+type Point struct {
+	X                    float64  `protobuf:"fixed64,1,opt,name=X,proto3" json:"X,omitempty"`
+	Y                    float64  `protobuf:"fixed64,2,opt,name=Y,proto3" json:"Y,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *Point) Reset()         { *m = Point{} }
+func (m *Point) String() string { return proto.CompactTextString(m) }
+func (*Point) ProtoMessage()    {}
+func (*Point) Descriptor() ([]byte, []int) {
+	return fileDescriptor_cityaq_067e2154450e2bec, []int{14}
+}
+func (m *Point) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Point.Unmarshal(m, b)
+}
+func (m *Point) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Point.Marshal(b, m, deterministic)
+}
+func (dst *Point) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Point.Merge(dst, src)
+}
+func (m *Point) XXX_Size() int {
+	return xxx_messageInfo_Point.Size(m)
+}
+func (m *Point) XXX_DiscardUnknown() {
+	xxx_messageInfo_Point.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Point proto.InternalMessageInfo
+
+func (m *Point) GetX() float64 {
+	if m != nil {
+		return m.X
+	}
+	return 0
+}
+
+func (m *Point) GetY() float64 {
+	if m != nil {
+		return m.Y
+	}
+	return 0
+}
+
+type GriddedEmissionsRequest struct {
+	CityName string `protobuf:"bytes,1,opt,name=CityName,proto3" json:"CityName,omitempty"`
+	// SourceType is the name of a source type returned by SourceTypes,
+	// or for CityTotal and Total simulations, an emissions inventory
+	// sector. Sectors that are not source types are allocated within
+	// the city, or within the EGUGrid if their names have the suffix
+	// "_egugrid".
+	SourceType string   `protobuf:"bytes,2,opt,name=SourceType,proto3" json:"SourceType,omitempty"`
+	Emission   Emission `protobuf:"varint,3,opt,name=Emission,proto3,enum=cityaqrpc.Emission" json:"Emission,omitempty"`
+	// SimulationType, if it is CityTotal or Total, specifies that the
+	// emissions should be those of the emissions inventory sector
+	// SourceType that drive simulations of that type, in which case the
+	// amounts, CustomSurrogate, SourceTypeMix, and Speciate are ignored.
+	SimulationType SimulationType `protobuf:"varint,4,opt,name=SimulationType,proto3,enum=cityaqrpc.SimulationType" json:"SimulationType,omitempty"`
+	// Amount is the amount of emissions, in AmountUnits. If it is zero,
+	// 1 kilotonne of emissions is emitted over the emissions period.
+	Amount      float64       `protobuf:"fixed64,5,opt,name=Amount,proto3" json:"Amount,omitempty"`
+	AmountUnits EmissionUnits `protobuf:"varint,6,opt,name=AmountUnits,proto3,enum=cityaqrpc.EmissionUnits" json:"AmountUnits,omitempty"`
+	// Begin and End are the start and end of the emissions period, as
+	// Unix times in seconds. If both are zero, the emissions period is
+	// calendar year 2016.
+	Begin int64 `protobuf:"varint,7,opt,name=Begin,proto3" json:"Begin,omitempty"`
+	End   int64 `protobuf:"varint,8,opt,name=End,proto3" json:"End,omitempty"`
+	// Pollutants, if it is not empty, specifies the amounts of multiple
+	// pollutants to emit over the emissions period, in which case
+	// Emission, Amount, and AmountUnits are ignored.
+	Pollutants []*PollutantAmount `protobuf:"bytes,9,rep,name=Pollutants,proto3" json:"Pollutants,omitempty"`
+	// CustomSurrogate, if it is set, specifies a spatial surrogate to
+	// build from OpenStreetMap data and use instead of SourceType.
+	CustomSurrogate *CustomSurrogate `protobuf:"bytes,10,opt,name=CustomSurrogate,proto3" json:"CustomSurrogate,omitempty"`
+	// SourceTypeMix, if it is set, specifies a weighted mix of source
+	// types whose spatial allocations are blended to allocate the
+	// emissions, in which case SourceType is ignored.
+	SourceTypeMix []*SourceTypeWeight `protobuf:"bytes,11,rep,name=SourceTypeMix,proto3" json:"SourceTypeMix,omitempty"`
+	// Resolution, if it is greater than zero, is the emissions grid cell
+	// edge length, in degrees, or in meters if the server builds grids in
+	// a projection. If it is zero, the resolution of the source type is used.
+	Resolution float64 `protobuf:"fixed64,12,opt,name=Resolution,proto3" json:"Resolution,omitempty"`
+	// AutoResolution specifies that the emissions grid resolution should
+	// be chosen from the extent of the area that emissions are allocated
+	// within, so that grids for large and small cities have similar
+	// numbers of cells. Resolution must be zero if it is set.
+	AutoResolution bool `protobuf:"varint,13,opt,name=AutoResolution,proto3" json:"AutoResolution,omitempty"`
+	// TemporalResolution, if it is set, specifies that the response should
+	// include TimeSteps with the emissions in each time step of this length
+	// between WindowBegin and WindowEnd, allocated in time using the
+	// temporal profiles of the source type. An error is returned if the
+	// time steps would have more than 1,000,000 values in total over all
+	// of the grid cells and pollutants.
+	TemporalResolution TemporalResolution `protobuf:"varint,14,opt,name=TemporalResolution,proto3,enum=cityaqrpc.TemporalResolution" json:"TemporalResolution,omitempty"`
+	// WindowBegin and WindowEnd are the start and end of the time window
+	// for time-resolved emissions, as Unix times in seconds. The window
+	// must be within the emissions period. If both are zero, the window
+	// is the emissions period.
+	WindowBegin int64 `protobuf:"varint,15,opt,name=WindowBegin,proto3" json:"WindowBegin,omitempty"`
+	WindowEnd   int64 `protobuf:"varint,16,opt,name=WindowEnd,proto3" json:"WindowEnd,omitempty"`
+	// Speciate specifies that Amount is the total mass of all pollutants,
+	// which is split among the pollutants using the speciation profile of
+	// the source type, in which case Emission is ignored and Pollutants
+	// must not be set.
+	Speciate bool `protobuf:"varint,17,opt,name=Speciate,proto3" json:"Speciate,omitempty"`
+	// MassTolerance, if it is greater than zero, is the largest fraction
+	// of the requested mass of emissions that can be lost or gained when
+	// the emissions are allocated to the grid. If more is lost or gained,
+	// an error is returned instead of the emissions.
+	MassTolerance float64 `protobuf:"fixed64,18,opt,name=MassTolerance,proto3" json:"MassTolerance,omitempty"`
+	// AreaFallback specifies that the emissions of source types whose
+	// spatial surrogates are zero throughout the allocation domain should
+	// be allocated in proportion to area instead, which is reported in the
+	// Diagnostics. Otherwise, an error is returned for them.
+	AreaFallback         bool     `protobuf:"varint,19,opt,name=AreaFallback,proto3" json:"AreaFallback,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GriddedEmissionsRequest) Reset()         { *m = GriddedEmissionsRequest{} }
+func (m *GriddedEmissionsRequest) String() string { return proto.CompactTextString(m) }
+func (*GriddedEmissionsRequest) ProtoMessage()    {}
+func (*GriddedEmissionsRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_cityaq_067e2154450e2bec, []int{15}
+}
+func (m *GriddedEmissionsRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GriddedEmissionsRequest.Unmarshal(m, b)
+}
+func (m *GriddedEmissionsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GriddedEmissionsRequest.Marshal(b, m, deterministic)
+}
+func (dst *GriddedEmissionsRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GriddedEmissionsRequest.Merge(dst, src)
+}
+func (m *GriddedEmissionsRequest) XXX_Size() int {
+	return xxx_messageInfo_GriddedEmissionsRequest.Size(m)
+}
+func (m *GriddedEmissionsRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_GriddedEmissionsRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GriddedEmissionsRequest proto.InternalMessageInfo
+
+func (m *GriddedEmissionsRequest) GetCityName() string {
+	if m != nil {
+		return m.CityName
+	}
+	return ""
+}
+
+func (m *GriddedEmissionsRequest) GetSourceType() string {
+	if m != nil {
+		return m.SourceType
 	}
 	return ""
 }
@@ -437,599 +1278,2275 @@ func (m *GriddedEmissionsRequest) GetEmission() Emission {
 	return Emission_UNKNOWN_EMISSION
 }
 
-func (m *GriddedEmissionsRequest) GetSimulationType() SimulationType {
+func (m *GriddedEmissionsRequest) GetSimulationType() SimulationType {
+	if m != nil {
+		return m.SimulationType
+	}
+	return SimulationType_UNKNOWN_SIMULATIONTYPE
+}
+
+func (m *GriddedEmissionsRequest) GetAmount() float64 {
+	if m != nil {
+		return m.Amount
+	}
+	return 0
+}
+
+func (m *GriddedEmissionsRequest) GetAmountUnits() EmissionUnits {
+	if m != nil {
+		return m.AmountUnits
+	}
+	return EmissionUnits_UNKNOWN_EMISSIONUNITS
+}
+
+func (m *GriddedEmissionsRequest) GetBegin() int64 {
+	if m != nil {
+		return m.Begin
+	}
+	return 0
+}
+
+func (m *GriddedEmissionsRequest) GetEnd() int64 {
+	if m != nil {
+		return m.End
+	}
+	return 0
+}
+
+func (m *GriddedEmissionsRequest) GetPollutants() []*PollutantAmount {
+	if m != nil {
+		return m.Pollutants
+	}
+	return nil
+}
+
+func (m *GriddedEmissionsRequest) GetCustomSurrogate() *CustomSurrogate {
+	if m != nil {
+		return m.CustomSurrogate
+	}
+	return nil
+}
+
+func (m *GriddedEmissionsRequest) GetSourceTypeMix() []*SourceTypeWeight {
+	if m != nil {
+		return m.SourceTypeMix
+	}
+	return nil
+}
+
+func (m *GriddedEmissionsRequest) GetResolution() float64 {
+	if m != nil {
+		return m.Resolution
+	}
+	return 0
+}
+
+func (m *GriddedEmissionsRequest) GetAutoResolution() bool {
+	if m != nil {
+		return m.AutoResolution
+	}
+	return false
+}
+
+func (m *GriddedEmissionsRequest) GetTemporalResolution() TemporalResolution {
+	if m != nil {
+		return m.TemporalResolution
+	}
+	return TemporalResolution_UNKNOWN_TEMPORALRESOLUTION
+}
+
+func (m *GriddedEmissionsRequest) GetWindowBegin() int64 {
+	if m != nil {
+		return m.WindowBegin
+	}
+	return 0
+}
+
+func (m *GriddedEmissionsRequest) GetWindowEnd() int64 {
+	if m != nil {
+		return m.WindowEnd
+	}
+	return 0
+}
+
+func (m *GriddedEmissionsRequest) GetSpeciate() bool {
+	if m != nil {
+		return m.Speciate
+	}
+	return false
+}
+
+func (m *GriddedEmissionsRequest) GetMassTolerance() float64 {
+	if m != nil {
+		return m.MassTolerance
+	}
+	return 0
+}
+
+func (m *GriddedEmissionsRequest) GetAreaFallback() bool {
+	if m != nil {
+		return m.AreaFallback
+	}
+	return false
+}
+
+// EmissionsTimeStep holds the emissions in a time step.
+type EmissionsTimeStep struct {
+	// Begin and End are the start and end of the time step,
+	// as Unix times in seconds.
+	Begin int64 `protobuf:"varint,1,opt,name=Begin,proto3" json:"Begin,omitempty"`
+	End   int64 `protobuf:"varint,2,opt,name=End,proto3" json:"End,omitempty"`
+	// PollutantEmissions are the emissions of each of the requested
+	// pollutants within the time step, in kilograms.
+	PollutantEmissions   []*PollutantEmissions `protobuf:"bytes,3,rep,name=PollutantEmissions,proto3" json:"PollutantEmissions,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}              `json:"-"`
+	XXX_unrecognized     []byte                `json:"-"`
+	XXX_sizecache        int32                 `json:"-"`
+}
+
+func (m *EmissionsTimeStep) Reset()         { *m = EmissionsTimeStep{} }
+func (m *EmissionsTimeStep) String() string { return proto.CompactTextString(m) }
+func (*EmissionsTimeStep) ProtoMessage()    {}
+func (*EmissionsTimeStep) Descriptor() ([]byte, []int) {
+	return fileDescriptor_cityaq_067e2154450e2bec, []int{16}
+}
+func (m *EmissionsTimeStep) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_EmissionsTimeStep.Unmarshal(m, b)
+}
+func (m *EmissionsTimeStep) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_EmissionsTimeStep.Marshal(b, m, deterministic)
+}
+func (dst *EmissionsTimeStep) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_EmissionsTimeStep.Merge(dst, src)
+}
+func (m *EmissionsTimeStep) XXX_Size() int {
+	return xxx_messageInfo_EmissionsTimeStep.Size(m)
+}
+func (m *EmissionsTimeStep) XXX_DiscardUnknown() {
+	xxx_messageInfo_EmissionsTimeStep.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_EmissionsTimeStep proto.InternalMessageInfo
+
+func (m *EmissionsTimeStep) GetBegin() int64 {
+	if m != nil {
+		return m.Begin
+	}
+	return 0
+}
+
+func (m *EmissionsTimeStep) GetEnd() int64 {
+	if m != nil {
+		return m.End
+	}
+	return 0
+}
+
+func (m *EmissionsTimeStep) GetPollutantEmissions() []*PollutantEmissions {
+	if m != nil {
+		return m.PollutantEmissions
+	}
+	return nil
+}
+
+type GriddedEmissionsResponse struct {
+	Polygons []*Polygon `protobuf:"bytes,1,rep,name=Polygons,proto3" json:"Polygons,omitempty"`
+	// Emissions are the emissions of the requested pollutant
+	// when Pollutants is not set in the request, or the total
+	// emissions of all pollutants when Speciate is set.
+	Emissions []float64 `protobuf:"fixed64,2,rep,packed,name=Emissions,proto3" json:"Emissions,omitempty"`
+	// PollutantEmissions are the emissions of each of the
+	// requested pollutants, in the order they were requested.
+	PollutantEmissions []*PollutantEmissions `protobuf:"bytes,3,rep,name=PollutantEmissions,proto3" json:"PollutantEmissions,omitempty"`
+	// TimeSteps are the emissions in each time step when
+	// TemporalResolution is set in the request.
+	TimeSteps []*EmissionsTimeStep `protobuf:"bytes,4,rep,name=TimeSteps,proto3" json:"TimeSteps,omitempty"`
+	// Diagnostics describe how well the mass of the emissions
+	// was conserved when it was allocated to the grid.
+	Diagnostics          *EmissionsDiagnostics `protobuf:"bytes,5,opt,name=Diagnostics,proto3" json:"Diagnostics,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}              `json:"-"`
+	XXX_unrecognized     []byte                `json:"-"`
+	XXX_sizecache        int32                 `json:"-"`
+}
+
+func (m *GriddedEmissionsResponse) Reset()         { *m = GriddedEmissionsResponse{} }
+func (m *GriddedEmissionsResponse) String() string { return proto.CompactTextString(m) }
+func (*GriddedEmissionsResponse) ProtoMessage()    {}
+func (*GriddedEmissionsResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_cityaq_067e2154450e2bec, []int{17}
+}
+func (m *GriddedEmissionsResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GriddedEmissionsResponse.Unmarshal(m, b)
+}
+func (m *GriddedEmissionsResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GriddedEmissionsResponse.Marshal(b, m, deterministic)
+}
+func (dst *GriddedEmissionsResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GriddedEmissionsResponse.Merge(dst, src)
+}
+func (m *GriddedEmissionsResponse) XXX_Size() int {
+	return xxx_messageInfo_GriddedEmissionsResponse.Size(m)
+}
+func (m *GriddedEmissionsResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_GriddedEmissionsResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GriddedEmissionsResponse proto.InternalMessageInfo
+
+func (m *GriddedEmissionsResponse) GetPolygons() []*Polygon {
+	if m != nil {
+		return m.Polygons
+	}
+	return nil
+}
+
+func (m *GriddedEmissionsResponse) GetEmissions() []float64 {
+	if m != nil {
+		return m.Emissions
+	}
+	return nil
+}
+
+func (m *GriddedEmissionsResponse) GetPollutantEmissions() []*PollutantEmissions {
+	if m != nil {
+		return m.PollutantEmissions
+	}
+	return nil
+}
+
+func (m *GriddedEmissionsResponse) GetTimeSteps() []*EmissionsTimeStep {
+	if m != nil {
+		return m.TimeSteps
+	}
+	return nil
+}
+
+func (m *GriddedEmissionsResponse) GetDiagnostics() *EmissionsDiagnostics {
+	if m != nil {
+		return m.Diagnostics
+	}
+	return nil
+}
+
+// EmissionsDiagnostics describe the allocation of emissions to a grid.
+type EmissionsDiagnostics struct {
+	// RequestedMass is the total mass of all of the requested pollutants,
+	// in kilograms. For emissions inventory emissions, it is the mass of
+	// the inventory emissions within the grid, and for CityTotal
+	// simulations, within the city.
+	RequestedMass float64 `protobuf:"fixed64,1,opt,name=RequestedMass,proto3" json:"RequestedMass,omitempty"`
+	// AllocatedMass is the total mass of the emissions in the grid cells,
+	// in kilograms.
+	AllocatedMass float64 `protobuf:"fixed64,2,opt,name=AllocatedMass,proto3" json:"AllocatedMass,omitempty"`
+	// CityFraction is the fraction of the allocated mass that is in the
+	// city, assuming that emissions are evenly distributed within each
+	// grid cell.
+	CityFraction float64 `protobuf:"fixed64,3,opt,name=CityFraction,proto3" json:"CityFraction,omitempty"`
+	// LostFraction is the fraction of the requested mass that is not in
+	// the grid cells. It is negative if mass was gained.
+	LostFraction float64 `protobuf:"fixed64,4,opt,name=LostFraction,proto3" json:"LostFraction,omitempty"`
+	// SurrogateFallback is "area" if some of the emissions were allocated
+	// in proportion to area within the allocation domain because the
+	// spatial surrogate is zero throughout the domain and AreaFallback was
+	// set, or empty if the spatial surrogates were used.
+	SurrogateFallback string `protobuf:"bytes,5,opt,name=SurrogateFallback,proto3" json:"SurrogateFallback,omitempty"`
+	// FallbackSourceTypes are the source types whose spatial surrogates
+	// were replaced by SurrogateFallback.
+	FallbackSourceTypes  []string `protobuf:"bytes,6,rep,name=FallbackSourceTypes,proto3" json:"FallbackSourceTypes,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *EmissionsDiagnostics) Reset()         { *m = EmissionsDiagnostics{} }
+func (m *EmissionsDiagnostics) String() string { return proto.CompactTextString(m) }
+func (*EmissionsDiagnostics) ProtoMessage()    {}
+func (*EmissionsDiagnostics) Descriptor() ([]byte, []int) {
+	return fileDescriptor_cityaq_067e2154450e2bec, []int{18}
+}
+func (m *EmissionsDiagnostics) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_EmissionsDiagnostics.Unmarshal(m, b)
+}
+func (m *EmissionsDiagnostics) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_EmissionsDiagnostics.Marshal(b, m, deterministic)
+}
+func (dst *EmissionsDiagnostics) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_EmissionsDiagnostics.Merge(dst, src)
+}
+func (m *EmissionsDiagnostics) XXX_Size() int {
+	return xxx_messageInfo_EmissionsDiagnostics.Size(m)
+}
+func (m *EmissionsDiagnostics) XXX_DiscardUnknown() {
+	xxx_messageInfo_EmissionsDiagnostics.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_EmissionsDiagnostics proto.InternalMessageInfo
+
+func (m *EmissionsDiagnostics) GetRequestedMass() float64 {
+	if m != nil {
+		return m.RequestedMass
+	}
+	return 0
+}
+
+func (m *EmissionsDiagnostics) GetAllocatedMass() float64 {
+	if m != nil {
+		return m.AllocatedMass
+	}
+	return 0
+}
+
+func (m *EmissionsDiagnostics) GetCityFraction() float64 {
+	if m != nil {
+		return m.CityFraction
+	}
+	return 0
+}
+
+func (m *EmissionsDiagnostics) GetLostFraction() float64 {
+	if m != nil {
+		return m.LostFraction
+	}
+	return 0
+}
+
+func (m *EmissionsDiagnostics) GetSurrogateFallback() string {
+	if m != nil {
+		return m.SurrogateFallback
+	}
+	return ""
+}
+
+func (m *EmissionsDiagnostics) GetFallbackSourceTypes() []string {
+	if m != nil {
+		return m.FallbackSourceTypes
+	}
+	return nil
+}
+
+// PollutantAmount is an amount of emissions of a pollutant.
+type PollutantAmount struct {
+	Emission Emission `protobuf:"varint,1,opt,name=Emission,proto3,enum=cityaqrpc.Emission" json:"Emission,omitempty"`
+	// Amount is the amount of emissions, in AmountUnits. If it is zero,
+	// 1 kilotonne of emissions is emitted over the emissions period.
+	Amount               float64       `protobuf:"fixed64,2,opt,name=Amount,proto3" json:"Amount,omitempty"`
+	AmountUnits          EmissionUnits `protobuf:"varint,3,opt,name=AmountUnits,proto3,enum=cityaqrpc.EmissionUnits" json:"AmountUnits,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}      `json:"-"`
+	XXX_unrecognized     []byte        `json:"-"`
+	XXX_sizecache        int32         `json:"-"`
+}
+
+func (m *PollutantAmount) Reset()         { *m = PollutantAmount{} }
+func (m *PollutantAmount) String() string { return proto.CompactTextString(m) }
+func (*PollutantAmount) ProtoMessage()    {}
+func (*PollutantAmount) Descriptor() ([]byte, []int) {
+	return fileDescriptor_cityaq_067e2154450e2bec, []int{19}
+}
+func (m *PollutantAmount) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_PollutantAmount.Unmarshal(m, b)
+}
+func (m *PollutantAmount) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_PollutantAmount.Marshal(b, m, deterministic)
+}
+func (dst *PollutantAmount) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PollutantAmount.Merge(dst, src)
+}
+func (m *PollutantAmount) XXX_Size() int {
+	return xxx_messageInfo_PollutantAmount.Size(m)
+}
+func (m *PollutantAmount) XXX_DiscardUnknown() {
+	xxx_messageInfo_PollutantAmount.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PollutantAmount proto.InternalMessageInfo
+
+func (m *PollutantAmount) GetEmission() Emission {
+	if m != nil {
+		return m.Emission
+	}
+	return Emission_UNKNOWN_EMISSION
+}
+
+func (m *PollutantAmount) GetAmount() float64 {
+	if m != nil {
+		return m.Amount
+	}
+	return 0
+}
+
+func (m *PollutantAmount) GetAmountUnits() EmissionUnits {
+	if m != nil {
+		return m.AmountUnits
+	}
+	return EmissionUnits_UNKNOWN_EMISSIONUNITS
+}
+
+// CustomSurrogate is a spatial surrogate that is built at request
+// time from the OpenStreetMap features that match a set of tags.
+// The surrogate is cached, so later requests with the same
+// surrogate do not need to build it again.
+type CustomSurrogate struct {
+	// Tags are the tags that features must have to be included.
+	// A feature is included if it matches any of the tags.
+	Tags []*OSMTag `protobuf:"bytes,1,rep,name=Tags,proto3" json:"Tags,omitempty"`
+	// WeightBy specifies how features are weighted.
+	WeightBy             SurrogateWeight `protobuf:"varint,2,opt,name=WeightBy,proto3,enum=cityaqrpc.SurrogateWeight" json:"WeightBy,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}        `json:"-"`
+	XXX_unrecognized     []byte          `json:"-"`
+	XXX_sizecache        int32           `json:"-"`
+}
+
+func (m *CustomSurrogate) Reset()         { *m = CustomSurrogate{} }
+func (m *CustomSurrogate) String() string { return proto.CompactTextString(m) }
+func (*CustomSurrogate) ProtoMessage()    {}
+func (*CustomSurrogate) Descriptor() ([]byte, []int) {
+	return fileDescriptor_cityaq_067e2154450e2bec, []int{20}
+}
+func (m *CustomSurrogate) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CustomSurrogate.Unmarshal(m, b)
+}
+func (m *CustomSurrogate) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CustomSurrogate.Marshal(b, m, deterministic)
+}
+func (dst *CustomSurrogate) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CustomSurrogate.Merge(dst, src)
+}
+func (m *CustomSurrogate) XXX_Size() int {
+	return xxx_messageInfo_CustomSurrogate.Size(m)
+}
+func (m *CustomSurrogate) XXX_DiscardUnknown() {
+	xxx_messageInfo_CustomSurrogate.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CustomSurrogate proto.InternalMessageInfo
+
+func (m *CustomSurrogate) GetTags() []*OSMTag {
+	if m != nil {
+		return m.Tags
+	}
+	return nil
+}
+
+func (m *CustomSurrogate) GetWeightBy() SurrogateWeight {
+	if m != nil {
+		return m.WeightBy
+	}
+	return SurrogateWeight_UNKNOWN_SURROGATEWEIGHT
+}
+
+// OSMTag matches OpenStreetMap features with the tag Key and any of the
+// given Values, or any value if Values is empty.
+type OSMTag struct {
+	Key                  string   `protobuf:"bytes,1,opt,name=Key,proto3" json:"Key,omitempty"`
+	Values               []string `protobuf:"bytes,2,rep,name=Values,proto3" json:"Values,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *OSMTag) Reset()         { *m = OSMTag{} }
+func (m *OSMTag) String() string { return proto.CompactTextString(m) }
+func (*OSMTag) ProtoMessage()    {}
+func (*OSMTag) Descriptor() ([]byte, []int) {
+	return fileDescriptor_cityaq_067e2154450e2bec, []int{21}
+}
+func (m *OSMTag) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_OSMTag.Unmarshal(m, b)
+}
+func (m *OSMTag) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_OSMTag.Marshal(b, m, deterministic)
+}
+func (dst *OSMTag) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_OSMTag.Merge(dst, src)
+}
+func (m *OSMTag) XXX_Size() int {
+	return xxx_messageInfo_OSMTag.Size(m)
+}
+func (m *OSMTag) XXX_DiscardUnknown() {
+	xxx_messageInfo_OSMTag.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_OSMTag proto.InternalMessageInfo
+
+func (m *OSMTag) GetKey() string {
+	if m != nil {
+		return m.Key
+	}
+	return ""
+}
+
+func (m *OSMTag) GetValues() []string {
+	if m != nil {
+		return m.Values
+	}
+	return nil
+}
+
+// SourceTypeWeight is the weight of a source type in a mix of source
+// types. The weights of a mix do not need to add up to one; the
+// fraction of the emissions allocated using each source type is its
+// weight divided by the sum of the weights.
+type SourceTypeWeight struct {
+	SourceType           string   `protobuf:"bytes,1,opt,name=SourceType,proto3" json:"SourceType,omitempty"`
+	Weight               float64  `protobuf:"fixed64,2,opt,name=Weight,proto3" json:"Weight,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *SourceTypeWeight) Reset()         { *m = SourceTypeWeight{} }
+func (m *SourceTypeWeight) String() string { return proto.CompactTextString(m) }
+func (*SourceTypeWeight) ProtoMessage()    {}
+func (*SourceTypeWeight) Descriptor() ([]byte, []int) {
+	return fileDescriptor_cityaq_067e2154450e2bec, []int{22}
+}
+func (m *SourceTypeWeight) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SourceTypeWeight.Unmarshal(m, b)
+}
+func (m *SourceTypeWeight) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SourceTypeWeight.Marshal(b, m, deterministic)
+}
+func (dst *SourceTypeWeight) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SourceTypeWeight.Merge(dst, src)
+}
+func (m *SourceTypeWeight) XXX_Size() int {
+	return xxx_messageInfo_SourceTypeWeight.Size(m)
+}
+func (m *SourceTypeWeight) XXX_DiscardUnknown() {
+	xxx_messageInfo_SourceTypeWeight.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SourceTypeWeight proto.InternalMessageInfo
+
+func (m *SourceTypeWeight) GetSourceType() string {
+	if m != nil {
+		return m.SourceType
+	}
+	return ""
+}
+
+func (m *SourceTypeWeight) GetWeight() float64 {
+	if m != nil {
+		return m.Weight
+	}
+	return 0
+}
+
+// PointSource is an emissions source with an elevated release.
+type PointSource struct {
+	// Location is the longitude and latitude of the source.
+	Location *Point `protobuf:"bytes,1,opt,name=Location,proto3" json:"Location,omitempty"`
+	// PM2_5, VOC, NH3, NOx, and SOx are the annual
+	// emissions of each pollutant [kg/year].
+	PM2_5 float64 `protobuf:"fixed64,2,opt,name=PM2_5,json=PM25,proto3" json:"PM2_5,omitempty"`
+	VOC   float64 `protobuf:"fixed64,3,opt,name=VOC,proto3" json:"VOC,omitempty"`
+	NH3   float64 `protobuf:"fixed64,4,opt,name=NH3,proto3" json:"NH3,omitempty"`
+	NOx   float64 `protobuf:"fixed64,5,opt,name=NOx,proto3" json:"NOx,omitempty"`
+	SOx   float64 `protobuf:"fixed64,6,opt,name=SOx,proto3" json:"SOx,omitempty"`
+	// StackHeight is the height of the stack [m].
+	StackHeight float64 `protobuf:"fixed64,7,opt,name=StackHeight,proto3" json:"StackHeight,omitempty"`
+	// StackDiameter is the inside diameter of the stack [m].
+	StackDiameter float64 `protobuf:"fixed64,8,opt,name=StackDiameter,proto3" json:"StackDiameter,omitempty"`
+	// StackTemperature is the exit gas temperature [K].
+	StackTemperature float64 `protobuf:"fixed64,9,opt,name=StackTemperature,proto3" json:"StackTemperature,omitempty"`
+	// StackVelocity is the exit gas velocity [m/s].
+	StackVelocity        float64  `protobuf:"fixed64,10,opt,name=StackVelocity,proto3" json:"StackVelocity,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *PointSource) Reset()         { *m = PointSource{} }
+func (m *PointSource) String() string { return proto.CompactTextString(m) }
+func (*PointSource) ProtoMessage()    {}
+func (*PointSource) Descriptor() ([]byte, []int) {
+	return fileDescriptor_cityaq_067e2154450e2bec, []int{23}
+}
+func (m *PointSource) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_PointSource.Unmarshal(m, b)
+}
+func (m *PointSource) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_PointSource.Marshal(b, m, deterministic)
+}
+func (dst *PointSource) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PointSource.Merge(dst, src)
+}
+func (m *PointSource) XXX_Size() int {
+	return xxx_messageInfo_PointSource.Size(m)
+}
+func (m *PointSource) XXX_DiscardUnknown() {
+	xxx_messageInfo_PointSource.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PointSource proto.InternalMessageInfo
+
+func (m *PointSource) GetLocation() *Point {
+	if m != nil {
+		return m.Location
+	}
+	return nil
+}
+
+func (m *PointSource) GetPM2_5() float64 {
+	if m != nil {
+		return m.PM2_5
+	}
+	return 0
+}
+
+func (m *PointSource) GetVOC() float64 {
+	if m != nil {
+		return m.VOC
+	}
+	return 0
+}
+
+func (m *PointSource) GetNH3() float64 {
+	if m != nil {
+		return m.NH3
+	}
+	return 0
+}
+
+func (m *PointSource) GetNOx() float64 {
+	if m != nil {
+		return m.NOx
+	}
+	return 0
+}
+
+func (m *PointSource) GetSOx() float64 {
+	if m != nil {
+		return m.SOx
+	}
+	return 0
+}
+
+func (m *PointSource) GetStackHeight() float64 {
+	if m != nil {
+		return m.StackHeight
+	}
+	return 0
+}
+
+func (m *PointSource) GetStackDiameter() float64 {
+	if m != nil {
+		return m.StackDiameter
+	}
+	return 0
+}
+
+func (m *PointSource) GetStackTemperature() float64 {
+	if m != nil {
+		return m.StackTemperature
+	}
+	return 0
+}
+
+func (m *PointSource) GetStackVelocity() float64 {
+	if m != nil {
+		return m.StackVelocity
+	}
+	return 0
+}
+
+// PollutantEmissions are the gridded emissions of a pollutant.
+type PollutantEmissions struct {
+	Emission             Emission  `protobuf:"varint,1,opt,name=Emission,proto3,enum=cityaqrpc.Emission" json:"Emission,omitempty"`
+	Emissions            []float64 `protobuf:"fixed64,2,rep,packed,name=Emissions,proto3" json:"Emissions,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}  `json:"-"`
+	XXX_unrecognized     []byte    `json:"-"`
+	XXX_sizecache        int32     `json:"-"`
+}
+
+func (m *PollutantEmissions) Reset()         { *m = PollutantEmissions{} }
+func (m *PollutantEmissions) String() string { return proto.CompactTextString(m) }
+func (*PollutantEmissions) ProtoMessage()    {}
+func (*PollutantEmissions) Descriptor() ([]byte, []int) {
+	return fileDescriptor_cityaq_067e2154450e2bec, []int{24}
+}
+func (m *PollutantEmissions) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_PollutantEmissions.Unmarshal(m, b)
+}
+func (m *PollutantEmissions) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_PollutantEmissions.Marshal(b, m, deterministic)
+}
+func (dst *PollutantEmissions) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PollutantEmissions.Merge(dst, src)
+}
+func (m *PollutantEmissions) XXX_Size() int {
+	return xxx_messageInfo_PollutantEmissions.Size(m)
+}
+func (m *PollutantEmissions) XXX_DiscardUnknown() {
+	xxx_messageInfo_PollutantEmissions.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PollutantEmissions proto.InternalMessageInfo
+
+func (m *PollutantEmissions) GetEmission() Emission {
+	if m != nil {
+		return m.Emission
+	}
+	return Emission_UNKNOWN_EMISSION
+}
+
+func (m *PollutantEmissions) GetEmissions() []float64 {
+	if m != nil {
+		return m.Emissions
+	}
+	return nil
+}
+
+type GriddedConcentrationsRequest struct {
+	CityName   string   `protobuf:"bytes,1,opt,name=CityName,proto3" json:"CityName,omitempty"`
+	SourceType string   `protobuf:"bytes,2,opt,name=SourceType,proto3" json:"SourceType,omitempty"`
+	Emission   Emission `protobuf:"varint,3,opt,name=Emission,proto3,enum=cityaqrpc.Emission" json:"Emission,omitempty"`
+	// SimulationType is the type of simulation. Amount, Begin, End,
+	// Pollutants, and Speciate can only be set for CityMarginal
+	// simulations, because the concentrations of CityTotal and Total
+	// simulations result from an emissions inventory.
+	SimulationType SimulationType `protobuf:"varint,4,opt,name=SimulationType,proto3,enum=cityaqrpc.SimulationType" json:"SimulationType,omitempty"`
+	// Amount is the amount of emissions, in AmountUnits. If it is zero,
+	// 1 kilotonne of emissions is emitted over the emissions period.
+	Amount      float64       `protobuf:"fixed64,5,opt,name=Amount,proto3" json:"Amount,omitempty"`
+	AmountUnits EmissionUnits `protobuf:"varint,6,opt,name=AmountUnits,proto3,enum=cityaqrpc.EmissionUnits" json:"AmountUnits,omitempty"`
+	// Begin and End are the start and end of the emissions period, as
+	// Unix times in seconds. If both are zero, the emissions period is
+	// calendar year 2016.
+	Begin int64 `protobuf:"varint,7,opt,name=Begin,proto3" json:"Begin,omitempty"`
+	End   int64 `protobuf:"varint,8,opt,name=End,proto3" json:"End,omitempty"`
+	// Pollutants, if it is not empty, specifies the amounts of multiple
+	// pollutants to emit over the emissions period, in which case
+	// Emission, Amount, and AmountUnits are ignored and the
+	// concentrations are the total PM2.5 resulting from all of them.
+	Pollutants []*PollutantAmount `protobuf:"bytes,9,rep,name=Pollutants,proto3" json:"Pollutants,omitempty"`
+	// PointSources, if it is not empty, specifies point sources
+	// whose emissions are simulated instead of the emissions of
+	// SourceType. The point sources are simulated in the air quality
+	// modeling domain of the city, so they must be located within it.
+	// SimulationType must be CityMarginal, Emission, Amount, AmountUnits,
+	// Pollutants, and Speciate must not be set, and SourceType, Begin,
+	// and End are ignored.
+	// The concentrations are the annual average total PM2.5 resulting
+	// from all of the point source emissions.
+	PointSources []*PointSource `protobuf:"bytes,10,rep,name=PointSources,proto3" json:"PointSources,omitempty"`
+	// CustomSurrogate, if it is set, specifies a spatial surrogate to
+	// build from OpenStreetMap data and use instead of SourceType.
+	CustomSurrogate *CustomSurrogate `protobuf:"bytes,11,opt,name=CustomSurrogate,proto3" json:"CustomSurrogate,omitempty"`
+	// SourceTypeMix, if it is set, specifies a weighted mix of source
+	// types whose spatial allocations are blended to allocate the
+	// emissions, in which case SourceType is ignored.
+	SourceTypeMix []*SourceTypeWeight `protobuf:"bytes,12,rep,name=SourceTypeMix,proto3" json:"SourceTypeMix,omitempty"`
+	// Resolution, if it is greater than zero, is the emissions grid cell
+	// edge length, in degrees, or in meters if the server builds grids in
+	// a projection. If it is zero, the resolution of the source type is used.
+	// Resolution only affects CityMarginal simulations.
+	Resolution float64 `protobuf:"fixed64,13,opt,name=Resolution,proto3" json:"Resolution,omitempty"`
+	// AutoResolution specifies that the emissions grid resolution should
+	// be chosen from the extent of the area that emissions are allocated
+	// within, so that grids for large and small cities have similar
+	// numbers of cells. Resolution must be zero if it is set.
+	AutoResolution bool `protobuf:"varint,14,opt,name=AutoResolution,proto3" json:"AutoResolution,omitempty"`
+	// Speciate specifies that Amount is the total mass of all pollutants,
+	// which is split among the pollutants using the speciation profile of
+	// the source type, in which case Emission is ignored and Pollutants
+	// must not be set.
+	Speciate bool `protobuf:"varint,15,opt,name=Speciate,proto3" json:"Speciate,omitempty"`
+	// AreaFallback specifies that, in CityMarginal simulations, the
+	// emissions of source types whose spatial surrogates are zero
+	// throughout the allocation domain should be allocated in proportion
+	// to area instead. Otherwise, an error is returned for them.
+	AreaFallback         bool     `protobuf:"varint,16,opt,name=AreaFallback,proto3" json:"AreaFallback,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GriddedConcentrationsRequest) Reset()         { *m = GriddedConcentrationsRequest{} }
+func (m *GriddedConcentrationsRequest) String() string { return proto.CompactTextString(m) }
+func (*GriddedConcentrationsRequest) ProtoMessage()    {}
+func (*GriddedConcentrationsRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_cityaq_067e2154450e2bec, []int{25}
+}
+func (m *GriddedConcentrationsRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GriddedConcentrationsRequest.Unmarshal(m, b)
+}
+func (m *GriddedConcentrationsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GriddedConcentrationsRequest.Marshal(b, m, deterministic)
+}
+func (dst *GriddedConcentrationsRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GriddedConcentrationsRequest.Merge(dst, src)
+}
+func (m *GriddedConcentrationsRequest) XXX_Size() int {
+	return xxx_messageInfo_GriddedConcentrationsRequest.Size(m)
+}
+func (m *GriddedConcentrationsRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_GriddedConcentrationsRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GriddedConcentrationsRequest proto.InternalMessageInfo
+
+func (m *GriddedConcentrationsRequest) GetCityName() string {
+	if m != nil {
+		return m.CityName
+	}
+	return ""
+}
+
+func (m *GriddedConcentrationsRequest) GetSourceType() string {
+	if m != nil {
+		return m.SourceType
+	}
+	return ""
+}
+
+func (m *GriddedConcentrationsRequest) GetEmission() Emission {
+	if m != nil {
+		return m.Emission
+	}
+	return Emission_UNKNOWN_EMISSION
+}
+
+func (m *GriddedConcentrationsRequest) GetSimulationType() SimulationType {
+	if m != nil {
+		return m.SimulationType
+	}
+	return SimulationType_UNKNOWN_SIMULATIONTYPE
+}
+
+func (m *GriddedConcentrationsRequest) GetAmount() float64 {
+	if m != nil {
+		return m.Amount
+	}
+	return 0
+}
+
+func (m *GriddedConcentrationsRequest) GetAmountUnits() EmissionUnits {
+	if m != nil {
+		return m.AmountUnits
+	}
+	return EmissionUnits_UNKNOWN_EMISSIONUNITS
+}
+
+func (m *GriddedConcentrationsRequest) GetBegin() int64 {
+	if m != nil {
+		return m.Begin
+	}
+	return 0
+}
+
+func (m *GriddedConcentrationsRequest) GetEnd() int64 {
+	if m != nil {
+		return m.End
+	}
+	return 0
+}
+
+func (m *GriddedConcentrationsRequest) GetPollutants() []*PollutantAmount {
+	if m != nil {
+		return m.Pollutants
+	}
+	return nil
+}
+
+func (m *GriddedConcentrationsRequest) GetPointSources() []*PointSource {
+	if m != nil {
+		return m.PointSources
+	}
+	return nil
+}
+
+func (m *GriddedConcentrationsRequest) GetCustomSurrogate() *CustomSurrogate {
+	if m != nil {
+		return m.CustomSurrogate
+	}
+	return nil
+}
+
+func (m *GriddedConcentrationsRequest) GetSourceTypeMix() []*SourceTypeWeight {
+	if m != nil {
+		return m.SourceTypeMix
+	}
+	return nil
+}
+
+func (m *GriddedConcentrationsRequest) GetResolution() float64 {
+	if m != nil {
+		return m.Resolution
+	}
+	return 0
+}
+
+func (m *GriddedConcentrationsRequest) GetAutoResolution() bool {
+	if m != nil {
+		return m.AutoResolution
+	}
+	return false
+}
+
+func (m *GriddedConcentrationsRequest) GetSpeciate() bool {
+	if m != nil {
+		return m.Speciate
+	}
+	return false
+}
+
+func (m *GriddedConcentrationsRequest) GetAreaFallback() bool {
+	if m != nil {
+		return m.AreaFallback
+	}
+	return false
+}
+
+type GriddedConcentrationsResponse struct {
+	Polygons             []*Polygon `protobuf:"bytes,1,rep,name=Polygons,proto3" json:"Polygons,omitempty"`
+	Concentrations       []float64  `protobuf:"fixed64,2,rep,packed,name=Concentrations,proto3" json:"Concentrations,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}   `json:"-"`
+	XXX_unrecognized     []byte     `json:"-"`
+	XXX_sizecache        int32      `json:"-"`
+}
+
+func (m *GriddedConcentrationsResponse) Reset()         { *m = GriddedConcentrationsResponse{} }
+func (m *GriddedConcentrationsResponse) String() string { return proto.CompactTextString(m) }
+func (*GriddedConcentrationsResponse) ProtoMessage()    {}
+func (*GriddedConcentrationsResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_cityaq_067e2154450e2bec, []int{26}
+}
+func (m *GriddedConcentrationsResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GriddedConcentrationsResponse.Unmarshal(m, b)
+}
+func (m *GriddedConcentrationsResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GriddedConcentrationsResponse.Marshal(b, m, deterministic)
+}
+func (dst *GriddedConcentrationsResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GriddedConcentrationsResponse.Merge(dst, src)
+}
+func (m *GriddedConcentrationsResponse) XXX_Size() int {
+	return xxx_messageInfo_GriddedConcentrationsResponse.Size(m)
+}
+func (m *GriddedConcentrationsResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_GriddedConcentrationsResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GriddedConcentrationsResponse proto.InternalMessageInfo
+
+func (m *GriddedConcentrationsResponse) GetPolygons() []*Polygon {
+	if m != nil {
+		return m.Polygons
+	}
+	return nil
+}
+
+func (m *GriddedConcentrationsResponse) GetConcentrations() []float64 {
+	if m != nil {
+		return m.Concentrations
+	}
+	return nil
+}
+
+type GriddedPopulationRequest struct {
+	CityName       string         `protobuf:"bytes,1,opt,name=CityName,proto3" json:"CityName,omitempty"`
+	SourceType     string         `protobuf:"bytes,2,opt,name=SourceType,proto3" json:"SourceType,omitempty"`
+	Emission       Emission       `protobuf:"varint,3,opt,name=Emission,proto3,enum=cityaqrpc.Emission" json:"Emission,omitempty"`
+	SimulationType SimulationType `protobuf:"varint,4,opt,name=SimulationType,proto3,enum=cityaqrpc.SimulationType" json:"SimulationType,omitempty"`
+	// PointSources, if it is not empty, specifies point sources
+	// whose emissions are simulated instead of the emissions of
+	// SourceType. The point sources are simulated in the air quality
+	// modeling domain of the city, so they should be located near it.
+	// SimulationType must be CityMarginal.
+	PointSources []*PointSource `protobuf:"bytes,5,rep,name=PointSources,proto3" json:"PointSources,omitempty"`
+	// CustomSurrogate, if it is set, specifies a spatial surrogate to
+	// build from OpenStreetMap data and use instead of SourceType.
+	CustomSurrogate *CustomSurrogate `protobuf:"bytes,6,opt,name=CustomSurrogate,proto3" json:"CustomSurrogate,omitempty"`
+	// SourceTypeMix, if it is set, specifies a weighted mix of source
+	// types whose spatial allocations are blended to allocate the
+	// emissions, in which case SourceType is ignored.
+	SourceTypeMix []*SourceTypeWeight `protobuf:"bytes,7,rep,name=SourceTypeMix,proto3" json:"SourceTypeMix,omitempty"`
+	// Resolution, if it is greater than zero, is the emissions grid cell
+	// edge length, in degrees, or in meters if the server builds grids in
+	// a projection. If it is zero, the resolution of the source type is used.
+	// Resolution only affects CityMarginal simulations.
+	Resolution float64 `protobuf:"fixed64,8,opt,name=Resolution,proto3" json:"Resolution,omitempty"`
+	// AutoResolution specifies that the emissions grid resolution should
+	// be chosen from the extent of the area that emissions are allocated
+	// within, so that grids for large and small cities have similar
+	// numbers of cells. Resolution must be zero if it is set.
+	AutoResolution       bool     `protobuf:"varint,9,opt,name=AutoResolution,proto3" json:"AutoResolution,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GriddedPopulationRequest) Reset()         { *m = GriddedPopulationRequest{} }
+func (m *GriddedPopulationRequest) String() string { return proto.CompactTextString(m) }
+func (*GriddedPopulationRequest) ProtoMessage()    {}
+func (*GriddedPopulationRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_cityaq_067e2154450e2bec, []int{27}
+}
+func (m *GriddedPopulationRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GriddedPopulationRequest.Unmarshal(m, b)
+}
+func (m *GriddedPopulationRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GriddedPopulationRequest.Marshal(b, m, deterministic)
+}
+func (dst *GriddedPopulationRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GriddedPopulationRequest.Merge(dst, src)
+}
+func (m *GriddedPopulationRequest) XXX_Size() int {
+	return xxx_messageInfo_GriddedPopulationRequest.Size(m)
+}
+func (m *GriddedPopulationRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_GriddedPopulationRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GriddedPopulationRequest proto.InternalMessageInfo
+
+func (m *GriddedPopulationRequest) GetCityName() string {
+	if m != nil {
+		return m.CityName
+	}
+	return ""
+}
+
+func (m *GriddedPopulationRequest) GetSourceType() string {
+	if m != nil {
+		return m.SourceType
+	}
+	return ""
+}
+
+func (m *GriddedPopulationRequest) GetEmission() Emission {
+	if m != nil {
+		return m.Emission
+	}
+	return Emission_UNKNOWN_EMISSION
+}
+
+func (m *GriddedPopulationRequest) GetSimulationType() SimulationType {
+	if m != nil {
+		return m.SimulationType
+	}
+	return SimulationType_UNKNOWN_SIMULATIONTYPE
+}
+
+func (m *GriddedPopulationRequest) GetPointSources() []*PointSource {
+	if m != nil {
+		return m.PointSources
+	}
+	return nil
+}
+
+func (m *GriddedPopulationRequest) GetCustomSurrogate() *CustomSurrogate {
+	if m != nil {
+		return m.CustomSurrogate
+	}
+	return nil
+}
+
+func (m *GriddedPopulationRequest) GetSourceTypeMix() []*SourceTypeWeight {
+	if m != nil {
+		return m.SourceTypeMix
+	}
+	return nil
+}
+
+func (m *GriddedPopulationRequest) GetResolution() float64 {
+	if m != nil {
+		return m.Resolution
+	}
+	return 0
+}
+
+func (m *GriddedPopulationRequest) GetAutoResolution() bool {
+	if m != nil {
+		return m.AutoResolution
+	}
+	return false
+}
+
+type GriddedPopulationResponse struct {
+	Polygons             []*Polygon `protobuf:"bytes,1,rep,name=Polygons,proto3" json:"Polygons,omitempty"`
+	Population           []float64  `protobuf:"fixed64,2,rep,packed,name=Population,proto3" json:"Population,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}   `json:"-"`
+	XXX_unrecognized     []byte     `json:"-"`
+	XXX_sizecache        int32      `json:"-"`
+}
+
+func (m *GriddedPopulationResponse) Reset()         { *m = GriddedPopulationResponse{} }
+func (m *GriddedPopulationResponse) String() string { return proto.CompactTextString(m) }
+func (*GriddedPopulationResponse) ProtoMessage()    {}
+func (*GriddedPopulationResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_cityaq_067e2154450e2bec, []int{28}
+}
+func (m *GriddedPopulationResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GriddedPopulationResponse.Unmarshal(m, b)
+}
+func (m *GriddedPopulationResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GriddedPopulationResponse.Marshal(b, m, deterministic)
+}
+func (dst *GriddedPopulationResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GriddedPopulationResponse.Merge(dst, src)
+}
+func (m *GriddedPopulationResponse) XXX_Size() int {
+	return xxx_messageInfo_GriddedPopulationResponse.Size(m)
+}
+func (m *GriddedPopulationResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_GriddedPopulationResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GriddedPopulationResponse proto.InternalMessageInfo
+
+func (m *GriddedPopulationResponse) GetPolygons() []*Polygon {
+	if m != nil {
+		return m.Polygons
+	}
+	return nil
+}
+
+func (m *GriddedPopulationResponse) GetPopulation() []float64 {
+	if m != nil {
+		return m.Population
+	}
+	return nil
+}
+
+type ImpactSummaryRequest struct {
+	CityName   string   `protobuf:"bytes,1,opt,name=CityName,proto3" json:"CityName,omitempty"`
+	SourceType string   `protobuf:"bytes,2,opt,name=SourceType,proto3" json:"SourceType,omitempty"`
+	Emission   Emission `protobuf:"varint,3,opt,name=Emission,proto3,enum=cityaqrpc.Emission" json:"Emission,omitempty"`
+	// SimulationType is the type of simulation. Amount, Begin, End,
+	// and Speciate can only be set for CityMarginal simulations, because
+	// the impacts of CityTotal and Total simulations result from an
+	// emissions inventory.
+	SimulationType SimulationType `protobuf:"varint,4,opt,name=SimulationType,proto3,enum=cityaqrpc.SimulationType" json:"SimulationType,omitempty"`
+	// Amount is the amount of emissions, in AmountUnits. If it is zero,
+	// 1 kilotonne of emissions is emitted over the emissions period.
+	Amount      float64       `protobuf:"fixed64,5,opt,name=Amount,proto3" json:"Amount,omitempty"`
+	AmountUnits EmissionUnits `protobuf:"varint,6,opt,name=AmountUnits,proto3,enum=cityaqrpc.EmissionUnits" json:"AmountUnits,omitempty"`
+	// Begin and End are the start and end of the emissions period, as
+	// Unix times in seconds. If both are zero, the emissions period is
+	// calendar year 2016.
+	Begin int64 `protobuf:"varint,7,opt,name=Begin,proto3" json:"Begin,omitempty"`
+	End   int64 `protobuf:"varint,8,opt,name=End,proto3" json:"End,omitempty"`
+	// SourceTypeMix, if it is set, specifies a weighted mix of source
+	// types whose spatial allocations are blended to allocate the
+	// emissions, in which case SourceType is ignored.
+	SourceTypeMix []*SourceTypeWeight `protobuf:"bytes,9,rep,name=SourceTypeMix,proto3" json:"SourceTypeMix,omitempty"`
+	// Resolution, if it is greater than zero, is the emissions grid cell
+	// edge length, in degrees, or in meters if the server builds grids in
+	// a projection. If it is zero, the resolution of the source type is used.
+	// Resolution only affects CityMarginal simulations.
+	Resolution float64 `protobuf:"fixed64,10,opt,name=Resolution,proto3" json:"Resolution,omitempty"`
+	// AutoResolution specifies that the emissions grid resolution should
+	// be chosen from the extent of the area that emissions are allocated
+	// within, so that grids for large and small cities have similar
+	// numbers of cells. Resolution must be zero if it is set.
+	AutoResolution bool `protobuf:"varint,11,opt,name=AutoResolution,proto3" json:"AutoResolution,omitempty"`
+	// Speciate specifies that Amount is the total mass of all pollutants,
+	// which is split among the pollutants using the speciation profile of
+	// the source type, in which case Emission is ignored and Pollutants
+	// must not be set.
+	Speciate bool `protobuf:"varint,12,opt,name=Speciate,proto3" json:"Speciate,omitempty"`
+	// AreaFallback specifies that, in CityMarginal simulations, the
+	// emissions of source types whose spatial surrogates are zero
+	// throughout the allocation domain should be allocated in proportion
+	// to area instead. Otherwise, an error is returned for them.
+	AreaFallback         bool     `protobuf:"varint,13,opt,name=AreaFallback,proto3" json:"AreaFallback,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ImpactSummaryRequest) Reset()         { *m = ImpactSummaryRequest{} }
+func (m *ImpactSummaryRequest) String() string { return proto.CompactTextString(m) }
+func (*ImpactSummaryRequest) ProtoMessage()    {}
+func (*ImpactSummaryRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_cityaq_067e2154450e2bec, []int{29}
+}
+func (m *ImpactSummaryRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ImpactSummaryRequest.Unmarshal(m, b)
+}
+func (m *ImpactSummaryRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ImpactSummaryRequest.Marshal(b, m, deterministic)
+}
+func (dst *ImpactSummaryRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ImpactSummaryRequest.Merge(dst, src)
+}
+func (m *ImpactSummaryRequest) XXX_Size() int {
+	return xxx_messageInfo_ImpactSummaryRequest.Size(m)
+}
+func (m *ImpactSummaryRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ImpactSummaryRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ImpactSummaryRequest proto.InternalMessageInfo
+
+func (m *ImpactSummaryRequest) GetCityName() string {
+	if m != nil {
+		return m.CityName
+	}
+	return ""
+}
+
+func (m *ImpactSummaryRequest) GetSourceType() string {
+	if m != nil {
+		return m.SourceType
+	}
+	return ""
+}
+
+func (m *ImpactSummaryRequest) GetEmission() Emission {
+	if m != nil {
+		return m.Emission
+	}
+	return Emission_UNKNOWN_EMISSION
+}
+
+func (m *ImpactSummaryRequest) GetSimulationType() SimulationType {
+	if m != nil {
+		return m.SimulationType
+	}
+	return SimulationType_UNKNOWN_SIMULATIONTYPE
+}
+
+func (m *ImpactSummaryRequest) GetAmount() float64 {
+	if m != nil {
+		return m.Amount
+	}
+	return 0
+}
+
+func (m *ImpactSummaryRequest) GetAmountUnits() EmissionUnits {
+	if m != nil {
+		return m.AmountUnits
+	}
+	return EmissionUnits_UNKNOWN_EMISSIONUNITS
+}
+
+func (m *ImpactSummaryRequest) GetBegin() int64 {
+	if m != nil {
+		return m.Begin
+	}
+	return 0
+}
+
+func (m *ImpactSummaryRequest) GetEnd() int64 {
+	if m != nil {
+		return m.End
+	}
+	return 0
+}
+
+func (m *ImpactSummaryRequest) GetSourceTypeMix() []*SourceTypeWeight {
+	if m != nil {
+		return m.SourceTypeMix
+	}
+	return nil
+}
+
+func (m *ImpactSummaryRequest) GetResolution() float64 {
+	if m != nil {
+		return m.Resolution
+	}
+	return 0
+}
+
+func (m *ImpactSummaryRequest) GetAutoResolution() bool {
+	if m != nil {
+		return m.AutoResolution
+	}
+	return false
+}
+
+func (m *ImpactSummaryRequest) GetSpeciate() bool {
+	if m != nil {
+		return m.Speciate
+	}
+	return false
+}
+
+func (m *ImpactSummaryRequest) GetAreaFallback() bool {
+	if m != nil {
+		return m.AreaFallback
+	}
+	return false
+}
+
+type ImpactSummaryResponse struct {
+	// Population is the total population.
+	Population float64 `protobuf:"fixed64,1,opt,name=Population,proto3" json:"Population,omitempty"`
+	// CityPopulation is the total population in the city.
+	CityPopulation float64 `protobuf:"fixed64,2,opt,name=CityPopulation,proto3" json:"CityPopulation,omitempty"`
+	// CityExposure is the population-weighted average
+	// concentration in the city.
+	CityExposure float64 `protobuf:"fixed64,3,opt,name=CityExposure,proto3" json:"CityExposure,omitempty"`
+	// TotalExposure is the overall population-weighted average
+	// concentration.
+	TotalExposure float64 `protobuf:"fixed64,4,opt,name=TotalExposure,proto3" json:"TotalExposure,omitempty"`
+	// CityIF is the intake fraction in the city.
+	CityIF float64 `protobuf:"fixed64,5,opt,name=CityIF,proto3" json:"CityIF,omitempty"`
+	// TotalIF is the total intake fraction.
+	TotalIF float64 `protobuf:"fixed64,6,opt,name=TotalIF,proto3" json:"TotalIF,omitempty"`
+	// PollutantImpacts are the impacts of each of the pollutants
+	// that are emitted. The exposures of all the pollutants add up
+	// to the exposures above.
+	PollutantImpacts     []*PollutantImpacts `protobuf:"bytes,7,rep,name=PollutantImpacts,proto3" json:"PollutantImpacts,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}            `json:"-"`
+	XXX_unrecognized     []byte              `json:"-"`
+	XXX_sizecache        int32               `json:"-"`
+}
+
+func (m *ImpactSummaryResponse) Reset()         { *m = ImpactSummaryResponse{} }
+func (m *ImpactSummaryResponse) String() string { return proto.CompactTextString(m) }
+func (*ImpactSummaryResponse) ProtoMessage()    {}
+func (*ImpactSummaryResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_cityaq_067e2154450e2bec, []int{30}
+}
+func (m *ImpactSummaryResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ImpactSummaryResponse.Unmarshal(m, b)
+}
+func (m *ImpactSummaryResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ImpactSummaryResponse.Marshal(b, m, deterministic)
+}
+func (dst *ImpactSummaryResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ImpactSummaryResponse.Merge(dst, src)
+}
+func (m *ImpactSummaryResponse) XXX_Size() int {
+	return xxx_messageInfo_ImpactSummaryResponse.Size(m)
+}
+func (m *ImpactSummaryResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ImpactSummaryResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ImpactSummaryResponse proto.InternalMessageInfo
+
+func (m *ImpactSummaryResponse) GetPopulation() float64 {
+	if m != nil {
+		return m.Population
+	}
+	return 0
+}
+
+func (m *ImpactSummaryResponse) GetCityPopulation() float64 {
+	if m != nil {
+		return m.CityPopulation
+	}
+	return 0
+}
+
+func (m *ImpactSummaryResponse) GetCityExposure() float64 {
+	if m != nil {
+		return m.CityExposure
+	}
+	return 0
+}
+
+func (m *ImpactSummaryResponse) GetTotalExposure() float64 {
+	if m != nil {
+		return m.TotalExposure
+	}
+	return 0
+}
+
+func (m *ImpactSummaryResponse) GetCityIF() float64 {
+	if m != nil {
+		return m.CityIF
+	}
+	return 0
+}
+
+func (m *ImpactSummaryResponse) GetTotalIF() float64 {
+	if m != nil {
+		return m.TotalIF
+	}
+	return 0
+}
+
+func (m *ImpactSummaryResponse) GetPollutantImpacts() []*PollutantImpacts {
+	if m != nil {
+		return m.PollutantImpacts
+	}
+	return nil
+}
+
+// PollutantImpacts are the impacts of the emissions of a pollutant.
+type PollutantImpacts struct {
+	Emission Emission `protobuf:"varint,1,opt,name=Emission,proto3,enum=cityaqrpc.Emission" json:"Emission,omitempty"`
+	// Amount is the mass of the pollutant emitted over
+	// the emissions period, in kilograms.
+	Amount float64 `protobuf:"fixed64,2,opt,name=Amount,proto3" json:"Amount,omitempty"`
+	// CityExposure and TotalExposure are the population-weighted
+	// average PM2.5 concentrations in the city and overall resulting
+	// from the emissions of the pollutant.
+	CityExposure  float64 `protobuf:"fixed64,3,opt,name=CityExposure,proto3" json:"CityExposure,omitempty"`
+	TotalExposure float64 `protobuf:"fixed64,4,opt,name=TotalExposure,proto3" json:"TotalExposure,omitempty"`
+	// CityIF and TotalIF are the intake fractions of
+	// the pollutant in the city and overall.
+	CityIF               float64  `protobuf:"fixed64,5,opt,name=CityIF,proto3" json:"CityIF,omitempty"`
+	TotalIF              float64  `protobuf:"fixed64,6,opt,name=TotalIF,proto3" json:"TotalIF,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *PollutantImpacts) Reset()         { *m = PollutantImpacts{} }
+func (m *PollutantImpacts) String() string { return proto.CompactTextString(m) }
+func (*PollutantImpacts) ProtoMessage()    {}
+func (*PollutantImpacts) Descriptor() ([]byte, []int) {
+	return fileDescriptor_cityaq_067e2154450e2bec, []int{31}
+}
+func (m *PollutantImpacts) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_PollutantImpacts.Unmarshal(m, b)
+}
+func (m *PollutantImpacts) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_PollutantImpacts.Marshal(b, m, deterministic)
+}
+func (dst *PollutantImpacts) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PollutantImpacts.Merge(dst, src)
+}
+func (m *PollutantImpacts) XXX_Size() int {
+	return xxx_messageInfo_PollutantImpacts.Size(m)
+}
+func (m *PollutantImpacts) XXX_DiscardUnknown() {
+	xxx_messageInfo_PollutantImpacts.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PollutantImpacts proto.InternalMessageInfo
+
+func (m *PollutantImpacts) GetEmission() Emission {
+	if m != nil {
+		return m.Emission
+	}
+	return Emission_UNKNOWN_EMISSION
+}
+
+func (m *PollutantImpacts) GetAmount() float64 {
 	if m != nil {
-		return m.SimulationType
+		return m.Amount
 	}
-	return SimulationType_UNKNOWN_SIMULATIONTYPE
+	return 0
 }
 
-type GriddedEmissionsResponse struct {
-	Polygons             []*Polygon `protobuf:"bytes,1,rep,name=Polygons,proto3" json:"Polygons,omitempty"`
-	Emissions            []float64  `protobuf:"fixed64,2,rep,packed,name=Emissions,proto3" json:"Emissions,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}   `json:"-"`
-	XXX_unrecognized     []byte     `json:"-"`
-	XXX_sizecache        int32      `json:"-"`
+func (m *PollutantImpacts) GetCityExposure() float64 {
+	if m != nil {
+		return m.CityExposure
+	}
+	return 0
 }
 
-func (m *GriddedEmissionsResponse) Reset()         { *m = GriddedEmissionsResponse{} }
-func (m *GriddedEmissionsResponse) String() string { return proto.CompactTextString(m) }
-func (*GriddedEmissionsResponse) ProtoMessage()    {}
-func (*GriddedEmissionsResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_cityaq_5d59c783e971c48c, []int{8}
+func (m *PollutantImpacts) GetTotalExposure() float64 {
+	if m != nil {
+		return m.TotalExposure
+	}
+	return 0
 }
-func (m *GriddedEmissionsResponse) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_GriddedEmissionsResponse.Unmarshal(m, b)
+
+func (m *PollutantImpacts) GetCityIF() float64 {
+	if m != nil {
+		return m.CityIF
+	}
+	return 0
 }
-func (m *GriddedEmissionsResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_GriddedEmissionsResponse.Marshal(b, m, deterministic)
+
+func (m *PollutantImpacts) GetTotalIF() float64 {
+	if m != nil {
+		return m.TotalIF
+	}
+	return 0
 }
-func (dst *GriddedEmissionsResponse) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_GriddedEmissionsResponse.Merge(dst, src)
+
+type EmissionsGridBoundsRequest struct {
+	CityName   string `protobuf:"bytes,1,opt,name=CityName,proto3" json:"CityName,omitempty"`
+	SourceType string `protobuf:"bytes,2,opt,name=SourceType,proto3" json:"SourceType,omitempty"`
+	// Resolution, if it is greater than zero, is the emissions grid cell
+	// edge length, in degrees, or in meters if the server builds grids in
+	// a projection. If it is zero, the resolution of the source type is used.
+	Resolution float64 `protobuf:"fixed64,3,opt,name=Resolution,proto3" json:"Resolution,omitempty"`
+	// AutoResolution specifies that the emissions grid resolution should
+	// be chosen from the extent of the area that emissions are allocated
+	// within, so that grids for large and small cities have similar
+	// numbers of cells. Resolution must be zero if it is set.
+	AutoResolution       bool     `protobuf:"varint,4,opt,name=AutoResolution,proto3" json:"AutoResolution,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
-func (m *GriddedEmissionsResponse) XXX_Size() int {
-	return xxx_messageInfo_GriddedEmissionsResponse.Size(m)
+
+func (m *EmissionsGridBoundsRequest) Reset()         { *m = EmissionsGridBoundsRequest{} }
+func (m *EmissionsGridBoundsRequest) String() string { return proto.CompactTextString(m) }
+func (*EmissionsGridBoundsRequest) ProtoMessage()    {}
+func (*EmissionsGridBoundsRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_cityaq_067e2154450e2bec, []int{32}
 }
-func (m *GriddedEmissionsResponse) XXX_DiscardUnknown() {
-	xxx_messageInfo_GriddedEmissionsResponse.DiscardUnknown(m)
+func (m *EmissionsGridBoundsRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_EmissionsGridBoundsRequest.Unmarshal(m, b)
+}
+func (m *EmissionsGridBoundsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_EmissionsGridBoundsRequest.Marshal(b, m, deterministic)
+}
+func (dst *EmissionsGridBoundsRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_EmissionsGridBoundsRequest.Merge(dst, src)
+}
+func (m *EmissionsGridBoundsRequest) XXX_Size() int {
+	return xxx_messageInfo_EmissionsGridBoundsRequest.Size(m)
+}
+func (m *EmissionsGridBoundsRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_EmissionsGridBoundsRequest.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_GriddedEmissionsResponse proto.InternalMessageInfo
+var xxx_messageInfo_EmissionsGridBoundsRequest proto.InternalMessageInfo
 
-func (m *GriddedEmissionsResponse) GetPolygons() []*Polygon {
+func (m *EmissionsGridBoundsRequest) GetCityName() string {
 	if m != nil {
-		return m.Polygons
+		return m.CityName
+	}
+	return ""
+}
+
+func (m *EmissionsGridBoundsRequest) GetSourceType() string {
+	if m != nil {
+		return m.SourceType
+	}
+	return ""
+}
+
+func (m *EmissionsGridBoundsRequest) GetResolution() float64 {
+	if m != nil {
+		return m.Resolution
+	}
+	return 0
+}
+
+func (m *EmissionsGridBoundsRequest) GetAutoResolution() bool {
+	if m != nil {
+		return m.AutoResolution
+	}
+	return false
+}
+
+type EmissionsGridBoundsResponse struct {
+	Min                  *Point   `protobuf:"bytes,1,opt,name=Min,proto3" json:"Min,omitempty"`
+	Max                  *Point   `protobuf:"bytes,2,opt,name=Max,proto3" json:"Max,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *EmissionsGridBoundsResponse) Reset()         { *m = EmissionsGridBoundsResponse{} }
+func (m *EmissionsGridBoundsResponse) String() string { return proto.CompactTextString(m) }
+func (*EmissionsGridBoundsResponse) ProtoMessage()    {}
+func (*EmissionsGridBoundsResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_cityaq_067e2154450e2bec, []int{33}
+}
+func (m *EmissionsGridBoundsResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_EmissionsGridBoundsResponse.Unmarshal(m, b)
+}
+func (m *EmissionsGridBoundsResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_EmissionsGridBoundsResponse.Marshal(b, m, deterministic)
+}
+func (dst *EmissionsGridBoundsResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_EmissionsGridBoundsResponse.Merge(dst, src)
+}
+func (m *EmissionsGridBoundsResponse) XXX_Size() int {
+	return xxx_messageInfo_EmissionsGridBoundsResponse.Size(m)
+}
+func (m *EmissionsGridBoundsResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_EmissionsGridBoundsResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_EmissionsGridBoundsResponse proto.InternalMessageInfo
+
+func (m *EmissionsGridBoundsResponse) GetMin() *Point {
+	if m != nil {
+		return m.Min
+	}
+	return nil
+}
+
+func (m *EmissionsGridBoundsResponse) GetMax() *Point {
+	if m != nil {
+		return m.Max
 	}
 	return nil
 }
 
-func (m *GriddedEmissionsResponse) GetEmissions() []float64 {
+type EmissionsInventorySectorsRequest struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *EmissionsInventorySectorsRequest) Reset()         { *m = EmissionsInventorySectorsRequest{} }
+func (m *EmissionsInventorySectorsRequest) String() string { return proto.CompactTextString(m) }
+func (*EmissionsInventorySectorsRequest) ProtoMessage()    {}
+func (*EmissionsInventorySectorsRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_cityaq_067e2154450e2bec, []int{34}
+}
+func (m *EmissionsInventorySectorsRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_EmissionsInventorySectorsRequest.Unmarshal(m, b)
+}
+func (m *EmissionsInventorySectorsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_EmissionsInventorySectorsRequest.Marshal(b, m, deterministic)
+}
+func (dst *EmissionsInventorySectorsRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_EmissionsInventorySectorsRequest.Merge(dst, src)
+}
+func (m *EmissionsInventorySectorsRequest) XXX_Size() int {
+	return xxx_messageInfo_EmissionsInventorySectorsRequest.Size(m)
+}
+func (m *EmissionsInventorySectorsRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_EmissionsInventorySectorsRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_EmissionsInventorySectorsRequest proto.InternalMessageInfo
+
+type EmissionsInventorySectorsResponse struct {
+	Sectors              []string `protobuf:"bytes,1,rep,name=Sectors,proto3" json:"Sectors,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *EmissionsInventorySectorsResponse) Reset()         { *m = EmissionsInventorySectorsResponse{} }
+func (m *EmissionsInventorySectorsResponse) String() string { return proto.CompactTextString(m) }
+func (*EmissionsInventorySectorsResponse) ProtoMessage()    {}
+func (*EmissionsInventorySectorsResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_cityaq_067e2154450e2bec, []int{35}
+}
+func (m *EmissionsInventorySectorsResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_EmissionsInventorySectorsResponse.Unmarshal(m, b)
+}
+func (m *EmissionsInventorySectorsResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_EmissionsInventorySectorsResponse.Marshal(b, m, deterministic)
+}
+func (dst *EmissionsInventorySectorsResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_EmissionsInventorySectorsResponse.Merge(dst, src)
+}
+func (m *EmissionsInventorySectorsResponse) XXX_Size() int {
+	return xxx_messageInfo_EmissionsInventorySectorsResponse.Size(m)
+}
+func (m *EmissionsInventorySectorsResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_EmissionsInventorySectorsResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_EmissionsInventorySectorsResponse proto.InternalMessageInfo
+
+func (m *EmissionsInventorySectorsResponse) GetSectors() []string {
 	if m != nil {
-		return m.Emissions
+		return m.Sectors
 	}
 	return nil
 }
 
-type GriddedConcentrationsRequest struct {
-	CityName             string         `protobuf:"bytes,1,opt,name=CityName,proto3" json:"CityName,omitempty"`
-	SourceType           string         `protobuf:"bytes,2,opt,name=SourceType,proto3" json:"SourceType,omitempty"`
-	Emission             Emission       `protobuf:"varint,3,opt,name=Emission,proto3,enum=cityaqrpc.Emission" json:"Emission,omitempty"`
-	SimulationType       SimulationType `protobuf:"varint,4,opt,name=SimulationType,proto3,enum=cityaqrpc.SimulationType" json:"SimulationType,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}       `json:"-"`
-	XXX_unrecognized     []byte         `json:"-"`
-	XXX_sizecache        int32          `json:"-"`
+// SectorChange is a change in the emissions of an emissions inventory
+// sector within a city.
+type SectorChange struct {
+	// Sector is the name of the sector, as returned by
+	// EmissionsInventorySectors.
+	Sector string `protobuf:"bytes,1,opt,name=Sector,proto3" json:"Sector,omitempty"`
+	// Percent is the change in the emissions of the sector, in percent.
+	// For example, -40 is a 40% reduction. It must be at least -100.
+	Percent              float64  `protobuf:"fixed64,2,opt,name=Percent,proto3" json:"Percent,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *GriddedConcentrationsRequest) Reset()         { *m = GriddedConcentrationsRequest{} }
-func (m *GriddedConcentrationsRequest) String() string { return proto.CompactTextString(m) }
-func (*GriddedConcentrationsRequest) ProtoMessage()    {}
-func (*GriddedConcentrationsRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_cityaq_5d59c783e971c48c, []int{9}
+func (m *SectorChange) Reset()         { *m = SectorChange{} }
+func (m *SectorChange) String() string { return proto.CompactTextString(m) }
+func (*SectorChange) ProtoMessage()    {}
+func (*SectorChange) Descriptor() ([]byte, []int) {
+	return fileDescriptor_cityaq_067e2154450e2bec, []int{36}
 }
-func (m *GriddedConcentrationsRequest) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_GriddedConcentrationsRequest.Unmarshal(m, b)
+func (m *SectorChange) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SectorChange.Unmarshal(m, b)
 }
-func (m *GriddedConcentrationsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_GriddedConcentrationsRequest.Marshal(b, m, deterministic)
+func (m *SectorChange) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SectorChange.Marshal(b, m, deterministic)
 }
-func (dst *GriddedConcentrationsRequest) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_GriddedConcentrationsRequest.Merge(dst, src)
+func (dst *SectorChange) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SectorChange.Merge(dst, src)
 }
-func (m *GriddedConcentrationsRequest) XXX_Size() int {
-	return xxx_messageInfo_GriddedConcentrationsRequest.Size(m)
+func (m *SectorChange) XXX_Size() int {
+	return xxx_messageInfo_SectorChange.Size(m)
 }
-func (m *GriddedConcentrationsRequest) XXX_DiscardUnknown() {
-	xxx_messageInfo_GriddedConcentrationsRequest.DiscardUnknown(m)
+func (m *SectorChange) XXX_DiscardUnknown() {
+	xxx_messageInfo_SectorChange.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_GriddedConcentrationsRequest proto.InternalMessageInfo
+var xxx_messageInfo_SectorChange proto.InternalMessageInfo
 
-func (m *GriddedConcentrationsRequest) GetCityName() string {
+func (m *SectorChange) GetSector() string {
 	if m != nil {
-		return m.CityName
+		return m.Sector
 	}
 	return ""
 }
 
-func (m *GriddedConcentrationsRequest) GetSourceType() string {
+func (m *SectorChange) GetPercent() float64 {
 	if m != nil {
-		return m.SourceType
+		return m.Percent
 	}
-	return ""
+	return 0
 }
 
-func (m *GriddedConcentrationsRequest) GetEmission() Emission {
+type ReductionScenarioRequest struct {
+	CityName string `protobuf:"bytes,1,opt,name=CityName,proto3" json:"CityName,omitempty"`
+	// Changes are the changes in the emissions of each sector in the
+	// scenario. The baseline includes the emissions of all of the sectors,
+	// so a sector can be included with a zero change to make it part of
+	// the baseline, but each sector can only be included once.
+	Changes              []*SectorChange `protobuf:"bytes,2,rep,name=Changes,proto3" json:"Changes,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}        `json:"-"`
+	XXX_unrecognized     []byte          `json:"-"`
+	XXX_sizecache        int32           `json:"-"`
+}
+
+func (m *ReductionScenarioRequest) Reset()         { *m = ReductionScenarioRequest{} }
+func (m *ReductionScenarioRequest) String() string { return proto.CompactTextString(m) }
+func (*ReductionScenarioRequest) ProtoMessage()    {}
+func (*ReductionScenarioRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_cityaq_067e2154450e2bec, []int{37}
+}
+func (m *ReductionScenarioRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ReductionScenarioRequest.Unmarshal(m, b)
+}
+func (m *ReductionScenarioRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ReductionScenarioRequest.Marshal(b, m, deterministic)
+}
+func (dst *ReductionScenarioRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ReductionScenarioRequest.Merge(dst, src)
+}
+func (m *ReductionScenarioRequest) XXX_Size() int {
+	return xxx_messageInfo_ReductionScenarioRequest.Size(m)
+}
+func (m *ReductionScenarioRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ReductionScenarioRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ReductionScenarioRequest proto.InternalMessageInfo
+
+func (m *ReductionScenarioRequest) GetCityName() string {
 	if m != nil {
-		return m.Emission
+		return m.CityName
 	}
-	return Emission_UNKNOWN_EMISSION
+	return ""
 }
 
-func (m *GriddedConcentrationsRequest) GetSimulationType() SimulationType {
+func (m *ReductionScenarioRequest) GetChanges() []*SectorChange {
 	if m != nil {
-		return m.SimulationType
+		return m.Changes
 	}
-	return SimulationType_UNKNOWN_SIMULATIONTYPE
+	return nil
 }
 
-type GriddedConcentrationsResponse struct {
-	Polygons             []*Polygon `protobuf:"bytes,1,rep,name=Polygons,proto3" json:"Polygons,omitempty"`
-	Concentrations       []float64  `protobuf:"fixed64,2,rep,packed,name=Concentrations,proto3" json:"Concentrations,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}   `json:"-"`
-	XXX_unrecognized     []byte     `json:"-"`
-	XXX_sizecache        int32      `json:"-"`
+type ReductionScenarioResponse struct {
+	Polygons []*Polygon `protobuf:"bytes,1,rep,name=Polygons,proto3" json:"Polygons,omitempty"`
+	// BaselineConcentrations and ScenarioConcentrations are the total
+	// PM2.5 concentrations in each grid cell in the baseline and the
+	// scenario, in μg/m³.
+	BaselineConcentrations []float64 `protobuf:"fixed64,2,rep,packed,name=BaselineConcentrations,proto3" json:"BaselineConcentrations,omitempty"`
+	ScenarioConcentrations []float64 `protobuf:"fixed64,3,rep,packed,name=ScenarioConcentrations,proto3" json:"ScenarioConcentrations,omitempty"`
+	// ConcentrationChanges are the scenario concentrations minus the
+	// baseline concentrations.
+	ConcentrationChanges []float64 `protobuf:"fixed64,4,rep,packed,name=ConcentrationChanges,proto3" json:"ConcentrationChanges,omitempty"`
+	// Population is the population in each grid cell.
+	Population []float64 `protobuf:"fixed64,5,rep,packed,name=Population,proto3" json:"Population,omitempty"`
+	// BaselineCityExposure and BaselineTotalExposure are the
+	// population-weighted average baseline concentrations in the
+	// city and overall.
+	BaselineCityExposure  float64 `protobuf:"fixed64,6,opt,name=BaselineCityExposure,proto3" json:"BaselineCityExposure,omitempty"`
+	BaselineTotalExposure float64 `protobuf:"fixed64,7,opt,name=BaselineTotalExposure,proto3" json:"BaselineTotalExposure,omitempty"`
+	// CityExposureChange and TotalExposureChange are the changes in the
+	// population-weighted average concentrations in the city and overall.
+	CityExposureChange   float64  `protobuf:"fixed64,8,opt,name=CityExposureChange,proto3" json:"CityExposureChange,omitempty"`
+	TotalExposureChange  float64  `protobuf:"fixed64,9,opt,name=TotalExposureChange,proto3" json:"TotalExposureChange,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *GriddedConcentrationsResponse) Reset()         { *m = GriddedConcentrationsResponse{} }
-func (m *GriddedConcentrationsResponse) String() string { return proto.CompactTextString(m) }
-func (*GriddedConcentrationsResponse) ProtoMessage()    {}
-func (*GriddedConcentrationsResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_cityaq_5d59c783e971c48c, []int{10}
+func (m *ReductionScenarioResponse) Reset()         { *m = ReductionScenarioResponse{} }
+func (m *ReductionScenarioResponse) String() string { return proto.CompactTextString(m) }
+func (*ReductionScenarioResponse) ProtoMessage()    {}
+func (*ReductionScenarioResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_cityaq_067e2154450e2bec, []int{38}
 }
-func (m *GriddedConcentrationsResponse) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_GriddedConcentrationsResponse.Unmarshal(m, b)
+func (m *ReductionScenarioResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ReductionScenarioResponse.Unmarshal(m, b)
 }
-func (m *GriddedConcentrationsResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_GriddedConcentrationsResponse.Marshal(b, m, deterministic)
+func (m *ReductionScenarioResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ReductionScenarioResponse.Marshal(b, m, deterministic)
 }
-func (dst *GriddedConcentrationsResponse) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_GriddedConcentrationsResponse.Merge(dst, src)
+func (dst *ReductionScenarioResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ReductionScenarioResponse.Merge(dst, src)
 }
-func (m *GriddedConcentrationsResponse) XXX_Size() int {
-	return xxx_messageInfo_GriddedConcentrationsResponse.Size(m)
+func (m *ReductionScenarioResponse) XXX_Size() int {
+	return xxx_messageInfo_ReductionScenarioResponse.Size(m)
 }
-func (m *GriddedConcentrationsResponse) XXX_DiscardUnknown() {
-	xxx_messageInfo_GriddedConcentrationsResponse.DiscardUnknown(m)
+func (m *ReductionScenarioResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ReductionScenarioResponse.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_GriddedConcentrationsResponse proto.InternalMessageInfo
+var xxx_messageInfo_ReductionScenarioResponse proto.InternalMessageInfo
 
-func (m *GriddedConcentrationsResponse) GetPolygons() []*Polygon {
+func (m *ReductionScenarioResponse) GetPolygons() []*Polygon {
 	if m != nil {
 		return m.Polygons
 	}
 	return nil
 }
 
-func (m *GriddedConcentrationsResponse) GetConcentrations() []float64 {
+func (m *ReductionScenarioResponse) GetBaselineConcentrations() []float64 {
 	if m != nil {
-		return m.Concentrations
+		return m.BaselineConcentrations
 	}
 	return nil
 }
 
-type GriddedPopulationRequest struct {
-	CityName             string         `protobuf:"bytes,1,opt,name=CityName,proto3" json:"CityName,omitempty"`
-	SourceType           string         `protobuf:"bytes,2,opt,name=SourceType,proto3" json:"SourceType,omitempty"`
-	Emission             Emission       `protobuf:"varint,3,opt,name=Emission,proto3,enum=cityaqrpc.Emission" json:"Emission,omitempty"`
-	SimulationType       SimulationType `protobuf:"varint,4,opt,name=SimulationType,proto3,enum=cityaqrpc.SimulationType" json:"SimulationType,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}       `json:"-"`
-	XXX_unrecognized     []byte         `json:"-"`
-	XXX_sizecache        int32          `json:"-"`
+func (m *ReductionScenarioResponse) GetScenarioConcentrations() []float64 {
+	if m != nil {
+		return m.ScenarioConcentrations
+	}
+	return nil
 }
 
-func (m *GriddedPopulationRequest) Reset()         { *m = GriddedPopulationRequest{} }
-func (m *GriddedPopulationRequest) String() string { return proto.CompactTextString(m) }
-func (*GriddedPopulationRequest) ProtoMessage()    {}
-func (*GriddedPopulationRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_cityaq_5d59c783e971c48c, []int{11}
-}
-func (m *GriddedPopulationRequest) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_GriddedPopulationRequest.Unmarshal(m, b)
-}
-func (m *GriddedPopulationRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_GriddedPopulationRequest.Marshal(b, m, deterministic)
-}
-func (dst *GriddedPopulationRequest) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_GriddedPopulationRequest.Merge(dst, src)
-}
-func (m *GriddedPopulationRequest) XXX_Size() int {
-	return xxx_messageInfo_GriddedPopulationRequest.Size(m)
-}
-func (m *GriddedPopulationRequest) XXX_DiscardUnknown() {
-	xxx_messageInfo_GriddedPopulationRequest.DiscardUnknown(m)
+func (m *ReductionScenarioResponse) GetConcentrationChanges() []float64 {
+	if m != nil {
+		return m.ConcentrationChanges
+	}
+	return nil
 }
 
-var xxx_messageInfo_GriddedPopulationRequest proto.InternalMessageInfo
-
-func (m *GriddedPopulationRequest) GetCityName() string {
+func (m *ReductionScenarioResponse) GetPopulation() []float64 {
 	if m != nil {
-		return m.CityName
+		return m.Population
 	}
-	return ""
+	return nil
 }
 
-func (m *GriddedPopulationRequest) GetSourceType() string {
+func (m *ReductionScenarioResponse) GetBaselineCityExposure() float64 {
 	if m != nil {
-		return m.SourceType
+		return m.BaselineCityExposure
 	}
-	return ""
+	return 0
 }
 
-func (m *GriddedPopulationRequest) GetEmission() Emission {
+func (m *ReductionScenarioResponse) GetBaselineTotalExposure() float64 {
 	if m != nil {
-		return m.Emission
+		return m.BaselineTotalExposure
 	}
-	return Emission_UNKNOWN_EMISSION
+	return 0
 }
 
-func (m *GriddedPopulationRequest) GetSimulationType() SimulationType {
+func (m *ReductionScenarioResponse) GetCityExposureChange() float64 {
 	if m != nil {
-		return m.SimulationType
+		return m.CityExposureChange
 	}
-	return SimulationType_UNKNOWN_SIMULATIONTYPE
+	return 0
 }
 
-type GriddedPopulationResponse struct {
-	Polygons             []*Polygon `protobuf:"bytes,1,rep,name=Polygons,proto3" json:"Polygons,omitempty"`
-	Population           []float64  `protobuf:"fixed64,2,rep,packed,name=Population,proto3" json:"Population,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}   `json:"-"`
-	XXX_unrecognized     []byte     `json:"-"`
-	XXX_sizecache        int32      `json:"-"`
+func (m *ReductionScenarioResponse) GetTotalExposureChange() float64 {
+	if m != nil {
+		return m.TotalExposureChange
+	}
+	return 0
 }
 
-func (m *GriddedPopulationResponse) Reset()         { *m = GriddedPopulationResponse{} }
-func (m *GriddedPopulationResponse) String() string { return proto.CompactTextString(m) }
-func (*GriddedPopulationResponse) ProtoMessage()    {}
-func (*GriddedPopulationResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_cityaq_5d59c783e971c48c, []int{12}
+type SourceTypesRequest struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
-func (m *GriddedPopulationResponse) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_GriddedPopulationResponse.Unmarshal(m, b)
+
+func (m *SourceTypesRequest) Reset()         { *m = SourceTypesRequest{} }
+func (m *SourceTypesRequest) String() string { return proto.CompactTextString(m) }
+func (*SourceTypesRequest) ProtoMessage()    {}
+func (*SourceTypesRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_cityaq_067e2154450e2bec, []int{39}
 }
-func (m *GriddedPopulationResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_GriddedPopulationResponse.Marshal(b, m, deterministic)
+func (m *SourceTypesRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SourceTypesRequest.Unmarshal(m, b)
 }
-func (dst *GriddedPopulationResponse) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_GriddedPopulationResponse.Merge(dst, src)
+func (m *SourceTypesRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SourceTypesRequest.Marshal(b, m, deterministic)
 }
-func (m *GriddedPopulationResponse) XXX_Size() int {
-	return xxx_messageInfo_GriddedPopulationResponse.Size(m)
+func (dst *SourceTypesRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SourceTypesRequest.Merge(dst, src)
 }
-func (m *GriddedPopulationResponse) XXX_DiscardUnknown() {
-	xxx_messageInfo_GriddedPopulationResponse.DiscardUnknown(m)
+func (m *SourceTypesRequest) XXX_Size() int {
+	return xxx_messageInfo_SourceTypesRequest.Size(m)
 }
-
-var xxx_messageInfo_GriddedPopulationResponse proto.InternalMessageInfo
-
-func (m *GriddedPopulationResponse) GetPolygons() []*Polygon {
-	if m != nil {
-		return m.Polygons
-	}
-	return nil
+func (m *SourceTypesRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_SourceTypesRequest.DiscardUnknown(m)
 }
 
-func (m *GriddedPopulationResponse) GetPopulation() []float64 {
-	if m != nil {
-		return m.Population
-	}
-	return nil
-}
+var xxx_messageInfo_SourceTypesRequest proto.InternalMessageInfo
 
-type ImpactSummaryRequest struct {
-	CityName             string         `protobuf:"bytes,1,opt,name=CityName,proto3" json:"CityName,omitempty"`
-	SourceType           string         `protobuf:"bytes,2,opt,name=SourceType,proto3" json:"SourceType,omitempty"`
-	Emission             Emission       `protobuf:"varint,3,opt,name=Emission,proto3,enum=cityaqrpc.Emission" json:"Emission,omitempty"`
-	SimulationType       SimulationType `protobuf:"varint,4,opt,name=SimulationType,proto3,enum=cityaqrpc.SimulationType" json:"SimulationType,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}       `json:"-"`
-	XXX_unrecognized     []byte         `json:"-"`
-	XXX_sizecache        int32          `json:"-"`
+type SourceTypesResponse struct {
+	SourceTypes          []*SourceType `protobuf:"bytes,1,rep,name=SourceTypes,proto3" json:"SourceTypes,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}      `json:"-"`
+	XXX_unrecognized     []byte        `json:"-"`
+	XXX_sizecache        int32         `json:"-"`
 }
 
-func (m *ImpactSummaryRequest) Reset()         { *m = ImpactSummaryRequest{} }
-func (m *ImpactSummaryRequest) String() string { return proto.CompactTextString(m) }
-func (*ImpactSummaryRequest) ProtoMessage()    {}
-func (*ImpactSummaryRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_cityaq_5d59c783e971c48c, []int{13}
+func (m *SourceTypesResponse) Reset()         { *m = SourceTypesResponse{} }
+func (m *SourceTypesResponse) String() string { return proto.CompactTextString(m) }
+func (*SourceTypesResponse) ProtoMessage()    {}
+func (*SourceTypesResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_cityaq_067e2154450e2bec, []int{40}
 }
-func (m *ImpactSummaryRequest) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_ImpactSummaryRequest.Unmarshal(m, b)
+func (m *SourceTypesResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SourceTypesResponse.Unmarshal(m, b)
 }
-func (m *ImpactSummaryRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_ImpactSummaryRequest.Marshal(b, m, deterministic)
+func (m *SourceTypesResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SourceTypesResponse.Marshal(b, m, deterministic)
 }
-func (dst *ImpactSummaryRequest) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_ImpactSummaryRequest.Merge(dst, src)
+func (dst *SourceTypesResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SourceTypesResponse.Merge(dst, src)
 }
-func (m *ImpactSummaryRequest) XXX_Size() int {
-	return xxx_messageInfo_ImpactSummaryRequest.Size(m)
+func (m *SourceTypesResponse) XXX_Size() int {
+	return xxx_messageInfo_SourceTypesResponse.Size(m)
 }
-func (m *ImpactSummaryRequest) XXX_DiscardUnknown() {
-	xxx_messageInfo_ImpactSummaryRequest.DiscardUnknown(m)
+func (m *SourceTypesResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_SourceTypesResponse.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_ImpactSummaryRequest proto.InternalMessageInfo
+var xxx_messageInfo_SourceTypesResponse proto.InternalMessageInfo
 
-func (m *ImpactSummaryRequest) GetCityName() string {
+func (m *SourceTypesResponse) GetSourceTypes() []*SourceType {
 	if m != nil {
-		return m.CityName
+		return m.SourceTypes
 	}
-	return ""
+	return nil
 }
 
-func (m *ImpactSummaryRequest) GetSourceType() string {
+// SourceType describes how the emissions of a source type
+// are allocated and released.
+type SourceType struct {
+	// Name is the name of the source type to use in requests.
+	Name string `protobuf:"bytes,1,opt,name=Name,proto3" json:"Name,omitempty"`
+	// Label and Description are for display to users.
+	Label       string `protobuf:"bytes,2,opt,name=Label,proto3" json:"Label,omitempty"`
+	Description string `protobuf:"bytes,3,opt,name=Description,proto3" json:"Description,omitempty"`
+	// Domain is the area that emissions are allocated within.
+	Domain AllocationDomain `protobuf:"varint,4,opt,name=Domain,proto3,enum=cityaqrpc.AllocationDomain" json:"Domain,omitempty"`
+	// Resolution is the emissions grid cell edge length in degrees.
+	Resolution float64 `protobuf:"fixed64,5,opt,name=Resolution,proto3" json:"Resolution,omitempty"`
+	// CellSize is the emissions grid cell edge length in meters
+	// when the server builds emissions grids in a projection.
+	CellSize float64 `protobuf:"fixed64,10,opt,name=CellSize,proto3" json:"CellSize,omitempty"`
+	// StackHeight [m], StackDiameter [m], StackTemperature [K], and
+	// StackVelocity [m/s] are the stack parameters of elevated
+	// releases. They are zero for ground-level releases.
+	StackHeight      float64 `protobuf:"fixed64,6,opt,name=StackHeight,proto3" json:"StackHeight,omitempty"`
+	StackDiameter    float64 `protobuf:"fixed64,7,opt,name=StackDiameter,proto3" json:"StackDiameter,omitempty"`
+	StackTemperature float64 `protobuf:"fixed64,8,opt,name=StackTemperature,proto3" json:"StackTemperature,omitempty"`
+	StackVelocity    float64 `protobuf:"fixed64,9,opt,name=StackVelocity,proto3" json:"StackVelocity,omitempty"`
+	// Speciation is the fraction of the total emissions mass of
+	// the source type that is each pollutant. It is empty if the
+	// source type does not have a speciation profile.
+	Speciation           []*PollutantFraction `protobuf:"bytes,11,rep,name=Speciation,proto3" json:"Speciation,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
+	XXX_unrecognized     []byte               `json:"-"`
+	XXX_sizecache        int32                `json:"-"`
+}
+
+func (m *SourceType) Reset()         { *m = SourceType{} }
+func (m *SourceType) String() string { return proto.CompactTextString(m) }
+func (*SourceType) ProtoMessage()    {}
+func (*SourceType) Descriptor() ([]byte, []int) {
+	return fileDescriptor_cityaq_067e2154450e2bec, []int{41}
+}
+func (m *SourceType) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SourceType.Unmarshal(m, b)
+}
+func (m *SourceType) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SourceType.Marshal(b, m, deterministic)
+}
+func (dst *SourceType) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SourceType.Merge(dst, src)
+}
+func (m *SourceType) XXX_Size() int {
+	return xxx_messageInfo_SourceType.Size(m)
+}
+func (m *SourceType) XXX_DiscardUnknown() {
+	xxx_messageInfo_SourceType.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SourceType proto.InternalMessageInfo
+
+func (m *SourceType) GetName() string {
 	if m != nil {
-		return m.SourceType
+		return m.Name
 	}
 	return ""
 }
 
-func (m *ImpactSummaryRequest) GetEmission() Emission {
+func (m *SourceType) GetLabel() string {
 	if m != nil {
-		return m.Emission
+		return m.Label
 	}
-	return Emission_UNKNOWN_EMISSION
+	return ""
 }
 
-func (m *ImpactSummaryRequest) GetSimulationType() SimulationType {
+func (m *SourceType) GetDescription() string {
 	if m != nil {
-		return m.SimulationType
+		return m.Description
 	}
-	return SimulationType_UNKNOWN_SIMULATIONTYPE
+	return ""
 }
 
-type ImpactSummaryResponse struct {
-	// Population is the total population.
-	Population float64 `protobuf:"fixed64,1,opt,name=Population,proto3" json:"Population,omitempty"`
-	// CityPopulation is the total population in the city.
-	CityPopulation float64 `protobuf:"fixed64,2,opt,name=CityPopulation,proto3" json:"CityPopulation,omitempty"`
-	// CityExposure is the population-weighted average
-	// concentration in the city.
-	CityExposure float64 `protobuf:"fixed64,3,opt,name=CityExposure,proto3" json:"CityExposure,omitempty"`
-	// TotalExposure is the overall population-weighted average
-	// concentration.
-	TotalExposure float64 `protobuf:"fixed64,4,opt,name=TotalExposure,proto3" json:"TotalExposure,omitempty"`
-	// CityIF is the intake fraction in the city.
-	CityIF float64 `protobuf:"fixed64,5,opt,name=CityIF,proto3" json:"CityIF,omitempty"`
-	// TotalIF is the total intake fraction.
-	TotalIF              float64  `protobuf:"fixed64,6,opt,name=TotalIF,proto3" json:"TotalIF,omitempty"`
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
+func (m *SourceType) GetDomain() AllocationDomain {
+	if m != nil {
+		return m.Domain
+	}
+	return AllocationDomain_UNKNOWN_ALLOCATIONDOMAIN
 }
 
-func (m *ImpactSummaryResponse) Reset()         { *m = ImpactSummaryResponse{} }
-func (m *ImpactSummaryResponse) String() string { return proto.CompactTextString(m) }
-func (*ImpactSummaryResponse) ProtoMessage()    {}
-func (*ImpactSummaryResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_cityaq_5d59c783e971c48c, []int{14}
-}
-func (m *ImpactSummaryResponse) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_ImpactSummaryResponse.Unmarshal(m, b)
-}
-func (m *ImpactSummaryResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_ImpactSummaryResponse.Marshal(b, m, deterministic)
-}
-func (dst *ImpactSummaryResponse) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_ImpactSummaryResponse.Merge(dst, src)
-}
-func (m *ImpactSummaryResponse) XXX_Size() int {
-	return xxx_messageInfo_ImpactSummaryResponse.Size(m)
-}
-func (m *ImpactSummaryResponse) XXX_DiscardUnknown() {
-	xxx_messageInfo_ImpactSummaryResponse.DiscardUnknown(m)
+func (m *SourceType) GetResolution() float64 {
+	if m != nil {
+		return m.Resolution
+	}
+	return 0
 }
 
-var xxx_messageInfo_ImpactSummaryResponse proto.InternalMessageInfo
-
-func (m *ImpactSummaryResponse) GetPopulation() float64 {
+func (m *SourceType) GetCellSize() float64 {
 	if m != nil {
-		return m.Population
+		return m.CellSize
 	}
 	return 0
 }
 
-func (m *ImpactSummaryResponse) GetCityPopulation() float64 {
+func (m *SourceType) GetStackHeight() float64 {
 	if m != nil {
-		return m.CityPopulation
+		return m.StackHeight
 	}
 	return 0
 }
 
-func (m *ImpactSummaryResponse) GetCityExposure() float64 {
+func (m *SourceType) GetStackDiameter() float64 {
 	if m != nil {
-		return m.CityExposure
+		return m.StackDiameter
 	}
 	return 0
 }
 
-func (m *ImpactSummaryResponse) GetTotalExposure() float64 {
+func (m *SourceType) GetStackTemperature() float64 {
 	if m != nil {
-		return m.TotalExposure
+		return m.StackTemperature
 	}
 	return 0
 }
 
-func (m *ImpactSummaryResponse) GetCityIF() float64 {
+func (m *SourceType) GetStackVelocity() float64 {
 	if m != nil {
-		return m.CityIF
+		return m.StackVelocity
 	}
 	return 0
 }
 
-func (m *ImpactSummaryResponse) GetTotalIF() float64 {
+func (m *SourceType) GetSpeciation() []*PollutantFraction {
 	if m != nil {
-		return m.TotalIF
+		return m.Speciation
 	}
-	return 0
+	return nil
 }
 
-type EmissionsGridBoundsRequest struct {
-	CityName             string   `protobuf:"bytes,1,opt,name=CityName,proto3" json:"CityName,omitempty"`
-	SourceType           string   `protobuf:"bytes,2,opt,name=SourceType,proto3" json:"SourceType,omitempty"`
+// PollutantFraction is the fraction of a mass of emissions
+// that is a pollutant.
+type PollutantFraction struct {
+	Emission             Emission `protobuf:"varint,1,opt,name=Emission,proto3,enum=cityaqrpc.Emission" json:"Emission,omitempty"`
+	Fraction             float64  `protobuf:"fixed64,2,opt,name=Fraction,proto3" json:"Fraction,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *EmissionsGridBoundsRequest) Reset()         { *m = EmissionsGridBoundsRequest{} }
-func (m *EmissionsGridBoundsRequest) String() string { return proto.CompactTextString(m) }
-func (*EmissionsGridBoundsRequest) ProtoMessage()    {}
-func (*EmissionsGridBoundsRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_cityaq_5d59c783e971c48c, []int{15}
+func (m *PollutantFraction) Reset()         { *m = PollutantFraction{} }
+func (m *PollutantFraction) String() string { return proto.CompactTextString(m) }
+func (*PollutantFraction) ProtoMessage()    {}
+func (*PollutantFraction) Descriptor() ([]byte, []int) {
+	return fileDescriptor_cityaq_067e2154450e2bec, []int{42}
 }
-func (m *EmissionsGridBoundsRequest) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_EmissionsGridBoundsRequest.Unmarshal(m, b)
+func (m *PollutantFraction) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_PollutantFraction.Unmarshal(m, b)
 }
-func (m *EmissionsGridBoundsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_EmissionsGridBoundsRequest.Marshal(b, m, deterministic)
+func (m *PollutantFraction) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_PollutantFraction.Marshal(b, m, deterministic)
 }
-func (dst *EmissionsGridBoundsRequest) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_EmissionsGridBoundsRequest.Merge(dst, src)
+func (dst *PollutantFraction) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PollutantFraction.Merge(dst, src)
 }
-func (m *EmissionsGridBoundsRequest) XXX_Size() int {
-	return xxx_messageInfo_EmissionsGridBoundsRequest.Size(m)
+func (m *PollutantFraction) XXX_Size() int {
+	return xxx_messageInfo_PollutantFraction.Size(m)
 }
-func (m *EmissionsGridBoundsRequest) XXX_DiscardUnknown() {
-	xxx_messageInfo_EmissionsGridBoundsRequest.DiscardUnknown(m)
+func (m *PollutantFraction) XXX_DiscardUnknown() {
+	xxx_messageInfo_PollutantFraction.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_EmissionsGridBoundsRequest proto.InternalMessageInfo
+var xxx_messageInfo_PollutantFraction proto.InternalMessageInfo
 
-func (m *EmissionsGridBoundsRequest) GetCityName() string {
+func (m *PollutantFraction) GetEmission() Emission {
 	if m != nil {
-		return m.CityName
+		return m.Emission
 	}
-	return ""
+	return Emission_UNKNOWN_EMISSION
 }
 
-func (m *EmissionsGridBoundsRequest) GetSourceType() string {
+func (m *PollutantFraction) GetFraction() float64 {
 	if m != nil {
-		return m.SourceType
+		return m.Fraction
 	}
-	return ""
+	return 0
 }
 
-type EmissionsGridBoundsResponse struct {
-	Min                  *Point   `protobuf:"bytes,1,opt,name=Min,proto3" json:"Min,omitempty"`
-	Max                  *Point   `protobuf:"bytes,2,opt,name=Max,proto3" json:"Max,omitempty"`
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
+type ExportGriddedRequest struct {
+	// Exactly one of Emissions, Concentrations, and Population must be
+	// set, to specify the gridded data to export.
+	Emissions            *GriddedEmissionsRequest      `protobuf:"bytes,1,opt,name=Emissions,proto3" json:"Emissions,omitempty"`
+	Concentrations       *GriddedConcentrationsRequest `protobuf:"bytes,2,opt,name=Concentrations,proto3" json:"Concentrations,omitempty"`
+	Population           *GriddedPopulationRequest     `protobuf:"bytes,3,opt,name=Population,proto3" json:"Population,omitempty"`
+	Format               ExportFormat                  `protobuf:"varint,4,opt,name=Format,proto3,enum=cityaqrpc.ExportFormat" json:"Format,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}                      `json:"-"`
+	XXX_unrecognized     []byte                        `json:"-"`
+	XXX_sizecache        int32                         `json:"-"`
 }
 
-func (m *EmissionsGridBoundsResponse) Reset()         { *m = EmissionsGridBoundsResponse{} }
-func (m *EmissionsGridBoundsResponse) String() string { return proto.CompactTextString(m) }
-func (*EmissionsGridBoundsResponse) ProtoMessage()    {}
-func (*EmissionsGridBoundsResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_cityaq_5d59c783e971c48c, []int{16}
+func (m *ExportGriddedRequest) Reset()         { *m = ExportGriddedRequest{} }
+func (m *ExportGriddedRequest) String() string { return proto.CompactTextString(m) }
+func (*ExportGriddedRequest) ProtoMessage()    {}
+func (*ExportGriddedRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_cityaq_067e2154450e2bec, []int{43}
 }
-func (m *EmissionsGridBoundsResponse) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_EmissionsGridBoundsResponse.Unmarshal(m, b)
+func (m *ExportGriddedRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ExportGriddedRequest.Unmarshal(m, b)
 }
-func (m *EmissionsGridBoundsResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_EmissionsGridBoundsResponse.Marshal(b, m, deterministic)
+func (m *ExportGriddedRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ExportGriddedRequest.Marshal(b, m, deterministic)
 }
-func (dst *EmissionsGridBoundsResponse) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_EmissionsGridBoundsResponse.Merge(dst, src)
+func (dst *ExportGriddedRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ExportGriddedRequest.Merge(dst, src)
 }
-func (m *EmissionsGridBoundsResponse) XXX_Size() int {
-	return xxx_messageInfo_EmissionsGridBoundsResponse.Size(m)
+func (m *ExportGriddedRequest) XXX_Size() int {
+	return xxx_messageInfo_ExportGriddedRequest.Size(m)
 }
-func (m *EmissionsGridBoundsResponse) XXX_DiscardUnknown() {
-	xxx_messageInfo_EmissionsGridBoundsResponse.DiscardUnknown(m)
+func (m *ExportGriddedRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ExportGriddedRequest.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_EmissionsGridBoundsResponse proto.InternalMessageInfo
+var xxx_messageInfo_ExportGriddedRequest proto.InternalMessageInfo
 
-func (m *EmissionsGridBoundsResponse) GetMin() *Point {
+func (m *ExportGriddedRequest) GetEmissions() *GriddedEmissionsRequest {
 	if m != nil {
-		return m.Min
+		return m.Emissions
 	}
 	return nil
 }
 
-func (m *EmissionsGridBoundsResponse) GetMax() *Point {
+func (m *ExportGriddedRequest) GetConcentrations() *GriddedConcentrationsRequest {
 	if m != nil {
-		return m.Max
+		return m.Concentrations
 	}
 	return nil
 }
 
-type EmissionsInventorySectorsRequest struct {
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
+func (m *ExportGriddedRequest) GetPopulation() *GriddedPopulationRequest {
+	if m != nil {
+		return m.Population
+	}
+	return nil
 }
 
-func (m *EmissionsInventorySectorsRequest) Reset()         { *m = EmissionsInventorySectorsRequest{} }
-func (m *EmissionsInventorySectorsRequest) String() string { return proto.CompactTextString(m) }
-func (*EmissionsInventorySectorsRequest) ProtoMessage()    {}
-func (*EmissionsInventorySectorsRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_cityaq_5d59c783e971c48c, []int{17}
-}
-func (m *EmissionsInventorySectorsRequest) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_EmissionsInventorySectorsRequest.Unmarshal(m, b)
-}
-func (m *EmissionsInventorySectorsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_EmissionsInventorySectorsRequest.Marshal(b, m, deterministic)
-}
-func (dst *EmissionsInventorySectorsRequest) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_EmissionsInventorySectorsRequest.Merge(dst, src)
-}
-func (m *EmissionsInventorySectorsRequest) XXX_Size() int {
-	return xxx_messageInfo_EmissionsInventorySectorsRequest.Size(m)
-}
-func (m *EmissionsInventorySectorsRequest) XXX_DiscardUnknown() {
-	xxx_messageInfo_EmissionsInventorySectorsRequest.DiscardUnknown(m)
+func (m *ExportGriddedRequest) GetFormat() ExportFormat {
+	if m != nil {
+		return m.Format
+	}
+	return ExportFormat_UNKNOWN_EXPORTFORMAT
 }
 
-var xxx_messageInfo_EmissionsInventorySectorsRequest proto.InternalMessageInfo
-
-type EmissionsInventorySectorsResponse struct {
-	Sectors              []string `protobuf:"bytes,1,rep,name=Sectors,proto3" json:"Sectors,omitempty"`
+type ExportGriddedResponse struct {
+	// FileName is a suggested name for the file.
+	FileName string `protobuf:"bytes,1,opt,name=FileName,proto3" json:"FileName,omitempty"`
+	// ContentType is the MIME type of the file.
+	ContentType string `protobuf:"bytes,2,opt,name=ContentType,proto3" json:"ContentType,omitempty"`
+	// Data holds the contents of the file.
+	Data                 []byte   `protobuf:"bytes,3,opt,name=Data,proto3" json:"Data,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *EmissionsInventorySectorsResponse) Reset()         { *m = EmissionsInventorySectorsResponse{} }
-func (m *EmissionsInventorySectorsResponse) String() string { return proto.CompactTextString(m) }
-func (*EmissionsInventorySectorsResponse) ProtoMessage()    {}
-func (*EmissionsInventorySectorsResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_cityaq_5d59c783e971c48c, []int{18}
+func (m *ExportGriddedResponse) Reset()         { *m = ExportGriddedResponse{} }
+func (m *ExportGriddedResponse) String() string { return proto.CompactTextString(m) }
+func (*ExportGriddedResponse) ProtoMessage()    {}
+func (*ExportGriddedResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_cityaq_067e2154450e2bec, []int{44}
 }
-func (m *EmissionsInventorySectorsResponse) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_EmissionsInventorySectorsResponse.Unmarshal(m, b)
+func (m *ExportGriddedResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ExportGriddedResponse.Unmarshal(m, b)
 }
-func (m *EmissionsInventorySectorsResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_EmissionsInventorySectorsResponse.Marshal(b, m, deterministic)
+func (m *ExportGriddedResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ExportGriddedResponse.Marshal(b, m, deterministic)
 }
-func (dst *EmissionsInventorySectorsResponse) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_EmissionsInventorySectorsResponse.Merge(dst, src)
+func (dst *ExportGriddedResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ExportGriddedResponse.Merge(dst, src)
 }
-func (m *EmissionsInventorySectorsResponse) XXX_Size() int {
-	return xxx_messageInfo_EmissionsInventorySectorsResponse.Size(m)
+func (m *ExportGriddedResponse) XXX_Size() int {
+	return xxx_messageInfo_ExportGriddedResponse.Size(m)
 }
-func (m *EmissionsInventorySectorsResponse) XXX_DiscardUnknown() {
-	xxx_messageInfo_EmissionsInventorySectorsResponse.DiscardUnknown(m)
+func (m *ExportGriddedResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ExportGriddedResponse.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_EmissionsInventorySectorsResponse proto.InternalMessageInfo
+var xxx_messageInfo_ExportGriddedResponse proto.InternalMessageInfo
 
-func (m *EmissionsInventorySectorsResponse) GetSectors() []string {
+func (m *ExportGriddedResponse) GetFileName() string {
 	if m != nil {
-		return m.Sectors
+		return m.FileName
+	}
+	return ""
+}
+
+func (m *ExportGriddedResponse) GetContentType() string {
+	if m != nil {
+		return m.ContentType
+	}
+	return ""
+}
+
+func (m *ExportGriddedResponse) GetData() []byte {
+	if m != nil {
+		return m.Data
 	}
 	return nil
 }
 
 type MapScaleRequest struct {
-	CityName             string         `protobuf:"bytes,1,opt,name=CityName,proto3" json:"CityName,omitempty"`
-	ImpactType           ImpactType     `protobuf:"varint,2,opt,name=ImpactType,proto3,enum=cityaqrpc.ImpactType" json:"ImpactType,omitempty"`
-	Emission             Emission       `protobuf:"varint,3,opt,name=Emission,proto3,enum=cityaqrpc.Emission" json:"Emission,omitempty"`
-	SourceType           string         `protobuf:"bytes,4,opt,name=SourceType,proto3" json:"SourceType,omitempty"`
-	SimulationType       SimulationType `protobuf:"varint,5,opt,name=SimulationType,proto3,enum=cityaqrpc.SimulationType" json:"SimulationType,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}       `json:"-"`
-	XXX_unrecognized     []byte         `json:"-"`
-	XXX_sizecache        int32          `json:"-"`
+	CityName       string         `protobuf:"bytes,1,opt,name=CityName,proto3" json:"CityName,omitempty"`
+	ImpactType     ImpactType     `protobuf:"varint,2,opt,name=ImpactType,proto3,enum=cityaqrpc.ImpactType" json:"ImpactType,omitempty"`
+	Emission       Emission       `protobuf:"varint,3,opt,name=Emission,proto3,enum=cityaqrpc.Emission" json:"Emission,omitempty"`
+	SourceType     string         `protobuf:"bytes,4,opt,name=SourceType,proto3" json:"SourceType,omitempty"`
+	SimulationType SimulationType `protobuf:"varint,5,opt,name=SimulationType,proto3,enum=cityaqrpc.SimulationType" json:"SimulationType,omitempty"`
+	// Resolution, if it is greater than zero, is the emissions grid cell
+	// edge length, in degrees, or in meters if the server builds grids in
+	// a projection. If it is zero, the resolution of the source type is used.
+	Resolution float64 `protobuf:"fixed64,6,opt,name=Resolution,proto3" json:"Resolution,omitempty"`
+	// AutoResolution specifies that the emissions grid resolution should
+	// be chosen from the extent of the area that emissions are allocated
+	// within, so that grids for large and small cities have similar
+	// numbers of cells. Resolution must be zero if it is set.
+	AutoResolution       bool     `protobuf:"varint,7,opt,name=AutoResolution,proto3" json:"AutoResolution,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
 func (m *MapScaleRequest) Reset()         { *m = MapScaleRequest{} }
 func (m *MapScaleRequest) String() string { return proto.CompactTextString(m) }
 func (*MapScaleRequest) ProtoMessage()    {}
 func (*MapScaleRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_cityaq_5d59c783e971c48c, []int{19}
+	return fileDescriptor_cityaq_067e2154450e2bec, []int{45}
 }
 func (m *MapScaleRequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_MapScaleRequest.Unmarshal(m, b)
@@ -1084,6 +3601,20 @@ func (m *MapScaleRequest) GetSimulationType() SimulationType {
 	return SimulationType_UNKNOWN_SIMULATIONTYPE
 }
 
+func (m *MapScaleRequest) GetResolution() float64 {
+	if m != nil {
+		return m.Resolution
+	}
+	return 0
+}
+
+func (m *MapScaleRequest) GetAutoResolution() bool {
+	if m != nil {
+		return m.AutoResolution
+	}
+	return false
+}
+
 type MapScaleResponse struct {
 	Min                  float64  `protobuf:"fixed64,1,opt,name=Min,proto3" json:"Min,omitempty"`
 	Max                  float64  `protobuf:"fixed64,2,opt,name=Max,proto3" json:"Max,omitempty"`
@@ -1097,7 +3628,7 @@ func (m *MapScaleResponse) Reset()         { *m = MapScaleResponse{} }
 func (m *MapScaleResponse) String() string { return proto.CompactTextString(m) }
 func (*MapScaleResponse) ProtoMessage()    {}
 func (*MapScaleResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_cityaq_5d59c783e971c48c, []int{20}
+	return fileDescriptor_cityaq_067e2154450e2bec, []int{46}
 }
 func (m *MapScaleResponse) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_MapScaleResponse.Unmarshal(m, b)
@@ -1141,26 +3672,57 @@ func (m *MapScaleResponse) GetCutPt() float64 {
 func init() {
 	proto.RegisterType((*CitiesRequest)(nil), "cityaqrpc.CitiesRequest")
 	proto.RegisterType((*CitiesResponse)(nil), "cityaqrpc.CitiesResponse")
+	proto.RegisterType((*City)(nil), "cityaqrpc.City")
 	proto.RegisterType((*CityGeometryRequest)(nil), "cityaqrpc.CityGeometryRequest")
 	proto.RegisterType((*CityGeometryResponse)(nil), "cityaqrpc.CityGeometryResponse")
+	proto.RegisterType((*CitiesContainingRequest)(nil), "cityaqrpc.CitiesContainingRequest")
+	proto.RegisterType((*CitiesContainingResponse)(nil), "cityaqrpc.CitiesContainingResponse")
+	proto.RegisterType((*PointCities)(nil), "cityaqrpc.PointCities")
+	proto.RegisterType((*RegisterStudyAreaRequest)(nil), "cityaqrpc.RegisterStudyAreaRequest")
+	proto.RegisterType((*RegisterStudyAreaResponse)(nil), "cityaqrpc.RegisterStudyAreaResponse")
+	proto.RegisterType((*CityInfoRequest)(nil), "cityaqrpc.CityInfoRequest")
+	proto.RegisterType((*CityInfoResponse)(nil), "cityaqrpc.CityInfoResponse")
 	proto.RegisterType((*Polygon)(nil), "cityaqrpc.Polygon")
 	proto.RegisterType((*Path)(nil), "cityaqrpc.Path")
 	proto.RegisterType((*Point)(nil), "cityaqrpc.Point")
 	proto.RegisterType((*GriddedEmissionsRequest)(nil), "cityaqrpc.GriddedEmissionsRequest")
+	proto.RegisterType((*EmissionsTimeStep)(nil), "cityaqrpc.EmissionsTimeStep")
 	proto.RegisterType((*GriddedEmissionsResponse)(nil), "cityaqrpc.GriddedEmissionsResponse")
+	proto.RegisterType((*EmissionsDiagnostics)(nil), "cityaqrpc.EmissionsDiagnostics")
+	proto.RegisterType((*PollutantAmount)(nil), "cityaqrpc.PollutantAmount")
+	proto.RegisterType((*CustomSurrogate)(nil), "cityaqrpc.CustomSurrogate")
+	proto.RegisterType((*OSMTag)(nil), "cityaqrpc.OSMTag")
+	proto.RegisterType((*SourceTypeWeight)(nil), "cityaqrpc.SourceTypeWeight")
+	proto.RegisterType((*PointSource)(nil), "cityaqrpc.PointSource")
+	proto.RegisterType((*PollutantEmissions)(nil), "cityaqrpc.PollutantEmissions")
 	proto.RegisterType((*GriddedConcentrationsRequest)(nil), "cityaqrpc.GriddedConcentrationsRequest")
 	proto.RegisterType((*GriddedConcentrationsResponse)(nil), "cityaqrpc.GriddedConcentrationsResponse")
 	proto.RegisterType((*GriddedPopulationRequest)(nil), "cityaqrpc.GriddedPopulationRequest")
 	proto.RegisterType((*GriddedPopulationResponse)(nil), "cityaqrpc.GriddedPopulationResponse")
 	proto.RegisterType((*ImpactSummaryRequest)(nil), "cityaqrpc.ImpactSummaryRequest")
 	proto.RegisterType((*ImpactSummaryResponse)(nil), "cityaqrpc.ImpactSummaryResponse")
+	proto.RegisterType((*PollutantImpacts)(nil), "cityaqrpc.PollutantImpacts")
 	proto.RegisterType((*EmissionsGridBoundsRequest)(nil), "cityaqrpc.EmissionsGridBoundsRequest")
 	proto.RegisterType((*EmissionsGridBoundsResponse)(nil), "cityaqrpc.EmissionsGridBoundsResponse")
 	proto.RegisterType((*EmissionsInventorySectorsRequest)(nil), "cityaqrpc.EmissionsInventorySectorsRequest")
 	proto.RegisterType((*EmissionsInventorySectorsResponse)(nil), "cityaqrpc.EmissionsInventorySectorsResponse")
+	proto.RegisterType((*SectorChange)(nil), "cityaqrpc.SectorChange")
+	proto.RegisterType((*ReductionScenarioRequest)(nil), "cityaqrpc.ReductionScenarioRequest")
+	proto.RegisterType((*ReductionScenarioResponse)(nil), "cityaqrpc.ReductionScenarioResponse")
+	proto.RegisterType((*SourceTypesRequest)(nil), "cityaqrpc.SourceTypesRequest")
+	proto.RegisterType((*SourceTypesResponse)(nil), "cityaqrpc.SourceTypesResponse")
+	proto.RegisterType((*SourceType)(nil), "cityaqrpc.SourceType")
+	proto.RegisterType((*PollutantFraction)(nil), "cityaqrpc.PollutantFraction")
+	proto.RegisterType((*ExportGriddedRequest)(nil), "cityaqrpc.ExportGriddedRequest")
+	proto.RegisterType((*ExportGriddedResponse)(nil), "cityaqrpc.ExportGriddedResponse")
 	proto.RegisterType((*MapScaleRequest)(nil), "cityaqrpc.MapScaleRequest")
 	proto.RegisterType((*MapScaleResponse)(nil), "cityaqrpc.MapScaleResponse")
+	proto.RegisterEnum("cityaqrpc.TemporalResolution", TemporalResolution_name, TemporalResolution_value)
+	proto.RegisterEnum("cityaqrpc.SurrogateWeight", SurrogateWeight_name, SurrogateWeight_value)
+	proto.RegisterEnum("cityaqrpc.AllocationDomain", AllocationDomain_name, AllocationDomain_value)
 	proto.RegisterEnum("cityaqrpc.Emission", Emission_name, Emission_value)
+	proto.RegisterEnum("cityaqrpc.EmissionUnits", EmissionUnits_name, EmissionUnits_value)
+	proto.RegisterEnum("cityaqrpc.ExportFormat", ExportFormat_name, ExportFormat_value)
 	proto.RegisterEnum("cityaqrpc.ImpactType", ImpactType_name, ImpactType_value)
 	proto.RegisterEnum("cityaqrpc.SimulationType", SimulationType_name, SimulationType_value)
 }
@@ -1181,10 +3743,27 @@ type CityAQClient interface {
 	Cities(ctx context.Context, in *CitiesRequest, opts ...grpc.CallOption) (*CitiesResponse, error)
 	// CityGeometry returns the boundary of the specified city.
 	CityGeometry(ctx context.Context, in *CityGeometryRequest, opts ...grpc.CallOption) (*CityGeometryResponse, error)
-	// GriddedEmissions returns the distribution within the city of
-	// 1 kilotonne of emissions.
+	// CityInfo returns information about the boundary of the specified city.
+	CityInfo(ctx context.Context, in *CityInfoRequest, opts ...grpc.CallOption) (*CityInfoResponse, error)
+	// CitiesContaining returns the cities whose boundaries contain
+	// each of the given points.
+	CitiesContaining(ctx context.Context, in *CitiesContainingRequest, opts ...grpc.CallOption) (*CitiesContainingResponse, error)
+	// RegisterStudyArea stores a custom study area boundary so that it can
+	// be used in other requests in the same way as a city.
+	RegisterStudyArea(ctx context.Context, in *RegisterStudyAreaRequest, opts ...grpc.CallOption) (*RegisterStudyAreaResponse, error)
+	// GriddedEmissions returns the distribution within the city of the
+	// requested amount of emissions, in kilograms emitted over the
+	// emissions period. By default, 1 kilotonne of emissions is distributed.
+	// For CityTotal and Total simulations, it returns the emissions
+	// inventory emissions of the SourceType sector instead.
 	GriddedEmissions(ctx context.Context, in *GriddedEmissionsRequest, opts ...grpc.CallOption) (*GriddedEmissionsResponse, error)
 	// EmissionsGridBounds returns the bounds of the grid used in GriddedEmissions.
+	// For source types in the "egugrid" allocation domain, the grid covers
+	// the region of the server's grid region file that the city is in, or,
+	// if there is none, the country that the city is in, or, if the country
+	// is larger than an average US NERC region, the part of the country
+	// within a 5.4 degree radius buffer around the city. For other source
+	// types, it covers the city.
 	EmissionsGridBounds(ctx context.Context, in *EmissionsGridBoundsRequest, opts ...grpc.CallOption) (*EmissionsGridBoundsResponse, error)
 	// GriddedConcentrations returns the concentrations resulting from the
 	// corresponding GriddedEmissions.
@@ -1198,6 +3777,17 @@ type CityAQClient interface {
 	// ImpactSummary returns a summary of the impacts from the given request.
 	ImpactSummary(ctx context.Context, in *ImpactSummaryRequest, opts ...grpc.CallOption) (*ImpactSummaryResponse, error)
 	EmissionsInventorySectors(ctx context.Context, in *EmissionsInventorySectorsRequest, opts ...grpc.CallOption) (*EmissionsInventorySectorsResponse, error)
+	// SourceTypes returns the source types that emissions can be
+	// allocated to in CityMarginal simulations.
+	SourceTypes(ctx context.Context, in *SourceTypesRequest, opts ...grpc.CallOption) (*SourceTypesResponse, error)
+	// ExportGridded returns gridded emissions, concentrations, or
+	// population as a file in a format that GIS software can read.
+	ExportGridded(ctx context.Context, in *ExportGriddedRequest, opts ...grpc.CallOption) (*ExportGriddedResponse, error)
+	// ReductionScenario returns the changes in PM2.5 concentrations and
+	// exposure that result from reducing or increasing the emissions of
+	// emissions inventory sectors within a city by given percentages,
+	// compared to a baseline CityTotal simulation of the same sectors.
+	ReductionScenario(ctx context.Context, in *ReductionScenarioRequest, opts ...grpc.CallOption) (*ReductionScenarioResponse, error)
 }
 
 type cityAQClient struct {
@@ -1226,6 +3816,33 @@ func (c *cityAQClient) CityGeometry(ctx context.Context, in *CityGeometryRequest
 	return out, nil
 }
 
+func (c *cityAQClient) CityInfo(ctx context.Context, in *CityInfoRequest, opts ...grpc.CallOption) (*CityInfoResponse, error) {
+	out := new(CityInfoResponse)
+	err := c.cc.Invoke(ctx, "/cityaqrpc.CityAQ/CityInfo", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *cityAQClient) CitiesContaining(ctx context.Context, in *CitiesContainingRequest, opts ...grpc.CallOption) (*CitiesContainingResponse, error) {
+	out := new(CitiesContainingResponse)
+	err := c.cc.Invoke(ctx, "/cityaqrpc.CityAQ/CitiesContaining", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *cityAQClient) RegisterStudyArea(ctx context.Context, in *RegisterStudyAreaRequest, opts ...grpc.CallOption) (*RegisterStudyAreaResponse, error) {
+	out := new(RegisterStudyAreaResponse)
+	err := c.cc.Invoke(ctx, "/cityaqrpc.CityAQ/RegisterStudyArea", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *cityAQClient) GriddedEmissions(ctx context.Context, in *GriddedEmissionsRequest, opts ...grpc.CallOption) (*GriddedEmissionsResponse, error) {
 	out := new(GriddedEmissionsResponse)
 	err := c.cc.Invoke(ctx, "/cityaqrpc.CityAQ/GriddedEmissions", in, out, opts...)
@@ -1289,16 +3906,60 @@ func (c *cityAQClient) EmissionsInventorySectors(ctx context.Context, in *Emissi
 	return out, nil
 }
 
+func (c *cityAQClient) SourceTypes(ctx context.Context, in *SourceTypesRequest, opts ...grpc.CallOption) (*SourceTypesResponse, error) {
+	out := new(SourceTypesResponse)
+	err := c.cc.Invoke(ctx, "/cityaqrpc.CityAQ/SourceTypes", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *cityAQClient) ExportGridded(ctx context.Context, in *ExportGriddedRequest, opts ...grpc.CallOption) (*ExportGriddedResponse, error) {
+	out := new(ExportGriddedResponse)
+	err := c.cc.Invoke(ctx, "/cityaqrpc.CityAQ/ExportGridded", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *cityAQClient) ReductionScenario(ctx context.Context, in *ReductionScenarioRequest, opts ...grpc.CallOption) (*ReductionScenarioResponse, error) {
+	out := new(ReductionScenarioResponse)
+	err := c.cc.Invoke(ctx, "/cityaqrpc.CityAQ/ReductionScenario", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // CityAQServer is the server API for CityAQ service.
 type CityAQServer interface {
 	// Cities returns the available cities.
 	Cities(context.Context, *CitiesRequest) (*CitiesResponse, error)
 	// CityGeometry returns the boundary of the specified city.
 	CityGeometry(context.Context, *CityGeometryRequest) (*CityGeometryResponse, error)
-	// GriddedEmissions returns the distribution within the city of
-	// 1 kilotonne of emissions.
+	// CityInfo returns information about the boundary of the specified city.
+	CityInfo(context.Context, *CityInfoRequest) (*CityInfoResponse, error)
+	// CitiesContaining returns the cities whose boundaries contain
+	// each of the given points.
+	CitiesContaining(context.Context, *CitiesContainingRequest) (*CitiesContainingResponse, error)
+	// RegisterStudyArea stores a custom study area boundary so that it can
+	// be used in other requests in the same way as a city.
+	RegisterStudyArea(context.Context, *RegisterStudyAreaRequest) (*RegisterStudyAreaResponse, error)
+	// GriddedEmissions returns the distribution within the city of the
+	// requested amount of emissions, in kilograms emitted over the
+	// emissions period. By default, 1 kilotonne of emissions is distributed.
+	// For CityTotal and Total simulations, it returns the emissions
+	// inventory emissions of the SourceType sector instead.
 	GriddedEmissions(context.Context, *GriddedEmissionsRequest) (*GriddedEmissionsResponse, error)
 	// EmissionsGridBounds returns the bounds of the grid used in GriddedEmissions.
+	// For source types in the "egugrid" allocation domain, the grid covers
+	// the region of the server's grid region file that the city is in, or,
+	// if there is none, the country that the city is in, or, if the country
+	// is larger than an average US NERC region, the part of the country
+	// within a 5.4 degree radius buffer around the city. For other source
+	// types, it covers the city.
 	EmissionsGridBounds(context.Context, *EmissionsGridBoundsRequest) (*EmissionsGridBoundsResponse, error)
 	// GriddedConcentrations returns the concentrations resulting from the
 	// corresponding GriddedEmissions.
@@ -1312,6 +3973,17 @@ type CityAQServer interface {
 	// ImpactSummary returns a summary of the impacts from the given request.
 	ImpactSummary(context.Context, *ImpactSummaryRequest) (*ImpactSummaryResponse, error)
 	EmissionsInventorySectors(context.Context, *EmissionsInventorySectorsRequest) (*EmissionsInventorySectorsResponse, error)
+	// SourceTypes returns the source types that emissions can be
+	// allocated to in CityMarginal simulations.
+	SourceTypes(context.Context, *SourceTypesRequest) (*SourceTypesResponse, error)
+	// ExportGridded returns gridded emissions, concentrations, or
+	// population as a file in a format that GIS software can read.
+	ExportGridded(context.Context, *ExportGriddedRequest) (*ExportGriddedResponse, error)
+	// ReductionScenario returns the changes in PM2.5 concentrations and
+	// exposure that result from reducing or increasing the emissions of
+	// emissions inventory sectors within a city by given percentages,
+	// compared to a baseline CityTotal simulation of the same sectors.
+	ReductionScenario(context.Context, *ReductionScenarioRequest) (*ReductionScenarioResponse, error)
 }
 
 func RegisterCityAQServer(s *grpc.Server, srv CityAQServer) {
@@ -1354,6 +4026,60 @@ func _CityAQ_CityGeometry_Handler(srv interface{}, ctx context.Context, dec func
 	return interceptor(ctx, in, info, handler)
 }
 
+func _CityAQ_CityInfo_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CityInfoRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CityAQServer).CityInfo(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/cityaqrpc.CityAQ/CityInfo",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CityAQServer).CityInfo(ctx, req.(*CityInfoRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CityAQ_CitiesContaining_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CitiesContainingRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CityAQServer).CitiesContaining(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/cityaqrpc.CityAQ/CitiesContaining",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CityAQServer).CitiesContaining(ctx, req.(*CitiesContainingRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CityAQ_RegisterStudyArea_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RegisterStudyAreaRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CityAQServer).RegisterStudyArea(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/cityaqrpc.CityAQ/RegisterStudyArea",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CityAQServer).RegisterStudyArea(ctx, req.(*RegisterStudyAreaRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _CityAQ_GriddedEmissions_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(GriddedEmissionsRequest)
 	if err := dec(in); err != nil {
@@ -1480,6 +4206,60 @@ func _CityAQ_EmissionsInventorySectors_Handler(srv interface{}, ctx context.Cont
 	return interceptor(ctx, in, info, handler)
 }
 
+func _CityAQ_SourceTypes_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SourceTypesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CityAQServer).SourceTypes(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/cityaqrpc.CityAQ/SourceTypes",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CityAQServer).SourceTypes(ctx, req.(*SourceTypesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CityAQ_ExportGridded_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ExportGriddedRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CityAQServer).ExportGridded(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/cityaqrpc.CityAQ/ExportGridded",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CityAQServer).ExportGridded(ctx, req.(*ExportGriddedRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CityAQ_ReductionScenario_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReductionScenarioRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CityAQServer).ReductionScenario(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/cityaqrpc.CityAQ/ReductionScenario",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CityAQServer).ReductionScenario(ctx, req.(*ReductionScenarioRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 var _CityAQ_serviceDesc = grpc.ServiceDesc{
 	ServiceName: "cityaqrpc.CityAQ",
 	HandlerType: (*CityAQServer)(nil),
@@ -1492,6 +4272,18 @@ var _CityAQ_serviceDesc = grpc.ServiceDesc{
 			MethodName: "CityGeometry",
 			Handler:    _CityAQ_CityGeometry_Handler,
 		},
+		{
+			MethodName: "CityInfo",
+			Handler:    _CityAQ_CityInfo_Handler,
+		},
+		{
+			MethodName: "CitiesContaining",
+			Handler:    _CityAQ_CitiesContaining_Handler,
+		},
+		{
+			MethodName: "RegisterStudyArea",
+			Handler:    _CityAQ_RegisterStudyArea_Handler,
+		},
 		{
 			MethodName: "GriddedEmissions",
 			Handler:    _CityAQ_GriddedEmissions_Handler,
@@ -1520,75 +4312,206 @@ var _CityAQ_serviceDesc = grpc.ServiceDesc{
 			MethodName: "EmissionsInventorySectors",
 			Handler:    _CityAQ_EmissionsInventorySectors_Handler,
 		},
+		{
+			MethodName: "SourceTypes",
+			Handler:    _CityAQ_SourceTypes_Handler,
+		},
+		{
+			MethodName: "ExportGridded",
+			Handler:    _CityAQ_ExportGridded_Handler,
+		},
+		{
+			MethodName: "ReductionScenario",
+			Handler:    _CityAQ_ReductionScenario_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "cityaq.proto",
 }
 
-func init() { proto.RegisterFile("cityaq.proto", fileDescriptor_cityaq_5d59c783e971c48c) }
-
-var fileDescriptor_cityaq_5d59c783e971c48c = []byte{
-	// 977 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xd4, 0x57, 0xdd, 0x73, 0xdb, 0x44,
-	0x10, 0xcf, 0xf9, 0x2b, 0xf1, 0x36, 0x1f, 0xc7, 0xe6, 0x03, 0x45, 0x2d, 0xa9, 0xb9, 0x7e, 0xe0,
-	0x09, 0x4c, 0x28, 0xee, 0xf4, 0x91, 0x61, 0x52, 0x8f, 0x13, 0x3c, 0xd4, 0x1f, 0x95, 0x5c, 0x48,
-	0x98, 0x61, 0x8a, 0x70, 0x44, 0xa3, 0xc1, 0x96, 0x5c, 0xe9, 0x0c, 0xf6, 0x3f, 0xc8, 0x0c, 0x7d,
-	0xe0, 0x1f, 0xe0, 0x1f, 0xe1, 0x91, 0xd1, 0xe9, 0x74, 0x96, 0x6c, 0x39, 0x4d, 0x33, 0x3c, 0xb4,
-	0x6f, 0xb7, 0xbb, 0xbf, 0xdd, 0xbd, 0xfb, 0xed, 0x69, 0x6f, 0x05, 0xeb, 0x7d, 0x87, 0x4f, 0xad,
-	0xd7, 0x47, 0x23, 0xdf, 0xe3, 0x1e, 0x96, 0x23, 0xc9, 0x1f, 0xf5, 0xd9, 0x16, 0x6c, 0xd4, 0x1d,
-	0xee, 0xd8, 0x81, 0x61, 0xbf, 0x1e, 0xdb, 0x01, 0x67, 0x0f, 0x61, 0x33, 0x56, 0x04, 0x23, 0xcf,
-	0x0d, 0x6c, 0xdc, 0x81, 0x62, 0xdb, 0x1a, 0xda, 0x81, 0x46, 0x2a, 0xf9, 0x6a, 0xd9, 0x88, 0x04,
-	0xf6, 0x15, 0x6c, 0xd7, 0x1d, 0x3e, 0x3d, 0xb5, 0xbd, 0xa1, 0xcd, 0xfd, 0xa9, 0x74, 0x47, 0x1d,
-	0xd6, 0x42, 0x75, 0x88, 0xd1, 0x48, 0x85, 0x54, 0xcb, 0x86, 0x92, 0xd9, 0x09, 0xec, 0xa4, 0x5d,
-	0x64, 0x82, 0x23, 0x58, 0xeb, 0x7a, 0x83, 0xe9, 0x2b, 0xcf, 0x8d, 0x72, 0xdc, 0xaa, 0xe1, 0x91,
-	0xda, 0xe1, 0x91, 0x34, 0x19, 0x0a, 0xc3, 0x1e, 0xc1, 0xaa, 0x5c, 0xe3, 0x03, 0x28, 0x76, 0x2d,
-	0x7e, 0x19, 0xfb, 0x6d, 0x25, 0xfd, 0x2c, 0x7e, 0x69, 0x44, 0x56, 0xf6, 0x08, 0x0a, 0xe1, 0x02,
-	0xab, 0x50, 0xea, 0x7a, 0x8e, 0xcb, 0x63, 0x3c, 0x4d, 0xe5, 0x71, 0x5c, 0x6e, 0x48, 0x3b, 0xbb,
-	0x07, 0x45, 0xb1, 0xc2, 0x75, 0x20, 0x67, 0xe2, 0x24, 0xc4, 0x20, 0x67, 0xa1, 0x74, 0xae, 0xe5,
-	0x22, 0xe9, 0x9c, 0xfd, 0x45, 0xe0, 0xe3, 0x53, 0xdf, 0xb9, 0xb8, 0xb0, 0x2f, 0x1a, 0x43, 0x27,
-	0x08, 0x1c, 0xcf, 0x0d, 0xae, 0x41, 0x04, 0x1e, 0x00, 0x98, 0xde, 0xd8, 0xef, 0xdb, 0xbd, 0xe9,
-	0xc8, 0x16, 0xe1, 0xca, 0x46, 0x42, 0x83, 0x5f, 0xc2, 0x5a, 0x1c, 0x4f, 0xcb, 0x57, 0x48, 0x75,
-	0xb3, 0xb6, 0x9d, 0xd8, 0x68, 0x6c, 0x32, 0x14, 0x08, 0x8f, 0x61, 0xd3, 0x74, 0x86, 0xe3, 0x81,
-	0xc5, 0x1d, 0xcf, 0x15, 0x41, 0x0b, 0xc2, 0x6d, 0x3f, 0xe1, 0x96, 0x06, 0x18, 0x73, 0x0e, 0xec,
-	0x12, 0xb4, 0xc5, 0xa3, 0xdc, 0xac, 0x40, 0x78, 0x07, 0xca, 0x2a, 0x88, 0x96, 0xab, 0xe4, 0xab,
-	0xc4, 0x98, 0x29, 0xd8, 0xdf, 0x04, 0xee, 0xc8, 0x54, 0x75, 0xcf, 0xed, 0xdb, 0x2e, 0xf7, 0xc5,
-	0x36, 0x3e, 0x58, 0xea, 0xfe, 0x80, 0x4f, 0x96, 0x9c, 0xe7, 0x86, 0xfc, 0x85, 0xdf, 0x60, 0x2a,
-	0x92, 0x24, 0x71, 0x4e, 0xcb, 0xde, 0x10, 0x55, 0xb4, 0xae, 0x37, 0x92, 0x5b, 0xfa, 0x50, 0x59,
-	0xfc, 0x0d, 0xf6, 0x33, 0xce, 0x72, 0x43, 0x06, 0x0f, 0x00, 0x66, 0x51, 0x24, 0x7b, 0x09, 0x0d,
-	0xfb, 0x93, 0xc0, 0x4e, 0x73, 0x38, 0xb2, 0xfa, 0xdc, 0x1c, 0x0f, 0x87, 0xd6, 0xb5, 0xfa, 0xd7,
-	0x7b, 0xc9, 0xda, 0x3f, 0x04, 0x76, 0xe7, 0x0e, 0x22, 0x29, 0x4b, 0x53, 0x10, 0x75, 0xb0, 0x84,
-	0x06, 0xa3, 0x46, 0x3f, 0x4d, 0xd1, 0x44, 0xc4, 0x25, 0x4b, 0x69, 0x91, 0xc1, 0x7a, 0xa8, 0x69,
-	0x4c, 0x46, 0x5e, 0x30, 0xf6, 0x6d, 0x71, 0x32, 0x62, 0xa4, 0x74, 0x78, 0x1f, 0x36, 0x7a, 0x1e,
-	0xb7, 0x06, 0x0a, 0x54, 0x10, 0xa0, 0xb4, 0x12, 0xf7, 0xa0, 0x14, 0x7a, 0x35, 0x4f, 0xb4, 0xa2,
-	0x30, 0x4b, 0x09, 0x35, 0x58, 0x15, 0xc0, 0xe6, 0x89, 0x56, 0x12, 0x86, 0x58, 0x64, 0x67, 0xa0,
-	0xab, 0xbe, 0x11, 0x5e, 0x8e, 0xa7, 0xde, 0xd8, 0xbd, 0xf8, 0x3f, 0xfa, 0x04, 0xb3, 0xe1, 0x76,
-	0x66, 0x64, 0x49, 0x1e, 0x83, 0x7c, 0xcb, 0x89, 0x58, 0xcb, 0x7a, 0x25, 0x42, 0xa3, 0xc0, 0x58,
-	0x13, 0x11, 0x3b, 0x1b, 0x63, 0x4d, 0x18, 0x83, 0x8a, 0x4a, 0xd3, 0x74, 0x7f, 0xb7, 0x5d, 0xee,
-	0xf9, 0x53, 0xd3, 0xee, 0x73, 0xcf, 0x57, 0x2f, 0xee, 0xd7, 0xf0, 0xe9, 0x15, 0x18, 0xb9, 0x21,
-	0x0d, 0x56, 0xa5, 0x4a, 0x3e, 0xc3, 0xb1, 0xc8, 0xfe, 0x25, 0xb0, 0xd5, 0xb2, 0x46, 0x66, 0xdf,
-	0x1a, 0xd8, 0xd7, 0x61, 0xe6, 0x09, 0x40, 0x74, 0x61, 0x14, 0x33, 0x9b, 0xb5, 0xdd, 0xc4, 0xee,
-	0x67, 0x46, 0x23, 0x01, 0x7c, 0xf7, 0xcb, 0x9d, 0xae, 0x40, 0x61, 0xe1, 0x6b, 0x59, 0xbc, 0xfc,
-	0xc5, 0x77, 0xbd, 0xfc, 0xcf, 0x80, 0xce, 0x4e, 0x2e, 0x89, 0xa2, 0xb3, 0xca, 0x91, 0xa8, 0x4e,
-	0x74, 0x56, 0x27, 0x22, 0xaa, 0x12, 0x4e, 0x34, 0xf5, 0x31, 0xef, 0x72, 0x79, 0x97, 0x23, 0xe1,
-	0xb0, 0x33, 0x3b, 0x21, 0xee, 0x00, 0x7d, 0xd1, 0xfe, 0xae, 0xdd, 0xf9, 0xa1, 0xfd, 0xb2, 0xd1,
-	0x6a, 0x9a, 0x66, 0xb3, 0xd3, 0xa6, 0x2b, 0x58, 0x86, 0x62, 0xb7, 0x55, 0x7b, 0xf9, 0x84, 0x12,
-	0x5c, 0x85, 0x7c, 0xfb, 0xdb, 0xc7, 0x34, 0x27, 0x16, 0x9d, 0x09, 0xcd, 0x87, 0x0b, 0xb3, 0x33,
-	0xa1, 0x85, 0x70, 0xf1, 0x7d, 0xa7, 0x4e, 0x8b, 0x87, 0xa7, 0x49, 0xa6, 0x71, 0x0f, 0x30, 0x0e,
-	0xd9, 0x6c, 0x75, 0x8f, 0xeb, 0xbd, 0xde, 0x79, 0xb7, 0x41, 0x57, 0x70, 0x23, 0xf1, 0x58, 0x52,
-	0x82, 0x38, 0xdf, 0xfb, 0x69, 0xee, 0xf0, 0x6c, 0x9e, 0x2a, 0xd4, 0x61, 0x2f, 0x0e, 0x66, 0x36,
-	0x5b, 0x2f, 0x9e, 0x1d, 0xf7, 0x9a, 0x9d, 0xb6, 0x0c, 0x58, 0x86, 0xa2, 0xf8, 0x7e, 0x28, 0x09,
-	0x63, 0x87, 0x75, 0x8f, 0xc4, 0x1c, 0xd2, 0xe8, 0x53, 0x6e, 0x59, 0xfe, 0x2b, 0xc7, 0xb5, 0x06,
-	0x34, 0x5f, 0x7b, 0x53, 0x8a, 0xbe, 0xc9, 0xe3, 0xe7, 0xf8, 0x8d, 0x58, 0x39, 0x76, 0x80, 0x5a,
-	0xa2, 0x02, 0xa9, 0xe1, 0x50, 0xdf, 0xcf, 0xb0, 0x44, 0xbc, 0xb3, 0x15, 0x7c, 0x1e, 0x45, 0x8f,
-	0xc7, 0x3b, 0x3c, 0x48, 0x83, 0xe7, 0x47, 0x45, 0xfd, 0xee, 0x52, 0xbb, 0x0a, 0xf9, 0x13, 0xd0,
-	0xf9, 0xa1, 0x04, 0x59, 0xc2, 0x6d, 0xc9, 0xf0, 0xa5, 0xdf, 0xbb, 0x12, 0xa3, 0xc2, 0xff, 0x0a,
-	0xdb, 0x19, 0x4d, 0x00, 0x1f, 0x64, 0x5c, 0xec, 0xc5, 0xf6, 0xa3, 0x3f, 0x7c, 0x1b, 0x4c, 0xe5,
-	0x19, 0xc0, 0x6e, 0xe6, 0x80, 0x80, 0x9f, 0x2d, 0xee, 0x33, 0x73, 0x24, 0xd2, 0xab, 0x6f, 0x07,
-	0xaa, 0x6c, 0x0d, 0x58, 0x8b, 0xbf, 0x0a, 0xd4, 0x13, 0x7e, 0x73, 0x4d, 0x42, 0xbf, 0x9d, 0x69,
-	0x53, 0x61, 0x7e, 0x86, 0x8f, 0x16, 0xde, 0x63, 0xcc, 0x20, 0x76, 0x61, 0xf2, 0xd0, 0xef, 0x5f,
-	0x0d, 0x52, 0x19, 0x7a, 0xb0, 0x91, 0x7a, 0xba, 0xf0, 0xee, 0x42, 0x1b, 0x4a, 0xbf, 0xce, 0x7a,
-	0x65, 0x39, 0x40, 0x45, 0x9d, 0xc0, 0xfe, 0xd2, 0x76, 0x8a, 0x9f, 0x67, 0xd5, 0x6c, 0x49, 0x63,
-	0xd6, 0xbf, 0xb8, 0x1e, 0x38, 0xce, 0xfc, 0xf4, 0xd6, 0x8f, 0xb3, 0x1f, 0xab, 0x5f, 0x4a, 0xe2,
-	0x57, 0xeb, 0xf1, 0x7f, 0x01, 0x00, 0x00, 0xff, 0xff, 0x34, 0xeb, 0x17, 0xd9, 0x7a, 0x0d, 0x00,
-	0x00,
+func init() { proto.RegisterFile("cityaq.proto", fileDescriptor_cityaq_067e2154450e2bec) }
+
+var fileDescriptor_cityaq_067e2154450e2bec = []byte{
+	// 2893 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xec, 0x5a, 0x49, 0x8f, 0x1b, 0xc7,
+	0xf5, 0x77, 0x73, 0xe7, 0xe3, 0x0c, 0xa7, 0x55, 0x33, 0x92, 0x7a, 0xa8, 0xc5, 0xf3, 0x6f, 0xc9,
+	0xf6, 0x60, 0x2c, 0xc8, 0xfe, 0x8f, 0x63, 0x07, 0x10, 0x12, 0xc4, 0x14, 0x67, 0x11, 0xad, 0xe1,
+	0xe2, 0x22, 0x47, 0x8b, 0x81, 0xc0, 0x6e, 0x73, 0x4a, 0x9c, 0x8e, 0x9a, 0xdd, 0x74, 0x77, 0x33,
+	0x1e, 0xe6, 0x23, 0x18, 0xc8, 0x29, 0x40, 0xae, 0x49, 0x80, 0x7c, 0x82, 0x1c, 0x72, 0xce, 0x2d,
+	0x1f, 0x20, 0x07, 0x03, 0x09, 0xf2, 0x5d, 0x82, 0x5a, 0xba, 0x59, 0xdd, 0x2c, 0x52, 0xd4, 0x82,
+	0x18, 0x06, 0x72, 0xab, 0x7a, 0xef, 0xd5, 0xab, 0xed, 0xf7, 0x96, 0x5a, 0x60, 0x6d, 0x60, 0x87,
+	0x53, 0xeb, 0x9b, 0xbb, 0x63, 0xdf, 0x0b, 0x3d, 0x54, 0xe6, 0x35, 0x7f, 0x3c, 0x30, 0xff, 0xad,
+	0xc1, 0x7a, 0xc3, 0x0e, 0x6d, 0x12, 0x60, 0xf2, 0xcd, 0x84, 0x04, 0x21, 0xaa, 0x41, 0xe9, 0xc4,
+	0x72, 0x87, 0x13, 0x6b, 0x48, 0x0c, 0x6d, 0x47, 0xdb, 0x2d, 0xe3, 0xb8, 0x8e, 0xb6, 0x20, 0xff,
+	0xf9, 0x84, 0xf8, 0x53, 0x23, 0xc3, 0x18, 0xbc, 0x82, 0x0c, 0x28, 0x36, 0xbc, 0x89, 0x1b, 0xfa,
+	0x53, 0x23, 0xcb, 0xe8, 0x51, 0x15, 0x99, 0x90, 0x6d, 0xd9, 0xae, 0x91, 0xdb, 0xd1, 0x76, 0x2b,
+	0xfb, 0xfa, 0xdd, 0xb8, 0xdb, 0xbb, 0x5d, 0xcf, 0x76, 0x43, 0x4c, 0x99, 0x4c, 0xc6, 0xba, 0x30,
+	0xf2, 0x0b, 0x65, 0xac, 0x0b, 0x3a, 0xa6, 0xae, 0x35, 0x24, 0x3d, 0xfb, 0x37, 0xc4, 0x28, 0xec,
+	0x68, 0xbb, 0x79, 0x1c, 0xd7, 0xd1, 0x75, 0x28, 0xd3, 0x72, 0xdf, 0x7b, 0x4e, 0x5c, 0xa3, 0xc8,
+	0xfa, 0x9f, 0x11, 0xcc, 0xdf, 0x69, 0x50, 0x8d, 0xe6, 0x17, 0x8c, 0x3d, 0x37, 0x60, 0x93, 0x68,
+	0x5b, 0x23, 0x12, 0x18, 0xda, 0x4e, 0x96, 0x4e, 0x82, 0x55, 0xd0, 0x7b, 0x50, 0xe0, 0x72, 0x46,
+	0x66, 0x27, 0xbb, 0x5b, 0xd9, 0xdf, 0x90, 0x46, 0xd2, 0xb0, 0xc3, 0x29, 0x16, 0x6c, 0x74, 0x1b,
+	0xd6, 0xdb, 0xe4, 0x22, 0x9c, 0xf5, 0xc9, 0xe7, 0x9c, 0x24, 0xd2, 0x51, 0xf5, 0xbd, 0xd0, 0x72,
+	0xd8, 0x90, 0x73, 0x6c, 0xc8, 0x33, 0x82, 0xf9, 0x2b, 0xc8, 0x51, 0x9d, 0xa8, 0x0a, 0x99, 0xe6,
+	0x81, 0x58, 0xe5, 0x4c, 0xf3, 0x00, 0xed, 0x40, 0xe5, 0xc0, 0x0e, 0xc6, 0x8e, 0x35, 0xa5, 0x83,
+	0x12, 0xab, 0x2c, 0x93, 0x96, 0xac, 0xf5, 0x15, 0x28, 0x60, 0x32, 0xb4, 0x3d, 0xbe, 0xdc, 0x65,
+	0x2c, 0x6a, 0x66, 0x0b, 0x36, 0x69, 0x5f, 0xc7, 0xc4, 0x1b, 0x91, 0xd0, 0x9f, 0x4a, 0xdb, 0x4c,
+	0xc9, 0xac, 0x1f, 0xb1, 0xcd, 0x51, 0x3d, 0x01, 0x81, 0x4c, 0x12, 0x02, 0xe6, 0x39, 0x6c, 0x25,
+	0xd5, 0x89, 0x55, 0xbd, 0x0b, 0xa5, 0xae, 0xe7, 0x4c, 0x87, 0x9e, 0xcb, 0x17, 0xb6, 0xb2, 0x8f,
+	0x12, 0x7b, 0xc9, 0x58, 0x38, 0x96, 0x79, 0xf1, 0x54, 0xcd, 0x2f, 0xe1, 0x2a, 0x5f, 0xf2, 0x86,
+	0xe7, 0x86, 0x96, 0xed, 0xda, 0xee, 0x30, 0x1a, 0xfc, 0x2e, 0x14, 0x18, 0x3a, 0xa2, 0xae, 0xe6,
+	0x61, 0x23, 0xf8, 0x4b, 0xa7, 0xf2, 0x19, 0x18, 0xf3, 0x1d, 0xc4, 0xd3, 0x49, 0xf6, 0x70, 0x25,
+	0xdd, 0x83, 0x00, 0x95, 0x90, 0x32, 0x3f, 0x81, 0x8a, 0x44, 0x96, 0xd0, 0xa4, 0x2d, 0x45, 0x93,
+	0xf9, 0x05, 0x18, 0x74, 0x9f, 0x82, 0x90, 0xf8, 0xbd, 0x70, 0x72, 0x36, 0xad, 0xfb, 0xc4, 0x8a,
+	0x66, 0x89, 0x20, 0x27, 0x6d, 0x4f, 0x2e, 0xda, 0xff, 0x63, 0xe2, 0x7d, 0xd6, 0xeb, 0xb4, 0xc5,
+	0x74, 0xa2, 0x2a, 0xd2, 0x21, 0xfb, 0xf8, 0x61, 0x5f, 0xa0, 0x82, 0x16, 0xcd, 0xf7, 0x61, 0x5b,
+	0xa1, 0x5b, 0x4c, 0x30, 0x05, 0x3d, 0xb3, 0x09, 0x1b, 0x74, 0x60, 0x4d, 0xf7, 0x99, 0xf7, 0xba,
+	0x10, 0xf9, 0x6b, 0x06, 0xf4, 0x99, 0x2e, 0x75, 0x7f, 0x2b, 0x40, 0x1d, 0x41, 0x8e, 0x8e, 0x98,
+	0xcd, 0x48, 0xc3, 0xac, 0x8c, 0xee, 0x40, 0xa9, 0x41, 0xdc, 0xd0, 0xf7, 0xec, 0xb3, 0x85, 0x5e,
+	0x25, 0x96, 0x88, 0xdc, 0x4f, 0x7e, 0x05, 0xf7, 0x53, 0x58, 0xe6, 0x7e, 0x24, 0xa3, 0x2b, 0x26,
+	0x8d, 0xee, 0x0e, 0x14, 0x0f, 0x8f, 0x4f, 0x8f, 0x7d, 0xfb, 0xcc, 0x28, 0x2d, 0x04, 0x7d, 0x24,
+	0x82, 0x6e, 0x02, 0x74, 0x7d, 0x6f, 0x4c, 0x7c, 0x86, 0x8c, 0x32, 0x53, 0x25, 0x51, 0xcc, 0x0f,
+	0xa1, 0x28, 0xda, 0xa0, 0x77, 0x20, 0xdf, 0xb5, 0xc2, 0x73, 0x15, 0x7e, 0x28, 0x1d, 0x73, 0xae,
+	0xf9, 0x21, 0xe4, 0x68, 0x61, 0x75, 0x83, 0x30, 0x6f, 0x41, 0x9e, 0x95, 0xd0, 0x1a, 0x68, 0x4f,
+	0xd8, 0x7e, 0x68, 0x58, 0x7b, 0x42, 0x6b, 0x4f, 0xd9, 0x26, 0x68, 0x58, 0x7b, 0x6a, 0xfe, 0xbd,
+	0x00, 0x57, 0xe9, 0x88, 0xcf, 0xc8, 0xd9, 0xe1, 0xc8, 0x0e, 0x02, 0xdb, 0x73, 0x83, 0x55, 0x50,
+	0x71, 0x13, 0xa0, 0xe7, 0x4d, 0xfc, 0x01, 0xe9, 0x4f, 0xc7, 0xd1, 0x9e, 0x4a, 0x14, 0xf4, 0x01,
+	0x94, 0x22, 0x7d, 0x6c, 0x5b, 0xab, 0xfb, 0x9b, 0xd2, 0x40, 0x23, 0x16, 0x8e, 0x85, 0x50, 0x1d,
+	0xaa, 0x3d, 0x7b, 0x34, 0x71, 0xac, 0xd0, 0xf6, 0x5c, 0xa6, 0x34, 0xc7, 0x9a, 0x6d, 0x4b, 0xcd,
+	0x92, 0x02, 0x38, 0xd5, 0x80, 0xfa, 0xc5, 0xfa, 0x88, 0x6e, 0x17, 0xc3, 0x81, 0x86, 0x45, 0x0d,
+	0xdd, 0x83, 0x0a, 0x2f, 0x9d, 0xba, 0x76, 0x18, 0x30, 0x00, 0x54, 0xf7, 0x0d, 0xc5, 0x70, 0x18,
+	0x1f, 0xcb, 0xc2, 0x34, 0x84, 0xdc, 0x27, 0x43, 0x9b, 0xc7, 0x9b, 0x2c, 0xe6, 0x15, 0x6a, 0x81,
+	0x87, 0x2e, 0x05, 0x02, 0xa5, 0xd1, 0x22, 0xba, 0x07, 0xd0, 0xf5, 0x1c, 0x67, 0x12, 0x5a, 0x74,
+	0x6b, 0xca, 0x6c, 0x6b, 0x6a, 0x49, 0x84, 0x70, 0x26, 0x57, 0x8e, 0x25, 0x69, 0x74, 0x00, 0x1b,
+	0x8d, 0x49, 0x10, 0x7a, 0xa3, 0xde, 0xc4, 0xf7, 0xbd, 0xa1, 0x15, 0x12, 0x03, 0x76, 0xb4, 0x94,
+	0x82, 0x94, 0x04, 0x4e, 0x37, 0x41, 0x75, 0x58, 0x9f, 0xad, 0x7f, 0xcb, 0xbe, 0x30, 0x2a, 0x6c,
+	0x10, 0xd7, 0xe4, 0xf5, 0x8b, 0xf9, 0x8f, 0x89, 0x3d, 0x3c, 0x0f, 0x71, 0xb2, 0x05, 0xdd, 0x54,
+	0x4c, 0x02, 0xcf, 0x99, 0xd0, 0x25, 0x35, 0xd6, 0xd8, 0x22, 0x4a, 0x14, 0xf4, 0x2e, 0x54, 0xeb,
+	0x93, 0xd0, 0x93, 0x64, 0xd6, 0x77, 0xb4, 0xdd, 0x12, 0x4e, 0x51, 0x51, 0x0b, 0x50, 0x9f, 0x8c,
+	0xc6, 0x9e, 0x6f, 0x39, 0x92, 0x6c, 0x95, 0xad, 0xfb, 0x0d, 0x69, 0x3c, 0xf3, 0x42, 0x58, 0xd1,
+	0x90, 0x3a, 0x90, 0xc7, 0xb6, 0x7b, 0xe6, 0x7d, 0xcb, 0x77, 0x62, 0x83, 0xad, 0xba, 0x4c, 0xa2,
+	0x31, 0x98, 0x57, 0xe9, 0xae, 0xe8, 0x8c, 0x3f, 0x23, 0x50, 0x1c, 0xf7, 0xc6, 0x64, 0x60, 0xd3,
+	0x85, 0xbd, 0xc4, 0x06, 0x1c, 0xd7, 0x69, 0x8c, 0x6f, 0x59, 0x41, 0xd0, 0xf7, 0x1c, 0xe2, 0x5b,
+	0xee, 0x80, 0x18, 0x88, 0xcd, 0x3a, 0x49, 0x44, 0x26, 0xac, 0x51, 0xa7, 0x74, 0x64, 0x39, 0xce,
+	0xd7, 0xd6, 0xe0, 0xb9, 0xb1, 0xc9, 0xb4, 0x24, 0x68, 0xe6, 0x77, 0x1a, 0x5c, 0x8a, 0x4d, 0xa8,
+	0x6f, 0x8f, 0x48, 0x2f, 0x24, 0xe3, 0x19, 0x7e, 0x34, 0x05, 0x7e, 0x32, 0x33, 0xfc, 0xb4, 0x00,
+	0xc5, 0x88, 0x88, 0xb5, 0x18, 0x59, 0xb6, 0x85, 0x37, 0x54, 0x38, 0x9a, 0x59, 0xab, 0xa2, 0x21,
+	0x75, 0xcc, 0xc6, 0xbc, 0x59, 0xbf, 0x62, 0x00, 0xbf, 0x0e, 0xe5, 0xd9, 0x90, 0x68, 0xce, 0xa4,
+	0xe1, 0x19, 0xe1, 0x0d, 0x8f, 0x1c, 0xdd, 0x83, 0x72, 0xb4, 0x78, 0x81, 0x91, 0x63, 0x5a, 0xae,
+	0x2b, 0x4c, 0x35, 0x5e, 0x61, 0x3c, 0x13, 0x47, 0x75, 0x1a, 0x69, 0xac, 0xa1, 0xeb, 0x05, 0xa1,
+	0x3d, 0x08, 0x44, 0x34, 0x78, 0x5b, 0xd5, 0x5a, 0x12, 0xc3, 0x72, 0x1b, 0xf3, 0xbb, 0x0c, 0x6c,
+	0xa9, 0xa4, 0x28, 0x50, 0x84, 0x5f, 0x24, 0x67, 0x14, 0x1c, 0xc2, 0xa1, 0x26, 0x89, 0x54, 0xaa,
+	0xee, 0x38, 0xde, 0xc0, 0x8a, 0xa4, 0xb8, 0xa3, 0x4d, 0x12, 0x29, 0x9c, 0xa8, 0x23, 0x3d, 0xf2,
+	0xad, 0x41, 0x18, 0x39, 0x48, 0x0d, 0x27, 0x68, 0x54, 0xe6, 0xc4, 0x0b, 0xc2, 0x58, 0x26, 0xc7,
+	0x65, 0x64, 0x1a, 0xba, 0x03, 0x97, 0x62, 0xfb, 0x8f, 0xb1, 0x99, 0x67, 0xbe, 0x78, 0x9e, 0x81,
+	0x3e, 0x84, 0xcd, 0xa8, 0x3c, 0x33, 0x7b, 0xea, 0x0e, 0x69, 0x6e, 0xac, 0x62, 0x99, 0xbf, 0xd7,
+	0x60, 0x23, 0xe5, 0xb8, 0x12, 0x8e, 0x5d, 0x5b, 0xc5, 0xb1, 0xcf, 0xbc, 0x72, 0x66, 0x99, 0x57,
+	0xce, 0xbe, 0x84, 0x57, 0x36, 0xc7, 0x73, 0x1e, 0x13, 0xbd, 0x03, 0xb9, 0xbe, 0x35, 0x8c, 0x00,
+	0x7d, 0x49, 0xd2, 0xd3, 0xe9, 0xb5, 0xfa, 0xd6, 0x10, 0x33, 0x36, 0xfa, 0x04, 0x4a, 0xdc, 0xf7,
+	0xdd, 0xe7, 0x47, 0x9b, 0x6a, 0xc2, 0xc9, 0xc6, 0xea, 0xb8, 0x0c, 0x8e, 0x65, 0xcd, 0x7d, 0x28,
+	0x70, 0x3d, 0xd4, 0x76, 0x1f, 0x92, 0xa9, 0x08, 0x88, 0xb4, 0x48, 0x67, 0xf8, 0xc8, 0x72, 0x26,
+	0xe2, 0x40, 0x51, 0xc6, 0xa2, 0x66, 0x7e, 0x06, 0x7a, 0xda, 0xe3, 0xa6, 0xe2, 0xa6, 0x36, 0x17,
+	0x37, 0xaf, 0x40, 0x81, 0x4b, 0x46, 0xab, 0xc5, 0x6b, 0xe6, 0x5f, 0x32, 0x22, 0xed, 0xe4, 0xb2,
+	0x34, 0x3d, 0x3a, 0xa1, 0x88, 0x8a, 0xb6, 0x41, 0x99, 0x1e, 0x45, 0x12, 0x68, 0x13, 0xf2, 0xdd,
+	0xd6, 0xfe, 0x97, 0x1f, 0x0b, 0xa5, 0xb9, 0x6e, 0x6b, 0xff, 0x63, 0x3a, 0x91, 0x47, 0x9d, 0x86,
+	0x00, 0x1f, 0x2d, 0x52, 0x4a, 0xfb, 0xc1, 0x47, 0x02, 0x6a, 0xb4, 0xc8, 0x28, 0x9d, 0x0b, 0x11,
+	0x4f, 0x69, 0x91, 0x52, 0x7a, 0x1d, 0x9e, 0x45, 0x69, 0x98, 0x16, 0xa9, 0x7b, 0xee, 0x85, 0xd6,
+	0xe0, 0xf9, 0x03, 0x3e, 0xee, 0x22, 0xe3, 0xc8, 0x24, 0x6a, 0x15, 0xac, 0x7a, 0x60, 0x5b, 0x23,
+	0x12, 0x12, 0x9f, 0x05, 0x4e, 0x0d, 0x27, 0x89, 0x68, 0x0f, 0x74, 0x46, 0xa0, 0x11, 0x80, 0xf8,
+	0x56, 0x38, 0xf1, 0x09, 0xcb, 0x9c, 0x34, 0x3c, 0x47, 0x8f, 0x35, 0x3e, 0x22, 0x8e, 0x47, 0xa7,
+	0x6d, 0x80, 0xa4, 0x31, 0x22, 0x9a, 0x03, 0x95, 0x6b, 0x7a, 0x79, 0x04, 0x2f, 0xf5, 0x7f, 0xe6,
+	0x3f, 0xf2, 0x70, 0x5d, 0xb8, 0xda, 0x86, 0xe7, 0x0e, 0x68, 0x46, 0x6a, 0x85, 0xff, 0x4b, 0xa3,
+	0xfe, 0xfb, 0x69, 0xd4, 0x3d, 0x58, 0x93, 0x2c, 0x24, 0x30, 0x40, 0x7d, 0x9c, 0xe3, 0x6c, 0x9c,
+	0x90, 0x55, 0xa5, 0x60, 0x95, 0x37, 0x90, 0x82, 0xad, 0xbd, 0x66, 0x0a, 0xb6, 0xbe, 0x42, 0x0a,
+	0x56, 0x55, 0xa6, 0x60, 0x72, 0xce, 0xb3, 0x91, 0xca, 0x79, 0xd2, 0xd9, 0x8c, 0xae, 0xc8, 0x66,
+	0xbe, 0x85, 0x1b, 0x0b, 0x40, 0xfd, 0x8a, 0x49, 0xc4, 0xbb, 0x50, 0x4d, 0x6a, 0x12, 0x96, 0x94,
+	0xa2, 0x9a, 0xdf, 0x67, 0xe3, 0xcc, 0xa5, 0xeb, 0x8d, 0x05, 0x2e, 0x7f, 0xac, 0xa6, 0x94, 0x86,
+	0x64, 0xfe, 0xf5, 0x20, 0x59, 0x78, 0x03, 0x90, 0x2c, 0xbe, 0x26, 0x24, 0x4b, 0x2b, 0x40, 0xb2,
+	0xac, 0x82, 0xa4, 0xf9, 0x1c, 0xb6, 0x15, 0x1b, 0xfb, 0x8a, 0x70, 0xa2, 0x07, 0xec, 0x58, 0x8b,
+	0x80, 0x92, 0x44, 0x31, 0x7f, 0x9b, 0x83, 0xad, 0xe6, 0x68, 0x6c, 0x0d, 0xc2, 0xde, 0x64, 0x34,
+	0xb2, 0xfc, 0xe9, 0x8f, 0x15, 0x42, 0x3f, 0xa4, 0x37, 0x9e, 0x03, 0x4f, 0xf9, 0x35, 0xc1, 0x03,
+	0x2b, 0x80, 0xa7, 0xf2, 0x42, 0x7f, 0xb6, 0xf6, 0x02, 0x7f, 0xb6, 0xae, 0xf0, 0x67, 0x7f, 0xce,
+	0xc0, 0xe5, 0x14, 0x1e, 0x04, 0xf2, 0x92, 0x48, 0xe2, 0x59, 0xbd, 0x44, 0x61, 0x8e, 0xcb, 0x0e,
+	0xa7, 0x09, 0xb4, 0x69, 0xcc, 0x71, 0x25, 0xa8, 0x51, 0x52, 0x7f, 0x78, 0x31, 0xf6, 0x02, 0x9a,
+	0xba, 0x48, 0x49, 0x7d, 0x44, 0xa3, 0x69, 0x0b, 0xbb, 0x1a, 0x8e, 0x85, 0x78, 0xaa, 0x95, 0x24,
+	0xd2, 0x2d, 0x67, 0x97, 0x6a, 0x47, 0xd1, 0x96, 0xf3, 0x1a, 0xbd, 0x9c, 0x62, 0x82, 0xcd, 0x23,
+	0x91, 0x7e, 0x45, 0x55, 0x74, 0x0c, 0x7a, 0x1c, 0x08, 0xf9, 0x2c, 0x03, 0x85, 0xa1, 0xa7, 0x45,
+	0xf0, 0x5c, 0x23, 0xf3, 0x7b, 0x6d, 0x5e, 0xd3, 0x9b, 0x4b, 0xf9, 0x7f, 0xc0, 0x25, 0x32, 0xff,
+	0xa8, 0x41, 0x2d, 0x4e, 0xda, 0xa8, 0x1f, 0xba, 0xef, 0x4d, 0xdc, 0xb3, 0x37, 0x92, 0xa4, 0x25,
+	0x31, 0x9e, 0x5d, 0x01, 0xe3, 0x39, 0xa5, 0x83, 0x24, 0x70, 0x4d, 0x39, 0x42, 0x01, 0x54, 0x71,
+	0xc7, 0xa9, 0xad, 0x70, 0xc7, 0x99, 0x59, 0x72, 0xc7, 0x69, 0x9a, 0xb0, 0x13, 0x77, 0xd3, 0x74,
+	0x7f, 0x4d, 0xdc, 0xd0, 0xf3, 0xa7, 0x3d, 0x32, 0x08, 0x3d, 0x3f, 0x5a, 0x0e, 0xf3, 0xe7, 0xf0,
+	0x7f, 0x4b, 0x64, 0xc4, 0x80, 0x0c, 0x28, 0x0a, 0x92, 0x78, 0x60, 0x89, 0xaa, 0xe6, 0xa7, 0xb0,
+	0xc6, 0x8b, 0x8d, 0x73, 0xcb, 0x1d, 0xb2, 0xed, 0xe2, 0x75, 0xb1, 0xb6, 0xa2, 0x46, 0x35, 0x74,
+	0x89, 0x4f, 0xc3, 0xbf, 0x40, 0x4a, 0x54, 0x35, 0x6d, 0x7a, 0x5b, 0x7e, 0x36, 0x61, 0xe7, 0xdc,
+	0xde, 0x80, 0xb8, 0x96, 0x6f, 0xaf, 0x74, 0x5b, 0xfd, 0xff, 0x50, 0xe4, 0x7d, 0x46, 0xaf, 0x3b,
+	0x57, 0x65, 0x67, 0x25, 0x8d, 0x09, 0x47, 0x72, 0xe6, 0xbf, 0xb2, 0xb0, 0xad, 0xe8, 0xeb, 0x15,
+	0x03, 0xd3, 0x27, 0x70, 0xe5, 0xbe, 0x15, 0x10, 0xc7, 0x76, 0x89, 0x32, 0xdf, 0x59, 0xc0, 0xa5,
+	0xed, 0xa2, 0xbe, 0x53, 0xed, 0xb2, 0xbc, 0x9d, 0x9a, 0x8b, 0xf6, 0x61, 0x2b, 0x41, 0x89, 0x66,
+	0x9f, 0x63, 0xad, 0x94, 0xbc, 0x94, 0xcb, 0xcb, 0xa7, 0x83, 0x27, 0xd5, 0x19, 0x8f, 0x52, 0xb6,
+	0x57, 0x6e, 0x52, 0x4a, 0x1e, 0xfa, 0x09, 0x5c, 0x8e, 0xe8, 0x49, 0xfb, 0xe5, 0xe7, 0x41, 0x35,
+	0x13, 0xdd, 0x05, 0x24, 0x6b, 0xe1, 0x03, 0x14, 0x39, 0x86, 0x82, 0x43, 0xef, 0x30, 0x12, 0x0a,
+	0x44, 0x03, 0x7e, 0x4c, 0x54, 0xb1, 0xcc, 0x2d, 0x40, 0xd2, 0x95, 0x46, 0x84, 0xef, 0x36, 0x6c,
+	0x26, 0xa8, 0x62, 0xb3, 0x7f, 0x0a, 0x15, 0x89, 0x2c, 0xf6, 0xfb, 0xb2, 0x32, 0xdc, 0x61, 0x59,
+	0xd2, 0xfc, 0x53, 0x56, 0xf6, 0x11, 0xca, 0xf7, 0x9c, 0x2d, 0xc8, 0x9f, 0x58, 0x5f, 0x13, 0x27,
+	0x7a, 0x51, 0x65, 0x15, 0xf6, 0x38, 0x42, 0x82, 0x81, 0x6f, 0x8f, 0x63, 0xe7, 0x51, 0xc6, 0x32,
+	0x09, 0x7d, 0x04, 0x85, 0x03, 0x6f, 0x64, 0x89, 0xc7, 0xd5, 0x6a, 0xc2, 0xa3, 0x8b, 0x6b, 0x25,
+	0xdb, 0x73, 0xb9, 0x08, 0x16, 0xa2, 0x29, 0x97, 0x94, 0x9f, 0x73, 0x49, 0xd4, 0x84, 0x88, 0xc3,
+	0xdf, 0x2c, 0x79, 0x50, 0x8e, 0xeb, 0xe9, 0xf3, 0x7c, 0x61, 0x85, 0xf3, 0x7c, 0x71, 0xd5, 0xf3,
+	0x7c, 0x69, 0xd5, 0xf3, 0x7c, 0x59, 0x71, 0x9e, 0x47, 0x3f, 0x03, 0x10, 0x41, 0x9f, 0x27, 0x0a,
+	0xe9, 0xcb, 0xc1, 0x38, 0x72, 0x45, 0x37, 0x64, 0x58, 0x92, 0x37, 0xbf, 0x82, 0x4b, 0x73, 0x02,
+	0x2f, 0x1f, 0xdb, 0x6a, 0x50, 0x8a, 0x1a, 0x0b, 0x9f, 0x15, 0xd7, 0xcd, 0x3f, 0xd0, 0xcb, 0xc3,
+	0x8b, 0xb1, 0xe7, 0x87, 0x22, 0xd1, 0x8d, 0x3c, 0xd6, 0xa7, 0xf2, 0x0d, 0x02, 0x77, 0xe0, 0xa6,
+	0xd4, 0xcd, 0x82, 0x07, 0x18, 0xf9, 0x96, 0xb5, 0xa3, 0x38, 0x3e, 0x51, 0x35, 0xef, 0xcd, 0xab,
+	0x51, 0xde, 0x42, 0xa4, 0xcf, 0x59, 0xa8, 0x91, 0xf0, 0x01, 0x59, 0xa6, 0xec, 0xd6, 0xbc, 0xb2,
+	0xb9, 0x33, 0x58, 0xc2, 0x51, 0x7c, 0x00, 0x85, 0x23, 0xcf, 0x1f, 0x59, 0xa1, 0xc0, 0xa6, 0xec,
+	0x6c, 0xf9, 0x42, 0x70, 0x36, 0x16, 0x62, 0xa6, 0x0d, 0x97, 0x53, 0x0b, 0x24, 0x2c, 0x8f, 0x2e,
+	0xab, 0xed, 0x10, 0xd9, 0xa7, 0x47, 0x75, 0x0a, 0x48, 0xfa, 0x6e, 0x4b, 0xdc, 0x50, 0x0a, 0xc0,
+	0x32, 0x89, 0xda, 0xdb, 0x81, 0x15, 0xf2, 0x07, 0xc4, 0x35, 0xcc, 0xca, 0xe6, 0xdf, 0x32, 0xb0,
+	0xd1, 0xb2, 0xc6, 0xbd, 0x81, 0xe5, 0x90, 0x55, 0x22, 0xc7, 0xc7, 0x00, 0x3c, 0xe1, 0x89, 0x3b,
+	0xa9, 0x26, 0x4c, 0x7f, 0xc6, 0xc4, 0x92, 0xe0, 0xcb, 0x9f, 0x09, 0x92, 0xd9, 0x44, 0x6e, 0x2e,
+	0x9b, 0x98, 0x3f, 0x33, 0xe4, 0x5f, 0xf6, 0xcc, 0x90, 0xb4, 0xfe, 0xc2, 0x0a, 0x09, 0x49, 0x51,
+	0x99, 0x90, 0x9c, 0x80, 0x3e, 0x5b, 0x41, 0xb1, 0x51, 0xfa, 0x2c, 0x0b, 0xd1, 0x78, 0xce, 0xa1,
+	0xcf, 0x72, 0x0e, 0x8d, 0xbf, 0xa2, 0x6e, 0x41, 0xbe, 0x31, 0x09, 0xbb, 0xa1, 0xc8, 0x85, 0x78,
+	0x65, 0xef, 0x89, 0xea, 0x55, 0x08, 0xdd, 0x84, 0xda, 0x69, 0xfb, 0x61, 0xbb, 0xf3, 0xb8, 0xfd,
+	0x65, 0xff, 0xb0, 0xd5, 0xed, 0xe0, 0xfa, 0x09, 0x3e, 0xec, 0x75, 0x4e, 0x4e, 0xfb, 0xcd, 0x4e,
+	0x5b, 0x7f, 0x0b, 0x01, 0x14, 0x1e, 0x78, 0x13, 0xdf, 0x99, 0xea, 0x1a, 0x2a, 0x43, 0xfe, 0xc0,
+	0xb2, 0x9d, 0xa9, 0x9e, 0x41, 0x15, 0x28, 0xb6, 0x3c, 0x37, 0x3c, 0x77, 0xa6, 0x7a, 0x76, 0xaf,
+	0x03, 0x1b, 0xa9, 0x9b, 0x5b, 0x74, 0x0d, 0xae, 0x46, 0x6a, 0x7b, 0xa7, 0x18, 0x77, 0x8e, 0xeb,
+	0xfd, 0xc3, 0xc7, 0x87, 0xcd, 0xe3, 0x07, 0x7d, 0xae, 0xf3, 0x84, 0xb8, 0xc3, 0xf0, 0x5c, 0xd7,
+	0x50, 0x89, 0xbf, 0x3d, 0xeb, 0x19, 0xaa, 0x9d, 0x3d, 0xf6, 0xea, 0xd9, 0xbd, 0x63, 0xd0, 0xd3,
+	0xae, 0x15, 0x5d, 0x07, 0x23, 0xd2, 0x58, 0x3f, 0x39, 0xe9, 0x34, 0xea, 0x74, 0x80, 0x07, 0x9d,
+	0x56, 0xbd, 0x49, 0x87, 0x59, 0xe2, 0xff, 0x3c, 0x74, 0x8d, 0x8e, 0x4c, 0xbc, 0x02, 0xeb, 0x99,
+	0xbd, 0xce, 0x0c, 0x1d, 0x68, 0x0b, 0xf4, 0x48, 0xc1, 0x61, 0xab, 0xd9, 0xeb, 0xf1, 0xf9, 0x95,
+	0xc5, 0xd5, 0xac, 0xae, 0xa1, 0x22, 0xbb, 0x7e, 0xd5, 0x33, 0xac, 0xd0, 0xb9, 0xd0, 0xb3, 0xb4,
+	0xd0, 0xeb, 0x5c, 0xe8, 0x39, 0x5a, 0x78, 0xd4, 0x69, 0xe8, 0xf9, 0xbd, 0xe7, 0xb0, 0x9e, 0x38,
+	0xd8, 0xa1, 0x6d, 0xb8, 0x9c, 0xd6, 0x7a, 0xda, 0x6e, 0xf6, 0x7b, 0xfa, 0x5b, 0x68, 0x1d, 0xca,
+	0x0f, 0x6d, 0xc7, 0x1b, 0xfa, 0xd6, 0x28, 0xd0, 0x35, 0x3a, 0xeb, 0xbe, 0xe7, 0xba, 0x24, 0xd0,
+	0x33, 0xa8, 0x0a, 0x40, 0x59, 0x21, 0xaf, 0x67, 0xe9, 0xd8, 0x5a, 0x64, 0x68, 0x31, 0xd1, 0x2e,
+	0xf1, 0x9f, 0x12, 0xcb, 0xd7, 0x73, 0x7b, 0xcf, 0x60, 0x4d, 0xb6, 0x62, 0x64, 0xc0, 0x56, 0xdc,
+	0xd7, 0x93, 0x6e, 0x07, 0xf7, 0x8f, 0x3a, 0xb8, 0x55, 0xa7, 0x2b, 0x5a, 0x61, 0x9f, 0x15, 0xfa,
+	0xcd, 0xa3, 0x23, 0xde, 0x51, 0x9b, 0x84, 0x8d, 0x83, 0x23, 0x3d, 0x43, 0xc7, 0xd0, 0x3b, 0xb7,
+	0xc6, 0xe4, 0x99, 0xed, 0x10, 0x3d, 0x2b, 0xe4, 0xe8, 0x2f, 0x06, 0x3e, 0xa9, 0x46, 0xef, 0x91,
+	0x9e, 0xdf, 0x3b, 0x96, 0x4d, 0x0f, 0x5d, 0x01, 0x14, 0xf5, 0xd2, 0x6c, 0x75, 0xeb, 0x8d, 0x7e,
+	0xff, 0x69, 0xf7, 0x90, 0x4f, 0x27, 0xf6, 0x87, 0xba, 0x86, 0x50, 0xda, 0x23, 0xea, 0x99, 0xbd,
+	0x27, 0x69, 0xdb, 0x41, 0x35, 0xb8, 0x12, 0xe3, 0xa0, 0xd9, 0x3a, 0x3d, 0x61, 0xbb, 0x26, 0x14,
+	0x96, 0x21, 0xcf, 0x32, 0x06, 0x5d, 0xa3, 0xba, 0xe9, 0xf6, 0xf1, 0x6a, 0x06, 0xe9, 0xfc, 0xa0,
+	0xd2, 0xb2, 0xfc, 0xa1, 0xed, 0x5a, 0x8e, 0x9e, 0xdd, 0xff, 0x27, 0xf0, 0x13, 0x47, 0xfd, 0x73,
+	0xf4, 0x8b, 0xe8, 0xc7, 0x07, 0x32, 0x92, 0x7f, 0x3d, 0x66, 0x5f, 0xab, 0x6a, 0xdb, 0x0a, 0x0e,
+	0x37, 0x20, 0xf3, 0x2d, 0xf4, 0x39, 0xac, 0xc9, 0x1f, 0x6b, 0xd0, 0xcd, 0xa4, 0x70, 0xfa, 0x03,
+	0x4f, 0xed, 0xed, 0x85, 0xfc, 0x58, 0xe5, 0x21, 0x94, 0xa2, 0x7f, 0x18, 0xa8, 0x96, 0x12, 0x97,
+	0x3e, 0x7a, 0xd4, 0xae, 0x29, 0x79, 0xb1, 0x9a, 0x5f, 0x82, 0x9e, 0xfe, 0x27, 0x83, 0xcc, 0xb9,
+	0xa9, 0xcc, 0xfd, 0xd2, 0xa9, 0xdd, 0x5a, 0x2a, 0x13, 0xab, 0xff, 0x0a, 0x2e, 0xcd, 0x7d, 0x53,
+	0x41, 0x72, 0xdb, 0x45, 0x1f, 0x64, 0x6a, 0xb7, 0x97, 0x0b, 0xc9, 0x13, 0x48, 0x07, 0x53, 0xb4,
+	0x42, 0xa4, 0xad, 0xdd, 0x5a, 0x2a, 0x13, 0xab, 0x7f, 0x06, 0x9b, 0x8a, 0x13, 0x1a, 0x7a, 0x47,
+	0xf5, 0xc4, 0x38, 0x77, 0xc6, 0xac, 0xbd, 0xfb, 0x22, 0xb1, 0xb8, 0x1f, 0x07, 0x2e, 0x2b, 0x83,
+	0x39, 0x5a, 0x35, 0xdc, 0xd7, 0x76, 0x5f, 0x2c, 0x28, 0x83, 0x27, 0x72, 0xf3, 0x09, 0xf0, 0xa4,
+	0xa2, 0x67, 0xed, 0x9a, 0x92, 0x27, 0xef, 0xee, 0x5c, 0xd2, 0x80, 0x56, 0x49, 0x29, 0x6a, 0xb7,
+	0x97, 0x0b, 0xc5, 0x3d, 0xf4, 0x61, 0x3d, 0x71, 0x87, 0x83, 0xde, 0x9e, 0x8b, 0xcf, 0xc9, 0xdb,
+	0xbe, 0xda, 0xce, 0x62, 0x81, 0x58, 0xeb, 0x05, 0x6c, 0x2f, 0x3c, 0xeb, 0xa2, 0xf7, 0x55, 0x7b,
+	0xb6, 0xe0, 0xd4, 0x5c, 0xbb, 0xb3, 0x9a, 0x70, 0xdc, 0x73, 0x3b, 0x71, 0xdc, 0x40, 0x37, 0x94,
+	0x07, 0x8d, 0x58, 0xfb, 0xcd, 0x45, 0x6c, 0x79, 0x7d, 0x12, 0xd9, 0x55, 0x62, 0x7d, 0x54, 0x89,
+	0x69, 0x6d, 0x67, 0xb1, 0x40, 0xd2, 0x6a, 0x53, 0xc7, 0xe3, 0x94, 0xd5, 0xaa, 0x0f, 0xea, 0xb5,
+	0xdb, 0xcb, 0x85, 0xa2, 0x1e, 0xee, 0x57, 0xbe, 0x98, 0xfd, 0x53, 0xfd, 0xba, 0xc0, 0x7e, 0xae,
+	0x7e, 0xf4, 0x9f, 0x01, 0x00, 0x54, 0xbe, 0x3f, 0x0a, 0xc9, 0x2a, 0x00, 0x00,
 }
@@ -75,6 +75,66 @@ func (mr *MockCityAQClientMockRecorder) CityGeometry(ctx, in interface{}, opts .
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CityGeometry", reflect.TypeOf((*MockCityAQClient)(nil).CityGeometry), varargs...)
 }
 
+// CityInfo mocks base method
+func (m *MockCityAQClient) CityInfo(ctx context.Context, in *cityaqrpc.CityInfoRequest, opts ...grpc.CallOption) (*cityaqrpc.CityInfoResponse, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "CityInfo", varargs...)
+	ret0, _ := ret[0].(*cityaqrpc.CityInfoResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CityInfo indicates an expected call of CityInfo
+func (mr *MockCityAQClientMockRecorder) CityInfo(ctx, in interface{}, opts ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CityInfo", reflect.TypeOf((*MockCityAQClient)(nil).CityInfo), varargs...)
+}
+
+// CitiesContaining mocks base method
+func (m *MockCityAQClient) CitiesContaining(ctx context.Context, in *cityaqrpc.CitiesContainingRequest, opts ...grpc.CallOption) (*cityaqrpc.CitiesContainingResponse, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "CitiesContaining", varargs...)
+	ret0, _ := ret[0].(*cityaqrpc.CitiesContainingResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CitiesContaining indicates an expected call of CitiesContaining
+func (mr *MockCityAQClientMockRecorder) CitiesContaining(ctx, in interface{}, opts ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CitiesContaining", reflect.TypeOf((*MockCityAQClient)(nil).CitiesContaining), varargs...)
+}
+
+// RegisterStudyArea mocks base method
+func (m *MockCityAQClient) RegisterStudyArea(ctx context.Context, in *cityaqrpc.RegisterStudyAreaRequest, opts ...grpc.CallOption) (*cityaqrpc.RegisterStudyAreaResponse, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "RegisterStudyArea", varargs...)
+	ret0, _ := ret[0].(*cityaqrpc.RegisterStudyAreaResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RegisterStudyArea indicates an expected call of RegisterStudyArea
+func (mr *MockCityAQClientMockRecorder) RegisterStudyArea(ctx, in interface{}, opts ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RegisterStudyArea", reflect.TypeOf((*MockCityAQClient)(nil).RegisterStudyArea), varargs...)
+}
+
 // GriddedEmissions mocks base method
 func (m *MockCityAQClient) GriddedEmissions(ctx context.Context, in *cityaqrpc.GriddedEmissionsRequest, opts ...grpc.CallOption) (*cityaqrpc.GriddedEmissionsResponse, error) {
 	m.ctrl.T.Helper()
@@ -215,6 +275,66 @@ func (mr *MockCityAQClientMockRecorder) EmissionsInventorySectors(ctx, in interf
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EmissionsInventorySectors", reflect.TypeOf((*MockCityAQClient)(nil).EmissionsInventorySectors), varargs...)
 }
 
+// SourceTypes mocks base method
+func (m *MockCityAQClient) SourceTypes(ctx context.Context, in *cityaqrpc.SourceTypesRequest, opts ...grpc.CallOption) (*cityaqrpc.SourceTypesResponse, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "SourceTypes", varargs...)
+	ret0, _ := ret[0].(*cityaqrpc.SourceTypesResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SourceTypes indicates an expected call of SourceTypes
+func (mr *MockCityAQClientMockRecorder) SourceTypes(ctx, in interface{}, opts ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SourceTypes", reflect.TypeOf((*MockCityAQClient)(nil).SourceTypes), varargs...)
+}
+
+// ExportGridded mocks base method
+func (m *MockCityAQClient) ExportGridded(ctx context.Context, in *cityaqrpc.ExportGriddedRequest, opts ...grpc.CallOption) (*cityaqrpc.ExportGriddedResponse, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "ExportGridded", varargs...)
+	ret0, _ := ret[0].(*cityaqrpc.ExportGriddedResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ExportGridded indicates an expected call of ExportGridded
+func (mr *MockCityAQClientMockRecorder) ExportGridded(ctx, in interface{}, opts ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExportGridded", reflect.TypeOf((*MockCityAQClient)(nil).ExportGridded), varargs...)
+}
+
+// ReductionScenario mocks base method
+func (m *MockCityAQClient) ReductionScenario(ctx context.Context, in *cityaqrpc.ReductionScenarioRequest, opts ...grpc.CallOption) (*cityaqrpc.ReductionScenarioResponse, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "ReductionScenario", varargs...)
+	ret0, _ := ret[0].(*cityaqrpc.ReductionScenarioResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReductionScenario indicates an expected call of ReductionScenario
+func (mr *MockCityAQClientMockRecorder) ReductionScenario(ctx, in interface{}, opts ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReductionScenario", reflect.TypeOf((*MockCityAQClient)(nil).ReductionScenario), varargs...)
+}
+
 // MockCityAQServer is a mock of CityAQServer interface
 type MockCityAQServer struct {
 	ctrl     *gomock.Controller
@@ -268,6 +388,51 @@ func (mr *MockCityAQServerMockRecorder) CityGeometry(arg0, arg1 interface{}) *go
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CityGeometry", reflect.TypeOf((*MockCityAQServer)(nil).CityGeometry), arg0, arg1)
 }
 
+// CityInfo mocks base method
+func (m *MockCityAQServer) CityInfo(arg0 context.Context, arg1 *cityaqrpc.CityInfoRequest) (*cityaqrpc.CityInfoResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CityInfo", arg0, arg1)
+	ret0, _ := ret[0].(*cityaqrpc.CityInfoResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CityInfo indicates an expected call of CityInfo
+func (mr *MockCityAQServerMockRecorder) CityInfo(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CityInfo", reflect.TypeOf((*MockCityAQServer)(nil).CityInfo), arg0, arg1)
+}
+
+// CitiesContaining mocks base method
+func (m *MockCityAQServer) CitiesContaining(arg0 context.Context, arg1 *cityaqrpc.CitiesContainingRequest) (*cityaqrpc.CitiesContainingResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CitiesContaining", arg0, arg1)
+	ret0, _ := ret[0].(*cityaqrpc.CitiesContainingResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CitiesContaining indicates an expected call of CitiesContaining
+func (mr *MockCityAQServerMockRecorder) CitiesContaining(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CitiesContaining", reflect.TypeOf((*MockCityAQServer)(nil).CitiesContaining), arg0, arg1)
+}
+
+// RegisterStudyArea mocks base method
+func (m *MockCityAQServer) RegisterStudyArea(arg0 context.Context, arg1 *cityaqrpc.RegisterStudyAreaRequest) (*cityaqrpc.RegisterStudyAreaResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RegisterStudyArea", arg0, arg1)
+	ret0, _ := ret[0].(*cityaqrpc.RegisterStudyAreaResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RegisterStudyArea indicates an expected call of RegisterStudyArea
+func (mr *MockCityAQServerMockRecorder) RegisterStudyArea(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RegisterStudyArea", reflect.TypeOf((*MockCityAQServer)(nil).RegisterStudyArea), arg0, arg1)
+}
+
 // GriddedEmissions mocks base method
 func (m *MockCityAQServer) GriddedEmissions(arg0 context.Context, arg1 *cityaqrpc.GriddedEmissionsRequest) (*cityaqrpc.GriddedEmissionsResponse, error) {
 	m.ctrl.T.Helper()
@@ -372,3 +537,48 @@ func (mr *MockCityAQServerMockRecorder) EmissionsInventorySectors(arg0, arg1 int
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EmissionsInventorySectors", reflect.TypeOf((*MockCityAQServer)(nil).EmissionsInventorySectors), arg0, arg1)
 }
+
+// SourceTypes mocks base method
+func (m *MockCityAQServer) SourceTypes(arg0 context.Context, arg1 *cityaqrpc.SourceTypesRequest) (*cityaqrpc.SourceTypesResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SourceTypes", arg0, arg1)
+	ret0, _ := ret[0].(*cityaqrpc.SourceTypesResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SourceTypes indicates an expected call of SourceTypes
+func (mr *MockCityAQServerMockRecorder) SourceTypes(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SourceTypes", reflect.TypeOf((*MockCityAQServer)(nil).SourceTypes), arg0, arg1)
+}
+
+// ExportGridded mocks base method
+func (m *MockCityAQServer) ExportGridded(arg0 context.Context, arg1 *cityaqrpc.ExportGriddedRequest) (*cityaqrpc.ExportGriddedResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ExportGridded", arg0, arg1)
+	ret0, _ := ret[0].(*cityaqrpc.ExportGriddedResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ExportGridded indicates an expected call of ExportGridded
+func (mr *MockCityAQServerMockRecorder) ExportGridded(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExportGridded", reflect.TypeOf((*MockCityAQServer)(nil).ExportGridded), arg0, arg1)
+}
+
+// ReductionScenario mocks base method
+func (m *MockCityAQServer) ReductionScenario(arg0 context.Context, arg1 *cityaqrpc.ReductionScenarioRequest) (*cityaqrpc.ReductionScenarioResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReductionScenario", arg0, arg1)
+	ret0, _ := ret[0].(*cityaqrpc.ReductionScenarioResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReductionScenario indicates an expected call of ReductionScenario
+func (mr *MockCityAQServerMockRecorder) ReductionScenario(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReductionScenario", reflect.TypeOf((*MockCityAQServer)(nil).ReductionScenario), arg0, arg1)
+}
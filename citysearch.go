@@ -0,0 +1,194 @@
+package cityaq
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"unicode"
+
+	"github.com/ctessum/geom"
+)
+
+// cityMeta holds information about a city that is derived
+// from its boundary, which is expensive to compute.
+type cityMeta struct {
+	once    sync.Once
+	country string
+	bounds  *geom.Bounds
+	err     error
+}
+
+// cityMetaKey returns the key of the given city in CityAQ.cityMeta,
+// which changes whenever the city's boundary changes.
+func cityMetaKey(f cityFile) string {
+	return f.id + "|" + f.version
+}
+
+// evictCityMeta removes the information about cities that are
+// no longer in the given catalog files, or whose boundaries have
+// changed, from CityAQ.cityMeta.
+func (c *CityAQ) evictCityMeta(files []cityFile) {
+	keep := make(map[string]bool, len(files))
+	for _, f := range files {
+		keep[cityMetaKey(f)] = true
+	}
+	c.cityMetaMu.Lock()
+	defer c.cityMetaMu.Unlock()
+	for key := range c.cityMeta {
+		if !keep[key] {
+			delete(c.cityMeta, key)
+		}
+	}
+}
+
+// meta returns information derived from the boundary of the given city.
+// The information is computed the first time it is requested and then
+// reused until the boundary file changes. If the boundary can't be read,
+// the err field of the result is set. The country is taken from the
+// "country" or "is_in:country" property of the boundary file if it is
+// present; otherwise it is the country that the boundary overlaps most.
+// If the country can't be determined, for example because the country
+// boundaries are not available, the country field is empty.
+func (c *CityAQ) meta(f cityFile) *cityMeta {
+	key := cityMetaKey(f)
+	c.cityMetaMu.Lock()
+	if c.cityMeta == nil {
+		c.cityMeta = make(map[string]*cityMeta)
+	}
+	m, ok := c.cityMeta[key]
+	if !ok {
+		m = new(cityMeta)
+		c.cityMeta[key] = m
+	}
+	c.cityMetaMu.Unlock()
+
+	m.once.Do(func() {
+		m.country = stringProperty(f.props, "country", "is_in:country")
+		g, err := c.cityGeometry(f.id)
+		if err != nil {
+			m.err = err
+			return
+		}
+		m.bounds = g.Bounds()
+		if m.country == "" {
+			if ctry, err := c.country(f.id); err == nil {
+				m.country = ctry.Name
+			}
+		}
+	})
+	return m
+}
+
+// cityRegion returns the region of the world that a city with the
+// given boundary file properties is in, or an empty string
+// if it is not known.
+func cityRegion(props map[string]interface{}) string {
+	return stringProperty(props, "region", "c40_region", "is_in:continent")
+}
+
+// stringProperty returns the value of the first of the given keys
+// that has a non-empty string value in props, or an empty string
+// if there is no such key.
+func stringProperty(props map[string]interface{}, keys ...string) string {
+	for _, key := range keys {
+		if v, ok := props[key].(string); ok && v != "" {
+			return v
+		}
+	}
+	return ""
+}
+
+// foldName returns s in lower case, with accents and characters
+// other than letters and numbers removed, for use in matching names.
+func foldName(s string) []rune {
+	var o []rune
+	for _, r := range strings.ToLower(s) {
+		if f, ok := latinFold[r]; ok {
+			r = f
+		}
+		if unicode.IsLetter(r) || unicode.IsNumber(r) {
+			o = append(o, r)
+		}
+	}
+	return o
+}
+
+// matchesQuery returns whether any of the given names matches query.
+// A name matches if it contains the query, ignoring case, accents,
+// spaces, and punctuation, or if part of it differs from the query by
+// at most one typing error for every four characters in the query.
+func matchesQuery(query string, names ...string) bool {
+	q := foldName(query)
+	if len(q) == 0 {
+		return true
+	}
+	maxDist := len(q) / 4
+	for _, name := range names {
+		n := foldName(name)
+		if strings.Contains(string(n), string(q)) {
+			return true
+		}
+		if maxDist == 0 {
+			continue
+		}
+		for start := range n {
+			for l := len(q) - maxDist; l <= len(q)+maxDist && start+l <= len(n); l++ {
+				if editDistance(q, n[start:start+l]) <= maxDist {
+					return true
+				}
+			}
+		}
+	}
+	return false
+}
+
+// editDistance returns the Levenshtein distance between a and b.
+func editDistance(a, b []rune) int {
+	prev := make([]int, len(b)+1)
+	cur := make([]int, len(b)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		cur[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			cur[j] = min3(prev[j]+1, cur[j-1]+1, prev[j-1]+cost)
+		}
+		prev, cur = cur, prev
+	}
+	return prev[len(b)]
+}
+
+func min3(a, b, c int) int {
+	if b < a {
+		a = b
+	}
+	if c < a {
+		a = c
+	}
+	return a
+}
+
+// boundsOverlap returns whether a and b overlap.
+func boundsOverlap(a, b *geom.Bounds) bool {
+	return a.Min.X <= b.Max.X && a.Max.X >= b.Min.X &&
+		a.Min.Y <= b.Max.Y && a.Max.Y >= b.Min.Y
+}
+
+// parsePageToken returns the offset of the first result
+// represented by the given page token.
+func parsePageToken(token string) (int, error) {
+	if token == "" {
+		return 0, nil
+	}
+	offset, err := strconv.Atoi(token)
+	if err != nil || offset < 0 {
+		return 0, fmt.Errorf("cityaq: invalid page token %q", token)
+	}
+	return offset, nil
+}
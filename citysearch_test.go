@@ -0,0 +1,145 @@
+package cityaq
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	rpc "github.com/ctessum/cityaq/cityaqrpc"
+	"github.com/spatialmodel/inmap/emissions/aep/aeputil"
+)
+
+func TestCityAQ_CitiesSearch(t *testing.T) {
+	c := &CityAQ{
+		CityGeomDir: "testdata/boundaries",
+		SpatialConfig: aeputil.SpatialConfig{
+			SrgShapefileDirectory: "testdata",
+		},
+	}
+
+	tests := []struct {
+		name      string
+		req       *rpc.CitiesRequest
+		want      []string
+		total     int32
+		nextToken string
+	}{
+		{
+			name:  "all",
+			req:   &rpc.CitiesRequest{},
+			want:  []string{"cape-coast", "kumasi", "tamale", "tema"},
+			total: 4,
+		},
+		{
+			name:  "substring",
+			req:   &rpc.CitiesRequest{Query: "COAST"},
+			want:  []string{"cape-coast"},
+			total: 1,
+		},
+		{
+			name:  "display name",
+			req:   &rpc.CitiesRequest{Query: "koumas", Language: "fr"},
+			want:  []string{"kumasi"},
+			total: 1,
+		},
+		{
+			name:  "typo",
+			req:   &rpc.CitiesRequest{Query: "Kumassi"},
+			want:  []string{"kumasi"},
+			total: 1,
+		},
+		{
+			name:  "country",
+			req:   &rpc.CitiesRequest{Country: "ghana"},
+			want:  []string{"cape-coast", "kumasi", "tamale", "tema"},
+			total: 4,
+		},
+		{
+			name: "other country",
+			req:  &rpc.CitiesRequest{Country: "Pakistan"},
+		},
+		{
+			name: "bounding box",
+			req: &rpc.CitiesRequest{
+				Min: &rpc.Point{X: -1, Y: 5},
+				Max: &rpc.Point{X: 0.5, Y: 6},
+			},
+			want:  []string{"tema"},
+			total: 1,
+		},
+		{
+			name:      "first page",
+			req:       &rpc.CitiesRequest{PageSize: 3},
+			want:      []string{"cape-coast", "kumasi", "tamale"},
+			total:     4,
+			nextToken: "3",
+		},
+		{
+			name:  "last page",
+			req:   &rpc.CitiesRequest{PageSize: 3, PageToken: "3"},
+			want:  []string{"tema"},
+			total: 4,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			r, err := c.Cities(context.Background(), test.req)
+			if err != nil {
+				t.Fatal(err)
+			}
+			var ids []string
+			for _, city := range r.Cities {
+				ids = append(ids, city.ID)
+				if city.Country != "Ghana" {
+					t.Errorf("%s: country %q != Ghana", city.ID, city.Country)
+				}
+			}
+			if !reflect.DeepEqual(ids, test.want) {
+				t.Errorf("IDs: %v != %v", ids, test.want)
+			}
+			if len(r.Names) != len(r.Cities) {
+				t.Errorf("have %d names and %d cities", len(r.Names), len(r.Cities))
+			}
+			if r.TotalSize != test.total {
+				t.Errorf("total size: %d != %d", r.TotalSize, test.total)
+			}
+			if r.NextPageToken != test.nextToken {
+				t.Errorf("next page token: %q != %q", r.NextPageToken, test.nextToken)
+			}
+		})
+	}
+
+	for _, req := range []*rpc.CitiesRequest{
+		{PageToken: "x"},
+		{PageSize: -1},
+		{Min: &rpc.Point{X: 0, Y: 0}},
+		{Min: &rpc.Point{X: 1, Y: 1}, Max: &rpc.Point{X: 0, Y: 0}},
+	} {
+		if _, err := c.Cities(context.Background(), req); err == nil {
+			t.Errorf("%v: expected an error", req)
+		}
+	}
+}
+
+func TestMatchesQuery(t *testing.T) {
+	tests := []struct {
+		query, name string
+		want        bool
+	}{
+		{query: "", name: "Accra", want: true},
+		{query: "acc", name: "Accra Metropolitan", want: true},
+		{query: "metro", name: "Accra Metropolitan", want: true},
+		{query: "sao paulo", name: "São Paulo", want: true},
+		{query: "saopaulo", name: "São Paulo", want: true},
+		{query: "Karchi", name: "Karachi", want: true},
+		{query: "Krachi", name: "ڪراچي Karachi", want: true},
+		{query: "lgs", name: "Lagos", want: false},
+		{query: "Lima", name: "Accra", want: false},
+		{query: "Bogata", name: "Bogotá", want: true},
+	}
+	for _, test := range tests {
+		if got := matchesQuery(test.query, test.name); got != test.want {
+			t.Errorf("%q, %q: %v != %v", test.query, test.name, got, test.want)
+		}
+	}
+}
@@ -1,6 +1,7 @@
 package main
 
 import (
+	"context"
 	"crypto/tls"
 	"net/http"
 	"os"
@@ -42,13 +43,21 @@ func main() {
 			InputSR:               "+proj=longlat",
 			MaxCacheEntries:       100,
 		},
+		SourceTypeFile:              "cmd/sourcetypes.toml",
 		CacheLoc:                    "file://" + cache,
 		Version:                     "latest",
 		InMAPCityMarginalConfigFile: "testdata/inmap_config.toml",
 		InMAPCityTotalConfigFile:    "testdata/inmap_config_coards.toml",
 		InMAPTotalConfigFile:        "testdata/inmap_config_coards.toml",
+
+		// Set TemporalRefFile and TemporalProFile to SMOKE temporal
+		// files to allocate emissions in time; without them,
+		// emissions are constant over time.
 	}
 
+	// Pick up changes to the city boundary files without restarting.
+	go c.WatchCities(context.Background(), time.Minute)
+
 	srv := cityaq.NewGRPCServer(c)
 	srv.Log = logger
 
@@ -0,0 +1,38 @@
+// Command validatecities checks the city boundary files in a directory
+// for problems and exits with a nonzero status if any are found, so that
+// it can be run as part of continuous integration.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/ctessum/cityaq"
+	"github.com/spatialmodel/inmap/emissions/aep/aeputil"
+)
+
+func main() {
+	cityDir := flag.String("cities", "testdata/cities", "directory containing the city boundary files")
+	countryDir := flag.String("countries", "testdata", "directory containing the Countries_WGS84 shapefile; if empty, boundaries are not matched to countries")
+	flag.Parse()
+
+	c := &cityaq.CityAQ{
+		CityGeomDir: *cityDir,
+		SpatialConfig: aeputil.SpatialConfig{
+			SrgShapefileDirectory: *countryDir,
+		},
+	}
+	problems, err := c.ValidateCities()
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(2)
+	}
+	for _, p := range problems {
+		fmt.Println(p)
+	}
+	if len(problems) > 0 {
+		fmt.Fprintf(os.Stderr, "found %d problems\n", len(problems))
+		os.Exit(1)
+	}
+}
@@ -3,7 +3,9 @@ package cityaq
 import (
 	"bytes"
 	"context"
+	"crypto/sha256"
 	"encoding/gob"
+	"encoding/hex"
 	"encoding/json"
 	"fmt"
 	"io/ioutil"
@@ -33,9 +35,51 @@ import (
 )
 
 // GriddedConcentrations returns PM2.5 concentrations calculated by the InMAP
-// air quality model.
+// air quality model, averaged over the emissions period. Amounts, periods,
+// pollutants, and speciation can only be requested for CityMarginal
+// simulations.
 func (c *CityAQ) GriddedConcentrations(ctx context.Context, req *rpc.GriddedConcentrationsRequest) (*rpc.GriddedConcentrationsResponse, error) {
-	var err error
+	points, err := requestPointSources(req.PointSources)
+	if err != nil {
+		return nil, err
+	}
+	surrogate, err := requestCustomSurrogate(req.CustomSurrogate)
+	if err != nil {
+		return nil, err
+	}
+	blend, err := requestSourceTypeBlend(req.SourceTypeMix)
+	if err != nil {
+		return nil, err
+	}
+	if err := checkMarginalAmounts(req.SimulationType, req.Amount, req.Begin, req.End, req.Pollutants, req.Speciate); err != nil {
+		return nil, err
+	}
+	if err := checkPointSourceAmounts(points, req.Emission, req.Amount, req.AmountUnits, req.Pollutants, req.Speciate); err != nil {
+		return nil, err
+	}
+	var amounts []pollutantAmount
+	if len(points) > 0 {
+		if err := c.checkPointSourceDomain(req.CityName, points); err != nil {
+			return nil, err
+		}
+		// The simulation is run with the requested point source
+		// emissions, so include all PM2.5 species without scaling.
+		for _, p := range []rpc.Emission{rpc.Emission_PM2_5, rpc.Emission_NH3, rpc.Emission_NOx, rpc.Emission_SOx, rpc.Emission_VOC} {
+			amounts = append(amounts, pollutantAmount{pollutant: p, emissionsAmount: referenceAmount})
+		}
+	} else {
+		var st *sourceType
+		if req.Speciate {
+			st, err = c.requestSourceType(req.SourceType, surrogate, blend)
+			if err != nil {
+				return nil, err
+			}
+		}
+		amounts, err = requestSpeciatedAmounts(st, req.Speciate, req.Emission, req.Amount, req.AmountUnits, req.Pollutants, req.Begin, req.End)
+		if err != nil {
+			return nil, err
+		}
+	}
 	c.cloudSetupOnce.Do(func() {
 		err = c.cloudSetup()
 	})
@@ -44,35 +88,33 @@ func (c *CityAQ) GriddedConcentrations(ctx context.Context, req *rpc.GriddedConc
 	}
 	c.setupCache()
 
-	job := &concentrationJob{
-		c:              c,
-		CityName:       req.CityName,
-		SourceType:     req.SourceType,
-		SimulationType: req.SimulationType,
+	job, err := c.newConcentrationJob(req.CityName, req.SourceType, req.SimulationType, points, surrogate, blend, req.Resolution, req.AutoResolution, req.AreaFallback)
+	if err != nil {
+		return nil, err
 	}
 
-	inmapReq := c.cache.NewRequest(ctx, job)
-	var result inmapResult
-	if err := inmapReq.Result(&result); err != nil {
+	result, err := job.result(ctx)
+	if err != nil {
 		return nil, err
 	}
 
+	// The simulation was run with the reference amount of each
+	// pollutant, and each PM2.5 species only results from the emissions
+	// of a single pollutant, so scale the concentrations of each species
+	// to match the requested amounts and sum them.
 	o := &rpc.GriddedConcentrationsResponse{
-		Polygons: polygonsToRPC(result.Grid),
+		Polygons:       polygonsToRPC(result.Grid),
+		Concentrations: make([]float64, len(result.Grid)),
 	}
-	switch req.Emission {
-	case rpc.Emission_PM2_5:
-		o.Concentrations = result.PrimaryPM25
-	case rpc.Emission_NH3:
-		o.Concentrations = result.PNH4
-	case rpc.Emission_NOx:
-		o.Concentrations = result.PNO3
-	case rpc.Emission_SOx:
-		o.Concentrations = result.PSO4
-	case rpc.Emission_VOC:
-		o.Concentrations = result.SOA
-	default:
-		return nil, fmt.Errorf("cityaq: invalid emission type %s", req.Emission)
+	for _, a := range amounts {
+		conc, err := result.concentrations(a.pollutant)
+		if err != nil {
+			return nil, err
+		}
+		scale := a.scale()
+		for i, v := range conc {
+			o.Concentrations[i] += v * scale
+		}
 	}
 	return o, nil
 }
@@ -80,7 +122,18 @@ func (c *CityAQ) GriddedConcentrations(ctx context.Context, req *rpc.GriddedConc
 // GriddedPopulation returns population counts on the same grid as
 // the gridded concentrations.
 func (c *CityAQ) GriddedPopulation(ctx context.Context, req *rpc.GriddedPopulationRequest) (*rpc.GriddedPopulationResponse, error) {
-	var err error
+	points, err := requestPointSources(req.PointSources)
+	if err != nil {
+		return nil, err
+	}
+	surrogate, err := requestCustomSurrogate(req.CustomSurrogate)
+	if err != nil {
+		return nil, err
+	}
+	blend, err := requestSourceTypeBlend(req.SourceTypeMix)
+	if err != nil {
+		return nil, err
+	}
 	c.cloudSetupOnce.Do(func() {
 		err = c.cloudSetup()
 	})
@@ -89,16 +142,13 @@ func (c *CityAQ) GriddedPopulation(ctx context.Context, req *rpc.GriddedPopulati
 	}
 	c.setupCache()
 
-	job := &concentrationJob{
-		c:              c,
-		CityName:       req.CityName,
-		SourceType:     req.SourceType,
-		SimulationType: req.SimulationType,
+	job, err := c.newConcentrationJob(req.CityName, req.SourceType, req.SimulationType, points, surrogate, blend, req.Resolution, req.AutoResolution, false)
+	if err != nil {
+		return nil, err
 	}
 
-	inmapReq := c.cache.NewRequest(ctx, job)
-	var result inmapResult
-	if err := inmapReq.Result(&result); err != nil {
+	result, err := job.result(ctx)
+	if err != nil {
 		return nil, err
 	}
 
@@ -178,24 +228,251 @@ func (c *CityAQ) cloudSetup() error {
 
 type concentrationJob struct {
 	c              *CityAQ
-	CityName       string
+	CityID         string
 	SourceType     string
 	SimulationType cityaqrpc.SimulationType
+
+	// cityName is the default name of the city, which was
+	// used in cache keys before cities had IDs.
+	cityName string
+
+	// cityVersion identifies the version of the city boundary,
+	// so that results are recomputed when the boundary changes.
+	cityVersion string
+
+	// pointSources are user-specified point sources to simulate
+	// instead of SourceType emissions, and pointSourcesID
+	// identifies them.
+	pointSources   []pointSource
+	pointSourcesID string
+
+	// surrogate, if not nil, is a custom surrogate that is used
+	// to allocate the emissions instead of the SourceType surrogate.
+	surrogate *customSurrogate
+
+	// blend, if not nil, is a mix of source types whose surrogates are
+	// used to allocate the emissions instead of the SourceType surrogate.
+	blend *sourceTypeBlend
+
+	// grid identifies the projection, resolution, and grid region of
+	// the emissions grid if they differ from the defaults, and resolution is the
+	// requested grid resolution, or zero for the source type default.
+	grid       string
+	resolution float64
+
+	// areaFallback specifies that emissions whose surrogates are
+	// zero throughout the allocation domain are allocated in
+	// proportion to area rather than causing an error.
+	areaFallback bool
+
+	// sectorChanges, if not empty, specifies a CityTotal simulation
+	// of the emissions inventory sectors in it, with the emissions of
+	// each sector within the city changed by the given percentage,
+	// rather than of SourceType alone. scenarioID identifies it.
+	sectorChanges []sectorChange
+	scenarioID    string
 }
 
+// newConcentrationJob creates a new concentration job for the given
+// city, which can be specified by either ID or name, and makes sure
+// that the job's cache key does not collide with the key of a
+// different job. If points is not empty, the job simulates
+// the given point sources rather than sourceType, and if surrogate or
+// blend is not nil, the job allocates emissions using it rather than
+// the surrogate of sourceType. resolution and autoResolution set the
+// resolution of the emissions grid as in gridSourceType, and areaFallback
+// is as in GriddedEmissionsRequest; they are ignored unless the job
+// allocates emissions in a CityMarginal simulation.
+func (c *CityAQ) newConcentrationJob(city, sourceType string, simulationType cityaqrpc.SimulationType, points []pointSource, surrogate *customSurrogate, blend *sourceTypeBlend, resolution float64, autoResolution, areaFallback bool) (*concentrationJob, error) {
+	job := &concentrationJob{
+		c:              c,
+		SourceType:     sourceType,
+		SimulationType: simulationType,
+	}
+	if len(points) > 0 {
+		if simulationType != cityaqrpc.SimulationType_CityMarginal {
+			return nil, fmt.Errorf("cityaq: point sources can only be used in %s simulations, not %s", cityaqrpc.SimulationType_CityMarginal, simulationType)
+		}
+		job.SourceType = ""
+		job.pointSources = points
+		job.pointSourcesID = pointSourcesID(points)
+	} else if surrogate != nil || blend != nil {
+		if simulationType != cityaqrpc.SimulationType_CityMarginal {
+			return nil, fmt.Errorf("cityaq: custom surrogates and source type mixes can only be used in %s simulations, not %s", cityaqrpc.SimulationType_CityMarginal, simulationType)
+		}
+		st, err := c.requestSourceType(sourceType, surrogate, blend)
+		if err != nil {
+			return nil, err
+		}
+		job.SourceType = st.Name
+		job.surrogate = surrogate
+		job.blend = blend
+	}
+	if simulationType != cityaqrpc.SimulationType_Total {
+		_, f, err := c.catalog().lookup(city)
+		if err != nil {
+			return nil, err
+		}
+		job.CityID = f.id
+		job.cityName = f.name
+		job.cityVersion = f.version
+	}
+	if simulationType == cityaqrpc.SimulationType_CityMarginal && len(points) == 0 {
+		if err := job.setGrid(resolution, autoResolution); err != nil {
+			return nil, err
+		}
+		job.areaFallback = areaFallback
+	}
+	if err := c.checkCacheKey(job.Key(), job.description()); err != nil {
+		return nil, err
+	}
+	return job, nil
+}
+
+// setGrid sets the projection and resolution of the emissions grid of
+// the receiver, so that the emissions passed to InMAP are allocated
+// to the same grid as the gridded emissions and maps.
+func (j *concentrationJob) setGrid(resolution float64, autoResolution bool) error {
+	st, err := j.c.requestSourceType(j.SourceType, j.surrogate, j.blend)
+	if err != nil {
+		return err
+	}
+	gst, err := j.c.gridSourceType(j.CityID, st, resolution, autoResolution)
+	if err != nil {
+		return err
+	}
+	projection, err := j.c.gridProjection()
+	if err != nil {
+		return err
+	}
+	j.grid = gridSuffix(projection, gst)
+	region, err := j.c.egugridID(j.CityID, gst)
+	if err != nil {
+		return err
+	}
+	if region != "" {
+		if j.grid != "" {
+			j.grid += "_"
+		}
+		j.grid += region
+	}
+	if resolution > 0 || autoResolution {
+		// Store the resolution that was chosen rather than whether it
+		// was automatic, in case the automatic resolution changes.
+		j.resolution = gst.Resolution
+		if projection != lonLatProjection {
+			j.resolution = gst.CellSize
+		}
+	}
+	return nil
+}
+
+// maxKeyLength is the maximum length of a cache key. Keys are
+// also used as Kubernetes job names, which are limited to 63 characters.
+const maxKeyLength = 63
+
 var alphanum *regexp.Regexp
 
 func init() {
 	alphanum = regexp.MustCompile("[^a-z0-9]+")
 }
 
+// cacheKey joins the given parts into a key that only contains lower-case
+// letters, numbers, and hyphens. If that changes any of the parts, or if
+// the key would be too long, a hash of the parts is appended to the key,
+// shortening it if necessary, so that different parts can't produce the
+// same key.
+func cacheKey(parts ...string) string {
+	clean := make([]string, len(parts))
+	changed := false
+	for i, p := range parts {
+		clean[i] = strings.Trim(alphanum.ReplaceAllString(strings.ToLower(p), "-"), "-")
+		changed = changed || clean[i] != p
+	}
+	k := strings.Join(clean, "-")
+	if !changed && len(k) <= maxKeyLength {
+		return k
+	}
+	h := sha256.Sum256([]byte(strings.Join(parts, "\x00")))
+	hash := hex.EncodeToString(h[:4])
+	if len(k) > maxKeyLength-len(hash)-1 {
+		k = k[:maxKeyLength-len(hash)-1]
+	}
+	return strings.TrimRight(k, "-") + "-" + hash
+}
+
+// checkCacheKey returns an error if key has already been used
+// in this process for a request with a different description, which
+// would cause the two requests to share cached results. Results cached
+// by other processes are checked by concentrationJob.result.
+func (c *CityAQ) checkCacheKey(key, description string) error {
+	c.cacheKeysMu.Lock()
+	defer c.cacheKeysMu.Unlock()
+	if c.cacheKeys == nil {
+		c.cacheKeys = make(map[string]string)
+	}
+	if other, ok := c.cacheKeys[key]; ok && other != description {
+		return fmt.Errorf("cityaq: cache key %s is used by both %s and %s", key, other, description)
+	}
+	c.cacheKeys[key] = description
+	return nil
+}
+
 func (j *concentrationJob) Key() string {
+	switch j.SimulationType {
+	case cityaqrpc.SimulationType_CityMarginal:
+		if j.pointSourcesID != "" {
+			return cacheKey("concentration", "points", j.CityID, j.cityVersion, j.pointSourcesID)
+		}
+		parts := []string{"concentration", j.CityID, j.cityVersion, j.SourceType}
+		if j.grid != "" {
+			parts = append(parts, j.grid)
+		}
+		if j.areaFallback {
+			parts = append(parts, "areafallback")
+		}
+		return cacheKey(parts...)
+	case cityaqrpc.SimulationType_CityTotal:
+		if j.scenarioID != "" {
+			return cacheKey("concentration", "scenario", j.CityID, j.cityVersion, j.scenarioID)
+		}
+		return cacheKey("concentration", strings.ToLower(j.SimulationType.String()), j.CityID, j.cityVersion, j.SourceType)
+	case cityaqrpc.SimulationType_Total:
+		return cacheKey("concentration", strings.ToLower(j.SimulationType.String()), j.SourceType)
+	default:
+		return "invalidsimulationtype"
+	}
+}
+
+// description returns a description of the job that
+// includes all of the information that distinguishes it
+// from other jobs.
+func (j *concentrationJob) description() string {
+	if j.pointSourcesID != "" {
+		return fmt.Sprintf("%s simulation of city %q version %s with point sources %s", j.SimulationType, j.CityID, j.cityVersion, j.pointSourcesID)
+	}
+	if j.scenarioID != "" {
+		return fmt.Sprintf("%s simulation of city %q version %s with sector changes %v", j.SimulationType, j.CityID, j.cityVersion, j.sectorChanges)
+	}
+	d := fmt.Sprintf("%s simulation of city %q version %s with source type %q", j.SimulationType, j.CityID, j.cityVersion, j.SourceType)
+	if j.grid != "" {
+		d += " on grid " + j.grid
+	}
+	if j.areaFallback {
+		d += " with area fallback"
+	}
+	return d
+}
+
+// legacyKey returns the key that was used for the receiver
+// before cities had IDs, when keys were derived from city names.
+func (j *concentrationJob) legacyKey() string {
 	var k string
 	switch j.SimulationType {
 	case cityaqrpc.SimulationType_CityMarginal:
-		k = fmt.Sprintf("concentration_%s_%s", j.CityName, j.SourceType)
+		k = fmt.Sprintf("concentration_%s_%s", j.cityName, j.SourceType)
 	case cityaqrpc.SimulationType_CityTotal:
-		k = fmt.Sprintf("concentration_%s_%s_%s", j.SimulationType.String(), j.CityName, j.SourceType)
+		k = fmt.Sprintf("concentration_%s_%s_%s", j.SimulationType.String(), j.cityName, j.SourceType)
 	case cityaqrpc.SimulationType_Total:
 		k = fmt.Sprintf("concentration_%s_%s", j.SimulationType.String(), j.SourceType)
 	default:
@@ -211,7 +488,75 @@ func (j *concentrationJob) Key() string {
 	return k
 }
 
+// legacyKeyUnique returns whether the legacy key of the receiver
+// is different from the legacy keys of the same job for all other cities.
+// Legacy keys that are shared by more than one city cannot be migrated.
+func (j *concentrationJob) legacyKeyUnique() bool {
+	if j.SimulationType == cityaqrpc.SimulationType_Total {
+		return true
+	}
+	key := j.legacyKey()
+	for _, city := range j.c.catalog().cities() {
+		if city.id == j.CityID {
+			continue
+		}
+		other := *j
+		other.cityName = city.name
+		if other.legacyKey() == key {
+			return false
+		}
+	}
+	return true
+}
+
+// legacyResult retrieves a result that was cached under a legacy key.
+// It is never computed if it is not already in the cache.
+type legacyResult struct {
+	key string
+}
+
+func (l *legacyResult) Key() string { return l.key }
+
+func (l *legacyResult) Run(ctx context.Context, result requestcache.Result) error {
+	return fmt.Errorf("cityaq: no cached result for legacy key %s", l.key)
+}
+
+// result returns the result of the receiver, running the job
+// if the result is not already cached. It returns an error if
+// the cached result was computed by a different job whose
+// key is the same.
+func (j *concentrationJob) result(ctx context.Context) (*inmapResult, error) {
+	var r inmapResult
+	if err := j.c.cache.NewRequest(ctx, j).Result(&r); err != nil {
+		return nil, err
+	}
+	if err := j.checkResult(&r); err != nil {
+		return nil, err
+	}
+	return &r, nil
+}
+
+// checkResult returns an error if r was computed by a job
+// other than the receiver. Results that were cached before
+// descriptions were stored with them are assumed to be valid.
+func (j *concentrationJob) checkResult(r *inmapResult) error {
+	if r.Description != "" && r.Description != j.description() {
+		return fmt.Errorf("cityaq: cache key %s is used by both %s and %s", j.Key(), r.Description, j.description())
+	}
+	return nil
+}
+
 func (j *concentrationJob) Run(ctx context.Context, result requestcache.Result) error {
+	// Migrate results that were cached before cities had IDs
+	// rather than rerunning the simulation.
+	// Point source simulations were not possible before then.
+	if legacy := j.legacyKey(); legacy != j.Key() && j.pointSourcesID == "" && j.surrogate == nil && j.blend == nil && j.grid == "" && j.scenarioID == "" && j.legacyKeyUnique() {
+		if err := j.c.cache.NewRequest(ctx, &legacyResult{key: legacy}).Result(result); err == nil {
+			result.(*inmapResult).Description = j.description()
+			return nil
+		}
+	}
+
 	ctx = context.WithValue(ctx, "user", "cityaq_user")
 
 	var cfg *inmaputil.Cfg
@@ -283,6 +628,7 @@ func (j *concentrationJob) Run(ctx context.Context, result requestcache.Result)
 	if err := inmapOutputToResult(output, result); err != nil {
 		return err
 	}
+	result.(*inmapResult).Description = j.description()
 	if _, err := j.c.inmapClient.Delete(ctx, &cloudrpc.JobName{
 		Version: inmap.Version,
 		Name:    j.Key(),
@@ -295,7 +641,13 @@ func (j *concentrationJob) Run(ctx context.Context, result requestcache.Result)
 
 // cityTotalConfig configures InMAP to run a simulation with marginal emissions in a single city.
 func (j *concentrationJob) cityMarginalConfig(ctx context.Context) (*inmaputil.Cfg, error) {
-	shpFile, err := j.emisToShp(ctx, rpc.SimulationType_CityMarginal)
+	var shpFile string
+	var err error
+	if len(j.pointSources) > 0 {
+		shpFile, err = j.pointSourcesToShp()
+	} else {
+		shpFile, err = j.emisToShp(ctx, rpc.SimulationType_CityMarginal)
+	}
 	if err != nil {
 		return nil, err
 	}
@@ -334,9 +686,17 @@ func (j *concentrationJob) cityTotalConfig(ctx context.Context) (*inmaputil.Cfg,
 	//	return nil, err
 	//}
 	j.setSectorEmis(cfg)
+	if j.hasSectorChanges() {
+		// Add the changes in emissions to the inventory emissions.
+		shpFile, err := j.sectorChangesToShp(ctx)
+		if err != nil {
+			return nil, err
+		}
+		cfg.Set("EmissionsShapefiles", []string{shpFile})
+	}
 
 	// Set emission mask for city.
-	g, err := j.c.geojsonGeometry(j.CityName)
+	g, err := j.c.cityGeometry(j.CityID)
 	if err != nil {
 		return nil, err
 	}
@@ -374,50 +734,107 @@ func (j *concentrationJob) totalConfig(ctx context.Context) (*inmaputil.Cfg, err
 }
 
 func (j *concentrationJob) cityDomain(ctx context.Context, cfg *inmaputil.Cfg) error {
-	cityGeom, err := j.c.CityGeometry(ctx, &rpc.CityGeometryRequest{CityName: j.CityName})
+	d, err := j.c.cityMarginalDomain(j.CityID, cfg)
 	if err != nil {
 		return err
 	}
-	center := rpcToGeom(cityGeom.Polygons[0]).Centroid()
+	cfg.Set("VarGrid.VariableGridXo", d.xo)
+	cfg.Set("VarGrid.VariableGridYo", d.yo)
+	cfg.Set("VarGrid.Xnests", intSliceToArg(d.xNests))
+	cfg.Set("VarGrid.Ynests", intSliceToArg(d.yNests))
+	return nil
+}
 
+// inmapDomain is the outer grid of an InMAP simulation.
+type inmapDomain struct {
+	xo, yo, dx, dy float64
+	xNests, yNests []int
+}
+
+// bounds returns the extent of the domain.
+func (d *inmapDomain) bounds() *geom.Bounds {
+	return &geom.Bounds{
+		Min: geom.Point{X: d.xo, Y: d.yo},
+		Max: geom.Point{X: d.xo + d.dx*float64(d.xNests[0]), Y: d.yo + d.dy*float64(d.yNests[0])},
+	}
+}
+
+// cityMarginalDomain returns the domain of CityMarginal simulations of
+// the given city, which is the domain in cfg moved so that the city is
+// in its center, while still overlapping the underlying CTM grid.
+func (c *CityAQ) cityMarginalDomain(city string, cfg *inmaputil.Cfg) (*inmapDomain, error) {
+	cityGeom, err := c.cityGeometry(city)
+	if err != nil {
+		return nil, err
+	}
+	// Use the centroid of all parts of the city,
+	// weighted by their areas.
+	center := cityGeom.Centroid()
+
+	d := new(inmapDomain)
+	d.xNests, err = toIntSliceE(cfg.Get("VarGrid.Xnests"))
+	if err != nil {
+		return nil, fmt.Errorf("VarGrid.Xnests: %v", err)
+	}
+	d.yNests, err = toIntSliceE(cfg.Get("VarGrid.Ynests"))
+	if err != nil {
+		return nil, fmt.Errorf("VarGrid.Ynests: %v", err)
+	}
+	d.dx = cfg.GetFloat64("VarGrid.VariableGridDx")
+	d.dy = cfg.GetFloat64("VarGrid.VariableGridDy")
+	nx := d.xNests[0]
+	ny := d.yNests[0]
 	// Set lower-left corner of grid so that the
-	// city is in its center, while still overlapping
-	// the underlying CTM grid.
-	xNests, err := toIntSliceE(cfg.Get("VarGrid.Xnests"))
-	if err != nil {
-		return fmt.Errorf("VarGrid.Xnests: %v", err)
-	}
-	yNests, err := toIntSliceE(cfg.Get("VarGrid.Ynests"))
-	if err != nil {
-		return fmt.Errorf("VarGrid.Ynests: %v", err)
-	}
-	xo := cfg.GetFloat64("VarGrid.VariableGridXo")
-	yo := cfg.GetFloat64("VarGrid.VariableGridYo")
-	nx := xNests[0]
-	ny := yNests[0]
-	dx := cfg.GetFloat64("VarGrid.VariableGridDx")
-	dy := cfg.GetFloat64("VarGrid.VariableGridDy")
-	xo = math.Max(xo, roundUnit(center.X-float64(nx)*dx/2, dx))
-	yo = math.Max(yo, roundUnit(center.Y-float64(ny)*dy/2, dy))
-	cfg.Set("VarGrid.VariableGridXo", xo)
-	cfg.Set("VarGrid.VariableGridYo", yo)
-	if xo+dx*float64(nx) > 178 {
-		nx = int((178 - xo) / dx)
-	}
-	if yo+dy*float64(ny) > 89.5 {
-		ny = int((89.5 - yo) / dy)
-	}
-	xNests[0] = nx
-	yNests[0] = ny
-	cfg.Set("VarGrid.Xnests", intSliceToArg(xNests))
-	cfg.Set("VarGrid.Ynests", intSliceToArg(yNests))
+	// city is in its center.
+	d.xo = math.Max(cfg.GetFloat64("VarGrid.VariableGridXo"), roundUnit(center.X-float64(nx)*d.dx/2, d.dx))
+	d.yo = math.Max(cfg.GetFloat64("VarGrid.VariableGridYo"), roundUnit(center.Y-float64(ny)*d.dy/2, d.dy))
+	if d.xo+d.dx*float64(nx) > 178 {
+		nx = int((178 - d.xo) / d.dx)
+	}
+	if d.yo+d.dy*float64(ny) > 89.5 {
+		ny = int((89.5 - d.yo) / d.dy)
+	}
+	d.xNests[0] = nx
+	d.yNests[0] = ny
+	return d, nil
+}
+
+// checkPointSourceDomain returns an error if any of the given point
+// sources are outside of the domain of CityMarginal simulations of
+// the given city, where their emissions would not be simulated.
+func (c *CityAQ) checkPointSourceDomain(city string, points []pointSource) error {
+	cfg := inmaputil.InitializeConfig()
+	cfg.SetConfigFile(c.InMAPCityMarginalConfigFile)
+	if err := cfg.ReadInConfig(); err != nil {
+		return fmt.Errorf("cityaq: problem reading InMAP configuration file: %v", err)
+	}
+	d, err := c.cityMarginalDomain(city, cfg)
+	if err != nil {
+		return err
+	}
+	b := d.bounds()
+	for i, p := range points {
+		if p.Point.X < b.Min.X || p.Point.X > b.Max.X || p.Point.Y < b.Min.Y || p.Point.Y > b.Max.Y {
+			return fmt.Errorf("cityaq: point source %d at (%g, %g) is outside of the air quality modeling domain of %s, which extends from (%g, %g) to (%g, %g)",
+				i, p.Point.X, p.Point.Y, city, b.Min.X, b.Min.Y, b.Max.X, b.Max.Y)
+		}
+	}
 	return nil
 }
 
+// setSectorEmis removes the emissions inventory sectors that are
+// not part of the receiver's simulation from the configuration.
 func (j *concentrationJob) setSectorEmis(cfg *inmaputil.Cfg) {
+	keep := map[string]bool{j.SourceType: true}
+	if len(j.sectorChanges) > 0 {
+		keep = make(map[string]bool)
+		for _, sc := range j.sectorChanges {
+			keep[sc.sector] = true
+		}
+	}
 	emis := cfg.GetStringMapStringSlice("aep.InventoryConfig.COARDSFiles")
 	for sector := range emis {
-		if sector != j.SourceType {
+		if !keep[sector] {
 			delete(emis, sector)
 		}
 	}
@@ -472,69 +889,128 @@ func roundUnit(x, unit float64) float64 {
 }
 
 // emisToShp calculates the emissions associated with this job and
-// saves them to a temporary shapefile.
+// saves them to a temporary shapefile. Each pollutant is emitted in the
+// reference amount; because each PM2.5 species only results from the
+// emissions of a single pollutant, the concentrations of each species are
+// scaled afterwards to the requested or speciated amount of its precursor.
 func (j *concentrationJob) emisToShp(ctx context.Context, simulationType rpc.SimulationType) (string, error) {
 	eReq := &rpc.GriddedEmissionsRequest{
-		CityName:       j.CityName,
+		CityName:       j.CityID,
 		SourceType:     j.SourceType,
-		Emission:       rpc.Emission_PM2_5,
 		SimulationType: simulationType,
+		Resolution:     j.resolution,
+		AreaFallback:   j.areaFallback,
+		Pollutants: []*rpc.PollutantAmount{
+			{Emission: rpc.Emission_PM2_5},
+			{Emission: rpc.Emission_VOC},
+			{Emission: rpc.Emission_NH3},
+			{Emission: rpc.Emission_NOx},
+			{Emission: rpc.Emission_SOx},
+		},
+	}
+	if j.surrogate != nil {
+		eReq.CustomSurrogate = j.surrogate.req
+	}
+	if j.blend != nil {
+		eReq.SourceTypeMix = j.blend.req
 	}
 	emis, err := j.c.GriddedEmissions(ctx, eReq)
 	if err != nil {
 		return "", err
 	}
+	st, err := j.c.requestSourceType(j.SourceType, j.surrogate, j.blend)
+	if err != nil {
+		return "", err
+	}
+	return writeEmisShp(emisRecords(emis, st, 1))
+}
 
+// emisRecord is a grid cell of emissions in the format that
+// InMAP reads from emissions shapefiles.
+type emisRecord struct {
+	geom.Polygon
+	PM2_5, VOC, NH3, NOx, SOx    float64
+	Height, Diam, Temp, Velocity float64
+}
+
+// emisRecords converts the given gridded emissions, multiplied by
+// scale, to emissions shapefile records with the stack parameters
+// of st.
+func emisRecords(emis *rpc.GriddedEmissionsResponse, st *sourceType, scale float64) []*emisRecord {
+	pol := make(map[rpc.Emission][]float64)
+	for _, pe := range emis.PollutantEmissions {
+		pol[pe.Emission] = pe.Emissions
+	}
+	value := func(e rpc.Emission, i int) float64 {
+		if v := pol[e]; v != nil {
+			return v[i] * scale
+		}
+		return 0
+	}
+	o := make([]*emisRecord, len(emis.Polygons))
+	for i, p := range emis.Polygons {
+		er := &emisRecord{
+			Polygon: rpcToGeom(p),
+			PM2_5:   value(rpc.Emission_PM2_5, i),
+			VOC:     value(rpc.Emission_VOC, i),
+			NH3:     value(rpc.Emission_NH3, i),
+			NOx:     value(rpc.Emission_NOx, i),
+			SOx:     value(rpc.Emission_SOx, i),
+		}
+		if st.StackHeight > 0 {
+			er.Height = st.StackHeight
+			er.Diam = st.StackDiameter
+			er.Temp = st.StackTemperature
+			er.Velocity = st.StackVelocity
+		}
+		o[i] = er
+	}
+	return o
+}
+
+// writeEmisShp saves the given emissions records to a temporary shapefile.
+func writeEmisShp(records []*emisRecord) (string, error) {
 	dir, err := ioutil.TempDir("", "cityaq_emissions")
 	if err != nil {
 		return "", err
 	}
 	file := filepath.Join(dir, "emissions.shp")
-	type emisRecord struct {
-		geom.Polygon
-		PM2_5, VOC, NH3, NOx, SOx    float64
-		Height, Diam, Temp, Velocity float64
-	}
 	e, err := shp.NewEncoder(file, emisRecord{})
 	if err != nil {
 		return "", err
 	}
-	for i, p := range emis.Polygons {
-		v := emis.Emissions[i]
-		er := &emisRecord{
-			Polygon: rpcToGeom(p),
-			PM2_5:   v,
-			VOC:     v,
-			NH3:     v,
-			NOx:     v,
-			SOx:     v,
-		}
-		if egugridEmissions(j.SourceType) {
-			// Average EGU stack parameters from 2014 NEI
-			// as processed by Tessum et al 2019 PNAS.
-			er.Height = 63.5
-			er.Diam = 4.1
-			er.Temp = 519.2
-			er.Velocity = 24.7
-		}
-		err := e.Encode(er)
-		if err != nil {
+	for _, er := range records {
+		if err := e.Encode(er); err != nil {
 			return "", err
 		}
 	}
 	e.Close()
-	prjFile, err := os.Create(filepath.Join(dir, "emissions.prj"))
-	if err != nil {
+	if err := writeLonLatPRJ(file); err != nil {
 		return "", err
 	}
-	defer prjFile.Close()
+	return file, nil
+}
+
+// writeLonLatPRJ writes a projection file specifying WGS84 longitude
+// and latitude coordinates for the given shapefile.
+func writeLonLatPRJ(shpFile string) error {
+	prjFile, err := os.Create(strings.TrimSuffix(shpFile, filepath.Ext(shpFile)) + ".prj")
+	if err != nil {
+		return err
+	}
 	if _, err := fmt.Fprint(prjFile, `GEOGCS["GCS_WGS_1984",DATUM["D_WGS_1984",SPHEROID["WGS_1984",6378137,298.257223563]],PRIMEM["Greenwich",0],UNIT["Degree",0.017453292519943295]]`); err != nil {
-		return "", err
+		prjFile.Close()
+		return err
 	}
-	return file, nil
+	return prjFile.Close()
 }
 
 type inmapResult struct {
+	// Description is the description of the job that
+	// computed the result, which is used to detect results
+	// cached by other processes under a colliding key.
+	Description string
+
 	Grid       []geom.Polygon
 	Population []float64
 	//MortalityRate []float64
@@ -545,9 +1021,40 @@ type inmapResult struct {
 	PSO4        []float64
 }
 
+// concentrations returns the concentrations of the PM2.5 species
+// that results from emissions of the given pollutant.
+func (r *inmapResult) concentrations(pollutant rpc.Emission) ([]float64, error) {
+	switch pollutant {
+	case rpc.Emission_PM2_5:
+		return r.PrimaryPM25, nil
+	case rpc.Emission_NH3:
+		return r.PNH4, nil
+	case rpc.Emission_NOx:
+		return r.PNO3, nil
+	case rpc.Emission_SOx:
+		return r.PSO4, nil
+	case rpc.Emission_VOC:
+		return r.SOA, nil
+	default:
+		return nil, fmt.Errorf("cityaq: invalid emission type %s", pollutant)
+	}
+}
+
+// totalPM25 returns the sum of the concentrations of all PM2.5 species.
+func (r *inmapResult) totalPM25() []float64 {
+	o := make([]float64, len(r.Grid))
+	for _, species := range [][]float64{r.PrimaryPM25, r.SOA, r.PNH4, r.PNO3, r.PSO4} {
+		for i, v := range species {
+			o[i] += v
+		}
+	}
+	return o
+}
+
 type wrapInmapResult struct {
-	Grid       []geom.Polygon
-	Population []float64
+	Description string
+	Grid        []geom.Polygon
+	Population  []float64
 	//MortalityRate []float64
 	PrimaryPM25 []float64
 	SOA         []float64
@@ -557,7 +1064,7 @@ type wrapInmapResult struct {
 }
 
 func (r *inmapResult) MarshalBinary() ([]byte, error) {
-	w := wrapInmapResult{Grid: r.Grid, Population: r.Population,
+	w := wrapInmapResult{Description: r.Description, Grid: r.Grid, Population: r.Population,
 		//MortalityRate: r.MortalityRate,
 		PrimaryPM25: r.PrimaryPM25,
 		SOA:         r.SOA, PNH4: r.PNH4, PNO3: r.PNO3, PSO4: r.PSO4}
@@ -575,6 +1082,7 @@ func (r *inmapResult) UnmarshalBinary(b []byte) error {
 	if err := dec.Decode(w); err != nil {
 		return err
 	}
+	r.Description = w.Description
 	r.Grid = w.Grid
 	r.Population = w.Population
 	//r.MortalityRate = w.MortalityRate
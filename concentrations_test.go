@@ -73,6 +73,18 @@ func TestCityAQ_GriddedConcentrations_cityTotal(t *testing.T) {
 		SimulationType: rpc.SimulationType_CityTotal,
 	}
 
+	// Inventory-driven results can't be scaled to a requested amount.
+	for _, bad := range []*rpc.GriddedConcentrationsRequest{
+		{CityName: r.CityName, Emission: r.Emission, SourceType: r.SourceType, SimulationType: r.SimulationType, Amount: 2, AmountUnits: rpc.EmissionUnits_Kilotonnes},
+		{CityName: r.CityName, Emission: r.Emission, SourceType: r.SourceType, SimulationType: r.SimulationType, Begin: 1, End: 2},
+		{CityName: r.CityName, SourceType: r.SourceType, SimulationType: r.SimulationType, Pollutants: []*rpc.PollutantAmount{{Emission: rpc.Emission_NOx}}},
+		{CityName: r.CityName, SourceType: r.SourceType, SimulationType: r.SimulationType, Speciate: true},
+	} {
+		if _, err := c.GriddedConcentrations(context.Background(), bad); err == nil {
+			t.Errorf("expected an error for %v", bad)
+		}
+	}
+
 	conc, err := c.GriddedConcentrations(context.Background(), r)
 	if err != nil {
 		t.Fatal(err)
@@ -120,3 +132,163 @@ func TestCityAQ_GriddedConcentrations_total(t *testing.T) {
 		t.Errorf("concentration sum: %g != %g", concSum, wantConcSum)
 	}
 }
+
+func TestConcentrationJob_Key(t *testing.T) {
+	c := &CityAQ{CityGeomDir: "testdata/cities"}
+	for _, test := range []struct {
+		simType        rpc.SimulationType
+		city           string
+		key, legacyKey string
+	}{
+		{
+			simType:   rpc.SimulationType_CityMarginal,
+			city:      "Accra Metropolitan",
+			key:       "concentration-accra-metropolitan-cb28dbd7-roadways",
+			legacyKey: "concentrationaccraroadways",
+		},
+		{
+			simType:   rpc.SimulationType_CityTotal,
+			city:      "karachi",
+			key:       "concentration-citytotal-karachi-b2ecbc79-roadways",
+			legacyKey: "concentrationcitytotalkarachiroadways",
+		},
+		{
+			simType:   rpc.SimulationType_Total,
+			key:       "concentration-total-roadways",
+			legacyKey: "concentrationtotalroadways",
+		},
+	} {
+		t.Run(test.simType.String(), func(t *testing.T) {
+			j, err := c.newConcentrationJob(test.city, "roadways", test.simType, nil, nil, nil, 0, false, false)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if k := j.Key(); k != test.key {
+				t.Errorf("key: %s != %s", k, test.key)
+			}
+			if k := j.legacyKey(); k != test.legacyKey {
+				t.Errorf("legacy key: %s != %s", k, test.legacyKey)
+			}
+			if !j.legacyKeyUnique() {
+				t.Error("legacy key should be unique")
+			}
+		})
+	}
+}
+
+func TestConcentrationJob_resolution(t *testing.T) {
+	c := &CityAQ{CityGeomDir: "testdata/cities"}
+	j, err := c.newConcentrationJob("Accra Metropolitan", "roadways", rpc.SimulationType_CityMarginal, nil, nil, nil, 0.01, false, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := "concentration-accra-metropolitan-cb28dbd7-roadways-lon-9eafdd52"; j.Key() != want {
+		t.Errorf("key: %s != %s", j.Key(), want)
+	}
+	if j.resolution != 0.01 {
+		t.Errorf("resolution: %g != 0.01", j.resolution)
+	}
+	// Automatic resolution is stored as the resolution that was chosen.
+	j, err = c.newConcentrationJob("Accra Metropolitan", "roadways", rpc.SimulationType_CityMarginal, nil, nil, nil, 0, true, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := "concentration-accra-metropolitan-cb28dbd7-roadways-lon-46a58f7e"; j.Key() != want {
+		t.Errorf("automatic key: %s != %s", j.Key(), want)
+	}
+	if j.resolution != 0.0025 {
+		t.Errorf("automatic resolution: %g != 0.0025", j.resolution)
+	}
+	if _, err := c.newConcentrationJob("Accra Metropolitan", "roadways", rpc.SimulationType_CityMarginal, nil, nil, nil, -1, false, false); err == nil {
+		t.Error("expected an error for a negative resolution")
+	}
+}
+
+func TestConcentrationJob_areaFallback(t *testing.T) {
+	c := &CityAQ{CityGeomDir: "testdata/cities"}
+	for _, test := range []struct {
+		simType rpc.SimulationType
+		differ  bool
+	}{
+		{simType: rpc.SimulationType_CityMarginal, differ: true},
+		// Area fallback only affects emissions allocated with surrogates.
+		{simType: rpc.SimulationType_CityTotal, differ: false},
+	} {
+		t.Run(test.simType.String(), func(t *testing.T) {
+			j1, err := c.newConcentrationJob("Accra Metropolitan", "roadways", test.simType, nil, nil, nil, 0, false, false)
+			if err != nil {
+				t.Fatal(err)
+			}
+			j2, err := c.newConcentrationJob("Accra Metropolitan", "roadways", test.simType, nil, nil, nil, 0, false, true)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if differ := j1.Key() != j2.Key(); differ != test.differ {
+				t.Errorf("keys %s and %s: differ = %v, want %v", j1.Key(), j2.Key(), differ, test.differ)
+			}
+			if j2.areaFallback != test.differ {
+				t.Errorf("areaFallback: %v != %v", j2.areaFallback, test.differ)
+			}
+		})
+	}
+}
+
+func TestCacheKey(t *testing.T) {
+	long := cacheKey("concentration", "citytotal", "city-of-johannesburg-metropolitan-municipality", "roadways_motorway")
+	if len(long) > maxKeyLength {
+		t.Errorf("key %s is too long", long)
+	}
+	long2 := cacheKey("concentration", "citytotal", "city-of-johannesburg-metropolitan-municipality", "roadways_primary")
+	if long == long2 {
+		t.Errorf("shortened keys should differ: %s", long)
+	}
+
+	c := new(CityAQ)
+	if err := c.checkCacheKey(cacheKey("a", "b_c"), "a b_c"); err != nil {
+		t.Fatal(err)
+	}
+	if err := c.checkCacheKey(cacheKey("a", "b_c"), "a b_c"); err != nil {
+		t.Errorf("repeated request should not collide: %v", err)
+	}
+	if err := c.checkCacheKey(cacheKey("a", "b-c"), "a b-c"); err != nil {
+		t.Errorf("sanitized keys should not collide: %v", err)
+	}
+	if k := cacheKey("a", "b-c"); k != "a-b-c" {
+		t.Errorf("keys that don't need to be sanitized should be unchanged: %s", k)
+	}
+	if err := c.checkCacheKey(cacheKey("a", "b-c"), "another b-c"); err == nil {
+		t.Error("colliding keys should cause an error")
+	}
+}
+
+func TestConcentrationJob_checkResult(t *testing.T) {
+	c := &CityAQ{CityGeomDir: "testdata/cities"}
+	j, err := c.newConcentrationJob("Accra Metropolitan", "roadways", rpc.SimulationType_CityMarginal, nil, nil, nil, 0, false, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := j.checkResult(&inmapResult{Description: j.description()}); err != nil {
+		t.Errorf("result of the same job: %v", err)
+	}
+	if err := j.checkResult(&inmapResult{}); err != nil {
+		t.Errorf("result without a description: %v", err)
+	}
+	// A result cached by another process for a different job.
+	other := &inmapResult{Description: "CityMarginal simulation of city \"other\" with source type \"roadways\""}
+	if err := j.checkResult(other); err == nil {
+		t.Error("result of a different job should cause an error")
+	}
+
+	// The description survives the round trip through the cache.
+	b, err := (&inmapResult{Description: j.description()}).MarshalBinary()
+	if err != nil {
+		t.Fatal(err)
+	}
+	var r inmapResult
+	if err := r.UnmarshalBinary(b); err != nil {
+		t.Fatal(err)
+	}
+	if r.Description != j.description() {
+		t.Errorf("description: %q != %q", r.Description, j.description())
+	}
+}
@@ -2,22 +2,39 @@ package cityaq
 
 import (
 	"fmt"
+	"os"
 	"path/filepath"
 	"strings"
 
 	"github.com/ctessum/geom"
 	"github.com/ctessum/geom/encoding/shp"
 	"github.com/ctessum/geom/index/rtree"
+	"github.com/ctessum/geom/proj"
 )
 
 // country returns the name and geometry of the country that the
 // given city is nearest to.
 func (c *CityAQ) country(cityName string) (*country, error) {
-	c.loadCountries()
-	cityGeom, err := c.geojsonGeometry(cityName)
+	cityGeom, err := c.cityGeometry(cityName)
 	if err != nil {
 		return nil, err
 	}
+	ctry, err := c.countryOf(cityGeom)
+	if err != nil {
+		return nil, err
+	}
+	if ctry == nil {
+		return nil, fmt.Errorf("couldn't match country to city %s", cityName)
+	}
+	return ctry, nil
+}
+
+// countryOf returns the country that overlaps the most with the
+// given city boundary, or nil if there is no overlapping country.
+func (c *CityAQ) countryOf(cityGeom geom.MultiPolygon) (*country, error) {
+	if err := c.loadCountries(); err != nil {
+		return nil, err
+	}
 	var ctry *country
 	var isect float64
 	for _, cI := range c.countries.SearchIntersect(cityGeom.Bounds()) {
@@ -31,7 +48,7 @@ func (c *CityAQ) country(cityName string) (*country, error) {
 		}
 	}
 	if ctry == nil || len(ctry.Polygon) == 0 {
-		return nil, fmt.Errorf("couldn't match country to city %s", cityName)
+		return nil, nil
 	}
 	return ctry, nil
 }
@@ -41,13 +58,17 @@ type country struct {
 	Name string `shp:"CNTRY_NAME"`
 }
 
-func (c *CityAQ) loadCountries() {
+// loadCountries loads the country boundaries from the
+// Countries_WGS84 shapefile in SrgShapefileDirectory.
+func (c *CityAQ) loadCountries() error {
 	c.loadCountriesOnce.Do(func() {
 		c.countries = rtree.NewTree(25, 50)
 		d, err := shp.NewDecoder(filepath.Join(c.SpatialConfig.SrgShapefileDirectory, "Countries_WGS84.shp"))
 		if err != nil {
-			panic(err)
+			c.countriesErr = fmt.Errorf("cityaq: loading countries: %v", err)
+			return
 		}
+		defer d.Close()
 		for {
 			var row country
 			if more := d.DecodeRow(&row); !more {
@@ -56,10 +77,133 @@ func (c *CityAQ) loadCountries() {
 			c.countries.Insert(&row)
 		}
 	})
+	return c.countriesErr
+}
+
+// gridRegion is an area that the emissions of source types in the
+// "egugrid" domain are allocated within.
+type gridRegion struct {
+	geom.MultiPolygon
+	Name string
+
+	// id identifies regions from GridRegionFile in grid names
+	// and cache keys. It is empty for the fallback regions.
+	id string
+}
+
+// loadGridRegions loads the electric grid regions from GridRegionFile.
+// Records with the same name are combined into a single region.
+func (c *CityAQ) loadGridRegions() error {
+	c.loadGridRegionsOnce.Do(func() {
+		c.gridRegions = rtree.NewTree(25, 50)
+		if c.GridRegionFile == "" {
+			return
+		}
+		regions, err := readGridRegions(c.GridRegionFile, c.GridRegionNameField)
+		if err != nil {
+			c.gridRegionsErr = fmt.Errorf("cityaq: loading grid regions: %v", err)
+			return
+		}
+		for _, r := range regions {
+			c.gridRegions.Insert(r)
+		}
+	})
+	return c.gridRegionsErr
 }
 
-// countryOrBuffer returns the smaller of the country that the city is located
-// in or a circular buffer with area equivalent to the average area among
+// readGridRegions reads the regions in the given shapefile, which are
+// named by the nameField attribute, or by record number if nameField is
+// empty. If there is a .prj file, the regions are converted to longitude
+// and latitude.
+func readGridRegions(path, nameField string) ([]*gridRegion, error) {
+	d, err := shp.NewDecoder(path)
+	if err != nil {
+		return nil, fmt.Errorf("file %s: %v", path, err)
+	}
+	defer d.Close()
+	var sr *proj.SR
+	prj := strings.TrimSuffix(path, filepath.Ext(path)) + ".prj"
+	if _, err := os.Stat(prj); err == nil {
+		if sr, err = readPRJ(prj); err != nil {
+			return nil, fmt.Errorf("file %s: %v", prj, err)
+		}
+	}
+	var fields []string
+	if nameField != "" {
+		fields = []string{nameField}
+	}
+	var regions []*gridRegion
+	byName := make(map[string]*gridRegion)
+	for i := 1; ; i++ {
+		g, attrs, more := d.DecodeRowFields(fields...)
+		if !more {
+			break
+		}
+		name := fmt.Sprintf("region %d", i)
+		if nameField != "" {
+			name = strings.TrimSpace(attrs[nameField])
+			if name == "" {
+				return nil, fmt.Errorf("file %s: record %d is missing the %s attribute", path, i, nameField)
+			}
+		}
+		// Shapefile polygon records don't distinguish between
+		// the parts of multipart polygons and holes.
+		if p, ok := g.(geom.Polygon); ok {
+			g = splitRings(p)
+		}
+		r, ok := byName[name]
+		if !ok {
+			r = &gridRegion{Name: name, id: cacheKey("region", name)}
+			byName[name] = r
+			regions = append(regions, r)
+		}
+		r.MultiPolygon = appendPolygons(r.MultiPolygon, g)
+	}
+	if err := d.Error(); err != nil {
+		return nil, fmt.Errorf("file %s: %v", path, err)
+	}
+	for _, r := range regions {
+		if len(r.MultiPolygon) == 0 {
+			return nil, fmt.Errorf("file %s: region %s doesn't have any polygons", path, r.Name)
+		}
+		if r.MultiPolygon, err = toLonLat(r.MultiPolygon, sr); err != nil {
+			return nil, fmt.Errorf("file %s: %v", path, err)
+		}
+	}
+	return regions, nil
+}
+
+// gridRegionOf returns the region from GridRegionFile that contains the
+// centroid of the given city boundary or, if none of them do, that
+// overlaps the most with the city. It returns nil if no region
+// overlaps the city.
+func (c *CityAQ) gridRegionOf(cityGeom geom.MultiPolygon) (*gridRegion, error) {
+	if err := c.loadGridRegions(); err != nil {
+		return nil, err
+	}
+	centroid := cityGeom.Centroid()
+	var region *gridRegion
+	var isect float64
+	for _, rI := range c.gridRegions.SearchIntersect(cityGeom.Bounds()) {
+		r := rI.(*gridRegion)
+		if centroid.Within(r.MultiPolygon) != geom.Outside {
+			return r, nil
+		}
+		if iSect := cityGeom.Intersection(r.MultiPolygon); iSect != nil {
+			if ia := iSect.Area(); ia > isect {
+				isect = ia
+				region = r
+			}
+		}
+	}
+	return region, nil
+}
+
+// egugridRegion returns the area that the emissions of source types in
+// the "egugrid" domain are allocated within for the given city: the
+// region in GridRegionFile that the city is in, if there is one,
+// otherwise the smaller of the country that the city is located in or a
+// circular buffer with area equivalent to the average area among
 // the US NERC regions, intersected with the country.
 // The buffer area is calculated from the shapefile downloaded from
 // https://www.eia.gov/maps/layer_info-m.php on 10/22/2019.
@@ -76,7 +220,7 @@ func (c *CityAQ) loadCountries() {
 // Number of unique values:9
 // Range:318.735
 // Median:54.09
-func (c *CityAQ) countryOrGridBuffer(cityName string) (*country, error) {
+func (c *CityAQ) egugridRegion(cityName string) (*gridRegion, error) {
 	const (
 		area      = 91.6756666667 // degrees^2
 		radius    = 5.40196918017 // sqrt(area/pi) [degrees]
@@ -87,26 +231,46 @@ func (c *CityAQ) countryOrGridBuffer(cityName string) (*country, error) {
 		Min: geom.Point{X: -178, Y: -88},
 		Max: geom.Point{X: 178, Y: 88},
 	}
-	ctry, err := c.country(cityName)
+	cityGeom, err := c.cityGeometry(cityName)
 	if err != nil {
 		return nil, err
 	}
-	if ctry.Area() <= area {
-		return ctry, nil
+	region, err := c.gridRegionOf(cityGeom)
+	if err != nil {
+		return nil, err
 	}
-	// Country is too big, use buffer.
-	cityGeom, err := c.geojsonGeometry(cityName)
+	if region != nil {
+		clipped := appendPolygons(nil, region.Intersection(gridBounds))
+		if len(clipped) == 0 {
+			return nil, fmt.Errorf("cityaq: grid region %s is outside of the simulation domain", region.Name)
+		}
+		return &gridRegion{MultiPolygon: clipped, Name: region.Name, id: region.id}, nil
+	}
+	ctry, err := c.country(cityName)
 	if err != nil {
 		return nil, err
 	}
-	return &country{
-		Polygon: cityGeom.Centroid().Buffer(radius, nSegments).Intersection(ctry).Intersection(gridBounds).(geom.Polygon),
-		Name:    "buffer",
+	if ctry.Area() <= area {
+		return &gridRegion{MultiPolygon: geom.MultiPolygon{ctry.Polygon}, Name: ctry.Name}, nil
+	}
+	// Country is too big, use buffer.
+	return &gridRegion{
+		MultiPolygon: geom.MultiPolygon{cityGeom.Centroid().Buffer(radius, nSegments).Intersection(ctry).Intersection(gridBounds).(geom.Polygon)},
+		Name:         "buffer",
 	}, nil
 }
 
-// egugridEmissions returns whether the given sourceType should
-// be allocated to the country or electric grid buffer rather than a city.
-func egugridEmissions(sourceType string) bool {
-	return strings.HasSuffix(sourceType, "_egugrid")
+// egugridID returns a string that identifies the grid region that the
+// emissions of st are allocated within for the given city, for use in
+// grid names and cache keys, or an empty string if st is not in the
+// "egugrid" domain or the region is not from GridRegionFile.
+func (c *CityAQ) egugridID(cityName string, st *sourceType) (string, error) {
+	if !st.egugrid() || c.GridRegionFile == "" {
+		return "", nil
+	}
+	region, err := c.egugridRegion(cityName)
+	if err != nil {
+		return "", err
+	}
+	return region.id, nil
 }
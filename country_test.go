@@ -26,7 +26,7 @@ func TestCityAQ_egugrid(t *testing.T) {
 		},
 	}
 	t.Run("cityCountry", func(t *testing.T) {
-		country, err := c.countryOrGridBuffer("Accra Metropolitan")
+		country, err := c.egugridRegion("Accra Metropolitan")
 		if err != nil {
 			t.Fatal(err)
 		}
@@ -43,6 +43,49 @@ func TestCityAQ_egugrid(t *testing.T) {
 		}
 	})
 
+	t.Run("gridRegion", func(t *testing.T) {
+		c := &CityAQ{
+			CityGeomDir:         c.CityGeomDir,
+			SpatialConfig:       c.SpatialConfig,
+			GridRegionFile:      "testdata/grid_regions.shp",
+			GridRegionNameField: "NAME",
+		}
+		region, err := c.egugridRegion("Accra Metropolitan")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if region.Name != "Volta" {
+			t.Errorf("name: %s != Volta", region.Name)
+		}
+		wantBounds := &geom.Bounds{
+			Min: geom.Point{X: -1.5, Y: 4.5},
+			Max: geom.Point{X: 1.5, Y: 7},
+		}
+		if !reflect.DeepEqual(region.Bounds(), wantBounds) {
+			t.Errorf("bounds: %+v != %+v", region.Bounds(), wantBounds)
+		}
+		st, err := c.sourceType("electric_gen_egugrid")
+		if err != nil {
+			t.Fatal(err)
+		}
+		name, err := c.gridName("accra-metropolitan", st)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if want := "accra-metropolitan_cb28dbd7_region-volta-467c3f1c"; name != want {
+			t.Errorf("grid name: %s != %s", name, want)
+		}
+
+		// There is no region in the file for Karachi.
+		region, err = c.egugridRegion("ڪراچي Karachi")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if region.Name == "Volta" || region.Name == "Northern" || region.id != "" {
+			t.Errorf("Karachi should use the fallback region, not %s", region.Name)
+		}
+	})
+
 	t.Run("electric_gen_egugrid", func(t *testing.T) {
 		req := &rpc.GriddedEmissionsRequest{
 			CityName:   "Accra Metropolitan",
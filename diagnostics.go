@@ -0,0 +1,83 @@
+package cityaq
+
+import (
+	"fmt"
+	"math"
+
+	rpc "github.com/ctessum/cityaq/cityaqrpc"
+	"github.com/ctessum/geom"
+	"gonum.org/v1/gonum/floats"
+)
+
+// areaFallback is the SurrogateFallback of emissions that were
+// allocated in proportion to area.
+const areaFallback = "area"
+
+// requestedMass returns the total mass of the given amounts, in kg.
+func requestedMass(amounts []pollutantAmount) float64 {
+	var o float64
+	for _, a := range amounts {
+		o += a.mass
+	}
+	return o
+}
+
+// emissionsDiagnostics returns diagnostics of the allocation of the
+// requested mass of emissions, in kg, to the grid of the given city.
+// emis are the gridded emissions of each pollutant, and fallbacks are
+// the source types whose emissions were allocated in proportion to area.
+func (c *CityAQ) emissionsDiagnostics(cityID string, grid []geom.Polygonal, requested float64, emis []*rpc.PollutantEmissions, fallbacks []string) (*rpc.EmissionsDiagnostics, error) {
+	cityGeom, err := c.cityGeometry(cityID)
+	if err != nil {
+		return nil, err
+	}
+	total := make([]float64, len(grid))
+	for _, pe := range emis {
+		if len(pe.Emissions) != len(grid) {
+			return nil, fmt.Errorf("cityaq: %d %s emissions values for %d grid cells", len(pe.Emissions), pe.Emission, len(grid))
+		}
+		floats.Add(total, pe.Emissions)
+	}
+	o := &rpc.EmissionsDiagnostics{
+		RequestedMass:       requested,
+		AllocatedMass:       floats.Sum(total),
+		FallbackSourceTypes: fallbacks,
+	}
+	if len(fallbacks) > 0 {
+		o.SurrogateFallback = areaFallback
+	}
+	if requested != 0 {
+		o.LostFraction = (requested - o.AllocatedMass) / requested
+	}
+	if o.AllocatedMass == 0 {
+		return o, nil
+	}
+	cityBounds := cityGeom.Bounds()
+	var inCity float64
+	for i, cell := range grid {
+		if total[i] == 0 || !boundsOverlap(cell.Bounds(), cityBounds) {
+			continue
+		}
+		isect := cell.Intersection(cityGeom)
+		if isect == nil {
+			continue
+		}
+		inCity += total[i] * isect.Area() / cell.Area()
+	}
+	o.CityFraction = inCity / o.AllocatedMass
+	return o, nil
+}
+
+// checkMassConservation returns an error if the fraction of the requested
+// mass of emissions that was lost or gained is greater than tolerance.
+// The mass is not checked if tolerance is zero.
+func checkMassConservation(d *rpc.EmissionsDiagnostics, tolerance float64) error {
+	if tolerance < 0 || invalidFloat(tolerance) {
+		return fmt.Errorf("cityaq: invalid mass tolerance %g", tolerance)
+	}
+	if tolerance == 0 || math.Abs(d.LostFraction) <= tolerance {
+		return nil
+	}
+	return fmt.Errorf("cityaq: %.3g%% of the requested %g kg of emissions was lost when allocating them to the grid, which is more than the tolerance of %.3g%%",
+		d.LostFraction*100, d.RequestedMass, tolerance*100)
+}
@@ -0,0 +1,148 @@
+package cityaq
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"reflect"
+	"testing"
+
+	rpc "github.com/ctessum/cityaq/cityaqrpc"
+	"github.com/ctessum/geom"
+	"github.com/spatialmodel/inmap/emissions/aep/aeputil"
+)
+
+func TestCityAQ_GriddedEmissions_diagnostics(t *testing.T) {
+	c := &CityAQ{
+		CityGeomDir: "testdata/cities",
+		SpatialConfig: aeputil.SpatialConfig{
+			SrgSpecOSM:    "testdata/srgspec_osm.json",
+			SCCExactMatch: true,
+			GridRef:       []string{"testdata/gridref.txt"},
+			OutputSR:      "+proj=longlat",
+			InputSR:       "+proj=longlat",
+		},
+	}
+	req := &rpc.GriddedEmissionsRequest{
+		CityName:      "Accra Metropolitan",
+		SourceType:    "roadways",
+		Emission:      rpc.Emission_PM2_5,
+		MassTolerance: 1.0e-6,
+	}
+	emis, err := c.GriddedEmissions(context.Background(), req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	d := emis.Diagnostics
+	if d == nil {
+		t.Fatal("missing diagnostics")
+	}
+	if d.RequestedMass != 1.0e6 {
+		t.Errorf("requested mass: %g != 1e6", d.RequestedMass)
+	}
+	if !similar(d.AllocatedMass, d.RequestedMass, 1.0e-6) {
+		t.Errorf("allocated mass: %g != %g", d.AllocatedMass, d.RequestedMass)
+	}
+	if d.CityFraction <= 0.5 || d.CityFraction > 1+1.0e-10 {
+		t.Errorf("most of the emissions should be in the city: %g", d.CityFraction)
+	}
+	if d.SurrogateFallback != "" || len(d.FallbackSourceTypes) != 0 {
+		t.Errorf("unexpected surrogate fallback %q %v", d.SurrogateFallback, d.FallbackSourceTypes)
+	}
+
+	req.MassTolerance = -1
+	if _, err := c.GriddedEmissions(context.Background(), req); err == nil {
+		t.Error("expected an error for a negative tolerance")
+	}
+}
+
+func TestCityAQ_GriddedEmissions_areaFallback(t *testing.T) {
+	dir, err := ioutil.TempDir("", "cityaq_fallback")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	c := &CityAQ{
+		CityGeomDir: "testdata/cities",
+		SpatialConfig: aeputil.SpatialConfig{
+			SrgSpecOSM:    "testdata/srgspec_osm.json",
+			SCCExactMatch: true,
+			GridRef:       []string{"testdata/gridref.txt"},
+			OutputSR:      "+proj=longlat",
+			InputSR:       "+proj=longlat",
+		},
+		CacheLoc: "file://" + dir,
+	}
+	ctx := context.Background()
+	// The study area is offshore, so there are no roads in it.
+	area, err := c.RegisterStudyArea(ctx, &rpc.RegisterStudyAreaRequest{
+		Name: "Gulf of Guinea",
+		WKT:  "POLYGON ((-0.3 5.2, -0.2 5.2, -0.2 5.3, -0.3 5.3, -0.3 5.2))",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	req := &rpc.GriddedEmissionsRequest{
+		CityName:   area.ID,
+		SourceType: "roadways",
+		Emission:   rpc.Emission_PM2_5,
+	}
+	if _, err := c.GriddedEmissions(ctx, req); err == nil {
+		t.Error("expected an error for a zero surrogate")
+	}
+
+	req.AreaFallback = true
+	emis, err := c.GriddedEmissions(ctx, req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	d := emis.Diagnostics
+	if d.SurrogateFallback != areaFallback || !reflect.DeepEqual(d.FallbackSourceTypes, []string{"roadways"}) {
+		t.Errorf("wrong surrogate fallback %q %v", d.SurrogateFallback, d.FallbackSourceTypes)
+	}
+	if !similar(d.AllocatedMass, d.RequestedMass, 1.0e-6) {
+		t.Errorf("allocated mass: %g != %g", d.AllocatedMass, d.RequestedMass)
+	}
+}
+
+func TestCheckMassConservation(t *testing.T) {
+	d := &rpc.EmissionsDiagnostics{RequestedMass: 100, AllocatedMass: 90, LostFraction: 0.1}
+	if err := checkMassConservation(d, 0); err != nil {
+		t.Errorf("a zero tolerance should disable the check: %v", err)
+	}
+	if err := checkMassConservation(d, 0.2); err != nil {
+		t.Error(err)
+	}
+	if err := checkMassConservation(d, 0.05); err == nil {
+		t.Error("expected an error for lost mass")
+	}
+	d.AllocatedMass, d.LostFraction = 110, -0.1
+	if err := checkMassConservation(d, 0.05); err == nil {
+		t.Error("expected an error for gained mass")
+	}
+}
+
+func TestAreaEmissions(t *testing.T) {
+	square := func(x0, y0 float64) geom.Polygonal {
+		return geom.Polygon{{{X: x0, Y: y0}, {X: x0 + 1, Y: y0}, {X: x0 + 1, Y: y0 + 1}, {X: x0, Y: y0 + 1}}}
+	}
+	grid := []geom.Polygonal{square(0, 0), square(1, 0), square(0, 1), square(1, 1)}
+	// The polygon covers all of the first cell and half of the second.
+	polygon := geom.Polygon{{{X: 0, Y: 0}, {X: 1.5, Y: 0}, {X: 1.5, Y: 1}, {X: 0, Y: 1}}}
+	amounts := []pollutantAmount{{pollutant: rpc.Emission_NOx, emissionsAmount: &emissionsAmount{mass: 3}}}
+	emis, err := areaEmissions(polygon, grid, amounts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []float64{2, 1, 0, 0}
+	for i, v := range emis[rpc.Emission_NOx] {
+		if !similar(v, want[i], 1.0e-10) {
+			t.Errorf("%v != %v", emis[rpc.Emission_NOx], want)
+			break
+		}
+	}
+	if len(emis) != 1 {
+		t.Errorf("wrong pollutants: %v", emis)
+	}
+}
@@ -3,6 +3,7 @@ package cityaq
 import (
 	"context"
 	"fmt"
+	"math"
 	"time"
 
 	rpc "github.com/ctessum/cityaq/cityaqrpc"
@@ -14,10 +15,11 @@ import (
 	"github.com/paulmach/orb/geojson"
 	"github.com/spatialmodel/inmap/emissions/aep"
 	"github.com/spatialmodel/inmap/emissions/aep/aeputil"
+	"gonum.org/v1/gonum/floats"
 )
 
 type emissions struct {
-	geom.Polygon
+	geom.Polygonal
 	SR *proj.SR
 	aep.SourceData
 	aep.Emissions
@@ -26,30 +28,156 @@ type emissions struct {
 
 // Location returns the polygon representing the location of emissions.
 func (e *emissions) Location() *aep.Location {
-	return &aep.Location{Geom: e.Polygon, SR: e.SR, Name: e.cityName}
+	return &aep.Location{Geom: e.Polygonal, SR: e.SR, Name: e.cityName}
 }
 
-func newEmissions(poly geom.Polygon, pollutant rpc.Emission, sourceType, cityName string) (*emissions, time.Time, time.Time, error) {
-	begin := time.Date(2016, time.January, 1, 0, 0, 0, 0, time.UTC)
-	end := time.Date(2017, time.January, 1, 0, 0, 0, 0, time.UTC)
+// emissionsAmount is an amount of emissions
+// emitted evenly over a period of time.
+type emissionsAmount struct {
+	mass       float64 // kg
+	begin, end time.Time
+}
+
+// referenceAmount is the amount of emissions that is used when none
+// is specified: 1 kilotonne emitted over calendar year 2016. The air
+// quality simulations are run with this amount.
+var referenceAmount = &emissionsAmount{
+	mass:  1.0e6,
+	begin: time.Date(2016, time.January, 1, 0, 0, 0, 0, time.UTC),
+	end:   time.Date(2017, time.January, 1, 0, 0, 0, 0, time.UTC),
+}
+
+// requestAmount returns the amount of emissions specified by the given
+// request fields. If amount is zero, 1 kilotonne is emitted, and if
+// begin and end are zero, the emissions are emitted over calendar year
+// 2016. begin and end are Unix times in seconds.
+func requestAmount(amount float64, units rpc.EmissionUnits, begin, end int64) (*emissionsAmount, error) {
+	a := &emissionsAmount{
+		mass:  referenceAmount.mass,
+		begin: referenceAmount.begin,
+		end:   referenceAmount.end,
+	}
+	if begin != 0 || end != 0 {
+		a.begin, a.end = time.Unix(begin, 0).UTC(), time.Unix(end, 0).UTC()
+		if !a.end.After(a.begin) {
+			return nil, fmt.Errorf("cityaq: emissions period end (%v) must be after beginning (%v)", a.end, a.begin)
+		}
+	}
+	if amount < 0 || math.IsNaN(amount) || math.IsInf(amount, 0) {
+		return nil, fmt.Errorf("cityaq: invalid emissions amount %g", amount)
+	}
+	if amount == 0 {
+		return a, nil
+	}
+	const year = 365 * 24 * time.Hour
+	switch units {
+	case rpc.EmissionUnits_Kilograms:
+		a.mass = amount
+	case rpc.EmissionUnits_Tonnes:
+		a.mass = amount * 1.0e3
+	case rpc.EmissionUnits_Kilotonnes:
+		a.mass = amount * 1.0e6
+	case rpc.EmissionUnits_MegagramsPerYear:
+		a.mass = amount * 1.0e3 * a.end.Sub(a.begin).Hours() / year.Hours()
+	default:
+		return nil, fmt.Errorf("cityaq: invalid emissions units %s", units)
+	}
+	return a, nil
+}
+
+// days returns the length of the emissions period in days. Like rate,
+// it uses the actual length of the period, so the default period,
+// calendar year 2016, is 366 days long. Annual emissions rates such as
+// MegagramsPerYear are converted to masses using the actual length of
+// the period as well, so they are consistent with it.
+func (a *emissionsAmount) days() float64 {
+	return a.end.Sub(a.begin).Hours() / 24
+}
 
-	const kt = 1.0e6 // kilograms
-	duration := end.Sub(begin).Seconds()
-	rate := unit.New(kt/duration, unit.Dimensions{
-		unit.MassDim: 1,
-		unit.TimeDim: -1,
-	}) // 1 kilotonne/year in kg/s
+// rate returns the emissions rate in kg/s.
+func (a *emissionsAmount) rate() float64 {
+	return a.mass / a.end.Sub(a.begin).Seconds()
+}
+
+// scale returns the ratio of the emissions rate of the receiver to
+// the rate of referenceAmount. Because the air quality simulations are
+// run with referenceAmount and their results are linear in
+// the emissions rate, the simulated concentrations can be multiplied by
+// this ratio to get the concentrations resulting from the receiver.
+func (a *emissionsAmount) scale() float64 {
+	return a.rate() / referenceAmount.rate()
+}
+
+// checkMarginalAmounts returns an error if emissions amounts, periods,
+// pollutants, or speciation are requested for a simulation type other
+// than CityMarginal. Other simulation types are driven by emissions
+// inventories, so their results can't be scaled to a requested amount.
+func checkMarginalAmounts(simulationType rpc.SimulationType, amount float64, begin, end int64, pollutants []*rpc.PollutantAmount, speciate bool) error {
+	if simulationType == rpc.SimulationType_CityMarginal {
+		return nil
+	}
+	if amount != 0 || begin != 0 || end != 0 || len(pollutants) > 0 || speciate {
+		return fmt.Errorf("cityaq: emissions amounts, periods, pollutants, and speciation can only be used in %s simulations, not %s", rpc.SimulationType_CityMarginal, simulationType)
+	}
+	return nil
+}
+
+// pollutantAmount is an amount of emissions of a pollutant.
+type pollutantAmount struct {
+	pollutant rpc.Emission
+	*emissionsAmount
+}
+
+// requestPollutantAmounts returns the amounts of the pollutants specified
+// by the given request fields. If pollutants is empty, the result only
+// contains emission, in the given amount and units; otherwise, it contains
+// each of the given pollutants. All of the pollutants are emitted over
+// the same period.
+func requestPollutantAmounts(emission rpc.Emission, amount float64, units rpc.EmissionUnits, pollutants []*rpc.PollutantAmount, begin, end int64) ([]pollutantAmount, error) {
+	if len(pollutants) == 0 {
+		pollutants = []*rpc.PollutantAmount{{Emission: emission, Amount: amount, AmountUnits: units}}
+	}
+	o := make([]pollutantAmount, len(pollutants))
+	found := make(map[rpc.Emission]bool)
+	for i, p := range pollutants {
+		if p == nil {
+			return nil, fmt.Errorf("cityaq: pollutant %d is missing", i)
+		}
+		if _, ok := rpc.Emission_name[int32(p.Emission)]; !ok || p.Emission == rpc.Emission_UNKNOWN_EMISSION {
+			return nil, fmt.Errorf("cityaq: invalid emission type %s", p.Emission)
+		}
+		if found[p.Emission] {
+			return nil, fmt.Errorf("cityaq: emission type %s is requested more than once", p.Emission)
+		}
+		found[p.Emission] = true
+		a, err := requestAmount(p.Amount, p.AmountUnits, begin, end)
+		if err != nil {
+			return nil, err
+		}
+		o[i] = pollutantAmount{pollutant: p.Emission, emissionsAmount: a}
+	}
+	return o, nil
+}
 
+// newEmissions returns emissions of the given pollutants, which
+// must all have the same emissions period.
+func newEmissions(poly geom.Polygonal, sourceType, cityName string, amounts []pollutantAmount) (*emissions, error) {
 	e := new(aep.Emissions)
-	e.Add(begin, end, pollutant.String(), "", rate)
+	for _, a := range amounts {
+		rate := unit.New(a.rate(), unit.Dimensions{
+			unit.MassDim: 1,
+			unit.TimeDim: -1,
+		}) // kg/s
+		e.Add(a.begin, a.end, a.pollutant.String(), "", rate)
+	}
 
 	sr, err := proj.Parse("+proj=longlat")
 	if err != nil {
-		return nil, time.Time{}, time.Time{}, err
+		return nil, err
 	}
 
 	emis := &emissions{
-		Polygon:   poly,
+		Polygonal: poly,
 		SR:        sr,
 		Emissions: *e,
 		SourceData: aep.SourceData{
@@ -59,41 +187,96 @@ func newEmissions(poly geom.Polygon, pollutant rpc.Emission, sourceType, cityNam
 		},
 		cityName: cityName,
 	}
-	return emis, begin, end, nil
+	return emis, nil
 }
 
 func emissionsMapName(r *rpc.GriddedEmissionsRequest) string {
-	return fmt.Sprintf("%s_%d_%d_%s_%d", r.CityName, rpc.ImpactType_Emissions, r.Emission, r.SourceType, r.SimulationType)
+	return fmt.Sprintf("%s_%d_%d_%s_%d%s", r.CityName, rpc.ImpactType_Emissions, r.Emission, r.SourceType, r.SimulationType,
+		resolutionName(r.Resolution, r.AutoResolution))
 }
 
 func concentrationsMapName(r *rpc.GriddedConcentrationsRequest) string {
-	return fmt.Sprintf("%s_%d_%d_%s_%d", r.CityName, rpc.ImpactType_Concentrations, r.Emission, r.SourceType, r.SimulationType)
+	return fmt.Sprintf("%s_%d_%d_%s_%d%s", r.CityName, rpc.ImpactType_Concentrations, r.Emission, r.SourceType, r.SimulationType,
+		resolutionName(r.Resolution, r.AutoResolution))
+}
+
+// resolutionName returns a suffix for map names that identifies the
+// requested resolution, or an empty string for the default resolution.
+func resolutionName(resolution float64, autoResolution bool) string {
+	switch {
+	case autoResolution:
+		return "_auto"
+	case resolution > 0:
+		return fmt.Sprintf("_%g", resolution)
+	}
+	return ""
 }
 
-// GriddedEmissions returns gridded emissions for the request.
-// If req.SourceType has the suffix "_egugrid", emissions will be allocated
-// to the smaller of country that the city is in or the intersection of
-// the country with a 5.4 degree radius buffer around the city,
-// otherwise they will be allocated within the city itself.
+// GriddedEmissions returns gridded emissions for the request, in kilograms
+// emitted over the emissions period. All of the requested pollutants are
+// allocated to the same grid using the same spatial surrogate, which can
+// be a blend of the surrogates of several source types.
+// If the allocation domain of req.SourceType is "egugrid", emissions are
+// allocated within the region of the GridRegionFile that the city is in;
+// if there is no such region, they are allocated within the country that
+// the city is in, or, if the country is larger than an average US NERC
+// region, within the intersection of the country with a 5.4 degree
+// radius buffer around the city. Otherwise, they are allocated within
+// the city itself.
+// If req.Speciate is set, the requested amount is the total of all
+// pollutants, which is split among them using the speciation profile of
+// the source type.
+// The grid resolution is that of the source type unless req.Resolution
+// or req.AutoResolution is set. If req.TemporalResolution is set, the
+// response also includes the emissions in each time step of the time
+// window, allocated using the temporal profiles of the source type.
+// For CityTotal and Total simulations, the emissions are instead those of
+// the emissions inventory sector req.SourceType that drive the simulation,
+// masked to the city for CityTotal simulations.
+// The response includes diagnostics of how well the mass of the emissions
+// was conserved when allocating it to the grid. If the spatial surrogate
+// of a source type is zero throughout the allocation domain, an error is
+// returned, or if req.AreaFallback is set, its emissions are allocated in
+// proportion to area instead. If req.MassTolerance is set, an error is
+// returned if more than that fraction of the mass is lost or gained.
 func (c *CityAQ) GriddedEmissions(ctx context.Context, req *rpc.GriddedEmissionsRequest) (*rpc.GriddedEmissionsResponse, error) {
-	g, err := c.geojsonGeometry(req.CityName)
+	cityID, err := c.catalog().id(req.CityName)
 	if err != nil {
 		return nil, err
 	}
-	if egugridEmissions(req.SourceType) {
-		// Use EGU grid geometry instead of city.
-		country, err := c.countryOrGridBuffer(req.CityName)
-		if err != nil {
-			return nil, err
-		}
-		g = country.Polygon
+	if req.SimulationType == rpc.SimulationType_CityTotal || req.SimulationType == rpc.SimulationType_Total {
+		return c.inventoryGriddedEmissions(ctx, cityID, req)
 	}
-	e, begin, end, err := newEmissions(g, req.Emission, req.SourceType, req.CityName)
+	surrogate, err := requestCustomSurrogate(req.CustomSurrogate)
+	if err != nil {
+		return nil, err
+	}
+	blend, err := requestSourceTypeBlend(req.SourceTypeMix)
+	if err != nil {
+		return nil, err
+	}
+	st, err := c.requestSourceType(req.SourceType, surrogate, blend)
+	if err != nil {
+		return nil, err
+	}
+	amounts, err := requestSpeciatedAmounts(st, req.Speciate, req.Emission, req.Amount, req.AmountUnits, req.Pollutants, req.Begin, req.End)
+	if err != nil {
+		return nil, err
+	}
+	st, err = c.gridSourceType(cityID, st, req.Resolution, req.AutoResolution)
+	if err != nil {
+		return nil, err
+	}
+	g, err := c.gridDomain(cityID, st)
 	if err != nil {
 		return nil, err
 	}
 
-	grid, err := c.emissionsGrid(req.CityName, req.SourceType, mapResolution(req.SourceType, req.CityName))
+	grid, err := c.emissionsGrid(cityID, st)
+	if err != nil {
+		return nil, err
+	}
+	gridName, err := c.gridName(cityID, st)
 	if err != nil {
 		return nil, err
 	}
@@ -102,6 +285,72 @@ func (c *CityAQ) GriddedEmissions(ctx context.Context, req *rpc.GriddedEmissions
 		return nil, fmt.Errorf("emissionsGrid was not correctly created for %v", req)
 	}
 
+	var gridEmis map[rpc.Emission][]float64
+	var fallbacks []string
+	if surrogate != nil {
+		var fellBack bool
+		gridEmis, fellBack, err = c.customSurrogateEmissions(ctx, surrogate, g, gridName, grid, amounts, req.AreaFallback)
+		if fellBack {
+			fallbacks = []string{surrogate.name()}
+		}
+	} else if blend != nil {
+		gridEmis, fallbacks, err = c.blendEmissions(blend, g, gridName, grid, amounts, req.AreaFallback)
+	} else {
+		var fellBack bool
+		gridEmis, fellBack, err = c.surrogateOrAreaEmissions(g, st.Name, gridName, grid, amounts, req.AreaFallback)
+		if fellBack {
+			fallbacks = []string{st.Name}
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+	o := &rpc.GriddedEmissionsResponse{
+		Polygons:           polygonalsToRPC(grid),
+		PollutantEmissions: make([]*rpc.PollutantEmissions, len(amounts)),
+	}
+	for j, a := range amounts {
+		o.PollutantEmissions[j] = &rpc.PollutantEmissions{
+			Emission:  a.pollutant,
+			Emissions: gridEmis[a.pollutant],
+		}
+	}
+	if req.Speciate {
+		o.Emissions = make([]float64, len(grid))
+		for _, pe := range o.PollutantEmissions {
+			floats.Add(o.Emissions, pe.Emissions)
+		}
+	} else if len(req.Pollutants) == 0 {
+		o.Emissions = o.PollutantEmissions[0].Emissions
+	}
+	o.Diagnostics, err = c.emissionsDiagnostics(cityID, grid, requestedMass(amounts), o.PollutantEmissions, fallbacks)
+	if err != nil {
+		return nil, err
+	}
+	if err := checkMassConservation(o.Diagnostics, req.MassTolerance); err != nil {
+		return nil, err
+	}
+	if req.TemporalResolution != rpc.TemporalResolution_UNKNOWN_TEMPORALRESOLUTION {
+		o.TimeSteps, err = c.emissionsTimeSteps(req, cityID, blend, amounts, o.PollutantEmissions)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return o, nil
+}
+
+// surrogateEmissions allocates the given amounts of emissions within
+// polygon to the given grid using the spatial surrogate of sourceType
+// from the surrogate specification. gridName identifies the grid for
+// caching. It returns the gridded emissions of each pollutant, or nil
+// if the surrogate is zero throughout polygon.
+func (c *CityAQ) surrogateEmissions(polygon geom.Polygonal, sourceType, gridName string, grid []geom.Polygonal, amounts []pollutantAmount) (map[rpc.Emission][]float64, error) {
+	e, err := newEmissions(polygon, sourceType, gridName, amounts)
+	if err != nil {
+		return nil, err
+	}
+
 	// Make a copy of the spatial configuration to allow the
 	// use of multiple grids.
 	spatialConfig := aeputil.SpatialConfig{
@@ -117,7 +366,7 @@ func (c *CityAQ) GriddedEmissions(ctx context.Context, req *rpc.GriddedEmissions
 		SpatialCache:          c.SpatialConfig.SpatialCache,
 		MaxCacheEntries:       c.SpatialConfig.MaxCacheEntries,
 		GridCells:             grid,
-		GridName:              req.CityName,
+		GridName:              gridName,
 	}
 
 	sp, err := spatialConfig.SpatialProcessor()
@@ -128,27 +377,49 @@ func (c *CityAQ) GriddedEmissions(ctx context.Context, req *rpc.GriddedEmissions
 
 	rSrg := sp.AddSurrogate(e)
 	r := sp.GridRecord(rSrg)
-	gridEmis, _, err := r.GriddedEmissions(begin, end, 0)
+	gridEmis, _, err := r.GriddedEmissions(amounts[0].begin, amounts[0].end, 0)
 	if err != nil {
 		return nil, err
 	}
 	if len(gridEmis) == 0 {
-		return nil, fmt.Errorf("cityaq: no emissions for city %s, source %s", req.CityName, req.SourceType)
+		return nil, nil
 	}
-	polEmis, ok := gridEmis[aep.Pollutant{Name: req.Emission.String()}]
-	if !ok {
-		panic(fmt.Errorf("cityaq: missing gridded pollutant %v", req.Emission))
+	o := make(map[rpc.Emission][]float64)
+	for _, a := range amounts {
+		polEmis, ok := gridEmis[aep.Pollutant{Name: a.pollutant.String()}]
+		if !ok {
+			return nil, fmt.Errorf("cityaq: the surrogate for source type %s did not allocate any %s emissions", sourceType, a.pollutant)
+		}
+		v := make([]float64, len(grid))
+		for i, e := range polEmis.Elements {
+			v[i] = e
+		}
+		o[a.pollutant] = v
 	}
+	return o, nil
+}
 
-	o := &rpc.GriddedEmissionsResponse{
-		Polygons:  polygonalsToRPC(grid),
-		Emissions: make([]float64, len(grid)),
+// surrogateOrAreaEmissions allocates the given amounts of emissions as
+// in surrogateEmissions. If the surrogate of sourceType is zero throughout
+// polygon, it returns an error, or if areaFallback is true, allocates the
+// emissions in proportion to area within polygon instead. It returns
+// whether the emissions were allocated in proportion to area.
+func (c *CityAQ) surrogateOrAreaEmissions(polygon geom.Polygonal, sourceType, gridName string, grid []geom.Polygonal, amounts []pollutantAmount, areaFallback bool) (map[rpc.Emission][]float64, bool, error) {
+	o, err := c.surrogateEmissions(polygon, sourceType, gridName, grid, amounts)
+	if err != nil {
+		return nil, false, err
 	}
-	for i, v := range polEmis.Elements {
-		o.Emissions[i] = v
+	if len(o) != 0 {
+		return o, false, nil
 	}
-
-	return o, nil
+	if !areaFallback {
+		return nil, false, fmt.Errorf("%v for source type %s", errZeroSurrogate, sourceType)
+	}
+	o, err = areaEmissions(polygon, grid, amounts)
+	if err != nil {
+		return nil, false, fmt.Errorf("%v for source type %s", err, sourceType)
+	}
+	return o, true, nil
 }
 
 func (c *CityAQ) emissionsMapData(ctx context.Context, req *rpc.GriddedEmissionsRequest) (*mvt.Layer, error) {
@@ -204,8 +475,22 @@ func rpcToOrb(p *rpc.Polygon) orb.Polygon {
 	return o
 }
 
-func geomToOrb(g geom.Polygonal) orb.Polygon {
-	p := g.(geom.Polygon)
+// geomToOrb converts a Polygon or MultiPolygon to the
+// equivalent orb geometry.
+func geomToOrb(g geom.Polygonal) orb.Geometry {
+	switch t := g.(type) {
+	case geom.MultiPolygon:
+		o := make(orb.MultiPolygon, len(t))
+		for i, p := range t {
+			o[i] = polygonToOrb(p)
+		}
+		return o
+	default:
+		return polygonToOrb(g.(geom.Polygon))
+	}
+}
+
+func polygonToOrb(p geom.Polygon) orb.Polygon {
 	o := make(orb.Polygon, len(p))
 	for i, path := range p {
 		o[i] = make(orb.Ring, len(path))
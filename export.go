@@ -0,0 +1,1137 @@
+package cityaq
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"math"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ctessum/cdf"
+	rpc "github.com/ctessum/cityaq/cityaqrpc"
+	"github.com/ctessum/geom"
+	"github.com/ctessum/geom/encoding/shp"
+	goshp "github.com/jonas-p/go-shp"
+)
+
+// exportField is a gridded variable to export.
+type exportField struct {
+	name, longName, units string
+	values                []float64
+
+	// extensive is true for quantities that are totals within each
+	// grid cell, such as emissions, rather than averages, such as
+	// concentrations.
+	extensive bool
+}
+
+// exportData holds gridded data to export.
+type exportData struct {
+	// name is the base name for exported files.
+	name string
+
+	// polygons holds the grid cells, which have longitude
+	// and latitude coordinates.
+	polygons []geom.Polygon
+
+	fields []exportField
+
+	// metadata holds information about how the data were created.
+	metadata []exportMetadata
+}
+
+// exportMetadata is an item of metadata.
+type exportMetadata struct {
+	key, value string
+}
+
+// exportFormats holds the file extension and MIME type of each export format.
+var exportFormats = map[rpc.ExportFormat]struct{ ext, contentType string }{
+	rpc.ExportFormat_GeoTIFF:   {ext: ".tif", contentType: "image/tiff"},
+	rpc.ExportFormat_NetCDF:    {ext: ".nc", contentType: "application/x-netcdf"},
+	rpc.ExportFormat_Shapefile: {ext: ".zip", contentType: "application/zip"},
+	rpc.ExportFormat_GeoJSON:   {ext: ".geojson", contentType: "application/geo+json"},
+	rpc.ExportFormat_CSV:       {ext: ".csv", contentType: "text/csv"},
+}
+
+// ExportGridded returns gridded emissions, concentrations, or population
+// as a file in the requested format.
+func (c *CityAQ) ExportGridded(ctx context.Context, req *rpc.ExportGriddedRequest) (*rpc.ExportGriddedResponse, error) {
+	format, ok := exportFormats[req.Format]
+	if !ok {
+		return nil, fmt.Errorf("cityaq: invalid export format %s", req.Format)
+	}
+	d, err := c.exportData(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	var data []byte
+	switch req.Format {
+	case rpc.ExportFormat_GeoTIFF:
+		data, err = d.geoTIFF()
+	case rpc.ExportFormat_NetCDF:
+		data, err = d.netCDF()
+	case rpc.ExportFormat_Shapefile:
+		data, err = d.zippedShapefile()
+	case rpc.ExportFormat_GeoJSON:
+		data, err = d.geoJSON()
+	case rpc.ExportFormat_CSV:
+		data, err = d.csv()
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &rpc.ExportGriddedResponse{
+		FileName:    d.name + format.ext,
+		ContentType: format.contentType,
+		Data:        data,
+	}, nil
+}
+
+// exportData retrieves the gridded data specified by req.
+func (c *CityAQ) exportData(ctx context.Context, req *rpc.ExportGriddedRequest) (*exportData, error) {
+	var n int
+	for _, set := range []bool{req.Emissions != nil, req.Concentrations != nil, req.Population != nil} {
+		if set {
+			n++
+		}
+	}
+	if n != 1 {
+		return nil, fmt.Errorf("cityaq: exactly one of Emissions, Concentrations, and Population must be set in an export request")
+	}
+
+	period, err := exportPeriod(req)
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		quantity, city, sourceType string
+		simulationType             rpc.SimulationType
+		request                    interface{}
+		d                          = new(exportData)
+		polygons                   []*rpc.Polygon
+	)
+	switch {
+	case req.Emissions != nil:
+		r := req.Emissions
+		quantity, city, sourceType, simulationType, request = "emissions", r.CityName, r.SourceType, r.SimulationType, r
+		resp, err := c.GriddedEmissions(ctx, r)
+		if err != nil {
+			return nil, err
+		}
+		polygons = resp.Polygons
+		for _, pe := range resp.PollutantEmissions {
+			d.fields = append(d.fields, exportField{
+				name:      pe.Emission.String(),
+				longName:  pe.Emission.String() + " emissions over the emissions period",
+				units:     "kg",
+				values:    pe.Emissions,
+				extensive: true,
+			})
+		}
+	case req.Concentrations != nil:
+		r := req.Concentrations
+		quantity, city, sourceType, simulationType, request = "concentrations", r.CityName, r.SourceType, r.SimulationType, r
+		field, err := concentrationField(r)
+		if err != nil {
+			return nil, err
+		}
+		resp, err := c.GriddedConcentrations(ctx, r)
+		if err != nil {
+			return nil, err
+		}
+		polygons = resp.Polygons
+		field.values = resp.Concentrations
+		d.fields = []exportField{field}
+	case req.Population != nil:
+		r := req.Population
+		quantity, city, sourceType, simulationType, request = "population", r.CityName, r.SourceType, r.SimulationType, r
+		resp, err := c.GriddedPopulation(ctx, r)
+		if err != nil {
+			return nil, err
+		}
+		polygons = resp.Polygons
+		d.fields = []exportField{{
+			name:      "Population",
+			longName:  "Population count",
+			units:     "people",
+			values:    resp.Population,
+			extensive: true,
+		}}
+	}
+
+	cityID := city
+	if simulationType != rpc.SimulationType_Total {
+		id, err := c.catalog().id(city)
+		if err != nil {
+			return nil, err
+		}
+		cityID = id
+	}
+	d.name = cacheKey("cityaq", quantity, cityID, sourceType)
+	d.polygons = make([]geom.Polygon, len(polygons))
+	for i, p := range polygons {
+		d.polygons[i] = rpcToGeom(p)
+	}
+	reqJSON, err := json.Marshal(request)
+	if err != nil {
+		return nil, err
+	}
+	d.metadata = []exportMetadata{
+		{key: "title", value: fmt.Sprintf("CityAQ gridded %s for %s", quantity, city)},
+		{key: "source", value: "CityAQ"},
+		{key: "history", value: time.Now().UTC().Format(time.RFC3339) + " exported by CityAQ"},
+		{key: "city", value: city},
+		{key: "source_type", value: sourceType},
+		{key: "simulation_type", value: simulationType.String()},
+		{key: "crs", value: "EPSG:4326"},
+	}
+	if period != nil {
+		d.metadata = append(d.metadata,
+			exportMetadata{key: "emissions_period_begin", value: period.begin.UTC().Format(time.RFC3339)},
+			exportMetadata{key: "emissions_period_end", value: period.end.UTC().Format(time.RFC3339)},
+		)
+	}
+	if quantity != "emissions" && c.Version != "" {
+		d.metadata = append(d.metadata, exportMetadata{key: "inmap_version", value: c.Version})
+	}
+	d.metadata = append(d.metadata, exportMetadata{key: "request", value: string(reqJSON)})
+	return d, nil
+}
+
+// concentrationSpecies holds the name and description of the PM2.5
+// species that results from emissions of each pollutant.
+var concentrationSpecies = map[rpc.Emission]struct{ name, longName string }{
+	rpc.Emission_PM2_5: {name: "PrimaryPM2_5", longName: "primary PM2.5"},
+	rpc.Emission_NH3:   {name: "pNH4", longName: "particulate ammonium"},
+	rpc.Emission_NOx:   {name: "pNO3", longName: "particulate nitrate"},
+	rpc.Emission_SOx:   {name: "pSO4", longName: "particulate sulfate"},
+	rpc.Emission_VOC:   {name: "SOA", longName: "secondary organic aerosol"},
+}
+
+// concentrationField returns the export field, without values, for the
+// concentrations that GriddedConcentrations returns for r: total PM2.5
+// if r includes emissions of multiple pollutants, and otherwise the
+// PM2.5 species that results from emissions of r.Emission.
+func concentrationField(r *rpc.GriddedConcentrationsRequest) (exportField, error) {
+	if len(r.PointSources) > 0 || len(r.Pollutants) > 0 || r.Speciate {
+		return exportField{
+			name:     "TotalPM2_5",
+			longName: "Average total PM2.5 concentration over the emissions period",
+			units:    "ug m-3",
+		}, nil
+	}
+	species, ok := concentrationSpecies[r.Emission]
+	if !ok {
+		return exportField{}, fmt.Errorf("cityaq: invalid emission type %s", r.Emission)
+	}
+	return exportField{
+		name:     species.name,
+		longName: "Average " + species.longName + " concentration over the emissions period",
+		units:    "ug m-3",
+	}, nil
+}
+
+// orientRings returns a copy of p whose exterior ring is counterclockwise
+// if ccw is true, or clockwise otherwise, and whose holes have the
+// opposite orientation.
+func orientRings(p geom.Polygon, ccw bool) geom.Polygon {
+	o := make(geom.Polygon, len(p))
+	for i, ring := range p {
+		r := make(geom.Path, len(ring))
+		copy(r, ring)
+		if (planarArea(closeRing(r)) > 0) != (ccw == (i == 0)) {
+			for j, k := 0, len(r)-1; j < k; j, k = j+1, k-1 {
+				r[j], r[k] = r[k], r[j]
+			}
+		}
+		o[i] = r
+	}
+	return o
+}
+
+// closeRing returns ring with its first point repeated at the end
+// if it is not already.
+func closeRing(ring geom.Path) geom.Path {
+	if len(ring) > 0 && ring[0] != ring[len(ring)-1] {
+		return append(ring[:len(ring):len(ring)], ring[0])
+	}
+	return ring
+}
+
+// csv returns the receiver as a CSV file with metadata in comment
+// lines at the top, a WKT column with the geometry of each grid cell,
+// and a column for each field.
+func (d *exportData) csv() ([]byte, error) {
+	b := new(bytes.Buffer)
+	for _, m := range d.metadata {
+		fmt.Fprintf(b, "# %s: %s\n", m.key, m.value)
+	}
+	w := csv.NewWriter(b)
+	header := []string{"WKT"}
+	for _, f := range d.fields {
+		header = append(header, fmt.Sprintf("%s (%s)", f.name, f.units))
+	}
+	if err := w.Write(header); err != nil {
+		return nil, err
+	}
+	for i, p := range d.polygons {
+		row := []string{polygonWKT(orientRings(p, true))}
+		for _, f := range d.fields {
+			row = append(row, strconv.FormatFloat(f.values[i], 'g', -1, 64))
+		}
+		if err := w.Write(row); err != nil {
+			return nil, err
+		}
+	}
+	w.Flush()
+	return b.Bytes(), w.Error()
+}
+
+// polygonWKT returns the well-known text representation of p.
+func polygonWKT(p geom.Polygon) string {
+	b := new(strings.Builder)
+	b.WriteString("POLYGON (")
+	for i, ring := range p {
+		if i > 0 {
+			b.WriteString(", ")
+		}
+		b.WriteString("(")
+		for j, pt := range closeRing(ring) {
+			if j > 0 {
+				b.WriteString(", ")
+			}
+			b.WriteString(strconv.FormatFloat(pt.X, 'g', -1, 64))
+			b.WriteString(" ")
+			b.WriteString(strconv.FormatFloat(pt.Y, 'g', -1, 64))
+		}
+		b.WriteString(")")
+	}
+	b.WriteString(")")
+	return b.String()
+}
+
+// geoJSON returns the receiver as a GeoJSON feature collection. The
+// metadata and the units of each field are in a "metadata" member of
+// the feature collection.
+func (d *exportData) geoJSON() ([]byte, error) {
+	type feature struct {
+		Type     string `json:"type"`
+		Geometry struct {
+			Type        string         `json:"type"`
+			Coordinates [][][2]float64 `json:"coordinates"`
+		} `json:"geometry"`
+		Properties map[string]float64 `json:"properties"`
+	}
+	o := struct {
+		Type     string            `json:"type"`
+		Metadata map[string]string `json:"metadata"`
+		Features []feature         `json:"features"`
+	}{
+		Type:     "FeatureCollection",
+		Metadata: make(map[string]string),
+		Features: make([]feature, len(d.polygons)),
+	}
+	for _, m := range d.metadata {
+		o.Metadata[m.key] = m.value
+	}
+	for _, f := range d.fields {
+		o.Metadata[f.name+"_units"] = f.units
+		o.Metadata[f.name+"_description"] = f.longName
+	}
+	for i, p := range d.polygons {
+		ft := &o.Features[i]
+		ft.Type = "Feature"
+		ft.Geometry.Type = "Polygon"
+		for _, ring := range orientRings(p, true) {
+			var r [][2]float64
+			for _, pt := range closeRing(ring) {
+				r = append(r, [2]float64{pt.X, pt.Y})
+			}
+			ft.Geometry.Coordinates = append(ft.Geometry.Coordinates, r)
+		}
+		ft.Properties = make(map[string]float64, len(d.fields))
+		for _, f := range d.fields {
+			v := f.values[i]
+			if math.IsNaN(v) || math.IsInf(v, 0) {
+				// JSON can't represent these values.
+				continue
+			}
+			ft.Properties[f.name] = v
+		}
+	}
+	return json.Marshal(o)
+}
+
+// zippedShapefile returns the receiver as a zip file that contains a
+// shapefile and a text file with the metadata and field units.
+func (d *exportData) zippedShapefile() ([]byte, error) {
+	dir, err := ioutil.TempDir("", "cityaq_export")
+	if err != nil {
+		return nil, err
+	}
+	defer os.RemoveAll(dir)
+
+	// Shapefile field names can have at most 10 characters.
+	fields := make([]goshp.Field, len(d.fields))
+	names := make(map[string]bool)
+	for i, f := range d.fields {
+		name := f.name
+		if len(name) > 10 {
+			name = name[:10]
+		}
+		for j := 1; names[name]; j++ {
+			suffix := strconv.Itoa(j)
+			name = name[:int(math.Min(float64(len(name)), float64(10-len(suffix))))] + suffix
+		}
+		names[name] = true
+		fields[i] = goshp.FloatField(name, 24, 12)
+	}
+
+	file := filepath.Join(dir, d.name+".shp")
+	e, err := shp.NewEncoderFromFields(file, goshp.POLYGON, fields...)
+	if err != nil {
+		return nil, err
+	}
+	vals := make([]interface{}, len(d.fields))
+	for i, p := range d.polygons {
+		for j, f := range d.fields {
+			vals[j] = f.values[i]
+		}
+		if err := e.EncodeFields(orientRings(p, false), vals...); err != nil {
+			return nil, err
+		}
+	}
+	e.Close()
+	if err := writeLonLatPRJ(file); err != nil {
+		return nil, err
+	}
+
+	meta := new(bytes.Buffer)
+	for _, m := range d.metadata {
+		fmt.Fprintf(meta, "%s: %s\n", m.key, m.value)
+	}
+	fmt.Fprintln(meta, "\nfields:")
+	for i, f := range d.fields {
+		fmt.Fprintf(meta, "%s: %s [%s]\n", fields[i].String(), f.longName, f.units)
+	}
+	if err := ioutil.WriteFile(filepath.Join(dir, d.name+".txt"), meta.Bytes(), 0644); err != nil {
+		return nil, err
+	}
+
+	files, err := filepath.Glob(filepath.Join(dir, d.name+".*"))
+	if err != nil {
+		return nil, err
+	}
+	sort.Strings(files)
+	b := new(bytes.Buffer)
+	z := zip.NewWriter(b)
+	for _, f := range files {
+		data, err := ioutil.ReadFile(f)
+		if err != nil {
+			return nil, err
+		}
+		w, err := z.Create(filepath.Base(f))
+		if err != nil {
+			return nil, err
+		}
+		if _, err := w.Write(data); err != nil {
+			return nil, err
+		}
+	}
+	if err := z.Close(); err != nil {
+		return nil, err
+	}
+	return b.Bytes(), nil
+}
+
+// exportRaster is gridded data on a regular longitude-latitude raster.
+type exportRaster struct {
+	// x0 and y0 are the longitude and latitude of the
+	// south-west corner of the raster.
+	x0, y0 float64
+
+	// dx and dy are the cell sizes in degrees, and nx and ny
+	// are the numbers of columns and rows.
+	dx, dy float64
+	nx, ny int
+
+	// values holds the values of each field, indexed by
+	// row (from south to north) and then column.
+	values [][]float64
+}
+
+// raster converts the receiver to a regular raster whose cell size is the
+// size of the smallest grid cell. Larger grid cells, such as those in
+// nested grids, are split among the raster cells they cover; the values
+// of extensive fields are split in proportion to area. Raster cells that
+// are not covered by any grid cell are NaN. It returns an error if the
+// grid cells are not aligned to a regular raster.
+func (d *exportData) raster() (*exportRaster, error) {
+	if len(d.polygons) == 0 {
+		return nil, fmt.Errorf("cityaq: there are no grid cells to export")
+	}
+	const notRegular = "cityaq: the grid cells are not aligned to a regular longitude-latitude raster, so they can't be exported in a raster format"
+	r := &exportRaster{dx: math.Inf(1), dy: math.Inf(1)}
+	b := geom.NewBounds()
+	bounds := make([]*geom.Bounds, len(d.polygons))
+	for i, p := range d.polygons {
+		pb := p.Bounds()
+		if !isRectangle(p, pb) {
+			return nil, fmt.Errorf(notRegular)
+		}
+		bounds[i] = pb
+		b.Extend(pb)
+		r.dx = math.Min(r.dx, pb.Max.X-pb.Min.X)
+		r.dy = math.Min(r.dy, pb.Max.Y-pb.Min.Y)
+	}
+	r.x0, r.y0 = b.Min.X, b.Min.Y
+
+	// index returns the raster index of the given coordinate, and
+	// whether it is aligned with the raster.
+	index := func(v, v0, dv float64) (int, bool) {
+		f := (v - v0) / dv
+		i := math.Round(f)
+		return int(i), math.Abs(f-i) < 1e-6
+	}
+	var ok bool
+	if r.nx, ok = index(b.Max.X, r.x0, r.dx); !ok {
+		return nil, fmt.Errorf(notRegular)
+	}
+	if r.ny, ok = index(b.Max.Y, r.y0, r.dy); !ok {
+		return nil, fmt.Errorf(notRegular)
+	}
+	if r.nx*r.ny > maxGridCells {
+		return nil, fmt.Errorf("cityaq: a raster of the grid would have %d cells, which is more than the maximum of %d", r.nx*r.ny, maxGridCells)
+	}
+
+	r.values = make([][]float64, len(d.fields))
+	for k := range r.values {
+		r.values[k] = make([]float64, r.nx*r.ny)
+		for i := range r.values[k] {
+			r.values[k][i] = math.NaN()
+		}
+	}
+	covered := make([]bool, r.nx*r.ny)
+	for c, pb := range bounds {
+		i0, ok0 := index(pb.Min.X, r.x0, r.dx)
+		i1, ok1 := index(pb.Max.X, r.x0, r.dx)
+		j0, ok2 := index(pb.Min.Y, r.y0, r.dy)
+		j1, ok3 := index(pb.Max.Y, r.y0, r.dy)
+		if !ok0 || !ok1 || !ok2 || !ok3 {
+			return nil, fmt.Errorf(notRegular)
+		}
+		n := float64((i1 - i0) * (j1 - j0))
+		for j := j0; j < j1; j++ {
+			for i := i0; i < i1; i++ {
+				ii := j*r.nx + i
+				if covered[ii] {
+					return nil, fmt.Errorf("cityaq: grid cells overlap, so they can't be exported in a raster format")
+				}
+				covered[ii] = true
+				for k, f := range d.fields {
+					v := f.values[c]
+					if f.extensive {
+						v /= n
+					}
+					r.values[k][ii] = v
+				}
+			}
+		}
+	}
+	return r, nil
+}
+
+// isRectangle returns whether p is a rectangle with the given bounds.
+func isRectangle(p geom.Polygon, b *geom.Bounds) bool {
+	if len(p) != 1 {
+		return false
+	}
+	ring := p[0]
+	if len(ring) == 5 && ring[0] == ring[4] {
+		ring = ring[:4]
+	}
+	if len(ring) != 4 || b.Min.X >= b.Max.X || b.Min.Y >= b.Max.Y {
+		return false
+	}
+	corners := make(map[geom.Point]bool)
+	for _, pt := range ring {
+		if (pt.X != b.Min.X && pt.X != b.Max.X) || (pt.Y != b.Min.Y && pt.Y != b.Max.Y) {
+			return false
+		}
+		corners[pt] = true
+	}
+	return len(corners) == 4
+}
+
+// TIFF field types.
+const (
+	tiffASCII  = 2
+	tiffShort  = 3
+	tiffLong   = 4
+	tiffDouble = 12
+)
+
+// tiffEntry is a TIFF image file directory entry.
+type tiffEntry struct {
+	tag, typ uint16
+	count    uint32
+	data     []byte
+}
+
+// geoTIFF returns the receiver as a GeoTIFF with one 64-bit floating
+// point band per field. The metadata, band descriptions, and units are
+// stored in the GDAL metadata tag.
+func (d *exportData) geoTIFF() ([]byte, error) {
+	r, err := d.raster()
+	if err != nil {
+		return nil, err
+	}
+	nBands := len(d.fields)
+
+	shorts := func(v ...uint16) []byte {
+		b := make([]byte, 2*len(v))
+		for i, x := range v {
+			b[2*i], b[2*i+1] = byte(x), byte(x>>8)
+		}
+		return b
+	}
+	longs := func(v ...uint32) []byte {
+		b := make([]byte, 4*len(v))
+		for i, x := range v {
+			for j := 0; j < 4; j++ {
+				b[4*i+j] = byte(x >> (8 * j))
+			}
+		}
+		return b
+	}
+	doubles := func(v ...float64) []byte {
+		b := make([]byte, 8*len(v))
+		for i, x := range v {
+			bits := math.Float64bits(x)
+			for j := 0; j < 8; j++ {
+				b[8*i+j] = byte(bits >> (8 * j))
+			}
+		}
+		return b
+	}
+	ascii := func(s string) []byte { return append([]byte(s), 0) }
+
+	gdalMeta := new(bytes.Buffer)
+	gdalMeta.WriteString("<GDALMetadata>")
+	for _, m := range d.metadata {
+		fmt.Fprintf(gdalMeta, "<Item name=%q>%s</Item>", xmlEscape(m.key), xmlEscape(m.value))
+	}
+	for i, f := range d.fields {
+		fmt.Fprintf(gdalMeta, "<Item name=\"DESCRIPTION\" sample=\"%d\" role=\"description\">%s</Item>", i, xmlEscape(f.name))
+		fmt.Fprintf(gdalMeta, "<Item name=\"units\" sample=\"%d\" role=\"unittype\">%s</Item>", i, xmlEscape(f.units))
+		fmt.Fprintf(gdalMeta, "<Item name=\"long_name\" sample=\"%d\">%s</Item>", i, xmlEscape(f.longName))
+	}
+	gdalMeta.WriteString("</GDALMetadata>")
+
+	bitsPerSample := make([]uint16, nBands)
+	sampleFormat := make([]uint16, nBands)
+	stripByteCounts := make([]uint32, nBands)
+	for i := range bitsPerSample {
+		bitsPerSample[i] = 64
+		sampleFormat[i] = 3 // IEEE floating point
+		stripByteCounts[i] = uint32(8 * r.nx * r.ny)
+	}
+	entries := []tiffEntry{
+		{tag: 256, typ: tiffLong, count: 1, data: longs(uint32(r.nx))},                                    // ImageWidth
+		{tag: 257, typ: tiffLong, count: 1, data: longs(uint32(r.ny))},                                    // ImageLength
+		{tag: 258, typ: tiffShort, count: uint32(nBands), data: shorts(bitsPerSample...)},                 // BitsPerSample
+		{tag: 259, typ: tiffShort, count: 1, data: shorts(1)},                                             // Compression: none
+		{tag: 262, typ: tiffShort, count: 1, data: shorts(1)},                                             // PhotometricInterpretation: black is zero
+		{tag: 270, typ: tiffASCII, data: ascii(d.metadata[0].value)},                                      // ImageDescription
+		{tag: 273, typ: tiffLong, count: uint32(nBands)},                                                  // StripOffsets, set below
+		{tag: 277, typ: tiffShort, count: 1, data: shorts(uint16(nBands))},                                // SamplesPerPixel
+		{tag: 278, typ: tiffLong, count: 1, data: longs(uint32(r.ny))},                                    // RowsPerStrip
+		{tag: 279, typ: tiffLong, count: uint32(nBands), data: longs(stripByteCounts...)},                 // StripByteCounts
+		{tag: 284, typ: tiffShort, count: 1, data: shorts(2)},                                             // PlanarConfiguration: separate bands
+		{tag: 339, typ: tiffShort, count: uint32(nBands), data: shorts(sampleFormat...)},                  // SampleFormat
+		{tag: 33550, typ: tiffDouble, count: 3, data: doubles(r.dx, r.dy, 0)},                             // ModelPixelScale
+		{tag: 33922, typ: tiffDouble, count: 6, data: doubles(0, 0, 0, r.x0, r.y0+float64(r.ny)*r.dy, 0)}, // ModelTiepoint
+		{tag: 34735, typ: tiffShort, count: 16, data: shorts( // GeoKeyDirectory
+			1, 1, 0, 3, // Version 1.1.0 with 3 keys
+			1024, 0, 1, 2, // GTModelTypeGeoKey: geographic
+			1025, 0, 1, 1, // GTRasterTypeGeoKey: pixel is area
+			2048, 0, 1, 4326, // GeographicTypeGeoKey: WGS 84
+		)},
+		{tag: 42112, typ: tiffASCII, data: ascii(gdalMeta.String())}, // GDAL_METADATA
+		{tag: 42113, typ: tiffASCII, data: ascii("nan")},             // GDAL_NODATA
+	}
+	for i := range entries {
+		if entries[i].typ == tiffASCII {
+			entries[i].count = uint32(len(entries[i].data))
+		}
+	}
+
+	// The file consists of the header, the image file directory,
+	// the entry data that doesn't fit in the directory, and
+	// then the image data.
+	const headerSize = 8
+	ifdSize := 2 + 12*len(entries) + 4
+	offset := headerSize + ifdSize
+	for _, e := range entries {
+		if e.tag == 273 {
+			e.data = make([]byte, 4*nBands)
+		}
+		if len(e.data) > 4 {
+			offset += len(e.data) + len(e.data)%2
+		}
+	}
+	stripOffsets := make([]uint32, nBands)
+	for i := range stripOffsets {
+		stripOffsets[i] = uint32(offset + i*8*r.nx*r.ny)
+	}
+	for i := range entries {
+		if entries[i].tag == 273 {
+			entries[i].data = longs(stripOffsets...)
+		}
+	}
+
+	b := new(bytes.Buffer)
+	b.WriteString("II")
+	b.Write(shorts(42))
+	b.Write(longs(headerSize))
+	b.Write(shorts(uint16(len(entries))))
+	extra := new(bytes.Buffer)
+	extraOffset := headerSize + ifdSize
+	for _, e := range entries {
+		b.Write(shorts(e.tag, e.typ))
+		b.Write(longs(e.count))
+		if len(e.data) <= 4 {
+			v := make([]byte, 4)
+			copy(v, e.data)
+			b.Write(v)
+			continue
+		}
+		b.Write(longs(uint32(extraOffset + extra.Len())))
+		extra.Write(e.data)
+		if len(e.data)%2 == 1 {
+			extra.WriteByte(0) // Values must start on word boundaries.
+		}
+	}
+	b.Write(longs(0)) // No more directories.
+	b.Write(extra.Bytes())
+	for _, v := range r.values {
+		// TIFF rows go from north to south.
+		for j := r.ny - 1; j >= 0; j-- {
+			b.Write(doubles(v[j*r.nx : (j+1)*r.nx]...))
+		}
+	}
+	return b.Bytes(), nil
+}
+
+// xmlEscape escapes s for use in XML text and attribute values.
+func xmlEscape(s string) string {
+	var b strings.Builder
+	xmlReplacer.WriteString(&b, s)
+	return b.String()
+}
+
+var xmlReplacer = strings.NewReplacer("&", "&amp;", "<", "&lt;", ">", "&gt;", "\"", "&quot;", "'", "&apos;")
+
+// netCDF returns the receiver as a NetCDF file following the CF
+// conventions. If the grid cells are aligned to a regular raster, the
+// variables have latitude and longitude dimensions; otherwise they have
+// a cell dimension, with the cell corners as coordinate bounds.
+func (d *exportData) netCDF() ([]byte, error) {
+	f, err := ioutil.TempFile("", "cityaq_export*.nc")
+	if err != nil {
+		return nil, err
+	}
+	defer os.Remove(f.Name())
+	defer f.Close()
+
+	fillValue := []float64{math.NaN()}
+	var h *cdf.Header
+	var dims []string
+	var data [][]float64
+	var coords map[string][]float64
+	if r, err := d.raster(); err == nil {
+		dims = []string{"lat", "lon"}
+		h = cdf.NewHeader([]string{"lat", "lon", "nv"}, []int{r.ny, r.nx, 2})
+		coords = make(map[string][]float64)
+		for _, c := range []struct {
+			name     string
+			n        int
+			v0, dv   float64
+			units    string
+			standard string
+		}{
+			{name: "lat", n: r.ny, v0: r.y0, dv: r.dy, units: "degrees_north", standard: "latitude"},
+			{name: "lon", n: r.nx, v0: r.x0, dv: r.dx, units: "degrees_east", standard: "longitude"},
+		} {
+			h.AddVariable(c.name, []string{c.name}, []float64{0})
+			h.AddAttribute(c.name, "units", c.units)
+			h.AddAttribute(c.name, "standard_name", c.standard)
+			h.AddAttribute(c.name, "bounds", c.name+"_bnds")
+			h.AddVariable(c.name+"_bnds", []string{c.name, "nv"}, []float64{0})
+			v := make([]float64, c.n)
+			bnds := make([]float64, 2*c.n)
+			for i := range v {
+				v[i] = c.v0 + (float64(i)+0.5)*c.dv
+				bnds[2*i] = c.v0 + float64(i)*c.dv
+				bnds[2*i+1] = c.v0 + float64(i+1)*c.dv
+			}
+			coords[c.name] = v
+			coords[c.name+"_bnds"] = bnds
+		}
+		data = r.values
+	} else {
+		// Use the largest number of vertices of any cell,
+		// repeating the last vertex of cells with fewer.
+		nv := 0
+		for _, p := range d.polygons {
+			if len(p) > 0 {
+				nv = int(math.Max(float64(nv), float64(len(openRing(p[0])))))
+			}
+		}
+		dims = []string{"cell"}
+		h = cdf.NewHeader([]string{"cell", "nv"}, []int{len(d.polygons), nv})
+		coords = map[string][]float64{
+			"lat": make([]float64, len(d.polygons)), "lon": make([]float64, len(d.polygons)),
+			"lat_bnds": make([]float64, len(d.polygons)*nv), "lon_bnds": make([]float64, len(d.polygons)*nv),
+		}
+		for i, p := range d.polygons {
+			var ring geom.Path
+			if len(p) > 0 {
+				ring = openRing(orientRings(p, true)[0])
+			}
+			c := p.Centroid()
+			coords["lon"][i], coords["lat"][i] = c.X, c.Y
+			for j := 0; j < nv; j++ {
+				pt := geom.Point{X: math.NaN(), Y: math.NaN()}
+				if len(ring) > 0 {
+					pt = ring[int(math.Min(float64(j), float64(len(ring)-1)))]
+				}
+				coords["lon_bnds"][i*nv+j], coords["lat_bnds"][i*nv+j] = pt.X, pt.Y
+			}
+		}
+		for _, c := range []struct{ name, units, standard string }{
+			{name: "lat", units: "degrees_north", standard: "latitude"},
+			{name: "lon", units: "degrees_east", standard: "longitude"},
+		} {
+			h.AddVariable(c.name, []string{"cell"}, []float64{0})
+			h.AddAttribute(c.name, "units", c.units)
+			h.AddAttribute(c.name, "standard_name", c.standard)
+			h.AddAttribute(c.name, "long_name", c.standard+" of cell centroid")
+			h.AddAttribute(c.name, "bounds", c.name+"_bnds")
+			h.AddVariable(c.name+"_bnds", []string{"cell", "nv"}, []float64{0})
+		}
+		for _, f := range d.fields {
+			data = append(data, f.values)
+		}
+	}
+
+	h.AddAttribute("", "Conventions", "CF-1.7")
+	for _, m := range d.metadata {
+		h.AddAttribute("", m.key, m.value)
+	}
+	for _, f := range d.fields {
+		h.AddVariable(f.name, dims, []float64{0})
+		h.AddAttribute(f.name, "long_name", f.longName)
+		h.AddAttribute(f.name, "units", f.units)
+		h.AddAttribute(f.name, "_FillValue", fillValue)
+		if len(dims) == 1 {
+			h.AddAttribute(f.name, "coordinates", "lat lon")
+		}
+		if f.extensive {
+			h.AddAttribute(f.name, "cell_methods", "area: sum")
+		} else {
+			h.AddAttribute(f.name, "cell_methods", "area: mean")
+		}
+	}
+	h.Define()
+	if errs := h.Check(); len(errs) > 0 {
+		return nil, fmt.Errorf("cityaq: creating NetCDF file: %v", errs[0])
+	}
+	cf, err := cdf.Create(f, h)
+	if err != nil {
+		return nil, fmt.Errorf("cityaq: creating NetCDF file: %v", err)
+	}
+	write := func(name string, v []float64) error {
+		if _, err := cf.Writer(name, nil, nil).Write(v); err != nil {
+			return fmt.Errorf("cityaq: writing NetCDF variable %s: %v", name, err)
+		}
+		return nil
+	}
+	for name, v := range coords {
+		if err := write(name, v); err != nil {
+			return nil, err
+		}
+	}
+	for i, f := range d.fields {
+		if err := write(f.name, data[i]); err != nil {
+			return nil, err
+		}
+	}
+	if err := f.Close(); err != nil {
+		return nil, err
+	}
+	return ioutil.ReadFile(f.Name())
+}
+
+// openRing returns ring without its closing point.
+func openRing(ring geom.Path) geom.Path {
+	if len(ring) > 1 && ring[0] == ring[len(ring)-1] {
+		return ring[:len(ring)-1]
+	}
+	return ring
+}
+
+// ExportServer serves gridded data as files to download.
+type ExportServer struct {
+	c *CityAQ
+}
+
+// NewExportServer creates a new export server for c.
+func NewExportServer(c *CityAQ) *ExportServer {
+	return &ExportServer{c: c}
+}
+
+func (s *ExportServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	req, err := parseExportRequest(r.URL)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if err := s.c.checkExportRequest(req); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	resp, err := s.c.ExportGridded(r.Context(), req)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", resp.ContentType)
+	w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", resp.FileName))
+	w.Write(resp.Data)
+}
+
+// exportPeriod checks the emissions amounts, periods, and pollutants
+// of req, and returns the emissions period of the requested data, or
+// nil if the data does not depend on emissions.
+func exportPeriod(req *rpc.ExportGriddedRequest) (*emissionsAmount, error) {
+	var (
+		emission       rpc.Emission
+		amount         float64
+		units          rpc.EmissionUnits
+		pollutants     []*rpc.PollutantAmount
+		speciate       bool
+		begin, end     int64
+		simulationType rpc.SimulationType
+		pointSources   []*rpc.PointSource
+	)
+	switch {
+	case req.Emissions != nil:
+		r := req.Emissions
+		emission, amount, units, pollutants, speciate, begin, end = r.Emission, r.Amount, r.AmountUnits, r.Pollutants, r.Speciate, r.Begin, r.End
+		simulationType = r.SimulationType
+	case req.Concentrations != nil:
+		r := req.Concentrations
+		emission, amount, units, pollutants, speciate, begin, end = r.Emission, r.Amount, r.AmountUnits, r.Pollutants, r.Speciate, r.Begin, r.End
+		simulationType, pointSources = r.SimulationType, r.PointSources
+	default:
+		return nil, nil
+	}
+	if err := checkMarginalAmounts(simulationType, amount, begin, end, pollutants, speciate); err != nil {
+		return nil, err
+	}
+	if len(pointSources) > 0 {
+		points, err := requestPointSources(pointSources)
+		if err != nil {
+			return nil, err
+		}
+		if err := checkPointSourceAmounts(points, emission, amount, units, pollutants, speciate); err != nil {
+			return nil, err
+		}
+		return requestAmount(0, 0, begin, end)
+	}
+	if speciate {
+		if len(pollutants) > 0 {
+			return nil, errSpeciatePollutants
+		}
+		return requestAmount(amount, units, begin, end)
+	}
+	amounts, err := requestPollutantAmounts(emission, amount, units, pollutants, begin, end)
+	if err != nil {
+		return nil, err
+	}
+	return amounts[0].emissionsAmount, nil
+}
+
+// checkExportRequest checks the city, source type, simulation type,
+// emission type, and emissions amounts of req, so that problems with them can be reported as
+// problems with the request rather than with retrieving the data.
+// Problems with the server configuration are left to ExportGridded.
+func (c *CityAQ) checkExportRequest(req *rpc.ExportGriddedRequest) error {
+	if _, err := exportPeriod(req); err != nil {
+		return err
+	}
+	var (
+		city, sourceType string
+		emission         rpc.Emission
+		simulationType   rpc.SimulationType
+		checkEmission    bool
+		checkSourceType  bool
+	)
+	switch {
+	case req.Emissions != nil:
+		r := req.Emissions
+		city, sourceType, emission, simulationType = r.CityName, r.SourceType, r.Emission, r.SimulationType
+		checkEmission = len(r.Pollutants) == 0 && !r.Speciate
+		checkSourceType = r.CustomSurrogate == nil && len(r.SourceTypeMix) == 0
+	case req.Concentrations != nil:
+		r := req.Concentrations
+		city, sourceType, emission, simulationType = r.CityName, r.SourceType, r.Emission, r.SimulationType
+		checkEmission = len(r.Pollutants) == 0 && !r.Speciate && len(r.PointSources) == 0
+		checkSourceType = r.CustomSurrogate == nil && len(r.SourceTypeMix) == 0 && len(r.PointSources) == 0
+	case req.Population != nil:
+		r := req.Population
+		city, sourceType, simulationType = r.CityName, r.SourceType, r.SimulationType
+		checkSourceType = r.CustomSurrogate == nil && len(r.SourceTypeMix) == 0 && len(r.PointSources) == 0
+	default:
+		return nil
+	}
+	if _, ok := rpc.SimulationType_name[int32(simulationType)]; !ok {
+		return fmt.Errorf("cityaq: invalid simulation type %s", simulationType)
+	}
+	if checkEmission {
+		if _, ok := rpc.Emission_name[int32(emission)]; !ok || emission == rpc.Emission_UNKNOWN_EMISSION {
+			return fmt.Errorf("cityaq: invalid emission type %s", emission)
+		}
+	}
+	if simulationType != rpc.SimulationType_Total {
+		if _, err := c.catalog().id(city); err != nil {
+			return err
+		}
+	}
+	if !checkSourceType {
+		return nil
+	}
+	switch simulationType {
+	case rpc.SimulationType_CityTotal, rpc.SimulationType_Total:
+		cfg, cfgFile, err := c.inventoryConfig(simulationType)
+		if err != nil {
+			return nil
+		}
+		if _, ok := cfg.GetStringMapStringSlice("aep.InventoryConfig.COARDSFiles")[sourceType]; !ok {
+			return fmt.Errorf("cityaq: emissions inventory sector %q is not in %s", sourceType, cfgFile)
+		}
+	default:
+		if _, err := c.sourceTypes(); err != nil {
+			return nil
+		}
+		if _, err := c.sourceType(sourceType); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// parseExportRequest parses a request of the type
+// xxx?q={emissions|concentrations|population}&f={Format}&c={city}&em={Emission}&st={SourceType}&sit={SimulationType}
+// with optional parameters res={Resolution} and ares={AutoResolution}.
+// Format is one of the names of the ExportFormat values, in any case.
+func parseExportRequest(u *url.URL) (*rpc.ExportGriddedRequest, error) {
+	q := u.Query()
+	o := new(rpc.ExportGriddedRequest)
+	fs, err := queryString(u, q, "f")
+	if err != nil {
+		return nil, err
+	}
+	for name, v := range rpc.ExportFormat_value {
+		if strings.EqualFold(name, fs) && v != int32(rpc.ExportFormat_UNKNOWN_EXPORTFORMAT) {
+			o.Format = rpc.ExportFormat(v)
+		}
+	}
+	if o.Format == rpc.ExportFormat_UNKNOWN_EXPORTFORMAT {
+		return nil, fmt.Errorf("export request invalid value for f: %s", fs)
+	}
+	city, err := queryString(u, q, "c")
+	if err != nil {
+		return nil, err
+	}
+	sourceType, err := queryString(u, q, "st")
+	if err != nil {
+		return nil, err
+	}
+	var emission, simulationType int
+	if q.Get("em") != "" {
+		if emission, err = queryInt(u, q, "em"); err != nil {
+			return nil, err
+		}
+	}
+	if q.Get("sit") != "" {
+		if simulationType, err = queryInt(u, q, "sit"); err != nil {
+			return nil, err
+		}
+	}
+	var resolution float64
+	if v := q.Get("res"); v != "" {
+		resolution, err = strconv.ParseFloat(v, 64)
+		if err != nil {
+			return nil, fmt.Errorf("export request invalid value for res: %s", v)
+		}
+	}
+	var autoResolution bool
+	if v := q.Get("ares"); v != "" {
+		autoResolution, err = strconv.ParseBool(v)
+		if err != nil {
+			return nil, fmt.Errorf("export request invalid value for ares: %s", v)
+		}
+	}
+
+	quantity, err := queryString(u, q, "q")
+	if err != nil {
+		return nil, err
+	}
+	switch strings.ToLower(quantity) {
+	case "emissions":
+		o.Emissions = &rpc.GriddedEmissionsRequest{
+			CityName:       city,
+			SourceType:     sourceType,
+			Emission:       rpc.Emission(emission),
+			SimulationType: rpc.SimulationType(simulationType),
+			Resolution:     resolution,
+			AutoResolution: autoResolution,
+		}
+	case "concentrations":
+		o.Concentrations = &rpc.GriddedConcentrationsRequest{
+			CityName:       city,
+			SourceType:     sourceType,
+			Emission:       rpc.Emission(emission),
+			SimulationType: rpc.SimulationType(simulationType),
+			Resolution:     resolution,
+			AutoResolution: autoResolution,
+		}
+	case "population":
+		o.Population = &rpc.GriddedPopulationRequest{
+			CityName:       city,
+			SourceType:     sourceType,
+			Emission:       rpc.Emission(emission),
+			SimulationType: rpc.SimulationType(simulationType),
+			Resolution:     resolution,
+			AutoResolution: autoResolution,
+		}
+	default:
+		return nil, fmt.Errorf("export request invalid value for q: %s", quantity)
+	}
+	return o, nil
+}
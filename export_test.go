@@ -0,0 +1,656 @@
+package cityaq
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/ctessum/cdf"
+	rpc "github.com/ctessum/cityaq/cityaqrpc"
+	"github.com/ctessum/geom"
+	"github.com/ctessum/geom/encoding/shp"
+)
+
+// testExportData returns a nested grid with a 2x2 degree cell to the west
+// of four 1x1 degree cells.
+func testExportData() *exportData {
+	square := func(x0, y0, d float64) geom.Polygon {
+		return geom.Polygon{{{X: x0, Y: y0}, {X: x0 + d, Y: y0}, {X: x0 + d, Y: y0 + d}, {X: x0, Y: y0 + d}, {X: x0, Y: y0}}}
+	}
+	return &exportData{
+		name: "test",
+		polygons: []geom.Polygon{
+			square(0, 0, 2),
+			square(2, 0, 1), square(3, 0, 1),
+			square(2, 1, 1), square(3, 1, 1),
+		},
+		fields: []exportField{
+			{name: "PM2_5", longName: "PM2.5 emissions", units: "kg", values: []float64{8, 1, 2, 3, 4}, extensive: true},
+			{name: "Conc", longName: "concentration", units: "ug m-3", values: []float64{5, 1, 2, 3, 4}},
+		},
+		metadata: []exportMetadata{{key: "title", value: "test data"}, {key: "city", value: "Testville"}},
+	}
+}
+
+func TestExportData_raster(t *testing.T) {
+	r, err := testExportData().raster()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if r.nx != 4 || r.ny != 2 || r.dx != 1 || r.dy != 1 || r.x0 != 0 || r.y0 != 0 {
+		t.Errorf("raster dimensions: %+v", r)
+	}
+	wantEmis := []float64{2, 2, 1, 2, 2, 2, 3, 4}
+	if !reflect.DeepEqual(r.values[0], wantEmis) {
+		t.Errorf("extensive values: %v != %v", r.values[0], wantEmis)
+	}
+	wantConc := []float64{5, 5, 1, 2, 5, 5, 3, 4}
+	if !reflect.DeepEqual(r.values[1], wantConc) {
+		t.Errorf("intensive values: %v != %v", r.values[1], wantConc)
+	}
+
+	d := testExportData()
+	d.polygons[1] = geom.Polygon{{{X: 2, Y: 0}, {X: 3, Y: 0}, {X: 2.5, Y: 1}, {X: 2, Y: 0}}}
+	if _, err := d.raster(); err == nil {
+		t.Error("expected an error for a grid cell that isn't a rectangle")
+	}
+}
+
+// testTIFF is a decoded baseline TIFF file with a single image
+// of floating point samples stored in separate planes.
+type testTIFF struct {
+	shorts  map[uint16][]uint16
+	longs   map[uint16][]uint32
+	doubles map[uint16][]float64
+	ascii   map[uint16]string
+
+	// bands holds the values of each sample, indexed by
+	// row (from north to south) and then column.
+	bands [][]float64
+}
+
+// decodeTestTIFF decodes a little-endian TIFF file. It only supports
+// the features that are used for GeoTIFF exports, such as uncompressed
+// 64-bit floating point samples stored in one strip per band; the
+// golang.org/x/image/tiff decoder doesn't support floating point samples.
+func decodeTestTIFF(b []byte) (*testTIFF, error) {
+	if len(b) < 8 || string(b[:2]) != "II" || binary.LittleEndian.Uint16(b[2:]) != 42 {
+		return nil, fmt.Errorf("invalid TIFF header")
+	}
+	slice := func(offset, n uint32) ([]byte, error) {
+		if uint64(offset)+uint64(n) > uint64(len(b)) {
+			return nil, fmt.Errorf("offset %d and length %d are outside of the %d byte file", offset, n, len(b))
+		}
+		return b[offset : offset+n], nil
+	}
+	ifd := binary.LittleEndian.Uint32(b[4:])
+	nb, err := slice(ifd, 2)
+	if err != nil {
+		return nil, err
+	}
+	n := uint32(binary.LittleEndian.Uint16(nb))
+	entries, err := slice(ifd+2, 12*n+4)
+	if err != nil {
+		return nil, err
+	}
+	if next := binary.LittleEndian.Uint32(entries[12*n:]); next != 0 {
+		return nil, fmt.Errorf("unexpected second image file directory at %d", next)
+	}
+	t := &testTIFF{
+		shorts:  make(map[uint16][]uint16),
+		longs:   make(map[uint16][]uint32),
+		doubles: make(map[uint16][]float64),
+		ascii:   make(map[uint16]string),
+	}
+	sizes := map[uint16]uint32{tiffASCII: 1, tiffShort: 2, tiffLong: 4, tiffDouble: 8}
+	var prevTag uint16
+	for i := uint32(0); i < n; i++ {
+		e := entries[12*i : 12*(i+1)]
+		tag, typ, count := binary.LittleEndian.Uint16(e), binary.LittleEndian.Uint16(e[2:]), binary.LittleEndian.Uint32(e[4:])
+		if i > 0 && tag <= prevTag {
+			return nil, fmt.Errorf("tag %d is out of order", tag)
+		}
+		prevTag = tag
+		size, ok := sizes[typ]
+		if !ok {
+			return nil, fmt.Errorf("tag %d has unsupported type %d", tag, typ)
+		}
+		data := e[8:12]
+		if size*count > 4 {
+			offset := binary.LittleEndian.Uint32(e[8:])
+			if offset%2 != 0 {
+				return nil, fmt.Errorf("tag %d value offset %d is not on a word boundary", tag, offset)
+			}
+			if data, err = slice(offset, size*count); err != nil {
+				return nil, fmt.Errorf("tag %d: %v", tag, err)
+			}
+		}
+		switch typ {
+		case tiffASCII:
+			if count == 0 || data[count-1] != 0 {
+				return nil, fmt.Errorf("tag %d is not NUL-terminated", tag)
+			}
+			t.ascii[tag] = string(data[:count-1])
+		case tiffShort:
+			for j := uint32(0); j < count; j++ {
+				t.shorts[tag] = append(t.shorts[tag], binary.LittleEndian.Uint16(data[2*j:]))
+			}
+		case tiffLong:
+			for j := uint32(0); j < count; j++ {
+				t.longs[tag] = append(t.longs[tag], binary.LittleEndian.Uint32(data[4*j:]))
+			}
+		case tiffDouble:
+			for j := uint32(0); j < count; j++ {
+				t.doubles[tag] = append(t.doubles[tag], math.Float64frombits(binary.LittleEndian.Uint64(data[8*j:])))
+			}
+		}
+	}
+
+	width, height := t.longs[256], t.longs[257]
+	if len(width) != 1 || len(height) != 1 {
+		return nil, fmt.Errorf("missing image dimensions")
+	}
+	if v := t.shorts[259]; len(v) != 1 || v[0] != 1 {
+		return nil, fmt.Errorf("unsupported compression %v", v)
+	}
+	if v := t.shorts[284]; len(v) != 1 || v[0] != 2 {
+		return nil, fmt.Errorf("unsupported planar configuration %v", v)
+	}
+	if v := t.longs[278]; len(v) != 1 || v[0] != height[0] {
+		return nil, fmt.Errorf("unsupported rows per strip %v", v)
+	}
+	nBands := len(t.longs[273])
+	if spp := t.shorts[277]; len(spp) != 1 || int(spp[0]) != nBands || len(t.longs[279]) != nBands ||
+		len(t.shorts[258]) != nBands || len(t.shorts[339]) != nBands {
+		return nil, fmt.Errorf("inconsistent number of samples per pixel")
+	}
+	nValues := width[0] * height[0]
+	for i := 0; i < nBands; i++ {
+		if t.shorts[258][i] != 64 || t.shorts[339][i] != 3 {
+			return nil, fmt.Errorf("band %d: unsupported sample type", i)
+		}
+		if t.longs[279][i] != 8*nValues {
+			return nil, fmt.Errorf("band %d: strip has %d bytes, want %d", i, t.longs[279][i], 8*nValues)
+		}
+		strip, err := slice(t.longs[273][i], 8*nValues)
+		if err != nil {
+			return nil, fmt.Errorf("band %d: %v", i, err)
+		}
+		band := make([]float64, nValues)
+		for j := range band {
+			band[j] = math.Float64frombits(binary.LittleEndian.Uint64(strip[8*j:]))
+		}
+		t.bands = append(t.bands, band)
+	}
+	return t, nil
+}
+
+func TestExportData_geoTIFF(t *testing.T) {
+	b, err := testExportData().geoTIFF()
+	if err != nil {
+		t.Fatal(err)
+	}
+	tiff, err := decodeTestTIFF(b)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if w, h := tiff.longs[256][0], tiff.longs[257][0]; w != 4 || h != 2 {
+		t.Errorf("dimensions: %dx%d != 4x2", w, h)
+	}
+	if v := tiff.ascii[270]; v != "test data" {
+		t.Errorf("image description: %q", v)
+	}
+	// The cells are 1 degree square.
+	if v, want := tiff.doubles[33550], []float64{1, 1, 0}; !reflect.DeepEqual(v, want) {
+		t.Errorf("pixel scale: %v != %v", v, want)
+	}
+	// The north-west corner of the first pixel is at (0, 2).
+	if v, want := tiff.doubles[33922], []float64{0, 0, 0, 0, 2, 0}; !reflect.DeepEqual(v, want) {
+		t.Errorf("tiepoint: %v != %v", v, want)
+	}
+	wantKeys := []uint16{1, 1, 0, 3, 1024, 0, 1, 2, 1025, 0, 1, 1, 2048, 0, 1, 4326}
+	if v := tiff.shorts[34735]; !reflect.DeepEqual(v, wantKeys) {
+		t.Errorf("geo keys: %v != %v", v, wantKeys)
+	}
+	if v := tiff.ascii[42113]; v != "nan" {
+		t.Errorf("no data value: %q", v)
+	}
+	if !strings.Contains(tiff.ascii[42112], `<Item name="units" sample="1" role="unittype">ug m-3</Item>`) {
+		t.Error("missing units metadata")
+	}
+
+	// Rows go from north to south.
+	wantBands := [][]float64{
+		{2, 2, 3, 4, 2, 2, 1, 2},
+		{5, 5, 3, 4, 5, 5, 1, 2},
+	}
+	if !reflect.DeepEqual(tiff.bands, wantBands) {
+		t.Errorf("bands: %v != %v", tiff.bands, wantBands)
+	}
+}
+
+func TestExportData_csv(t *testing.T) {
+	b, err := testExportData().csv()
+	if err != nil {
+		t.Fatal(err)
+	}
+	lines := strings.Split(strings.TrimSpace(string(b)), "\n")
+	want := []string{
+		"# title: test data",
+		"# city: Testville",
+		"WKT,PM2_5 (kg),Conc (ug m-3)",
+		`"POLYGON ((0 0, 2 0, 2 2, 0 2, 0 0))",8,5`,
+	}
+	if len(lines) != 8 {
+		t.Fatalf("wrong number of lines: %d", len(lines))
+	}
+	if !reflect.DeepEqual(lines[:4], want) {
+		t.Errorf("%q != %q", lines[:4], want)
+	}
+}
+
+func TestExportData_geoJSON(t *testing.T) {
+	d := testExportData()
+	d.fields[1].values[2] = math.NaN()
+	b, err := d.geoJSON()
+	if err != nil {
+		t.Fatal(err)
+	}
+	var fc struct {
+		Type     string
+		Metadata map[string]string
+		Features []struct {
+			Geometry struct {
+				Coordinates [][][2]float64
+			}
+			Properties map[string]float64
+		}
+	}
+	if err := json.Unmarshal(b, &fc); err != nil {
+		t.Fatal(err)
+	}
+	if fc.Type != "FeatureCollection" || len(fc.Features) != 5 {
+		t.Fatalf("invalid feature collection: %s", b)
+	}
+	if fc.Metadata["Conc_units"] != "ug m-3" || fc.Metadata["city"] != "Testville" {
+		t.Errorf("missing metadata: %v", fc.Metadata)
+	}
+	if _, ok := fc.Features[2].Properties["Conc"]; ok {
+		t.Error("NaN values should be left out")
+	}
+	ring := make(geom.Path, 0)
+	for _, c := range fc.Features[0].Geometry.Coordinates[0] {
+		ring = append(ring, geom.Point{X: c[0], Y: c[1]})
+	}
+	if planarArea(ring) <= 0 {
+		t.Error("exterior rings should be counterclockwise")
+	}
+}
+
+func TestExportData_netCDF(t *testing.T) {
+	read := func(t *testing.T, d *exportData) *cdf.File {
+		b, err := d.netCDF()
+		if err != nil {
+			t.Fatal(err)
+		}
+		nc, err := cdf.Open(bytes.NewReader(b))
+		if err != nil {
+			t.Fatal(err)
+		}
+		return nc
+	}
+	attr := func(t *testing.T, nc *cdf.File, v, a string, want interface{}) {
+		t.Helper()
+		if have := nc.Header.GetAttribute(v, a); !reflect.DeepEqual(have, want) {
+			t.Errorf("%s attribute %s: %v != %v", v, a, have, want)
+		}
+	}
+	values := func(t *testing.T, nc *cdf.File, v string, want []float64) {
+		t.Helper()
+		have, err := readCOARDSVar(nc, v)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !reflect.DeepEqual(have, want) {
+			t.Errorf("%s: %v != %v", v, have, want)
+		}
+	}
+	// checkCF checks the attributes that the CF conventions require for
+	// the coordinates and fields.
+	checkCF := func(t *testing.T, nc *cdf.File, dims []string) {
+		t.Helper()
+		attr(t, nc, "", "Conventions", "CF-1.7")
+		attr(t, nc, "", "title", "test data")
+		attr(t, nc, "", "city", "Testville")
+		for _, c := range []struct{ name, units, standard string }{
+			{name: "lat", units: "degrees_north", standard: "latitude"},
+			{name: "lon", units: "degrees_east", standard: "longitude"},
+		} {
+			attr(t, nc, c.name, "units", c.units)
+			attr(t, nc, c.name, "standard_name", c.standard)
+			attr(t, nc, c.name, "bounds", c.name+"_bnds")
+			if have, want := nc.Header.Dimensions(c.name+"_bnds"), append(nc.Header.Dimensions(c.name), "nv"); !reflect.DeepEqual(have, want) {
+				t.Errorf("%s_bnds dimensions: %v != %v", c.name, have, want)
+			}
+		}
+		for _, f := range testExportData().fields {
+			if have := nc.Header.Dimensions(f.name); !reflect.DeepEqual(have, dims) {
+				t.Errorf("%s dimensions: %v != %v", f.name, have, dims)
+			}
+			attr(t, nc, f.name, "units", f.units)
+			attr(t, nc, f.name, "long_name", f.longName)
+			fill, ok := nc.Header.GetAttribute(f.name, "_FillValue").([]float64)
+			if !ok || len(fill) != 1 || !math.IsNaN(fill[0]) {
+				t.Errorf("%s _FillValue: %v", f.name, nc.Header.GetAttribute(f.name, "_FillValue"))
+			}
+		}
+		attr(t, nc, "PM2_5", "cell_methods", "area: sum")
+		attr(t, nc, "Conc", "cell_methods", "area: mean")
+	}
+
+	t.Run("raster", func(t *testing.T) {
+		nc := read(t, testExportData())
+		checkCF(t, nc, []string{"lat", "lon"})
+		if have := nc.Header.Lengths("PM2_5"); !reflect.DeepEqual(have, []int{2, 4}) {
+			t.Errorf("lengths: %v", have)
+		}
+		values(t, nc, "lat", []float64{0.5, 1.5})
+		values(t, nc, "lon", []float64{0.5, 1.5, 2.5, 3.5})
+		values(t, nc, "lat_bnds", []float64{0, 1, 1, 2})
+		values(t, nc, "lon_bnds", []float64{0, 1, 1, 2, 2, 3, 3, 4})
+		values(t, nc, "PM2_5", []float64{2, 2, 1, 2, 2, 2, 3, 4})
+		values(t, nc, "Conc", []float64{5, 5, 1, 2, 5, 5, 3, 4})
+	})
+
+	t.Run("cells", func(t *testing.T) {
+		d := testExportData()
+		d.polygons[1] = geom.Polygon{{{X: 2, Y: 0}, {X: 3, Y: 0}, {X: 2.5, Y: 1}, {X: 2, Y: 0}}}
+		nc := read(t, d)
+		checkCF(t, nc, []string{"cell"})
+		if have := nc.Header.Lengths("lon_bnds"); !reflect.DeepEqual(have, []int{5, 4}) {
+			t.Errorf("lengths: %v", have)
+		}
+		attr(t, nc, "PM2_5", "coordinates", "lat lon")
+		attr(t, nc, "Conc", "coordinates", "lat lon")
+		lon, err := readCOARDSVar(nc, "lon")
+		if err != nil {
+			t.Fatal(err)
+		}
+		lat, err := readCOARDSVar(nc, "lat")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if lon[0] != 1 || lat[0] != 1 {
+			t.Errorf("centroid of the first cell: (%g, %g) != (1, 1)", lon[0], lat[0])
+		}
+		// The triangle's last vertex is repeated to fill its bounds.
+		lonBnds, err := readCOARDSVar(nc, "lon_bnds")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if want := []float64{2, 3, 2.5, 2.5}; !reflect.DeepEqual(lonBnds[4:8], want) {
+			t.Errorf("triangle bounds: %v != %v", lonBnds[4:8], want)
+		}
+		values(t, nc, "PM2_5", []float64{8, 1, 2, 3, 4})
+		values(t, nc, "Conc", []float64{5, 1, 2, 3, 4})
+	})
+}
+
+func TestExportData_zippedShapefile(t *testing.T) {
+	d := testExportData()
+	d.fields[1].name = "Concentration"
+	b, err := d.zippedShapefile()
+	if err != nil {
+		t.Fatal(err)
+	}
+	z, err := zip.NewReader(bytes.NewReader(b), int64(len(b)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	dir, err := ioutil.TempDir("", "cityaq_export_test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	var names []string
+	for _, f := range z.File {
+		names = append(names, f.Name)
+		r, err := f.Open()
+		if err != nil {
+			t.Fatal(err)
+		}
+		data, err := ioutil.ReadAll(r)
+		r.Close()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := ioutil.WriteFile(filepath.Join(dir, f.Name), data, 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	wantNames := []string{"test.dbf", "test.prj", "test.shp", "test.shx", "test.txt"}
+	if !reflect.DeepEqual(names, wantNames) {
+		t.Fatalf("files: %v != %v", names, wantNames)
+	}
+
+	meta, err := ioutil.ReadFile(filepath.Join(dir, "test.txt"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{"title: test data", "PM2_5: PM2.5 emissions [kg]", "Concentrat: concentration [ug m-3]"} {
+		if !strings.Contains(string(meta), want) {
+			t.Errorf("metadata is missing %q:\n%s", want, meta)
+		}
+	}
+
+	p, err := shapefileGeometry(filepath.Join(dir, "test.shp"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(p) != len(d.polygons) {
+		t.Fatalf("wrong number of polygons: %d", len(p))
+	}
+	if a := p[0].Area(); a != 4 {
+		t.Errorf("area of the first cell: %g != 4", a)
+	}
+
+	dec, err := shp.NewDecoder(filepath.Join(dir, "test.shp"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer dec.Close()
+	var pm, conc []float64
+	for {
+		_, fields, more := dec.DecodeRowFields("PM2_5", "Concentrat")
+		if !more {
+			break
+		}
+		for _, v := range []struct {
+			name string
+			o    *[]float64
+		}{{"PM2_5", &pm}, {"Concentrat", &conc}} {
+			x, err := strconv.ParseFloat(strings.TrimSpace(fields[v.name]), 64)
+			if err != nil {
+				t.Fatal(err)
+			}
+			*v.o = append(*v.o, x)
+		}
+	}
+	if err := dec.Error(); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(pm, d.fields[0].values) {
+		t.Errorf("PM2_5: %v != %v", pm, d.fields[0].values)
+	}
+	if !reflect.DeepEqual(conc, d.fields[1].values) {
+		t.Errorf("Concentrat: %v != %v", conc, d.fields[1].values)
+	}
+}
+
+func TestParseExportRequest(t *testing.T) {
+	u, err := url.Parse("https://example.com/download?q=concentrations&f=geotiff&c=Accra%20Metropolitan&em=1&st=roadways&sit=2&res=0.01")
+	if err != nil {
+		t.Fatal(err)
+	}
+	req, err := parseExportRequest(u)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := &rpc.ExportGriddedRequest{
+		Format: rpc.ExportFormat_GeoTIFF,
+		Concentrations: &rpc.GriddedConcentrationsRequest{
+			CityName:       "Accra Metropolitan",
+			SourceType:     "roadways",
+			Emission:       rpc.Emission(1),
+			SimulationType: rpc.SimulationType(2),
+			Resolution:     0.01,
+		},
+	}
+	if !reflect.DeepEqual(req, want) {
+		t.Errorf("%+v != %+v", req, want)
+	}
+
+	for _, q := range []string{"q=emissions&f=tiff&c=a&st=b", "q=pm&f=csv&c=a&st=b", "q=emissions&f=csv&st=b"} {
+		u, err := url.Parse("https://example.com/download?" + q)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := parseExportRequest(u); err == nil {
+			t.Errorf("%s: expected an error", q)
+		}
+	}
+}
+
+func TestExportGridded_invalid(t *testing.T) {
+	c := new(CityAQ)
+	_, err := c.ExportGridded(context.Background(), &rpc.ExportGriddedRequest{
+		Format:     rpc.ExportFormat_CSV,
+		Emissions:  &rpc.GriddedEmissionsRequest{},
+		Population: &rpc.GriddedPopulationRequest{},
+	})
+	if err == nil {
+		t.Error("expected an error when more than one quantity is requested")
+	}
+	_, err = c.ExportGridded(context.Background(), &rpc.ExportGriddedRequest{
+		Population: &rpc.GriddedPopulationRequest{},
+	})
+	if err == nil {
+		t.Error("expected an error for a missing format")
+	}
+}
+
+func TestConcentrationField(t *testing.T) {
+	for _, test := range []struct {
+		req  *rpc.GriddedConcentrationsRequest
+		name string
+	}{
+		{req: &rpc.GriddedConcentrationsRequest{Emission: rpc.Emission_PM2_5}, name: "PrimaryPM2_5"},
+		{req: &rpc.GriddedConcentrationsRequest{Emission: rpc.Emission_NOx}, name: "pNO3"},
+		{req: &rpc.GriddedConcentrationsRequest{Emission: rpc.Emission_VOC}, name: "SOA"},
+		{req: &rpc.GriddedConcentrationsRequest{Emission: rpc.Emission_NOx, Speciate: true}, name: "TotalPM2_5"},
+		{
+			req: &rpc.GriddedConcentrationsRequest{Pollutants: []*rpc.PollutantAmount{
+				{Emission: rpc.Emission_NOx}, {Emission: rpc.Emission_SOx},
+			}},
+			name: "TotalPM2_5",
+		},
+	} {
+		f, err := concentrationField(test.req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if f.name != test.name || f.units != "ug m-3" {
+			t.Errorf("%v: field %s, want %s", test.req, f.name, test.name)
+		}
+	}
+	if _, err := concentrationField(&rpc.GriddedConcentrationsRequest{}); err == nil {
+		t.Error("expected an error for a missing emission type")
+	}
+}
+
+func TestExportServer_requestErrors(t *testing.T) {
+	s := NewExportServer(&CityAQ{CityGeomDir: "testdata/cities"})
+	for _, q := range []string{
+		"q=emissions&f=csv&c=Atlantis&em=1&st=roadways&sit=3",
+		"q=emissions&f=csv&c=Accra%20Metropolitan&em=1&st=roadway&sit=3",
+		"q=emissions&f=csv&c=Accra%20Metropolitan&em=9&st=roadways&sit=3",
+		"q=concentrations&f=csv&c=Accra%20Metropolitan&em=1&st=roadways&sit=7",
+		"q=emissions&f=tiff&c=Accra%20Metropolitan&em=1&st=roadways&sit=3",
+	} {
+		w := httptest.NewRecorder()
+		s.ServeHTTP(w, httptest.NewRequest("GET", "/export?"+q, nil))
+		if w.Code != http.StatusBadRequest {
+			t.Errorf("%s: status %d, want %d", q, w.Code, http.StatusBadRequest)
+		}
+	}
+}
+
+func TestCityAQ_checkExportRequest_amounts(t *testing.T) {
+	c := &CityAQ{CityGeomDir: "testdata/cities"}
+	emissions := func(modify func(*rpc.GriddedEmissionsRequest)) *rpc.ExportGriddedRequest {
+		r := &rpc.GriddedEmissionsRequest{
+			CityName:       "Accra Metropolitan",
+			SourceType:     "roadways",
+			Emission:       rpc.Emission_PM2_5,
+			SimulationType: rpc.SimulationType_CityMarginal,
+			Amount:         10,
+			AmountUnits:    rpc.EmissionUnits_Tonnes,
+		}
+		modify(r)
+		return &rpc.ExportGriddedRequest{Format: rpc.ExportFormat_CSV, Emissions: r}
+	}
+	if err := c.checkExportRequest(emissions(func(*rpc.GriddedEmissionsRequest) {})); err != nil {
+		t.Fatal(err)
+	}
+	for _, test := range []struct {
+		name string
+		req  *rpc.ExportGriddedRequest
+	}{
+		{name: "negative amount", req: emissions(func(r *rpc.GriddedEmissionsRequest) { r.Amount = -1 })},
+		{name: "no units", req: emissions(func(r *rpc.GriddedEmissionsRequest) { r.AmountUnits = 0 })},
+		{name: "backwards period", req: emissions(func(r *rpc.GriddedEmissionsRequest) { r.Begin, r.End = 10, 5 })},
+		{name: "inventory amount", req: emissions(func(r *rpc.GriddedEmissionsRequest) { r.SimulationType = rpc.SimulationType_CityTotal })},
+		{
+			name: "repeated pollutant",
+			req: emissions(func(r *rpc.GriddedEmissionsRequest) {
+				r.Pollutants = []*rpc.PollutantAmount{{Emission: rpc.Emission_NOx}, {Emission: rpc.Emission_NOx}}
+			}),
+		},
+		{
+			name: "speciated pollutants",
+			req: emissions(func(r *rpc.GriddedEmissionsRequest) {
+				r.Speciate = true
+				r.Pollutants = []*rpc.PollutantAmount{{Emission: rpc.Emission_NOx}}
+			}),
+		},
+		{
+			name: "point source amount",
+			req: &rpc.ExportGriddedRequest{Format: rpc.ExportFormat_CSV, Concentrations: &rpc.GriddedConcentrationsRequest{
+				CityName:       "Accra Metropolitan",
+				SimulationType: rpc.SimulationType_CityMarginal,
+				PointSources:   []*rpc.PointSource{testPointSource()},
+				Amount:         1,
+			}},
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			if err := c.checkExportRequest(test.req); err == nil {
+				t.Error("expected an error")
+			}
+		})
+	}
+}
@@ -3,10 +3,12 @@ module github.com/ctessum/cityaq
 go 1.13
 
 require (
+	github.com/BurntSushi/toml v0.3.1
 	github.com/andybalholm/brotli v0.0.0-20190821151343-b60f0d972eeb
 	github.com/cenkalti/backoff v2.2.1+incompatible
 	github.com/coreos/bbolt v1.3.1-coreos.6 // indirect
 	github.com/coreos/etcd v3.3.10+incompatible // indirect
+	github.com/ctessum/cdf v0.0.0-20181201011353-edced208ea9d
 	github.com/ctessum/geom v0.2.10
 	github.com/ctessum/requestcache/v4 v4.0.0
 	github.com/ctessum/sparse v0.0.0-20181201011727-57d6234a2c9d
@@ -19,12 +21,16 @@ require (
 	github.com/gonum/floats v0.0.0-20181209220543-c233463c7e82
 	github.com/improbable-eng/grpc-web v0.11.0
 	github.com/johanbrandhorst/grpc-wasm v0.0.0-20180613181153-d79a93c3901e
+	github.com/jonas-p/go-shp v0.1.2-0.20190401125246-9fd306ae10a6
 	github.com/lpar/gzipped v1.1.0
+	github.com/mattn/go-sqlite3 v1.11.0
 	github.com/mwitkow/go-conntrack v0.0.0-20190716064945-2f068394615f // indirect
 	github.com/paulmach/orb v0.1.6
+	github.com/paulmach/osm v0.1.1
 	github.com/rs/cors v1.7.0 // indirect
 	github.com/sirupsen/logrus v1.8.1
 	github.com/spatialmodel/inmap v1.9.3
+	gocloud.dev v0.23.0
 	golang.org/x/net v0.0.0-20210505214959-0714010a04ed
 	gonum.org/v1/gonum v0.0.0-20191009222026-5d5638e6749a
 	gonum.org/v1/plot v0.0.0-20190615073203-9aa86143727f
@@ -0,0 +1,295 @@
+package cityaq
+
+import (
+	"bytes"
+	"database/sql"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/ctessum/geom"
+	"github.com/ctessum/geom/proj"
+)
+
+// gpkgLayer holds information about a GeoPackage feature table.
+type gpkgLayer struct {
+	table, column string
+	sr            *proj.SR
+}
+
+// openGPKG opens a GeoPackage and returns its feature tables,
+// sorted by name.
+func openGPKG(path string) (*sql.DB, []gpkgLayer, error) {
+	if gpkgDriver == "" {
+		return nil, nil, fmt.Errorf("file %s: GeoPackages can't be read because cityaq was built without cgo", path)
+	}
+	db, err := sql.Open(gpkgDriver, "file:"+path+"?mode=ro")
+	if err != nil {
+		return nil, nil, fmt.Errorf("file %s: %v", path, err)
+	}
+	rows, err := db.Query(`SELECT g.table_name, g.column_name, s.srs_id, s.organization,
+		s.organization_coordsys_id, s.definition
+		FROM gpkg_geometry_columns g JOIN gpkg_spatial_ref_sys s ON g.srs_id = s.srs_id
+		ORDER BY g.table_name`)
+	if err != nil {
+		db.Close()
+		return nil, nil, fmt.Errorf("file %s: %v", path, err)
+	}
+	defer rows.Close()
+	var layers []gpkgLayer
+	for rows.Next() {
+		var l gpkgLayer
+		var srsID, orgID int
+		var org, def string
+		if err := rows.Scan(&l.table, &l.column, &srsID, &org, &orgID, &def); err != nil {
+			db.Close()
+			return nil, nil, fmt.Errorf("file %s: %v", path, err)
+		}
+		if l.sr, err = gpkgSR(srsID, org, orgID, def); err != nil {
+			db.Close()
+			return nil, nil, fmt.Errorf("file %s: table %s: %v", path, l.table, err)
+		}
+		layers = append(layers, l)
+	}
+	if err := rows.Err(); err != nil {
+		db.Close()
+		return nil, nil, fmt.Errorf("file %s: %v", path, err)
+	}
+	if len(layers) == 0 {
+		db.Close()
+		return nil, nil, fmt.Errorf("file %s: no feature tables", path)
+	}
+	return db, layers, nil
+}
+
+// gpkgSR returns the spatial reference for a GeoPackage spatial
+// reference system, or nil if it is longitude and latitude.
+func gpkgSR(srsID int, org string, orgID int, def string) (*proj.SR, error) {
+	if srsID == 0 || srsID == -1 { // Undefined geographic or Cartesian system.
+		return nil, nil
+	}
+	if strings.EqualFold(org, "EPSG") {
+		if sr, err := crsSR("EPSG:" + strconv.Itoa(orgID)); err == nil {
+			return sr, nil
+		}
+	}
+	if def == "" || strings.EqualFold(def, "undefined") {
+		return nil, fmt.Errorf("undefined spatial reference system %s:%d", org, orgID)
+	}
+	return proj.Parse(def)
+}
+
+// quoteIdent quotes an SQL identifier.
+func quoteIdent(s string) string {
+	return `"` + strings.Replace(s, `"`, `""`, -1) + `"`
+}
+
+// gpkgProperties returns the attributes of the first
+// feature in a GeoPackage.
+func gpkgProperties(path string) (map[string]interface{}, error) {
+	db, layers, err := openGPKG(path)
+	if err != nil {
+		return nil, err
+	}
+	defer db.Close()
+	l := layers[0]
+	rows, err := db.Query("SELECT * FROM " + quoteIdent(l.table) + " LIMIT 1")
+	if err != nil {
+		return nil, fmt.Errorf("file %s: %v", path, err)
+	}
+	defer rows.Close()
+	cols, err := rows.Columns()
+	if err != nil {
+		return nil, fmt.Errorf("file %s: %v", path, err)
+	}
+	if !rows.Next() {
+		if err := rows.Err(); err != nil {
+			return nil, fmt.Errorf("file %s: %v", path, err)
+		}
+		return nil, fmt.Errorf("file %s: couldn't find feature properties", path)
+	}
+	vals := make([]interface{}, len(cols))
+	ptrs := make([]interface{}, len(cols))
+	for i := range vals {
+		ptrs[i] = &vals[i]
+	}
+	if err := rows.Scan(ptrs...); err != nil {
+		return nil, fmt.Errorf("file %s: %v", path, err)
+	}
+	props := make(map[string]interface{}, len(cols))
+	for i, col := range cols {
+		if col == l.column {
+			continue
+		}
+		switch v := vals[i].(type) {
+		case []byte:
+			props[col] = string(v)
+		case nil:
+		default:
+			props[col] = v
+		}
+	}
+	return props, nil
+}
+
+// gpkgGeometry returns the boundary in a GeoPackage, combining the
+// features in all of its feature tables. Features in projected
+// spatial reference systems are converted to longitude and latitude.
+func gpkgGeometry(path string) (geom.MultiPolygon, error) {
+	db, layers, err := openGPKG(path)
+	if err != nil {
+		return nil, err
+	}
+	defer db.Close()
+	var polys geom.MultiPolygon
+	for _, l := range layers {
+		p, err := gpkgLayerGeometry(db, l)
+		if err != nil {
+			return nil, fmt.Errorf("file %s: table %s: %v", path, l.table, err)
+		}
+		polys = append(polys, p...)
+	}
+	return polys, nil
+}
+
+func gpkgLayerGeometry(db *sql.DB, l gpkgLayer) (geom.MultiPolygon, error) {
+	rows, err := db.Query("SELECT " + quoteIdent(l.column) + " FROM " + quoteIdent(l.table))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var polys geom.MultiPolygon
+	for rows.Next() {
+		var b []byte
+		if err := rows.Scan(&b); err != nil {
+			return nil, err
+		}
+		if b == nil {
+			continue
+		}
+		g, err := decodeGPKGGeometry(b)
+		if err != nil {
+			return nil, err
+		}
+		polys = appendPolygons(polys, g)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return toLonLat(polys, l.sr)
+}
+
+// decodeGPKGGeometry decodes a GeoPackage geometry blob, which
+// is a header followed by a Well-Known Binary geometry.
+func decodeGPKGGeometry(b []byte) (geom.Geom, error) {
+	if len(b) < 8 || b[0] != 'G' || b[1] != 'P' {
+		return nil, fmt.Errorf("invalid GeoPackage geometry")
+	}
+	flags := b[3]
+	if flags&0x10 != 0 { // Empty geometry.
+		return nil, nil
+	}
+	envelopeSizes := []int{0, 32, 48, 48, 64}
+	envelope := int(flags>>1) & 0x7
+	if envelope >= len(envelopeSizes) {
+		return nil, fmt.Errorf("invalid GeoPackage geometry envelope type %d", envelope)
+	}
+	n := 8 + envelopeSizes[envelope]
+	if len(b) < n {
+		return nil, fmt.Errorf("invalid GeoPackage geometry")
+	}
+	return decodeWKB(bytes.NewReader(b[n:]))
+}
+
+// decodeWKB decodes a Well-Known Binary Polygon or MultiPolygon.
+// Z and M values are discarded. Other geometry types are
+// returned as nil.
+func decodeWKB(r io.Reader) (geom.Geom, error) {
+	var order [1]byte
+	if _, err := io.ReadFull(r, order[:]); err != nil {
+		return nil, err
+	}
+	var bo binary.ByteOrder = binary.LittleEndian
+	if order[0] == 0 {
+		bo = binary.BigEndian
+	}
+	var typ uint32
+	if err := binary.Read(r, bo, &typ); err != nil {
+		return nil, err
+	}
+	// Handle both ISO (e.g. 1003) and extended (e.g. 0x80000003)
+	// WKB encodings of Z and M dimensions.
+	dims := 2
+	if typ&0x80000000 != 0 {
+		dims++
+	}
+	if typ&0x40000000 != 0 {
+		dims++
+	}
+	if typ&0x20000000 != 0 { // Embedded SRID.
+		var srid uint32
+		if err := binary.Read(r, bo, &srid); err != nil {
+			return nil, err
+		}
+	}
+	typ &= 0x0fffffff
+	switch typ / 1000 {
+	case 1, 2:
+		dims++
+	case 3:
+		dims += 2
+	}
+	switch typ % 1000 {
+	case 3:
+		return decodeWKBPolygon(r, bo, dims)
+	case 6:
+		var n uint32
+		if err := binary.Read(r, bo, &n); err != nil {
+			return nil, err
+		}
+		mp := make(geom.MultiPolygon, 0, n)
+		for i := uint32(0); i < n; i++ {
+			g, err := decodeWKB(r)
+			if err != nil {
+				return nil, err
+			}
+			p, ok := g.(geom.Polygon)
+			if !ok {
+				return nil, fmt.Errorf("invalid WKB MultiPolygon member")
+			}
+			mp = append(mp, p)
+		}
+		return mp, nil
+	default:
+		return nil, nil
+	}
+}
+
+func decodeWKBPolygon(r io.Reader, bo binary.ByteOrder, dims int) (geom.Polygon, error) {
+	var nRings uint32
+	if err := binary.Read(r, bo, &nRings); err != nil {
+		return nil, err
+	}
+	p := make(geom.Polygon, nRings)
+	coords := make([]float64, dims)
+	for i := range p {
+		var nPoints uint32
+		if err := binary.Read(r, bo, &nPoints); err != nil {
+			return nil, err
+		}
+		p[i] = make(geom.Path, nPoints)
+		for j := range p[i] {
+			if err := binary.Read(r, bo, coords); err != nil {
+				return nil, err
+			}
+			if math.IsNaN(coords[0]) || math.IsNaN(coords[1]) {
+				return nil, fmt.Errorf("invalid WKB coordinates")
+			}
+			p[i][j] = geom.Point{X: coords[0], Y: coords[1]}
+		}
+	}
+	return p, nil
+}
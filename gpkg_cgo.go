@@ -0,0 +1,10 @@
+// +build cgo
+
+package cityaq
+
+import (
+	_ "github.com/mattn/go-sqlite3" // Register the SQLite database driver for GeoPackages.
+)
+
+// gpkgDriver is the name of the database driver used to read GeoPackages.
+const gpkgDriver = "sqlite3"
@@ -0,0 +1,7 @@
+// +build !cgo
+
+package cityaq
+
+// gpkgDriver is empty because the SQLite database driver requires cgo,
+// so GeoPackages can't be read.
+const gpkgDriver = ""
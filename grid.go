@@ -0,0 +1,276 @@
+package cityaq
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/ctessum/geom"
+	"github.com/ctessum/geom/proj"
+)
+
+// Projections that emissions grids can be built in.
+const (
+	// lonLatProjection grids have square cells in degrees of
+	// longitude and latitude.
+	lonLatProjection = "lonlat"
+
+	// utmProjection grids have square cells in the UTM
+	// zone that contains the center of the grid.
+	utmProjection = "utm"
+
+	// equalAreaProjection grids have square cells in a Lambert
+	// azimuthal equal-area projection centered on the grid.
+	equalAreaProjection = "equalarea"
+)
+
+// defaultCellSize is the default emissions grid cell edge length
+// for each allocation domain in projected grids, in meters.
+var defaultCellSize = map[string]float64{
+	cityDomain:    500,
+	egugridDomain: 10000,
+}
+
+// gridProjection returns the projection that emissions grids
+// are built in.
+func (c *CityAQ) gridProjection() (string, error) {
+	switch c.GridProjection {
+	case "", lonLatProjection:
+		return lonLatProjection, nil
+	case utmProjection, equalAreaProjection:
+		return c.GridProjection, nil
+	default:
+		return "", fmt.Errorf("cityaq: invalid grid projection %q; it must be %q, %q, or %q",
+			c.GridProjection, lonLatProjection, utmProjection, equalAreaProjection)
+	}
+}
+
+// gridName returns a name for the emissions grid of the given city and
+// source type that is unique to the version of the city boundary and
+// to the projection, resolution, and grid region of the grid, for use
+// in spatial surrogate caching.
+func (c *CityAQ) gridName(cityID string, st *sourceType) (string, error) {
+	projection, err := c.gridProjection()
+	if err != nil {
+		return "", err
+	}
+	_, f, err := c.catalog().lookup(cityID)
+	if err != nil {
+		return "", err
+	}
+	name := f.id + "_" + f.version
+	if suffix := gridSuffix(projection, st); suffix != "" {
+		name += "_" + suffix
+	}
+	region, err := c.egugridID(cityID, st)
+	if err != nil {
+		return "", err
+	}
+	if region != "" {
+		name += "_" + region
+	}
+	return name, nil
+}
+
+// gridSuffix returns a string that identifies the projection and
+// resolution of emissions grids for st, or an empty string if the grid
+// is in longitude and latitude at the default resolution for its
+// allocation domain.
+func gridSuffix(projection string, st *sourceType) string {
+	if projection == lonLatProjection {
+		if st.Resolution == defaultResolution[st.Domain] {
+			return ""
+		}
+		return fmt.Sprintf("%s_%g", projection, st.Resolution)
+	}
+	return fmt.Sprintf("%s_%g", projection, st.CellSize)
+}
+
+// gridSR returns the spatial reference of a grid in the given
+// projection that is centered on the given longitude and latitude.
+func gridSR(projection string, center geom.Point) (*proj.SR, error) {
+	var def string
+	switch projection {
+	case utmProjection:
+		zone := int(math.Floor((center.X+180)/6)) + 1
+		if zone > 60 {
+			zone = 60
+		}
+		south := ""
+		if center.Y < 0 {
+			south = " +south"
+		}
+		def = fmt.Sprintf("+proj=utm +zone=%d%s +datum=WGS84 +units=m +no_defs", zone, south)
+	case equalAreaProjection:
+		def = fmt.Sprintf("+proj=laea +lat_0=%g +lon_0=%g +x_0=0 +y_0=0 +datum=WGS84 +units=m +no_defs", center.Y, center.X)
+	default:
+		return nil, fmt.Errorf("cityaq: invalid grid projection %q", projection)
+	}
+	return proj.Parse(def)
+}
+
+// gridTransforms returns transformations from longitude and latitude to
+// the given projection, centered on the centroid of polygon, and back again.
+func gridTransforms(polygon geom.Polygonal, projection string) (toGrid, fromGrid proj.Transformer, err error) {
+	sr, err := gridSR(projection, polygon.Centroid())
+	if err != nil {
+		return nil, nil, err
+	}
+	toGrid, err = lonLatSR.NewTransform(sr)
+	if err != nil {
+		return nil, nil, fmt.Errorf("cityaq: projecting emissions grid: %v", err)
+	}
+	fromGrid, err = sr.NewTransform(lonLatSR)
+	if err != nil {
+		return nil, nil, fmt.Errorf("cityaq: projecting emissions grid: %v", err)
+	}
+	return toGrid, fromGrid, nil
+}
+
+// gridDomainBounds returns the bounds of polygon, which has longitude
+// and latitude coordinates, in the given grid projection.
+func gridDomainBounds(polygon geom.Polygonal, projection string) (*geom.Bounds, error) {
+	if projection == lonLatProjection {
+		return polygon.Bounds(), nil
+	}
+	toGrid, _, err := gridTransforms(polygon, projection)
+	if err != nil {
+		return nil, err
+	}
+	projected, err := polygon.Transform(toGrid)
+	if err != nil {
+		return nil, fmt.Errorf("cityaq: projecting emissions grid: %v", err)
+	}
+	return projected.Bounds(), nil
+}
+
+// projectedGrid returns a grid with square cells with edge length dx
+// meters in the given projection that covers polygon, which has
+// longitude and latitude coordinates. The projection is centered on
+// polygon, and the returned cells are converted back to longitude and
+// latitude.
+func projectedGrid(polygon geom.Polygonal, projection string, dx float64) ([]geom.Polygonal, error) {
+	toGrid, fromGrid, err := gridTransforms(polygon, projection)
+	if err != nil {
+		return nil, err
+	}
+	projected, err := polygon.Transform(toGrid)
+	if err != nil {
+		return nil, fmt.Errorf("cityaq: projecting emissions grid: %v", err)
+	}
+	cells, err := gridCells(projected.Bounds(), dx)
+	if err != nil {
+		return nil, err
+	}
+	for i, cell := range cells {
+		// Adjacent cells share corners, so the converted cells
+		// still cover the domain without gaps or overlaps.
+		g, err := cell.Transform(fromGrid)
+		if err != nil {
+			return nil, fmt.Errorf("cityaq: projecting emissions grid: %v", err)
+		}
+		cells[i] = g.(geom.Polygonal)
+	}
+	return cells, nil
+}
+
+// maxGridCells is the maximum number of cells in an emissions grid.
+const maxGridCells = 1000000
+
+// gridCells returns square grid cells with edge length dx that cover
+// the given bounds plus a buffer, with cell edges at multiples of dx.
+func gridCells(b *geom.Bounds, dx float64) ([]geom.Polygonal, error) {
+	const bufferFrac = 0.1
+	buffer := math.Sqrt((b.Max.X-b.Min.X)*(b.Max.Y-b.Min.Y)) * bufferFrac
+	b.Min.X -= buffer
+	b.Min.Y -= buffer
+	b.Max.X += buffer
+	b.Max.Y += buffer
+	b.Min.X = roundUnit(b.Min.X, dx)
+	b.Min.Y = roundUnit(b.Min.Y, dx)
+	b.Max.X = roundUnit(b.Max.X+dx/2, dx) // Round the max values up.
+	b.Max.Y = roundUnit(b.Max.Y+dx/2, dx) // Round the max values up.
+	if n := math.Ceil((b.Max.X-b.Min.X)/dx+1) * math.Ceil((b.Max.Y-b.Min.Y)/dx+1); n > maxGridCells {
+		return nil, fmt.Errorf("cityaq: emissions grid with cell size %g would have %g cells, which is more than the maximum of %d", dx, n, maxGridCells)
+	}
+	var o []geom.Polygonal
+	for y := b.Min.Y; y < b.Max.Y+dx; y += dx {
+		for x := b.Min.X; x < b.Max.X+dx; x += dx {
+			o = append(o, geom.Polygon{
+				{
+					{X: x, Y: y}, {X: x + dx, Y: y}, {X: x + dx, Y: y + dx}, {X: x, Y: y + dx},
+				},
+			})
+		}
+	}
+	return o, nil
+}
+
+// autoGridCells is the approximate number of cells that grids
+// with automatic resolution have within the bounds of their
+// allocation domain.
+const autoGridCells = 2500
+
+// autoCellSize returns a grid cell edge length that results in
+// approximately autoGridCells cells within the given bounds. The edge
+// length is rounded to 1, 2, 2.5, or 5 times a power of ten so that
+// domains of similar sizes have the same resolution.
+func autoCellSize(b *geom.Bounds) float64 {
+	dx := math.Sqrt((b.Max.X - b.Min.X) * (b.Max.Y - b.Min.Y) / autoGridCells)
+	exp := math.Floor(math.Log10(dx))
+	best := math.NaN()
+	for _, m := range []float64{1, 2, 2.5, 5, 10} {
+		// Divide by negative powers of ten rather than multiplying
+		// by their inverses to avoid results like 0.005000000000000001.
+		v := m * math.Pow(10, exp)
+		if exp < 0 {
+			v = m / math.Pow(10, -exp)
+		}
+		if math.IsNaN(best) || math.Abs(math.Log(v/dx)) < math.Abs(math.Log(best/dx)) {
+			best = v
+		}
+	}
+	return best
+}
+
+// gridSourceType returns st with the emissions grid resolution of the
+// given city set as requested. If resolution is greater than zero, it is
+// the grid cell edge length in degrees, or in meters if GridProjection is
+// set. If autoResolution is true, the cell edge length is chosen from the
+// extent of the allocation domain of st using autoCellSize. Otherwise,
+// st is returned unchanged.
+func (c *CityAQ) gridSourceType(cityName string, st *sourceType, resolution float64, autoResolution bool) (*sourceType, error) {
+	if resolution < 0 || invalidFloat(resolution) {
+		return nil, fmt.Errorf("cityaq: invalid emissions grid resolution %g", resolution)
+	}
+	if resolution > 0 && autoResolution {
+		return nil, fmt.Errorf("cityaq: a resolution can't be specified together with automatic resolution")
+	}
+	if resolution == 0 && !autoResolution {
+		return st, nil
+	}
+	projection, err := c.gridProjection()
+	if err != nil {
+		return nil, err
+	}
+	if autoResolution {
+		polygon, err := c.gridDomain(cityName, st)
+		if err != nil {
+			return nil, err
+		}
+		b, err := gridDomainBounds(polygon, projection)
+		if err != nil {
+			return nil, err
+		}
+		if b.Min.X >= b.Max.X || b.Min.Y >= b.Max.Y {
+			return nil, fmt.Errorf("invalid emissionsGrid bounding box (%+v) for %s %s", b, cityName, st.Name)
+		}
+		resolution = autoCellSize(b)
+	}
+	o := *st
+	if projection == lonLatProjection {
+		o.Resolution = resolution
+	} else {
+		o.CellSize = resolution
+	}
+	return &o, nil
+}
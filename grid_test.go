@@ -0,0 +1,155 @@
+package cityaq
+
+import (
+	"context"
+	"math"
+	"testing"
+
+	rpc "github.com/ctessum/cityaq/cityaqrpc"
+	"github.com/ctessum/geom"
+)
+
+func TestProjectedGrid(t *testing.T) {
+	square := func(x0, y0, x1, y1 float64) geom.Polygon {
+		return geom.Polygon{{{X: x0, Y: y0}, {X: x1, Y: y0}, {X: x1, Y: y1}, {X: x0, Y: y1}, {X: x0, Y: y0}}}
+	}
+	for _, test := range []struct {
+		city    string
+		polygon geom.Polygon
+	}{
+		{city: "Accra", polygon: square(-0.3, 5.5, -0.1, 5.7)},
+		{city: "Oslo", polygon: square(10.6, 59.8, 10.9, 60)},
+		{city: "Santiago", polygon: square(-70.8, -33.6, -70.5, -33.3)},
+	} {
+		for _, projection := range []string{utmProjection, equalAreaProjection} {
+			t.Run(test.city+"_"+projection, func(t *testing.T) {
+				const dx = 1000.0 // m
+				grid, err := projectedGrid(test.polygon, projection, dx)
+				if err != nil {
+					t.Fatal(err)
+				}
+				covered := geom.NewBounds()
+				for i, cell := range grid {
+					covered.Extend(cell.Bounds())
+					// Areas are within 1% of the requested cell
+					// area regardless of latitude.
+					if a := sphericalArea(cell.(geom.Polygon)); !similar(a, dx*dx/1e6, 0.01) {
+						t.Errorf("cell %d area: have %g km², want %g km²", i, a, dx*dx/1e6)
+						break
+					}
+				}
+				if b := test.polygon.Bounds(); covered.Min.X > b.Min.X || covered.Min.Y > b.Min.Y || covered.Max.X < b.Max.X || covered.Max.Y < b.Max.Y {
+					t.Errorf("grid %+v does not cover %+v", covered, b)
+				}
+			})
+		}
+	}
+}
+
+func TestGridTransforms(t *testing.T) {
+	// An L-shaped city, whose centroid is not the center of its bounds.
+	polygon := geom.Polygon{{{X: 0, Y: 0}, {X: 1, Y: 0}, {X: 1, Y: 0.2}, {X: 0.2, Y: 0.2}, {X: 0.2, Y: 1}, {X: 0, Y: 1}, {X: 0, Y: 0}}}
+	toGrid, _, err := gridTransforms(polygon, equalAreaProjection)
+	if err != nil {
+		t.Fatal(err)
+	}
+	g, err := polygon.Centroid().Transform(toGrid)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// The projection is centered on the centroid.
+	if c := g.(geom.Point); math.Abs(c.X) > 1e-6 || math.Abs(c.Y) > 1e-6 {
+		t.Errorf("projected centroid: %+v != (0, 0)", c)
+	}
+}
+
+func TestCityAQ_gridProjection(t *testing.T) {
+	c := &CityAQ{CityGeomDir: "testdata/cities", GridProjection: "mercator"}
+	if _, err := c.gridProjection(); err == nil {
+		t.Error("expected an error for an invalid projection")
+	}
+	c.GridProjection = ""
+	st := &sourceType{Name: "roadways", CellSize: 500}
+	if name, err := c.gridName("accra-metropolitan", st); err != nil || name != "accra-metropolitan_cb28dbd7" {
+		t.Errorf("lon/lat grid name: %q, %v", name, err)
+	}
+	c.GridProjection = utmProjection
+	if name, err := c.gridName("accra-metropolitan", st); err != nil || name != "accra-metropolitan_cb28dbd7_utm_500" {
+		t.Errorf("UTM grid name: %q, %v", name, err)
+	}
+}
+
+func TestAutoCellSize(t *testing.T) {
+	for _, test := range []struct {
+		b    *geom.Bounds
+		want float64
+	}{
+		{b: &geom.Bounds{Min: geom.Point{X: 0, Y: 0}, Max: geom.Point{X: 1, Y: 1}}, want: 0.02},
+		{b: &geom.Bounds{Min: geom.Point{X: -0.3, Y: 5.5}, Max: geom.Point{X: -0.1, Y: 5.68}}, want: 0.005},
+		{b: &geom.Bounds{Min: geom.Point{X: 0, Y: 0}, Max: geom.Point{X: 100000, Y: 100000}}, want: 2000},
+	} {
+		if dx := autoCellSize(test.b); dx != test.want {
+			t.Errorf("%+v: have %v, want %v", test.b, dx, test.want)
+		}
+	}
+}
+
+func TestCityAQ_gridResolution(t *testing.T) {
+	c := &CityAQ{CityGeomDir: "testdata/cities"}
+	st, err := c.sourceType("roadways")
+	if err != nil {
+		t.Fatal(err)
+	}
+	grid := func(resolution float64, auto bool) []geom.Polygonal {
+		gst, err := c.gridSourceType("Accra Metropolitan", st, resolution, auto)
+		if err != nil {
+			t.Fatal(err)
+		}
+		g, err := c.emissionsGrid("Accra Metropolitan", gst)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return g
+	}
+	def := grid(0, false)
+	coarse := grid(0.01, false)
+	if len(coarse)*3 > len(def) {
+		t.Errorf("0.01° grid has %d cells but the default grid has %d", len(coarse), len(def))
+	}
+	if n := len(grid(0, true)); n < 1000 || n > 10000 {
+		t.Errorf("automatic resolution grid has %d cells", n)
+	}
+
+	bounds, err := c.EmissionsGridBounds(context.Background(), &rpc.EmissionsGridBoundsRequest{
+		CityName:   "Accra Metropolitan",
+		Resolution: 0.01,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	b := geom.NewBounds()
+	for _, cell := range coarse {
+		b.Extend(cell.Bounds())
+	}
+	if bounds.Min.X != b.Min.X || bounds.Min.Y != b.Min.Y || bounds.Max.X != b.Max.X || bounds.Max.Y != b.Max.Y {
+		t.Errorf("grid bounds %+v do not match grid %+v", bounds, b)
+	}
+
+	for _, test := range []struct {
+		resolution float64
+		auto       bool
+	}{
+		{resolution: -1},
+		{resolution: math.NaN()},
+		{resolution: 0.01, auto: true},
+		{resolution: 1e-6},
+	} {
+		gst, err := c.gridSourceType("Accra Metropolitan", st, test.resolution, test.auto)
+		if err == nil {
+			_, err = c.emissionsGrid("Accra Metropolitan", gst)
+		}
+		if err == nil {
+			t.Errorf("%+v: expected an error", test)
+		}
+	}
+}
@@ -19,6 +19,7 @@ type GRPCServer struct {
 	grpcServer   *grpcweb.WrappedGrpcServer
 	staticServer http.Handler
 	mapServer    *MapTileServer
+	exportServer *ExportServer
 
 	Log logrus.FieldLogger
 }
@@ -38,6 +39,7 @@ func NewGRPCServer(c *CityAQ) *GRPCServer {
 		},
 	)))
 	s.mapServer = NewMapTileServer(c, 50)
+	s.exportServer = NewExportServer(c)
 	return s
 }
 
@@ -79,6 +81,14 @@ func (s *GRPCServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 			}).Info("cityaq map tile request")
 		}
 		s.mapServer.ServeHTTP(w, r)
+	} else if strings.HasPrefix(r.URL.Path, "/download") {
+		if s.Log != nil {
+			s.Log.WithFields(logrus.Fields{
+				"url":  r.URL.String(),
+				"addr": r.RemoteAddr,
+			}).Info("cityaq download request")
+		}
+		s.exportServer.ServeHTTP(w, r)
 	} else {
 		if s.Log != nil {
 			s.Log.WithFields(logrus.Fields{
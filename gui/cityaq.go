@@ -15,6 +15,7 @@ import (
 type CityAQ struct {
 	rpc.CityAQClient
 	doc                    js.Value
+	citySearch             js.Value
 	citySelector           js.Value
 	impactTypeSelector     js.Value
 	emissionSelector       js.Value
@@ -26,7 +27,12 @@ type CityAQ struct {
 	mapboxMap              js.Value
 	cityLayer, dataLayer   js.Value
 	egugridLayer           js.Value
-	grid                   struct {
+
+	// egugridSourceTypes holds the names of the source types
+	// that are allocated to the electric grid domain.
+	egugridSourceTypes map[string]bool
+
+	grid struct {
 		geometry js.Value
 		gridCity string
 		gridType rpc.ImpactType
@@ -108,6 +114,12 @@ func (c *CityAQ) Monitor() {
 			return nil
 		}))
 	}
+	if !c.citySearch.IsNull() && !c.citySearch.IsUndefined() {
+		c.citySearch.Call("addEventListener", "input", js.FuncOf(func(this js.Value, args []js.Value) interface{} {
+			go c.updateCitySelector(context.TODO())
+			return nil
+		}))
+	}
 	var prevSimType rpc.SimulationType
 	c.simulationTypeSelector.Call("addEventListener", "change", js.FuncOf(func(this js.Value, args []js.Value) interface{} {
 		// Update source type choices if simulation type changes.
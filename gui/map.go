@@ -172,7 +172,7 @@ func (c *CityAQ) updateMap(ctx context.Context, sel *selections) {
 		}),
 	})
 
-	if strings.HasSuffix(sel.sourceType, "_egugrid") {
+	if c.egugridSourceTypes[sel.sourceType] {
 		c.egugridLayer = c.mapboxMap.Call("addLayer", map[string]interface{}{
 			"id":           "egugrid",
 			"source":       source,
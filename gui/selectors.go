@@ -3,6 +3,7 @@ package gui
 import (
 	"context"
 	"errors"
+	"fmt"
 	"strconv"
 	"syscall/js"
 
@@ -27,21 +28,78 @@ func updateSelector(doc, selector js.Value, values []interface{}, text []string)
 	}
 }
 
-// updateCitySelector updates the options of cities.
+// citySelectorPageSize is the maximum number of cities
+// to show in the city selector at one time.
+const citySelectorPageSize = 200
+
+// updateCitySelector updates the options of cities to
+// those that match the text in the city search box.
 func (c *CityAQ) updateCitySelector(ctx context.Context) {
 	if c.citySelector.IsUndefined() {
 		c.citySelector = c.doc.Call("getElementById", "citySelector")
 	}
-	cities, err := c.Cities(ctx, &rpc.CitiesRequest{})
+	cities, err := c.Cities(ctx, &rpc.CitiesRequest{
+		Language: browserLanguage(),
+		Query:    c.citySearchValue(),
+		PageSize: citySelectorPageSize,
+	})
 	if err != nil {
 		c.logError(err)
 		return
 	}
-	names := make([]interface{}, len(cities.Names))
-	for i, n := range cities.Names {
-		names[i] = n
+	if len(cities.Cities) == 0 {
+		names := make([]interface{}, len(cities.Names))
+		for i, n := range cities.Names {
+			names[i] = n
+		}
+		updateSelector(c.doc, c.citySelector, names, cities.Names)
+		return
+	}
+	ids := make([]interface{}, len(cities.Cities))
+	displayNames := make([]string, len(cities.Cities))
+	for i, city := range cities.Cities {
+		ids[i] = city.ID
+		displayNames[i] = city.DisplayName
+		if city.Country != "" {
+			displayNames[i] += " (" + city.Country + ")"
+		}
+	}
+	updateSelector(c.doc, c.citySelector, ids, displayNames)
+
+	if help := c.doc.Call("getElementById", "citySelectorHelp"); !help.IsNull() {
+		if cities.NextPageToken != "" {
+			help.Set("innerText", fmt.Sprintf("Showing %d of %d cities. Search to find others.",
+				len(cities.Cities), cities.TotalSize))
+		} else {
+			help.Set("innerText", "Choose a city to explore.")
+		}
+	}
+}
+
+// citySearchValue returns the text in the city search box,
+// if there is one.
+func (c *CityAQ) citySearchValue() string {
+	if c.citySearch.IsUndefined() {
+		c.citySearch = c.doc.Call("getElementById", "citySearch")
+	}
+	if c.citySearch.IsNull() || c.citySearch.IsUndefined() {
+		return ""
+	}
+	return c.citySearch.Get("value").String()
+}
+
+// browserLanguage returns the preferred language of the user,
+// or an empty string if it is not available.
+func browserLanguage() string {
+	nav := js.Global().Get("navigator")
+	if nav.IsUndefined() {
+		return ""
+	}
+	lang := nav.Get("language")
+	if lang.IsUndefined() || lang.IsNull() {
+		return ""
 	}
-	updateSelector(c.doc, c.citySelector, names, cities.Names)
+	return lang.String()
 }
 
 // updateImpactTypeSelector updates the options of impacts.
@@ -77,19 +135,21 @@ func (c *CityAQ) updateSourceTypeSelector() error {
 		simulationType = 0
 	}
 	if simulationType == rpc.SimulationType_CityMarginal || simulationType == 0 {
-		updateSelector(c.doc, c.sourceTypeSelector,
-			[]interface{}{
-				"electric_gen_egugrid", "population", "residential",
-				"commercial", "industrial", "builtup", "roadways", "roadways_motorway",
-				"roadways_trunk", "roadways_primary", "roadways_secondary", "roadways_tertiary",
-				"railways", "waterways", "bus_routes", "airports", "agricultural",
-			},
-			[]string{
-				"electric_gen_egugrid", "population", "residential",
-				"commercial", "industrial", "builtup", "roadways", "roadways_motorway",
-				"roadways_trunk", "roadways_primary", "roadways_secondary", "roadways_tertiary",
-				"railways", "waterways", "bus_routes", "airports", "agricultural",
-			})
+		sourceTypes, err := c.SourceTypes(context.Background(), &rpc.SourceTypesRequest{})
+		if err != nil {
+			return err
+		}
+		values := make([]interface{}, len(sourceTypes.SourceTypes))
+		text := make([]string, len(sourceTypes.SourceTypes))
+		c.egugridSourceTypes = make(map[string]bool)
+		for i, st := range sourceTypes.SourceTypes {
+			values[i] = st.Name
+			text[i] = st.Label
+			if st.Domain == rpc.AllocationDomain_EGUGrid {
+				c.egugridSourceTypes[st.Name] = true
+			}
+		}
+		updateSelector(c.doc, c.sourceTypeSelector, values, text)
 		return nil
 	}
 	sectors, err := c.EmissionsInventorySectors(context.Background(), &rpc.EmissionsInventorySectorsRequest{})
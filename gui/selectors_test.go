@@ -62,6 +62,25 @@ func TestCitySelector(t *testing.T) {
 	if html != want {
 		t.Errorf("%v != %v", html, want)
 	}
+
+	// Display names should be used when they are available.
+	client.EXPECT().Cities(
+		gomock.Any(),
+		gomock.Any(),
+	).Return(&rpc.CitiesResponse{
+		Names: []string{"city1", "city2"},
+		Cities: []*rpc.City{
+			{ID: "city1", DisplayName: "Ciudad 1"},
+			{ID: "city2", DisplayName: "Ciudad 2"},
+		},
+	}, nil)
+
+	c.updateCitySelector(context.Background())
+	html = c.citySelector.Get("innerHTML").String()
+	want = `<option disabled="" hidden="">-- select an option --</option><option value="city1">Ciudad 1</option><option value="city2">Ciudad 2</option>`
+	if html != want {
+		t.Errorf("%v != %v", html, want)
+	}
 }
 
 func TestImpactTypeSelector(t *testing.T) {
@@ -78,18 +97,44 @@ func TestImpactTypeSelector(t *testing.T) {
 	}
 }
 
+// testSourceTypes are source types from the source type catalog.
+var testSourceTypes = &rpc.SourceTypesResponse{
+	SourceTypes: []*rpc.SourceType{
+		{Name: "electric_gen_egugrid", Label: "Electricity generation", Domain: rpc.AllocationDomain_EGUGrid},
+		{Name: "residential", Label: "Residential", Domain: rpc.AllocationDomain_City},
+		{Name: "roadways", Label: "Roadways", Domain: rpc.AllocationDomain_City},
+		{Name: "bus_routes", Label: "Bus routes", Domain: rpc.AllocationDomain_City},
+	},
+}
+
 func TestSourceTypeSelector(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	client := caqmock.NewMockCityAQClient(mockCtrl)
+
+	client.EXPECT().SourceTypes(
+		gomock.Any(),
+		gomock.AssignableToTypeOf(&rpc.SourceTypesRequest{}),
+	).Return(testSourceTypes, nil)
+
 	c := &CityAQ{
-		doc: js.Global().Get("document"),
+		CityAQClient: client,
+		doc:          js.Global().Get("document"),
 	}
 	c.sourceTypeSelector = c.doc.Call("createElement", "select")
+	c.simulationTypeSelector = c.doc.Call("createElement", "select")
 
-	c.updateSourceTypeSelector()
+	if err := c.updateSourceTypeSelector(); err != nil {
+		t.Fatal(err)
+	}
 	html := c.sourceTypeSelector.Get("innerHTML").String()
-	want := `<option disabled="" hidden="">-- select an option --</option><option value="electric_gen">electric_gen</option><option value="residential">residential</option><option value="commercial">commercial</option><option value="industrial">industrial</option><option value="builtup">builtup</option><option value="roadways">roadways</option><option value="railways">railways</option><option value="waterways">waterways</option><option value="busways">busways</option><option value="airports">airports</option><option value="agricultural">agricultural</option>`
+	want := `<option disabled="" hidden="">-- select an option --</option><option value="electric_gen_egugrid">Electricity generation</option><option value="residential">Residential</option><option value="roadways">Roadways</option><option value="bus_routes">Bus routes</option>`
 	if html != want {
 		t.Errorf("%v != %v", html, want)
 	}
+	if !c.egugridSourceTypes["electric_gen_egugrid"] || c.egugridSourceTypes["roadways"] {
+		t.Errorf("wrong egugrid source types: %v", c.egugridSourceTypes)
+	}
 }
 
 func TestEmissionSelector(t *testing.T) {
@@ -119,6 +164,10 @@ func TestSelectors(t *testing.T) {
 		gomock.Any(), // expect any value for first parameter
 		gomock.Any(), // expect any value for second parameter
 	).Return(&rpc.CitiesResponse{Names: []string{"city1", "city2"}}, nil)
+	client.EXPECT().SourceTypes(
+		gomock.Any(),
+		gomock.Any(),
+	).Return(testSourceTypes, nil)
 
 	c := &CityAQ{
 		CityAQClient: client,
@@ -145,7 +194,7 @@ func TestSelectors(t *testing.T) {
 	if err != nil {
 		t.Fatal(err)
 	}
-	want := &selections{cityName: "city1", impactType: rpc.ImpactType_Emissions, emission: 1, sourceType: "electric_gen"}
+	want := &selections{cityName: "city1", impactType: rpc.ImpactType_Emissions, emission: 1, sourceType: "electric_gen_egugrid"}
 
 	if !reflect.DeepEqual(want, sel) {
 		t.Errorf("%v != %v", sel, want)
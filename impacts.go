@@ -2,6 +2,7 @@ package cityaq
 
 import (
 	"context"
+	"fmt"
 
 	rpc "github.com/ctessum/cityaq/cityaqrpc"
 	"github.com/ctessum/geom"
@@ -11,22 +12,44 @@ import (
 )
 
 // ImpactSummary returns a summary of the impacts from the given request.
+// If req.Speciate is set, the requested amount is split among pollutants
+// using the speciation profile of the source type, and the summary
+// includes the combined impacts of all of the pollutants as well as the
+// impacts of each one.
 func (c *CityAQ) ImpactSummary(ctx context.Context, req *rpc.ImpactSummaryRequest) (*rpc.ImpactSummaryResponse, error) {
-	conc, err := c.GriddedConcentrations(ctx, &rpc.GriddedConcentrationsRequest{
-		CityName:       req.CityName,
-		SourceType:     req.SourceType,
-		Emission:       req.Emission,
-		SimulationType: req.SimulationType,
-	})
-	if err != nil {
+	if err := checkMarginalAmounts(req.SimulationType, req.Amount, req.Begin, req.End, nil, req.Speciate); err != nil {
 		return nil, err
 	}
+	var amounts []pollutantAmount
+	if req.Speciate {
+		blend, err := requestSourceTypeBlend(req.SourceTypeMix)
+		if err != nil {
+			return nil, err
+		}
+		st, err := c.requestSourceType(req.SourceType, nil, blend)
+		if err != nil {
+			return nil, err
+		}
+		amounts, err = requestSpeciatedAmounts(st, true, req.Emission, req.Amount, req.AmountUnits, nil, req.Begin, req.End)
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		amount, err := requestAmount(req.Amount, req.AmountUnits, req.Begin, req.End)
+		if err != nil {
+			return nil, err
+		}
+		amounts = []pollutantAmount{{pollutant: req.Emission, emissionsAmount: amount}}
+	}
 
 	pop, err := c.GriddedPopulation(ctx, &rpc.GriddedPopulationRequest{
 		CityName:       req.CityName,
 		SourceType:     req.SourceType,
 		Emission:       req.Emission,
 		SimulationType: req.SimulationType,
+		SourceTypeMix:  req.SourceTypeMix,
+		Resolution:     req.Resolution,
+		AutoResolution: req.AutoResolution,
 	})
 	if err != nil {
 		return nil, err
@@ -35,14 +58,54 @@ func (c *CityAQ) ImpactSummary(ctx context.Context, req *rpc.ImpactSummaryReques
 	if err != nil {
 		return nil, err
 	}
-	return &rpc.ImpactSummaryResponse{
+
+	o := &rpc.ImpactSummaryResponse{
 		Population:     floats.Sum(pop.Population),
 		CityPopulation: floats.Sum(maskedPop),
-		TotalExposure:  exposure(conc.Concentrations, pop.Population),
-		CityExposure:   exposure(conc.Concentrations, maskedPop),
-		TotalIF:        iF(conc.Concentrations, pop.Population),
-		CityIF:         iF(conc.Concentrations, maskedPop),
-	}, nil
+	}
+	conc := make([]float64, len(pop.Population))
+	total := &emissionsAmount{begin: amounts[0].begin, end: amounts[0].end}
+	for _, a := range amounts {
+		concReq := &rpc.GriddedConcentrationsRequest{
+			CityName:       req.CityName,
+			SourceType:     req.SourceType,
+			Emission:       a.pollutant,
+			SimulationType: req.SimulationType,
+			SourceTypeMix:  req.SourceTypeMix,
+			Resolution:     req.Resolution,
+			AutoResolution: req.AutoResolution,
+			AreaFallback:   req.AreaFallback,
+		}
+		if req.SimulationType == rpc.SimulationType_CityMarginal {
+			concReq.Amount = a.mass
+			concReq.AmountUnits = rpc.EmissionUnits_Kilograms
+			concReq.Begin = a.begin.Unix()
+			concReq.End = a.end.Unix()
+		}
+		polConc, err := c.GriddedConcentrations(ctx, concReq)
+		if err != nil {
+			return nil, err
+		}
+		if len(polConc.Concentrations) != len(conc) {
+			return nil, fmt.Errorf("cityaq: concentration and population grids have different sizes (%d and %d)",
+				len(polConc.Concentrations), len(conc))
+		}
+		floats.Add(conc, polConc.Concentrations)
+		total.mass += a.mass
+		o.PollutantImpacts = append(o.PollutantImpacts, &rpc.PollutantImpacts{
+			Emission:      a.pollutant,
+			Amount:        a.mass,
+			TotalExposure: exposure(polConc.Concentrations, pop.Population),
+			CityExposure:  exposure(polConc.Concentrations, maskedPop),
+			TotalIF:       iF(polConc.Concentrations, pop.Population, a.emissionsAmount),
+			CityIF:        iF(polConc.Concentrations, maskedPop, a.emissionsAmount),
+		})
+	}
+	o.TotalExposure = exposure(conc, pop.Population)
+	o.CityExposure = exposure(conc, maskedPop)
+	o.TotalIF = iF(conc, pop.Population, total)
+	o.CityIF = iF(conc, maskedPop, total)
+	return o, nil
 }
 
 // exposure returns the population-weighted mean of the concentration.
@@ -51,13 +114,11 @@ func exposure(conc, pop []float64) float64 {
 }
 
 // iF returns the intake fraction (in ppm) of the given concentration (μg m-3) and
-// population, assuming 1 kilotonne of emissions.
-func iF(conc, pop []float64) float64 {
-	const (
-		br   = 15                  // m3 person-1 day-1
-		emis = 1.0e6 * 1.0e9 / 365 // μg / day
-	)
-	avgConc := exposure(conc, pop) // μg m-3
+// population, resulting from the given amount of emissions.
+func iF(conc, pop []float64, amount *emissionsAmount) float64 {
+	const br = 15                               // m3 person-1 day-1
+	emis := amount.mass * 1.0e9 / amount.days() // μg / day
+	avgConc := exposure(conc, pop)              // μg m-3
 	popSum := floats.Sum(pop)
 	// m3 person-1 day-1 μg m-3 person μg-1 day * 1e6 = ppm
 	return br * avgConc * popSum / emis * 1.0e6
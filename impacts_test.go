@@ -43,4 +43,38 @@ func TestCityAQ_ImpactSummary(t *testing.T) {
 	// There is no population in the example domain, so we get a null
 	// response.
 	fmt.Println(s)
+
+	r.Speciate = true
+	s, err = c.ImpactSummary(context.Background(), r)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(s.PollutantImpacts) != 5 {
+		t.Errorf("wrong number of pollutant impacts: %d", len(s.PollutantImpacts))
+	}
+}
+
+func TestIF(t *testing.T) {
+	conc := []float64{1, 3}
+	pop := []float64{1, 1}
+	// 15 m3/day * 2 μg/m3 * 2 people / (1e15 μg / 366 days) * 1e6
+	// 2016 is a leap year.
+	if v, want := iF(conc, pop, referenceAmount), 15*2*2*366/1.0e9; !similar(v, want, 1.0e-10) {
+		t.Errorf("default period: %g != %g", v, want)
+	}
+	begin := time.Date(2016, time.January, 1, 0, 0, 0, 0, time.UTC)
+	amount := &emissionsAmount{mass: 1.0e6, begin: begin, end: begin.AddDate(0, 0, 10)}
+	if v, want := iF(conc, pop, amount), 15*2*2*10/1.0e9; !similar(v, want, 1.0e-10) {
+		t.Errorf("10-day period: %g != %g", v, want)
+	}
+	// An annual rate over the default period is the same intake fraction
+	// as the same mass over any other period with the same rate.
+	annual, err := requestAmount(1000, rpc.EmissionUnits_MegagramsPerYear, 0, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	year := &emissionsAmount{mass: 1.0e6, begin: begin, end: begin.AddDate(0, 0, 365)}
+	if v, want := iF(conc, pop, annual), iF(conc, pop, year); !similar(v, want, 1.0e-10) {
+		t.Errorf("annual rate: %g != %g", v, want)
+	}
 }
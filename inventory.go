@@ -0,0 +1,425 @@
+package cityaq
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/ctessum/cdf"
+	rpc "github.com/ctessum/cityaq/cityaqrpc"
+	"github.com/ctessum/geom"
+	"github.com/ctessum/geom/index/rtree"
+	"github.com/spatialmodel/inmap/inmaputil"
+	"gonum.org/v1/gonum/floats"
+)
+
+// inventoryConfig reads the InMAP configuration file for simulations
+// of the given type that are driven by an emissions inventory. It
+// returns the configuration and the name of the file.
+func (c *CityAQ) inventoryConfig(simType rpc.SimulationType) (*inmaputil.Cfg, string, error) {
+	var f string
+	switch simType {
+	case rpc.SimulationType_CityTotal:
+		f = c.InMAPCityTotalConfigFile
+	case rpc.SimulationType_Total:
+		f = c.InMAPTotalConfigFile
+	default:
+		return nil, "", fmt.Errorf("cityaq: simulation type %s does not use an emissions inventory", simType)
+	}
+	if f == "" {
+		return nil, "", fmt.Errorf("cityaq: missing InMAP configuration file for %s simulations", simType)
+	}
+	cfg := inmaputil.InitializeConfig()
+	cfg.SetConfigFile(f)
+	if err := cfg.ReadInConfig(); err != nil {
+		return nil, "", fmt.Errorf("cityaq: problem reading InMAP configuration file: %v", err)
+	}
+	return cfg, f, nil
+}
+
+// inventoryUnits returns the factor to convert emissions in the given
+// inventory InputUnits to kilograms.
+func inventoryUnits(units string) (float64, error) {
+	switch strings.ToLower(units) {
+	case "tons":
+		return 907.18474, nil
+	case "tonnes":
+		return 1000, nil
+	case "kg":
+		return 1, nil
+	case "g":
+		return 0.001, nil
+	case "lbs":
+		return 0.45359237, nil
+	default:
+		return 0, fmt.Errorf("cityaq: invalid emissions inventory units %q", units)
+	}
+}
+
+// inventoryGriddedEmissions returns the emissions inventory emissions
+// of the sector req.SourceType that drive CityTotal and Total simulations,
+// regridded to the emissions grid of the requested city. For CityTotal
+// simulations, only the emissions within the city are included.
+// Inventory emissions are annual totals for the inventory year, which
+// are scaled to the length of the emissions period. req.Amount,
+// req.CustomSurrogate, req.SourceTypeMix, and req.Speciate are ignored,
+// as are the amounts in req.Pollutants.
+func (c *CityAQ) inventoryGriddedEmissions(ctx context.Context, cityID string, req *rpc.GriddedEmissionsRequest) (*rpc.GriddedEmissionsResponse, error) {
+	cfg, cfgFile, err := c.inventoryConfig(req.SimulationType)
+	if err != nil {
+		return nil, err
+	}
+	files, ok := cfg.GetStringMapStringSlice("aep.InventoryConfig.COARDSFiles")[req.SourceType]
+	if !ok {
+		return nil, fmt.Errorf("cityaq: emissions inventory sector %q is not in %s", req.SourceType, cfgFile)
+	}
+	toKg, err := inventoryUnits(cfg.GetString("aep.InventoryConfig.InputUnits"))
+	if err != nil {
+		return nil, err
+	}
+	year := cfg.GetInt("aep.InventoryConfig.COARDSYear")
+	if year == 0 {
+		return nil, fmt.Errorf("cityaq: missing emissions inventory year in %s", cfgFile)
+	}
+
+	amounts, err := requestPollutantAmounts(req.Emission, 0, 0, req.Pollutants, req.Begin, req.End)
+	if err != nil {
+		return nil, err
+	}
+	period := amounts[0].emissionsAmount
+	yearBegin := time.Date(year, time.January, 1, 0, 0, 0, 0, time.UTC)
+	yearLength := yearBegin.AddDate(1, 0, 0).Sub(yearBegin)
+	factor := toKg * period.end.Sub(period.begin).Hours() / yearLength.Hours()
+
+	st, err := c.sectorSourceType(req.SourceType)
+	if err != nil {
+		return nil, err
+	}
+	st, err = c.gridSourceType(cityID, st, req.Resolution, req.AutoResolution)
+	if err != nil {
+		return nil, err
+	}
+	grid, err := c.emissionsGrid(cityID, st)
+	if err != nil {
+		return nil, err
+	}
+	var mask geom.Polygonal
+	if req.SimulationType == rpc.SimulationType_CityTotal {
+		mask, err = c.cityGeometry(cityID)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	gridBounds := geom.NewBounds()
+	for _, cell := range grid {
+		gridBounds.Extend(cell.Bounds())
+	}
+	pollutants := make([]rpc.Emission, len(amounts))
+	for i, a := range amounts {
+		pollutants[i] = a.pollutant
+	}
+	gridEmis := make(map[rpc.Emission][]float64)
+	for _, p := range pollutants {
+		gridEmis[p] = make([]float64, len(grid))
+	}
+	var inventoryMass float64
+	for _, f := range files {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		cells, err := readCOARDS(os.ExpandEnv(f), gridBounds, pollutants)
+		if err != nil {
+			return nil, err
+		}
+		inventoryMass += regridInventory(cells, mask, gridBounds, grid, gridEmis)
+	}
+
+	o := &rpc.GriddedEmissionsResponse{
+		Polygons:           polygonalsToRPC(grid),
+		PollutantEmissions: make([]*rpc.PollutantEmissions, len(amounts)),
+	}
+	for j, a := range amounts {
+		e := gridEmis[a.pollutant]
+		floats.Scale(factor, e)
+		a.mass = floats.Sum(e)
+		o.PollutantEmissions[j] = &rpc.PollutantEmissions{
+			Emission:  a.pollutant,
+			Emissions: e,
+		}
+	}
+	if len(req.Pollutants) == 0 {
+		o.Emissions = o.PollutantEmissions[0].Emissions
+	}
+	o.Diagnostics, err = c.emissionsDiagnostics(cityID, grid, inventoryMass*factor, o.PollutantEmissions, nil)
+	if err != nil {
+		return nil, err
+	}
+	if err := checkMassConservation(o.Diagnostics, req.MassTolerance); err != nil {
+		return nil, err
+	}
+	if req.TemporalResolution != rpc.TemporalResolution_UNKNOWN_TEMPORALRESOLUTION {
+		o.TimeSteps, err = c.emissionsTimeSteps(req, cityID, nil, amounts, o.PollutantEmissions)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return o, nil
+}
+
+// inventoryCell is a grid cell of an emissions inventory file.
+type inventoryCell struct {
+	geom.Polygon
+	emis map[rpc.Emission]float64
+}
+
+// regridInventory adds the emissions in the given inventory cells to
+// the emissions in the grid, in proportion to the area of each inventory
+// cell that overlaps each grid cell. If mask is not nil, only the
+// emissions within it are included. It returns the total inventory
+// emissions of all pollutants within mask and bounds, which should all
+// be added to the grid if the grid covers bounds.
+func regridInventory(cells []*inventoryCell, mask geom.Polygonal, bounds *geom.Bounds, grid []geom.Polygonal, gridEmis map[rpc.Emission][]float64) float64 {
+	index := rtree.NewTree(25, 50)
+	for i, cell := range grid {
+		index.Insert(&gridCell{Polygonal: cell, i: i})
+	}
+	var total float64
+	for _, ic := range cells {
+		area := ic.Polygon.Area()
+		var p geom.Polygonal = ic.Polygon
+		if mask != nil {
+			p = ic.Polygon.Intersection(mask)
+			if p == nil {
+				continue
+			}
+		}
+		if inBounds := p.Intersection(bounds); inBounds != nil {
+			frac := inBounds.Area() / area
+			for _, v := range ic.emis {
+				total += v * frac
+			}
+		}
+		for _, cI := range index.SearchIntersect(p.Bounds()) {
+			cell := cI.(*gridCell)
+			isect := p.Intersection(cell.Polygonal)
+			if isect == nil {
+				continue
+			}
+			frac := isect.Area() / area
+			for pol, v := range ic.emis {
+				gridEmis[pol][cell.i] += v * frac
+			}
+		}
+	}
+	return total
+}
+
+// readCOARDS reads the emissions of the given pollutants in the
+// COARDS-compliant NetCDF emissions inventory file f, in the file's units
+// per year, from the cells of the file that overlap bounds.
+// Emissions variables must have latitude and longitude as their last
+// two dimensions, and they are matched to pollutants by name, ignoring
+// case, underscores, and periods.
+func readCOARDS(f string, bounds *geom.Bounds, pollutants []rpc.Emission) ([]*inventoryCell, error) {
+	r, err := os.Open(f)
+	if err != nil {
+		return nil, fmt.Errorf("cityaq: opening emissions inventory file: %v", err)
+	}
+	defer r.Close()
+	nc, err := cdf.Open(r)
+	if err != nil {
+		return nil, fmt.Errorf("cityaq: reading emissions inventory file %s: %v", f, err)
+	}
+
+	latName, lonName := coardsCoordinate(nc, "lat", "latitude"), coardsCoordinate(nc, "lon", "longitude")
+	if latName == "" || lonName == "" {
+		return nil, fmt.Errorf("cityaq: emissions inventory file %s is missing latitude or longitude", f)
+	}
+	lat, err := readCOARDSVar(nc, latName)
+	if err != nil {
+		return nil, fmt.Errorf("cityaq: reading %s from %s: %v", latName, f, err)
+	}
+	lon, err := readCOARDSVar(nc, lonName)
+	if err != nil {
+		return nil, fmt.Errorf("cityaq: reading %s from %s: %v", lonName, f, err)
+	}
+	latEdges, err := coardsEdges(lat)
+	if err != nil {
+		return nil, fmt.Errorf("cityaq: %s in %s: %v", latName, f, err)
+	}
+	lonEdges, err := coardsEdges(lon)
+	if err != nil {
+		return nil, fmt.Errorf("cityaq: %s in %s: %v", lonName, f, err)
+	}
+	i0, i1 := coardsRange(latEdges, bounds.Min.Y, bounds.Max.Y)
+	j0, j1 := coardsRange(lonEdges, bounds.Min.X, bounds.Max.X)
+	if i0 >= i1 || j0 >= j1 {
+		return nil, nil
+	}
+
+	cells := make([]*inventoryCell, (i1-i0)*(j1-j0))
+	for i := i0; i < i1; i++ {
+		y0, y1 := math.Min(latEdges[i], latEdges[i+1]), math.Max(latEdges[i], latEdges[i+1])
+		for j := j0; j < j1; j++ {
+			x0, x1 := math.Min(lonEdges[j], lonEdges[j+1]), math.Max(lonEdges[j], lonEdges[j+1])
+			cells[(i-i0)*(j1-j0)+j-j0] = &inventoryCell{
+				Polygon: geom.Polygon{{{X: x0, Y: y0}, {X: x1, Y: y0}, {X: x1, Y: y1}, {X: x0, Y: y1}}},
+				emis:    make(map[rpc.Emission]float64),
+			}
+		}
+	}
+
+	for _, v := range nc.Header.Variables() {
+		pol := coardsPollutant(v, pollutants)
+		if pol == rpc.Emission_UNKNOWN_EMISSION {
+			continue
+		}
+		dims := nc.Header.Dimensions(v)
+		lengths := nc.Header.Lengths(v)
+		n := len(dims)
+		if n < 2 || dims[n-2] != latName || dims[n-1] != lonName {
+			continue
+		}
+		begin, end := make([]int, n), make([]int, n)
+		for k := 0; k < n-2; k++ {
+			if lengths[k] != 1 {
+				return nil, fmt.Errorf("cityaq: emissions inventory variable %s in %s has length %d in dimension %s; only one time step is supported", v, f, lengths[k], dims[k])
+			}
+			end[k] = 1
+		}
+		fill := coardsFillValue(nc, v)
+		for i := i0; i < i1; i++ {
+			begin[n-2], end[n-2] = i, i+1
+			begin[n-1], end[n-1] = j0, j1
+			row, err := readCOARDSRange(nc, v, begin, end)
+			if err != nil {
+				return nil, fmt.Errorf("cityaq: reading %s from %s: %v", v, f, err)
+			}
+			for j, val := range row {
+				if math.IsNaN(val) || (fill != nil && val == *fill) {
+					continue
+				}
+				cells[(i-i0)*(j1-j0)+j].emis[pol] += val
+			}
+		}
+	}
+	return cells, nil
+}
+
+// coardsCoordinate returns the first of the given names that is
+// a variable in nc, or an empty string if there is none.
+func coardsCoordinate(nc *cdf.File, names ...string) string {
+	for _, v := range nc.Header.Variables() {
+		for _, n := range names {
+			if strings.EqualFold(v, n) {
+				return v
+			}
+		}
+	}
+	return ""
+}
+
+// coardsPollutant returns the pollutant that matches the
+// given inventory variable name, or UNKNOWN_EMISSION if
+// none of the pollutants match.
+func coardsPollutant(name string, pollutants []rpc.Emission) rpc.Emission {
+	normalize := strings.NewReplacer("_", "", ".", "")
+	name = normalize.Replace(strings.ToUpper(name))
+	for _, p := range pollutants {
+		if normalize.Replace(strings.ToUpper(p.String())) == name {
+			return p
+		}
+	}
+	return rpc.Emission_UNKNOWN_EMISSION
+}
+
+// coardsEdges returns the edges of the cells with the given
+// midpoints, which must be evenly increasing or decreasing.
+func coardsEdges(centers []float64) ([]float64, error) {
+	if len(centers) < 2 {
+		return nil, fmt.Errorf("at least two coordinates are required")
+	}
+	o := make([]float64, len(centers)+1)
+	for i := 1; i < len(centers); i++ {
+		o[i] = (centers[i-1] + centers[i]) / 2
+		if (centers[i]-centers[i-1])*(centers[1]-centers[0]) <= 0 {
+			return nil, fmt.Errorf("coordinates must be strictly increasing or decreasing")
+		}
+	}
+	o[0] = centers[0] - (o[1] - centers[0])
+	n := len(centers)
+	o[n] = centers[n-1] + (centers[n-1] - o[n-1])
+	return o, nil
+}
+
+// coardsRange returns the range [i0, i1) of the cells with the given
+// edges that overlap the interval from min to max.
+func coardsRange(edges []float64, min, max float64) (i0, i1 int) {
+	i0, i1 = len(edges)-1, 0
+	for i := 0; i < len(edges)-1; i++ {
+		lo, hi := math.Min(edges[i], edges[i+1]), math.Max(edges[i], edges[i+1])
+		if hi <= min || lo >= max {
+			continue
+		}
+		if i < i0 {
+			i0 = i
+		}
+		if i+1 > i1 {
+			i1 = i + 1
+		}
+	}
+	return i0, i1
+}
+
+// coardsFillValue returns the _FillValue attribute of variable v,
+// or nil if it doesn't have one.
+func coardsFillValue(nc *cdf.File, v string) *float64 {
+	var fill float64
+	switch a := nc.Header.GetAttribute(v, "_FillValue").(type) {
+	case []float32:
+		if len(a) == 0 {
+			return nil
+		}
+		fill = float64(a[0])
+	case []float64:
+		if len(a) == 0 {
+			return nil
+		}
+		fill = a[0]
+	default:
+		return nil
+	}
+	return &fill
+}
+
+// readCOARDSVar reads all of the values of variable v.
+func readCOARDSVar(nc *cdf.File, v string) ([]float64, error) {
+	lengths := nc.Header.Lengths(v)
+	return readCOARDSRange(nc, v, make([]int, len(lengths)), lengths)
+}
+
+// readCOARDSRange reads the values of variable v from index begin
+// up to, but not including, index end.
+func readCOARDSRange(nc *cdf.File, v string, begin, end []int) ([]float64, error) {
+	r := nc.Reader(v, begin, end)
+	buf := r.Zero(-1)
+	if _, err := r.Read(buf); err != nil {
+		return nil, err
+	}
+	switch t := buf.(type) {
+	case []float32:
+		o := make([]float64, len(t))
+		for i, x := range t {
+			o[i] = float64(x)
+		}
+		return o, nil
+	case []float64:
+		return t, nil
+	default:
+		return nil, fmt.Errorf("unsupported data type %T", buf)
+	}
+}
@@ -0,0 +1,110 @@
+package cityaq
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"reflect"
+	"testing"
+
+	rpc "github.com/ctessum/cityaq/cityaqrpc"
+	"github.com/spatialmodel/inmap/emissions/aep/aeputil"
+	"gonum.org/v1/gonum/floats"
+)
+
+func TestCityAQ_GriddedEmissions_inventory(t *testing.T) {
+	dir, err := ioutil.TempDir("", "cityaq_inventory")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	c := &CityAQ{
+		CityGeomDir: "testdata/cities",
+		SpatialConfig: aeputil.SpatialConfig{
+			SrgSpecOSM:    "testdata/srgspec_osm.json",
+			SCCExactMatch: true,
+			GridRef:       []string{"testdata/gridref.txt"},
+			OutputSR:      "+proj=longlat",
+			InputSR:       "+proj=longlat",
+		},
+		CacheLoc:                 "file://" + dir,
+		InMAPCityTotalConfigFile: "testdata/inmap_config_coards.toml",
+		InMAPTotalConfigFile:     "testdata/inmap_config_coards.toml",
+	}
+	ctx := context.Background()
+	// The study area is entirely within one 2.5 x 2 degree inventory cell.
+	area, err := c.RegisterStudyArea(ctx, &rpc.RegisterStudyAreaRequest{
+		Name: "Honolulu",
+		WKT:  "POLYGON ((-158.3 21.2, -157.6 21.2, -157.6 21.7, -158.3 21.7, -158.3 21.2))",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	req := &rpc.GriddedEmissionsRequest{
+		CityName:       area.ID,
+		SourceType:     "all",
+		Emission:       rpc.Emission_NOx,
+		SimulationType: rpc.SimulationType_CityTotal,
+		Resolution:     0.05,
+	}
+	cityEmis, err := c.GriddedEmissions(ctx, req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(cityEmis.Emissions) != len(cityEmis.Polygons) {
+		t.Fatalf("%d emissions values for %d grid cells", len(cityEmis.Emissions), len(cityEmis.Polygons))
+	}
+	citySum := floats.Sum(cityEmis.Emissions)
+	const tonsToKg = 907.18474
+	want := 26140.8 * (0.7 * 0.5) / (2.5 * 2) * tonsToKg
+	if !similar(citySum, want, 1.0e-6) {
+		t.Errorf("CityTotal emissions: %g != %g", citySum, want)
+	}
+
+	req.SimulationType = rpc.SimulationType_Total
+	totalEmis, err := c.GriddedEmissions(ctx, req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if totalSum := floats.Sum(totalEmis.Emissions); totalSum <= citySum {
+		t.Errorf("Total emissions %g should be greater than CityTotal emissions %g", totalSum, citySum)
+	}
+
+	req.SourceType = "roadways"
+	if _, err := c.GriddedEmissions(ctx, req); err == nil {
+		t.Error("expected an error for a sector that isn't in the inventory")
+	}
+}
+
+func TestCOARDSEdges(t *testing.T) {
+	edges, err := coardsEdges([]float64{24, 22, 20})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := []float64{25, 23, 21, 19}; !reflect.DeepEqual(edges, want) {
+		t.Errorf("%v != %v", edges, want)
+	}
+	if i0, i1 := coardsRange(edges, 21.2, 21.7); i0 != 1 || i1 != 2 {
+		t.Errorf("range: [%d, %d) != [1, 2)", i0, i1)
+	}
+	if _, err := coardsEdges([]float64{1, 2, 2}); err == nil {
+		t.Error("expected an error for repeated coordinates")
+	}
+}
+
+func TestCOARDSPollutant(t *testing.T) {
+	pols := []rpc.Emission{rpc.Emission_NOx, rpc.Emission_PM2_5}
+	for name, want := range map[string]rpc.Emission{
+		"PM2_5": rpc.Emission_PM2_5,
+		"pm25":  rpc.Emission_PM2_5,
+		"PM2.5": rpc.Emission_PM2_5,
+		"NOX":   rpc.Emission_NOx,
+		"SOx":   rpc.Emission_UNKNOWN_EMISSION,
+	} {
+		if p := coardsPollutant(name, pols); p != want {
+			t.Errorf("%s: %s != %s", name, p, want)
+		}
+	}
+}
@@ -0,0 +1,174 @@
+package cityaq
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/ctessum/geom"
+)
+
+// kmlPlacemark represents a KML Placemark element.
+type kmlPlacemark struct {
+	Name         string `xml:"name"`
+	ExtendedData struct {
+		Data []struct {
+			Name  string `xml:"name,attr"`
+			Value string `xml:"value"`
+		} `xml:"Data"`
+		SchemaData []struct {
+			SimpleData []struct {
+				Name  string `xml:"name,attr"`
+				Value string `xml:",chardata"`
+			} `xml:"SimpleData"`
+		} `xml:"SchemaData"`
+	} `xml:"ExtendedData"`
+	Polygons      []kmlPolygon       `xml:"Polygon"`
+	MultiGeometry []kmlMultiGeometry `xml:"MultiGeometry"`
+}
+
+type kmlMultiGeometry struct {
+	Polygons      []kmlPolygon       `xml:"Polygon"`
+	MultiGeometry []kmlMultiGeometry `xml:"MultiGeometry"`
+}
+
+type kmlPolygon struct {
+	Outer string   `xml:"outerBoundaryIs>LinearRing>coordinates"`
+	Inner []string `xml:"innerBoundaryIs>LinearRing>coordinates"`
+}
+
+// properties returns the name and extended data of the placemark.
+func (p *kmlPlacemark) properties() map[string]interface{} {
+	props := make(map[string]interface{})
+	if name := strings.TrimSpace(p.Name); name != "" {
+		props["name"] = name
+	}
+	for _, d := range p.ExtendedData.Data {
+		props[d.Name] = strings.TrimSpace(d.Value)
+	}
+	for _, sd := range p.ExtendedData.SchemaData {
+		for _, d := range sd.SimpleData {
+			props[d.Name] = strings.TrimSpace(d.Value)
+		}
+	}
+	return props
+}
+
+// polygons returns the polygons in the placemark.
+func (p *kmlPlacemark) polygons() (geom.MultiPolygon, error) {
+	return kmlPolygons(p.Polygons, p.MultiGeometry)
+}
+
+func kmlPolygons(polygons []kmlPolygon, multi []kmlMultiGeometry) (geom.MultiPolygon, error) {
+	var o geom.MultiPolygon
+	for _, p := range polygons {
+		ring, err := kmlCoordinates(p.Outer)
+		if err != nil {
+			return nil, err
+		}
+		poly := geom.Polygon{ring}
+		for _, inner := range p.Inner {
+			ring, err := kmlCoordinates(inner)
+			if err != nil {
+				return nil, err
+			}
+			poly = append(poly, ring)
+		}
+		o = append(o, poly)
+	}
+	for _, m := range multi {
+		polys, err := kmlPolygons(m.Polygons, m.MultiGeometry)
+		if err != nil {
+			return nil, err
+		}
+		o = append(o, polys...)
+	}
+	return o, nil
+}
+
+// kmlCoordinates parses a KML coordinates element, which contains
+// whitespace-separated longitude,latitude[,altitude] tuples.
+func kmlCoordinates(s string) (geom.Path, error) {
+	var o geom.Path
+	for _, tuple := range strings.Fields(s) {
+		parts := strings.Split(tuple, ",")
+		if len(parts) < 2 || len(parts) > 3 {
+			return nil, fmt.Errorf("invalid KML coordinates %q", tuple)
+		}
+		x, err := strconv.ParseFloat(parts[0], 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid KML coordinates %q", tuple)
+		}
+		y, err := strconv.ParseFloat(parts[1], 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid KML coordinates %q", tuple)
+		}
+		o = append(o, geom.Point{X: x, Y: y})
+	}
+	return o, nil
+}
+
+// kmlPlacemarks returns the Placemarks in a KML file, wherever
+// they are in the Document and Folder hierarchy.
+func kmlPlacemarks(path string) ([]kmlPlacemark, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	d := xml.NewDecoder(f)
+	var o []kmlPlacemark
+	for {
+		tok, err := d.Token()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("file %s: %v", path, err)
+		}
+		if se, ok := tok.(xml.StartElement); ok && se.Name.Local == "Placemark" {
+			var p kmlPlacemark
+			if err := d.DecodeElement(&p, &se); err != nil {
+				return nil, fmt.Errorf("file %s: %v", path, err)
+			}
+			o = append(o, p)
+		}
+	}
+	return o, nil
+}
+
+// kmlProperties returns the name and extended data of the first
+// Placemark in a KML file that has them.
+func kmlProperties(path string) (map[string]interface{}, error) {
+	placemarks, err := kmlPlacemarks(path)
+	if err != nil {
+		return nil, err
+	}
+	for _, p := range placemarks {
+		if props := p.properties(); len(props) > 0 {
+			return props, nil
+		}
+	}
+	return nil, fmt.Errorf("file %s: couldn't find feature properties", path)
+}
+
+// kmlGeometry returns the boundary in a KML file. KML coordinates
+// are always longitude and latitude, so no reprojection is needed.
+func kmlGeometry(path string) (geom.MultiPolygon, error) {
+	placemarks, err := kmlPlacemarks(path)
+	if err != nil {
+		return nil, err
+	}
+	var polys geom.MultiPolygon
+	for _, p := range placemarks {
+		poly, err := p.polygons()
+		if err != nil {
+			return nil, fmt.Errorf("file %s: %v", path, err)
+		}
+		polys = append(polys, poly...)
+	}
+	return polys, nil
+}
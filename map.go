@@ -79,12 +79,19 @@ type MapSpecification struct {
 	Emission       rpc.Emission
 	SourceType     string
 	SimulationType rpc.SimulationType
-	s              *MapTileServer
+
+	// Resolution and AutoResolution specify the emissions
+	// grid resolution as in GriddedEmissionsRequest.
+	Resolution     float64
+	AutoResolution bool
+
+	s *MapTileServer
 }
 
 // Key returns a unique identifier for the receiver.
 func (ms *MapSpecification) Key() string {
-	return fmt.Sprintf("%s_%d_%d_%s_%d", ms.CityName, ms.ImpactType, ms.Emission, ms.SourceType, ms.SimulationType)
+	return fmt.Sprintf("%s_%d_%d_%s_%d%s", ms.CityName, ms.ImpactType, ms.Emission, ms.SourceType, ms.SimulationType,
+		resolutionName(ms.Resolution, ms.AutoResolution))
 }
 
 func queryString(u *url.URL, q url.Values, k string) (string, error) {
@@ -109,6 +116,7 @@ func queryInt(u *url.URL, q url.Values, k string) (int, error) {
 
 // parseRequest parses a request of the type
 // xxx?x={x}&y={y}&z={z}&c={city}&it={ImpactType}&em={Emission}&st={SourceType}&sit={SimulationType}
+// with optional parameters res={Resolution} and ares={AutoResolution}.
 func parseMapRequest(u *url.URL) (*MapSpecification, int, int, int, error) {
 	q := u.Query()
 	ms := new(MapSpecification)
@@ -151,6 +159,19 @@ func parseMapRequest(u *url.URL) (*MapSpecification, int, int, int, error) {
 	}
 	ms.SimulationType = rpc.SimulationType(i)
 
+	if v := q.Get("res"); v != "" {
+		ms.Resolution, err = strconv.ParseFloat(v, 64)
+		if err != nil {
+			return nil, -1, -1, -1, fmt.Errorf("map request invalid value for res: %s", v)
+		}
+	}
+	if v := q.Get("ares"); v != "" {
+		ms.AutoResolution, err = strconv.ParseBool(v)
+		if err != nil {
+			return nil, -1, -1, -1, fmt.Errorf("map request invalid value for ares: %s", v)
+		}
+	}
+
 	return ms, x, y, z, nil
 }
 
@@ -179,13 +200,6 @@ func (s *MapTileServer) Layers(ctx context.Context, ms *MapSpecification) (mvt.L
 	return cloneLayers(layers.L), nil
 }
 
-func mapResolution(sourceType, cityName string) float64 {
-	if egugridEmissions(sourceType) {
-		return 0.1
-	}
-	return 0.005
-}
-
 func (ms *MapSpecification) Run(ctx context.Context, r requestcache.Result) error {
 	var dataLayer *mvt.Layer
 	switch ms.ImpactType {
@@ -195,6 +209,8 @@ func (ms *MapSpecification) Run(ctx context.Context, r requestcache.Result) erro
 			Emission:       ms.Emission,
 			SourceType:     ms.SourceType,
 			SimulationType: ms.SimulationType,
+			Resolution:     ms.Resolution,
+			AutoResolution: ms.AutoResolution,
 		}
 		var err error
 		dataLayer, err = ms.s.c.emissionsMapData(ctx, req)
@@ -207,6 +223,8 @@ func (ms *MapSpecification) Run(ctx context.Context, r requestcache.Result) erro
 			Emission:       ms.Emission,
 			SourceType:     ms.SourceType,
 			SimulationType: ms.SimulationType,
+			Resolution:     ms.Resolution,
+			AutoResolution: ms.AutoResolution,
 		}
 		var err error
 		dataLayer, err = ms.s.c.concentrationsMapData(ctx, req)
@@ -217,7 +235,7 @@ func (ms *MapSpecification) Run(ctx context.Context, r requestcache.Result) erro
 		return fmt.Errorf("invalid impact type %s", ms.ImpactType.String())
 	}
 
-	cityGeom, err := ms.s.c.geojsonGeometry(ms.CityName)
+	cityGeom, err := ms.s.c.cityGeometry(ms.CityName)
 	if err != nil {
 		return err
 	}
@@ -230,13 +248,23 @@ func (ms *MapSpecification) Run(ctx context.Context, r requestcache.Result) erro
 	o := r.(*layersResponse)
 	o.L = mvt.Layers{dataLayer, cityLayer}
 
-	if egugridEmissions(ms.SourceType) {
-		egugridGeom, err := ms.s.c.countryOrGridBuffer(ms.CityName)
+	var st *sourceType
+	switch ms.SimulationType {
+	case rpc.SimulationType_CityTotal, rpc.SimulationType_Total:
+		st, err = ms.s.c.sectorSourceType(ms.SourceType)
+	default:
+		st, err = ms.s.c.sourceType(ms.SourceType)
+	}
+	if err != nil {
+		return err
+	}
+	if st.egugrid() {
+		egugridGeom, err := ms.s.c.egugridRegion(ms.CityName)
 		if err != nil {
 			return err
 		}
 		egugridLayerData := geojson.NewFeatureCollection()
-		feature := geojson.NewFeature(geomToOrb(egugridGeom.Polygon))
+		feature := geojson.NewFeature(geomToOrb(egugridGeom.MultiPolygon))
 		feature.ID = uint64(0)
 		egugridLayerData = egugridLayerData.Append(feature)
 		o.L = append(o.L, mvt.NewLayer(ms.CityName+"_egugrid", egugridLayerData))
@@ -43,6 +43,22 @@ func TestParseMapRequest(t *testing.T) {
 	if z != 12 {
 		t.Errorf("z: %d != %d", z, 12)
 	}
+
+	u, err = url.Parse(u.String() + "&res=0.01&ares=false")
+	if err != nil {
+		t.Fatal(err)
+	}
+	ms.Resolution = 0.01
+	newMS, _, _, _, err = parseMapRequest(u)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(newMS, ms) {
+		t.Errorf("map spec with resolution; %+v != %+v", newMS, ms)
+	}
+	if newMS.Key() == (&MapSpecification{CityName: ms.CityName, Emission: ms.Emission, ImpactType: ms.ImpactType, SourceType: ms.SourceType}).Key() {
+		t.Error("map specifications with different resolutions should have different keys")
+	}
 }
 
 func TestMapTileServer_ServeHTTP(t *testing.T) {
@@ -0,0 +1,114 @@
+package cityaq
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	rpc "github.com/ctessum/cityaq/cityaqrpc"
+	"github.com/ctessum/geom"
+	"github.com/ctessum/geom/index/rtree"
+)
+
+// cityIndex is a spatial index of the boundaries of the cities
+// in the catalog.
+type cityIndex struct {
+	// key identifies the versions of the boundary files that
+	// the index was created from.
+	key  string
+	tree *rtree.Rtree
+}
+
+// cityIndexPart is one part of the boundary of a city in a cityIndex.
+type cityIndexPart struct {
+	geom.Polygon
+	city cityFile
+}
+
+// spatialIndex returns a spatial index of the boundaries of the cities
+// in the catalog. The index is rebuilt when the catalog changes.
+func (c *CityAQ) spatialIndex() (*rtree.Rtree, error) {
+	cat := c.catalog()
+	if err := cat.load(); err != nil {
+		return nil, err
+	}
+	cities := cat.cities()
+	keys := make([]string, len(cities))
+	for i, f := range cities {
+		keys[i] = cityMetaKey(f)
+	}
+	key := strings.Join(keys, "\n")
+
+	c.cityIndexMu.Lock()
+	defer c.cityIndexMu.Unlock()
+	if c.cityIndex != nil && c.cityIndex.key == key {
+		return c.cityIndex.tree, nil
+	}
+	tree := rtree.NewTree(25, 50)
+	for _, f := range cities {
+		g, err := c.cityGeometry(f.id)
+		if err != nil {
+			return nil, err
+		}
+		for _, p := range g {
+			tree.Insert(&cityIndexPart{Polygon: p, city: f})
+		}
+	}
+	c.cityIndex = &cityIndex{key: key, tree: tree}
+	return tree, nil
+}
+
+// polygonContains returns whether pt is inside of p. Rings that
+// are inside an odd number of other rings are treated as holes.
+func polygonContains(p geom.Polygon, pt geom.Point) bool {
+	var n int
+	for _, ring := range p {
+		if pointInRing(pt, ring) {
+			n++
+		}
+	}
+	return n%2 == 1
+}
+
+// CitiesContaining returns the cities whose boundaries
+// contain each of the requested points.
+func (c *CityAQ) CitiesContaining(ctx context.Context, req *rpc.CitiesContainingRequest) (*rpc.CitiesContainingResponse, error) {
+	index, err := c.spatialIndex()
+	if err != nil {
+		return nil, err
+	}
+	o := &rpc.CitiesContainingResponse{
+		Points: make([]*rpc.PointCities, len(req.Points)),
+	}
+	for i, p := range req.Points {
+		if p == nil {
+			return nil, fmt.Errorf("cityaq: point %d is missing", i)
+		}
+		pt := geom.Point{X: p.X, Y: p.Y}
+		pc := new(rpc.PointCities)
+		found := make(map[string]bool)
+		for _, partI := range index.SearchIntersect(pt.Bounds()) {
+			part := partI.(*cityIndexPart)
+			if found[part.city.id] || !polygonContains(part.Polygon, pt) {
+				continue
+			}
+			found[part.city.id] = true
+			displayName, err := localizedCityName(part.city.props, req.Language)
+			if err != nil {
+				return nil, err
+			}
+			pc.Cities = append(pc.Cities, &rpc.City{
+				ID:          part.city.id,
+				DisplayName: displayName,
+				Country:     c.meta(part.city).country,
+				Region:      cityRegion(part.city.props),
+			})
+		}
+		sort.Slice(pc.Cities, func(i, j int) bool {
+			return pc.Cities[i].DisplayName < pc.Cities[j].DisplayName
+		})
+		o.Points[i] = pc
+	}
+	return o, nil
+}
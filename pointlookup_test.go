@@ -0,0 +1,95 @@
+package cityaq
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	rpc "github.com/ctessum/cityaq/cityaqrpc"
+)
+
+func TestCityAQ_CitiesContaining(t *testing.T) {
+	dir, err := ioutil.TempDir("", "cityaq_containing")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	// An island city with a lake on the main island and a
+	// smaller island to the east.
+	const islands = `{"type":"FeatureCollection","features":[{"type":"Feature",
+"properties":{"name":"Islands"},
+"geometry":{"type":"MultiPolygon","coordinates":[
+[[[103.6,1.2],[104.0,1.2],[104.0,1.5],[103.6,1.5],[103.6,1.2]],
+[[103.7,1.3],[103.7,1.4],[103.8,1.4],[103.8,1.3],[103.7,1.3]]],
+[[[104.1,1.3],[104.2,1.3],[104.2,1.4],[104.1,1.4],[104.1,1.3]]]]}}]}`
+	// A district that overlaps the western part of the main island.
+	const district = `{"type":"FeatureCollection","features":[{"type":"Feature",
+"properties":{"name":"West District","name:fr":"District Ouest"},
+"geometry":{"type":"Polygon","coordinates":[
+[[103.5,1.1],[103.65,1.1],[103.65,1.6],[103.5,1.6],[103.5,1.1]]]}}]}`
+	if err := ioutil.WriteFile(filepath.Join(dir, "islands.geojson"), []byte(islands), 0644); err != nil {
+		t.Fatal(err)
+	}
+	c := &CityAQ{CityGeomDir: dir}
+
+	lookup := func(t *testing.T, lang string, points ...*rpc.Point) [][]string {
+		r, err := c.CitiesContaining(context.Background(), &rpc.CitiesContainingRequest{
+			Points:   points,
+			Language: lang,
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		o := make([][]string, len(r.Points))
+		for i, p := range r.Points {
+			for _, city := range p.Cities {
+				o[i] = append(o[i], city.DisplayName)
+			}
+		}
+		return o
+	}
+
+	t.Run("islands", func(t *testing.T) {
+		have := lookup(t, "",
+			&rpc.Point{X: 103.9, Y: 1.3},   // Main island.
+			&rpc.Point{X: 103.75, Y: 1.35}, // Lake.
+			&rpc.Point{X: 104.15, Y: 1.35}, // Small island.
+			&rpc.Point{X: 104.05, Y: 1.35}, // Sea.
+		)
+		want := [][]string{{"Islands"}, nil, {"Islands"}, nil}
+		if !reflect.DeepEqual(have, want) {
+			t.Errorf("%v != %v", have, want)
+		}
+	})
+
+	t.Run("overlapping", func(t *testing.T) {
+		if err := ioutil.WriteFile(filepath.Join(dir, "district.geojson"), []byte(district), 0644); err != nil {
+			t.Fatal(err)
+		}
+		if err := c.catalog().refresh(); err != nil {
+			t.Fatal(err)
+		}
+		have := lookup(t, "fr",
+			&rpc.Point{X: 103.62, Y: 1.3},
+			&rpc.Point{X: 103.55, Y: 1.3},
+			&rpc.Point{X: 103.9, Y: 1.3},
+		)
+		want := [][]string{{"District Ouest", "Islands"}, {"District Ouest"}, {"Islands"}}
+		if !reflect.DeepEqual(have, want) {
+			t.Errorf("%v != %v", have, want)
+		}
+	})
+
+	t.Run("missing point", func(t *testing.T) {
+		_, err := c.CitiesContaining(context.Background(), &rpc.CitiesContainingRequest{
+			Points: []*rpc.Point{nil},
+		})
+		if err == nil {
+			t.Error("expected an error")
+		}
+	})
+}
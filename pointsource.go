@@ -0,0 +1,113 @@
+package cityaq
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io/ioutil"
+	"math"
+	"path/filepath"
+
+	rpc "github.com/ctessum/cityaq/cityaqrpc"
+	"github.com/ctessum/geom"
+	"github.com/ctessum/geom/encoding/shp"
+)
+
+// pointSource is an emissions source with an elevated release,
+// in the format that InMAP reads from emissions shapefiles.
+type pointSource struct {
+	geom.Point
+	PM2_5, VOC, NH3, NOx, SOx    float64 // kg/year
+	Height, Diam, Temp, Velocity float64 // m, m, K, m/s
+}
+
+// requestPointSources checks the given point sources
+// and converts them to the format used by InMAP.
+func requestPointSources(sources []*rpc.PointSource) ([]pointSource, error) {
+	o := make([]pointSource, len(sources))
+	for i, s := range sources {
+		if s == nil || s.Location == nil {
+			return nil, fmt.Errorf("cityaq: point source %d is missing a location", i)
+		}
+		p := pointSource{
+			Point:    geom.Point{X: s.Location.X, Y: s.Location.Y},
+			PM2_5:    s.PM2_5,
+			VOC:      s.VOC,
+			NH3:      s.NH3,
+			NOx:      s.NOx,
+			SOx:      s.SOx,
+			Height:   s.StackHeight,
+			Diam:     s.StackDiameter,
+			Temp:     s.StackTemperature,
+			Velocity: s.StackVelocity,
+		}
+		if x, y := s.Location.X, s.Location.Y; invalidFloat(x, y) || x < -180 || x > 180 || y < -90 || y > 90 {
+			return nil, fmt.Errorf("cityaq: point source %d location (%g, %g) is not a valid longitude and latitude", i, x, y)
+		}
+		if invalidFloat(p.PM2_5, p.VOC, p.NH3, p.NOx, p.SOx) || p.PM2_5 < 0 || p.VOC < 0 || p.NH3 < 0 || p.NOx < 0 || p.SOx < 0 {
+			return nil, fmt.Errorf("cityaq: point source %d has invalid emissions", i)
+		}
+		if p.PM2_5+p.VOC+p.NH3+p.NOx+p.SOx == 0 {
+			return nil, fmt.Errorf("cityaq: point source %d has no emissions", i)
+		}
+		if invalidFloat(p.Height, p.Diam, p.Temp, p.Velocity) || p.Height < 0 || p.Diam <= 0 || p.Temp <= 0 || p.Velocity < 0 {
+			return nil, fmt.Errorf("cityaq: point source %d has invalid stack parameters: height %g m, diameter %g m, temperature %g K, velocity %g m/s",
+				i, p.Height, p.Diam, p.Temp, p.Velocity)
+		}
+		o[i] = p
+	}
+	return o, nil
+}
+
+// checkPointSourceAmounts returns an error if an emission type, amount,
+// units, pollutants, or speciation are specified along with point
+// sources, which specify their own emissions.
+func checkPointSourceAmounts(points []pointSource, emission rpc.Emission, amount float64, units rpc.EmissionUnits, pollutants []*rpc.PollutantAmount, speciate bool) error {
+	if len(points) == 0 {
+		return nil
+	}
+	if emission != rpc.Emission_UNKNOWN_EMISSION || amount != 0 || units != rpc.EmissionUnits_UNKNOWN_EMISSIONUNITS || len(pollutants) > 0 || speciate {
+		return fmt.Errorf("cityaq: Emission, Amount, AmountUnits, Pollutants, and Speciate can't be used with point sources, which specify their own emissions")
+	}
+	return nil
+}
+
+// invalidFloat returns whether any of the given values are NaN or infinite.
+func invalidFloat(vals ...float64) bool {
+	for _, v := range vals {
+		if math.IsNaN(v) || math.IsInf(v, 0) {
+			return true
+		}
+	}
+	return false
+}
+
+// pointSourcesID returns an identifier that is unique to the given
+// point sources, for use in cache keys.
+func pointSourcesID(points []pointSource) string {
+	h := sha256.Sum256([]byte(fmt.Sprintf("%v", points)))
+	return hex.EncodeToString(h[:8])
+}
+
+// pointSourcesToShp saves the point sources of this job to a temporary shapefile.
+func (j *concentrationJob) pointSourcesToShp() (string, error) {
+	dir, err := ioutil.TempDir("", "cityaq_point_sources")
+	if err != nil {
+		return "", err
+	}
+	file := filepath.Join(dir, "emissions.shp")
+	e, err := shp.NewEncoder(file, pointSource{})
+	if err != nil {
+		return "", err
+	}
+	for i := range j.pointSources {
+		if err := e.Encode(&j.pointSources[i]); err != nil {
+			return "", err
+		}
+	}
+	e.Close()
+	if err := writeLonLatPRJ(file); err != nil {
+		return "", err
+	}
+	return file, nil
+}
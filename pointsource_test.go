@@ -0,0 +1,187 @@
+package cityaq
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	rpc "github.com/ctessum/cityaq/cityaqrpc"
+	"github.com/ctessum/geom/encoding/shp"
+)
+
+func testPointSource() *rpc.PointSource {
+	return &rpc.PointSource{
+		Location:         &rpc.Point{X: -0.2, Y: 5.6},
+		NOx:              2.0e5,
+		PM2_5:            1.0e4,
+		StackHeight:      80,
+		StackDiameter:    5,
+		StackTemperature: 450,
+		StackVelocity:    20,
+	}
+}
+
+func TestRequestPointSources(t *testing.T) {
+	tests := []struct {
+		name   string
+		modify func(*rpc.PointSource)
+		err    bool
+	}{
+		{name: "valid", modify: func(*rpc.PointSource) {}},
+		{name: "ground level", modify: func(p *rpc.PointSource) { p.StackHeight = 0 }},
+		{name: "no location", modify: func(p *rpc.PointSource) { p.Location = nil }, err: true},
+		{name: "bad location", modify: func(p *rpc.PointSource) { p.Location.Y = 95 }, err: true},
+		{name: "negative emissions", modify: func(p *rpc.PointSource) { p.SOx = -1 }, err: true},
+		{name: "no emissions", modify: func(p *rpc.PointSource) { p.NOx, p.PM2_5 = 0, 0 }, err: true},
+		{name: "no diameter", modify: func(p *rpc.PointSource) { p.StackDiameter = 0 }, err: true},
+		{name: "no temperature", modify: func(p *rpc.PointSource) { p.StackTemperature = 0 }, err: true},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			p := testPointSource()
+			test.modify(p)
+			points, err := requestPointSources([]*rpc.PointSource{p})
+			if test.err {
+				if err == nil {
+					t.Error("expected an error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if points[0].NOx != p.NOx || points[0].Height != p.StackHeight {
+				t.Errorf("point source not converted correctly: %+v", points[0])
+			}
+		})
+	}
+}
+
+func TestConcentrationJob_pointSources(t *testing.T) {
+	c := &CityAQ{CityGeomDir: "testdata/cities"}
+	p1 := testPointSource()
+	p2 := testPointSource()
+	p2.StackHeight = 120
+	points1, err := requestPointSources([]*rpc.PointSource{p1})
+	if err != nil {
+		t.Fatal(err)
+	}
+	points2, err := requestPointSources([]*rpc.PointSource{p2})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	j1, err := c.newConcentrationJob("Accra Metropolitan", "roadways", rpc.SimulationType_CityMarginal, points1, nil, nil, 0, false, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	j2, err := c.newConcentrationJob("Accra Metropolitan", "airports", rpc.SimulationType_CityMarginal, points2, nil, nil, 0, false, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.HasPrefix(j1.Key(), "concentration-points-accra-metropolitan-cb28dbd7-") {
+		t.Errorf("unexpected key %s", j1.Key())
+	}
+	if j1.Key() == j2.Key() {
+		t.Errorf("point sources with different stack heights should have different keys: %s", j1.Key())
+	}
+	j3, err := c.newConcentrationJob("Accra Metropolitan", "airports", rpc.SimulationType_CityMarginal, points1, nil, nil, 0, false, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if j1.Key() != j3.Key() {
+		t.Errorf("the source type should not affect point source keys: %s != %s", j1.Key(), j3.Key())
+	}
+	if _, err := c.newConcentrationJob("Accra Metropolitan", "", rpc.SimulationType_CityTotal, points1, nil, nil, 0, false, false); err == nil {
+		t.Error("point sources should only be allowed in marginal simulations")
+	}
+
+	file, err := j1.pointSourcesToShp()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(filepath.Dir(file))
+	if _, err := os.Stat(strings.TrimSuffix(file, ".shp") + ".prj"); err != nil {
+		t.Error(err)
+	}
+	d, err := shp.NewDecoder(file)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer d.Close()
+	var n int
+	for {
+		var rec pointSource
+		if !d.DecodeRow(&rec) {
+			break
+		}
+		if !similar(rec.NOx, p1.NOx, 1e-8) || !similar(rec.Height, p1.StackHeight, 1e-8) {
+			t.Errorf("%+v != %+v", rec, points1[0])
+		}
+		n++
+	}
+	if err := d.Error(); err != nil {
+		t.Fatal(err)
+	}
+	if n != 1 {
+		t.Errorf("have %d point sources, want 1", n)
+	}
+}
+
+func TestCheckPointSourceAmounts(t *testing.T) {
+	points, err := requestPointSources([]*rpc.PointSource{testPointSource()})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := checkPointSourceAmounts(points, 0, 0, 0, nil, false); err != nil {
+		t.Errorf("point sources alone: %v", err)
+	}
+	if err := checkPointSourceAmounts(nil, rpc.Emission_NOx, 1, rpc.EmissionUnits_Tonnes, nil, false); err != nil {
+		t.Errorf("amounts alone: %v", err)
+	}
+	for _, test := range []struct {
+		name       string
+		emission   rpc.Emission
+		amount     float64
+		units      rpc.EmissionUnits
+		pollutants []*rpc.PollutantAmount
+		speciate   bool
+	}{
+		{name: "emission", emission: rpc.Emission_NOx},
+		{name: "amount", amount: 1},
+		{name: "units", units: rpc.EmissionUnits_Tonnes},
+		{name: "pollutants", pollutants: []*rpc.PollutantAmount{{Emission: rpc.Emission_SOx, Amount: 1}}},
+		{name: "speciate", speciate: true},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			if err := checkPointSourceAmounts(points, test.emission, test.amount, test.units, test.pollutants, test.speciate); err == nil {
+				t.Error("expected an error")
+			}
+		})
+	}
+}
+
+func TestCityAQ_checkPointSourceDomain(t *testing.T) {
+	c := &CityAQ{
+		CityGeomDir:                 "testdata/cities",
+		InMAPCityMarginalConfigFile: "testdata/inmap_config.toml",
+	}
+	inside, err := requestPointSources([]*rpc.PointSource{testPointSource()})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := c.checkPointSourceDomain("Accra Metropolitan", inside); err != nil {
+		t.Errorf("point source inside the domain: %v", err)
+	}
+	p := testPointSource()
+	p.Location.X = 5
+	outside, err := requestPointSources([]*rpc.PointSource{testPointSource(), p})
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = c.checkPointSourceDomain("Accra Metropolitan", outside)
+	if err == nil || !strings.Contains(err.Error(), "point source 1") {
+		t.Errorf("expected an error for point source 1, got %v", err)
+	}
+}
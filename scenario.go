@@ -0,0 +1,212 @@
+package cityaq
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"sort"
+	"strings"
+
+	rpc "github.com/ctessum/cityaq/cityaqrpc"
+	"gonum.org/v1/gonum/floats"
+)
+
+// sectorChange is a change in the emissions of an emissions
+// inventory sector within a city, in percent.
+type sectorChange struct {
+	sector  string
+	percent float64
+}
+
+// requestSectorChanges checks the given sector changes against the
+// sectors in the emissions inventory, which are specified in cfgFile,
+// and returns them sorted by sector.
+func requestSectorChanges(changes []*rpc.SectorChange, sectors map[string][]string, cfgFile string) ([]sectorChange, error) {
+	if len(changes) == 0 {
+		return nil, fmt.Errorf("cityaq: no sector changes were specified")
+	}
+	o := make([]sectorChange, len(changes))
+	found := make(map[string]bool)
+	var changed bool
+	for i, sc := range changes {
+		if sc == nil {
+			return nil, fmt.Errorf("cityaq: sector change %d is missing", i)
+		}
+		if _, ok := sectors[sc.Sector]; !ok {
+			return nil, fmt.Errorf("cityaq: emissions inventory sector %q is not in %s", sc.Sector, cfgFile)
+		}
+		if found[sc.Sector] {
+			return nil, fmt.Errorf("cityaq: sector %s is changed more than once", sc.Sector)
+		}
+		found[sc.Sector] = true
+		if invalidFloat(sc.Percent) || sc.Percent < -100 {
+			return nil, fmt.Errorf("cityaq: invalid change %g%% for sector %s", sc.Percent, sc.Sector)
+		}
+		if sc.Percent != 0 {
+			changed = true
+		}
+		o[i] = sectorChange{sector: sc.Sector, percent: sc.Percent}
+	}
+	if !changed {
+		return nil, fmt.Errorf("cityaq: none of the sector changes change the emissions")
+	}
+	sort.Slice(o, func(i, j int) bool { return o[i].sector < o[j].sector })
+	return o, nil
+}
+
+// sectorChangesID returns an identifier that is unique to the
+// given sector changes, for use in cache keys.
+func sectorChangesID(changes []sectorChange) string {
+	h := sha256.Sum256([]byte(fmt.Sprintf("%v", changes)))
+	return hex.EncodeToString(h[:8])
+}
+
+// newScenarioJob creates a new CityTotal concentration job for the
+// given city with the emissions inventory sectors in changes, whose
+// emissions in the city are changed by the given percentages.
+func (c *CityAQ) newScenarioJob(city string, changes []sectorChange) (*concentrationJob, error) {
+	if len(changes) == 1 && changes[0].percent == 0 {
+		// This is a regular CityTotal simulation, which may already
+		// have been run.
+		return c.newConcentrationJob(city, changes[0].sector, rpc.SimulationType_CityTotal, nil, nil, nil, 0, false, false)
+	}
+	_, f, err := c.catalog().lookup(city)
+	if err != nil {
+		return nil, err
+	}
+	sectors := make([]string, len(changes))
+	for i, sc := range changes {
+		sectors[i] = sc.sector
+	}
+	job := &concentrationJob{
+		c:              c,
+		CityID:         f.id,
+		cityName:       f.name,
+		cityVersion:    f.version,
+		SourceType:     strings.Join(sectors, ","),
+		SimulationType: rpc.SimulationType_CityTotal,
+		sectorChanges:  changes,
+		scenarioID:     sectorChangesID(changes),
+	}
+	if err := c.checkCacheKey(job.Key(), job.description()); err != nil {
+		return nil, err
+	}
+	return job, nil
+}
+
+// hasSectorChanges returns whether the receiver changes
+// the emissions of any of its sectors.
+func (j *concentrationJob) hasSectorChanges() bool {
+	for _, sc := range j.sectorChanges {
+		if sc.percent != 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// sectorChangesToShp saves the changes in the emissions of the
+// receiver's sectors within the city to a temporary shapefile. The
+// changes are negative where emissions are reduced; InMAP adds them to
+// the inventory emissions.
+func (j *concentrationJob) sectorChangesToShp(ctx context.Context) (string, error) {
+	var records []*emisRecord
+	for _, sc := range j.sectorChanges {
+		if sc.percent == 0 {
+			continue
+		}
+		emis, err := j.c.GriddedEmissions(ctx, &rpc.GriddedEmissionsRequest{
+			CityName:       j.CityID,
+			SourceType:     sc.sector,
+			SimulationType: rpc.SimulationType_CityTotal,
+			Pollutants: []*rpc.PollutantAmount{
+				{Emission: rpc.Emission_PM2_5},
+				{Emission: rpc.Emission_VOC},
+				{Emission: rpc.Emission_NH3},
+				{Emission: rpc.Emission_NOx},
+				{Emission: rpc.Emission_SOx},
+			},
+		})
+		if err != nil {
+			return "", err
+		}
+		st, err := j.c.sectorSourceType(sc.sector)
+		if err != nil {
+			return "", err
+		}
+		records = append(records, emisRecords(emis, st, sc.percent/100)...)
+	}
+	return writeEmisShp(records)
+}
+
+// ReductionScenario returns the changes in PM2.5 concentrations and
+// exposure that result from changing the emissions of emissions
+// inventory sectors within the city by the requested percentages.
+// It runs a CityTotal simulation of the requested sectors as the
+// baseline, and another with the changed emissions.
+func (c *CityAQ) ReductionScenario(ctx context.Context, req *rpc.ReductionScenarioRequest) (*rpc.ReductionScenarioResponse, error) {
+	cfg, cfgFile, err := c.inventoryConfig(rpc.SimulationType_CityTotal)
+	if err != nil {
+		return nil, err
+	}
+	changes, err := requestSectorChanges(req.Changes, cfg.GetStringMapStringSlice("aep.InventoryConfig.COARDSFiles"), cfgFile)
+	if err != nil {
+		return nil, err
+	}
+	baseline := make([]sectorChange, len(changes))
+	for i, sc := range changes {
+		baseline[i] = sectorChange{sector: sc.sector}
+	}
+
+	c.cloudSetupOnce.Do(func() {
+		err = c.cloudSetup()
+	})
+	if err != nil {
+		return nil, err
+	}
+	c.setupCache()
+
+	baseJob, err := c.newScenarioJob(req.CityName, baseline)
+	if err != nil {
+		return nil, err
+	}
+	scenarioJob, err := c.newScenarioJob(req.CityName, changes)
+	if err != nil {
+		return nil, err
+	}
+	base, err := baseJob.result(ctx)
+	if err != nil {
+		return nil, err
+	}
+	scenario, err := scenarioJob.result(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if len(base.Grid) != len(scenario.Grid) {
+		return nil, fmt.Errorf("cityaq: baseline and scenario grids have different sizes (%d and %d)",
+			len(base.Grid), len(scenario.Grid))
+	}
+
+	o := &rpc.ReductionScenarioResponse{
+		Polygons:               polygonsToRPC(base.Grid),
+		BaselineConcentrations: base.totalPM25(),
+		ScenarioConcentrations: scenario.totalPM25(),
+		ConcentrationChanges:   make([]float64, len(base.Grid)),
+		Population:             base.Population,
+	}
+	floats.SubTo(o.ConcentrationChanges, o.ScenarioConcentrations, o.BaselineConcentrations)
+
+	maskedPop, err := c.maskPopulation(ctx, &rpc.GriddedPopulationResponse{
+		Polygons:   o.Polygons,
+		Population: o.Population,
+	}, req.CityName)
+	if err != nil {
+		return nil, err
+	}
+	o.BaselineCityExposure = exposure(o.BaselineConcentrations, maskedPop)
+	o.BaselineTotalExposure = exposure(o.BaselineConcentrations, o.Population)
+	o.CityExposureChange = exposure(o.ConcentrationChanges, maskedPop)
+	o.TotalExposureChange = exposure(o.ConcentrationChanges, o.Population)
+	return o, nil
+}
@@ -0,0 +1,141 @@
+package cityaq
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"reflect"
+	"testing"
+
+	rpc "github.com/ctessum/cityaq/cityaqrpc"
+	"github.com/spatialmodel/inmap/emissions/aep/aeputil"
+	"gonum.org/v1/gonum/floats"
+)
+
+func TestRequestSectorChanges(t *testing.T) {
+	sectors := map[string][]string{"residential": nil, "transport": nil, "industry": nil}
+	changes, err := requestSectorChanges([]*rpc.SectorChange{
+		{Sector: "transport"},
+		{Sector: "residential", Percent: -40},
+	}, sectors, "config.toml")
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []sectorChange{{sector: "residential", percent: -40}, {sector: "transport"}}
+	if !reflect.DeepEqual(changes, want) {
+		t.Errorf("%v != %v", changes, want)
+	}
+
+	for _, test := range []struct {
+		name    string
+		changes []*rpc.SectorChange
+	}{
+		{"none", nil},
+		{"unknown sector", []*rpc.SectorChange{{Sector: "aviation", Percent: -10}}},
+		{"duplicate", []*rpc.SectorChange{{Sector: "industry", Percent: -10}, {Sector: "industry", Percent: 10}}},
+		{"too large reduction", []*rpc.SectorChange{{Sector: "industry", Percent: -110}}},
+		{"no change", []*rpc.SectorChange{{Sector: "industry"}}},
+	} {
+		if _, err := requestSectorChanges(test.changes, sectors, "config.toml"); err == nil {
+			t.Errorf("%s: expected an error", test.name)
+		}
+	}
+}
+
+func TestCityAQ_newScenarioJob(t *testing.T) {
+	c := &CityAQ{CityGeomDir: "testdata/cities"}
+	city := "Accra Metropolitan"
+
+	// The baseline of a single sector is a regular CityTotal simulation.
+	base, err := c.newScenarioJob(city, []sectorChange{{sector: "residential"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	regular, err := c.newConcentrationJob(city, "residential", rpc.SimulationType_CityTotal, nil, nil, nil, 0, false, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if base.Key() != regular.Key() {
+		t.Errorf("baseline key %s != %s", base.Key(), regular.Key())
+	}
+
+	keys := map[string]bool{base.Key(): true}
+	for _, changes := range [][]sectorChange{
+		{{sector: "residential", percent: -40}},
+		{{sector: "residential", percent: -50}},
+		{{sector: "industry"}, {sector: "residential"}},
+		{{sector: "industry"}, {sector: "residential", percent: -40}},
+	} {
+		j, err := c.newScenarioJob(city, changes)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if keys[j.Key()] {
+			t.Errorf("%v: duplicate key %s", changes, j.Key())
+		}
+		keys[j.Key()] = true
+		if len(j.Key()) > maxKeyLength {
+			t.Errorf("key %s is too long", j.Key())
+		}
+	}
+}
+
+func TestCityAQ_ReductionScenario(t *testing.T) {
+	dir, err := ioutil.TempDir("", "cityaq_scenario")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	c := &CityAQ{
+		CityGeomDir: "testdata/cities",
+		SpatialConfig: aeputil.SpatialConfig{
+			SrgSpecOSM:    "testdata/srgspec_osm.json",
+			SCCExactMatch: true,
+			GridRef:       []string{"testdata/gridref.txt"},
+			OutputSR:      "+proj=longlat",
+			InputSR:       "+proj=longlat",
+		},
+		CacheLoc: "file://" + dir,
+		Version:  "latest",
+		// The air quality modeling domain of this configuration
+		// covers the Hawaii test inventory.
+		InMAPCityTotalConfigFile: "testdata/inmap_config_hawaii.toml",
+	}
+	ctx := context.Background()
+	area, err := c.RegisterStudyArea(ctx, &rpc.RegisterStudyAreaRequest{
+		Name: "Honolulu",
+		WKT:  "POLYGON ((-158.3 21.2, -157.6 21.2, -157.6 21.7, -158.3 21.7, -158.3 21.2))",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	r, err := c.ReductionScenario(ctx, &rpc.ReductionScenarioRequest{
+		CityName: area.ID,
+		Changes:  []*rpc.SectorChange{{Sector: "all", Percent: -40}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.Remove("emis_mask.json")
+	if len(r.ConcentrationChanges) != len(r.Polygons) || len(r.BaselineConcentrations) != len(r.Polygons) {
+		t.Fatalf("wrong number of concentrations for %d grid cells", len(r.Polygons))
+	}
+	baseline := floats.Sum(r.BaselineConcentrations)
+	if baseline <= 0 {
+		t.Fatalf("baseline concentration sum %g should be positive", baseline)
+	}
+	// InMAP is linear in the emissions, so reducing all of the emissions
+	// in the city by 40% should reduce the concentrations by about 40%.
+	change := floats.Sum(r.ConcentrationChanges)
+	if !similar(change/baseline, -0.4, 0.05) {
+		t.Errorf("relative concentration change: %g != -0.4", change/baseline)
+	}
+	for i, v := range r.ConcentrationChanges {
+		if v > 0 {
+			t.Errorf("cell %d: concentration increased by %g", i, v)
+			break
+		}
+	}
+}
@@ -0,0 +1,341 @@
+package cityaq
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/BurntSushi/toml"
+	rpc "github.com/ctessum/cityaq/cityaqrpc"
+)
+
+// Allocation domains of source types.
+const (
+	// cityDomain specifies that emissions are allocated
+	// within the city boundary.
+	cityDomain = "city"
+
+	// egugridDomain specifies that emissions are allocated within
+	// the smaller of the country that the city is in or a buffer
+	// around the city the size of an average electric grid region.
+	egugridDomain = "egugrid"
+)
+
+// defaultResolution is the default emissions grid cell edge
+// length for each allocation domain, in degrees.
+var defaultResolution = map[string]float64{
+	cityDomain:    0.005,
+	egugridDomain: 0.1,
+}
+
+// sourceType describes how the emissions of a source type are
+// allocated and released.
+type sourceType struct {
+	// Name is the name of the spatial surrogate for the source type
+	// in the surrogate specification.
+	Name string
+
+	// Label and Description are for display to users.
+	Label       string
+	Description string
+
+	// Domain is the area that emissions are allocated within, either
+	// "city" or "egugrid". The default is "city".
+	Domain string
+
+	// Resolution is the emissions grid cell edge length in degrees.
+	// The default depends on Domain.
+	Resolution float64
+
+	// CellSize is the emissions grid cell edge length in meters when
+	// emissions grids are projected. The default depends on Domain.
+	CellSize float64
+
+	// StackHeight [m], StackDiameter [m], StackTemperature [K], and
+	// StackVelocity [m/s] are the stack parameters of elevated
+	// releases. They are zero for ground-level releases.
+	StackHeight      float64
+	StackDiameter    float64
+	StackTemperature float64
+	StackVelocity    float64
+
+	// Speciation is the fraction of the total emissions mass of the
+	// source type that is each pollutant, keyed by the pollutant names
+	// in the Emission enum. It is used to split requests for a total
+	// amount of emissions among pollutants. The fractions are
+	// normalized to add up to one.
+	Speciation map[string]float64
+}
+
+// sourceTypeFile is the format of a source type catalog file.
+type sourceTypeFile struct {
+	SourceType []*sourceType
+}
+
+// Approximate speciation of sectors, from the shares of each pollutant in
+// the total emissions of the sector in the 2014 US National Emissions
+// Inventory.
+var (
+	roadSpeciation        = map[string]float64{"NOx": 0.6, "VOC": 0.36, "PM2_5": 0.02, "NH3": 0.015, "SOx": 0.005}
+	egugridSpeciation     = map[string]float64{"SOx": 0.62, "NOx": 0.33, "PM2_5": 0.04, "VOC": 0.006, "NH3": 0.004}
+	residentialSpeciation = map[string]float64{"VOC": 0.37, "PM2_5": 0.33, "NOx": 0.24, "SOx": 0.03, "NH3": 0.03}
+	commercialSpeciation  = map[string]float64{"NOx": 0.5, "VOC": 0.3, "PM2_5": 0.1, "SOx": 0.07, "NH3": 0.03}
+	industrialSpeciation  = map[string]float64{"NOx": 0.35, "SOx": 0.3, "VOC": 0.2, "PM2_5": 0.12, "NH3": 0.03}
+	railSpeciation        = map[string]float64{"NOx": 0.9, "VOC": 0.05, "PM2_5": 0.03, "SOx": 0.02}
+	marineSpeciation      = map[string]float64{"NOx": 0.75, "SOx": 0.15, "PM2_5": 0.05, "VOC": 0.05}
+	aircraftSpeciation    = map[string]float64{"NOx": 0.55, "VOC": 0.3, "SOx": 0.08, "PM2_5": 0.07}
+	agSpeciation          = map[string]float64{"NH3": 0.8, "PM2_5": 0.1, "VOC": 0.1}
+)
+
+// defaultSourceTypes are the source types that are
+// used when SourceTypeFile is not specified.
+var defaultSourceTypes = []*sourceType{
+	{
+		Name:        "electric_gen_egugrid",
+		Label:       "Electricity generation",
+		Description: "Power plants serving the electric grid that the city is part of.",
+		Domain:      egugridDomain,
+		Resolution:  0.1,
+		// Average EGU stack parameters from 2014 NEI
+		// as processed by Tessum et al 2019 PNAS.
+		StackHeight:      63.5,
+		StackDiameter:    4.1,
+		StackTemperature: 519.2,
+		StackVelocity:    24.7,
+		Speciation:       egugridSpeciation,
+	},
+	{Name: "population", Label: "Population", Description: "Emissions in proportion to population."},
+	{Name: "residential", Label: "Residential", Description: "Residential areas.", Speciation: residentialSpeciation},
+	{Name: "commercial", Label: "Commercial", Description: "Commercial buildings and amenities.", Speciation: commercialSpeciation},
+	{Name: "industrial", Label: "Industrial", Description: "Industrial buildings and land.", Speciation: industrialSpeciation},
+	{Name: "builtup", Label: "Built-up area", Description: "All buildings."},
+	{Name: "roadways", Label: "Roadways", Description: "All roads.", Speciation: roadSpeciation},
+	{Name: "roadways_motorway", Label: "Motorways", Description: "Motorways.", Speciation: roadSpeciation},
+	{Name: "roadways_trunk", Label: "Trunk roads", Description: "Trunk roads.", Speciation: roadSpeciation},
+	{Name: "roadways_primary", Label: "Primary roads", Description: "Primary roads.", Speciation: roadSpeciation},
+	{Name: "roadways_secondary", Label: "Secondary roads", Description: "Secondary roads.", Speciation: roadSpeciation},
+	{Name: "roadways_tertiary", Label: "Tertiary roads", Description: "Tertiary roads.", Speciation: roadSpeciation},
+	{Name: "railways", Label: "Railways", Description: "Railway lines and yards.", Speciation: railSpeciation},
+	{Name: "waterways", Label: "Waterways", Description: "Waterways, ports, and ferry routes.", Speciation: marineSpeciation},
+	{Name: "bus_routes", Label: "Bus routes", Description: "Bus routes.", Speciation: roadSpeciation},
+	{Name: "airports", Label: "Airports", Description: "Airports.", Speciation: aircraftSpeciation},
+	{Name: "agricultural", Label: "Agricultural", Description: "Agricultural land and buildings.", Speciation: agSpeciation},
+}
+
+// loadSourceTypes reads a source type catalog from the given TOML or JSON
+// file, or returns the default catalog if file is empty.
+func loadSourceTypes(file string) ([]*sourceType, error) {
+	if file == "" {
+		return checkSourceTypes(defaultSourceTypes)
+	}
+	file = os.ExpandEnv(file)
+	f, err := os.Open(file)
+	if err != nil {
+		return nil, fmt.Errorf("cityaq: opening source type file: %v", err)
+	}
+	defer f.Close()
+	var st sourceTypeFile
+	switch strings.ToLower(filepath.Ext(file)) {
+	case ".toml":
+		_, err = toml.DecodeReader(f, &st)
+	case ".json":
+		err = json.NewDecoder(f).Decode(&st)
+	default:
+		return nil, fmt.Errorf("cityaq: source type file %s must have a .toml or .json extension", file)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("cityaq: reading source type file %s: %v", file, err)
+	}
+	return checkSourceTypes(st.SourceType)
+}
+
+// checkSourceTypes checks the given source types for problems
+// and returns copies of them with default values filled in.
+func checkSourceTypes(sourceTypes []*sourceType) ([]*sourceType, error) {
+	o := make([]*sourceType, len(sourceTypes))
+	names := make(map[string]bool)
+	for i, s := range sourceTypes {
+		if s == nil || s.Name == "" {
+			return nil, fmt.Errorf("cityaq: source type %d is missing a name", i)
+		}
+		if names[s.Name] {
+			return nil, fmt.Errorf("cityaq: source type %s is defined more than once", s.Name)
+		}
+		names[s.Name] = true
+		st := *s
+		if st.Label == "" {
+			st.Label = st.Name
+		}
+		if st.Domain == "" {
+			st.Domain = cityDomain
+		}
+		res, ok := defaultResolution[st.Domain]
+		if !ok {
+			return nil, fmt.Errorf("cityaq: source type %s has invalid domain %q; it must be %q or %q", st.Name, st.Domain, cityDomain, egugridDomain)
+		}
+		if st.Resolution == 0 {
+			st.Resolution = res
+		}
+		if st.Resolution < 0 || invalidFloat(st.Resolution) {
+			return nil, fmt.Errorf("cityaq: source type %s has invalid resolution %g", st.Name, st.Resolution)
+		}
+		if st.CellSize == 0 {
+			st.CellSize = defaultCellSize[st.Domain]
+		}
+		if st.CellSize < 0 || invalidFloat(st.CellSize) {
+			return nil, fmt.Errorf("cityaq: source type %s has invalid cell size %g", st.Name, st.CellSize)
+		}
+		if invalidFloat(st.StackHeight, st.StackDiameter, st.StackTemperature, st.StackVelocity) ||
+			st.StackHeight < 0 || st.StackDiameter < 0 || st.StackTemperature < 0 || st.StackVelocity < 0 ||
+			(st.StackHeight > 0 && (st.StackDiameter == 0 || st.StackTemperature == 0)) {
+			return nil, fmt.Errorf("cityaq: source type %s has invalid stack parameters", st.Name)
+		}
+		var err error
+		if st.Speciation, err = checkSpeciation(st.Name, st.Speciation); err != nil {
+			return nil, err
+		}
+		o[i] = &st
+	}
+	return o, nil
+}
+
+// sourceTypes returns the source type catalog, loading it from
+// SourceTypeFile the first time it is needed.
+func (c *CityAQ) sourceTypes() ([]*sourceType, error) {
+	c.sourceTypesOnce.Do(func() {
+		c.sourceTypeCatalog, c.sourceTypesErr = loadSourceTypes(c.SourceTypeFile)
+	})
+	return c.sourceTypeCatalog, c.sourceTypesErr
+}
+
+// sourceType returns the source type in the catalog with the given name.
+func (c *CityAQ) sourceType(name string) (*sourceType, error) {
+	sourceTypes, err := c.sourceTypes()
+	if err != nil {
+		return nil, err
+	}
+	for _, st := range sourceTypes {
+		if st.Name == name {
+			return st, nil
+		}
+	}
+	return nil, fmt.Errorf("cityaq: unknown source type %q", name)
+}
+
+// sectorSourceType returns the source type that the emissions of the
+// emissions inventory sector with the given name are allocated as: the
+// source type in the catalog with the same name, if there is one, and
+// otherwise the default source type for the name.
+func (c *CityAQ) sectorSourceType(name string) (*sourceType, error) {
+	sourceTypes, err := c.sourceTypes()
+	if err != nil {
+		return nil, err
+	}
+	for _, st := range sourceTypes {
+		if st.Name == name {
+			return st, nil
+		}
+	}
+	return defaultSourceType(name), nil
+}
+
+// defaultSourceType returns a source type with the given name that is
+// released at ground level and allocated at the default resolution
+// within the city, or within the electric grid region of the city if
+// the name has the suffix "_egugrid". It is used for custom surrogates
+// and emissions inventory sectors, which are not in the catalog.
+func defaultSourceType(name string) *sourceType {
+	domain := cityDomain
+	if strings.HasSuffix(name, "_"+egugridDomain) {
+		domain = egugridDomain
+	}
+	return &sourceType{
+		Name:       name,
+		Label:      name,
+		Domain:     domain,
+		Resolution: defaultResolution[domain],
+		CellSize:   defaultCellSize[domain],
+	}
+}
+
+// isInventorySector returns whether name is a sector of the emissions
+// inventory of CityTotal or Total simulations. Inventories whose
+// configuration files can't be read are skipped.
+func (c *CityAQ) isInventorySector(name string) bool {
+	for _, simType := range []rpc.SimulationType{rpc.SimulationType_CityTotal, rpc.SimulationType_Total} {
+		cfg, _, err := c.inventoryConfig(simType)
+		if err != nil {
+			continue
+		}
+		if _, ok := cfg.GetStringMapStringSlice("aep.InventoryConfig.COARDSFiles")[name]; ok {
+			return true
+		}
+	}
+	return false
+}
+
+// sourceTypeOrSector returns the source type in the catalog with the
+// given name, or if there is none, the source type of the emissions
+// inventory sector with the given name. It is for requests that can
+// refer to either.
+func (c *CityAQ) sourceTypeOrSector(name string) (*sourceType, error) {
+	if !c.isInventorySector(name) {
+		return c.sourceType(name)
+	}
+	return c.sectorSourceType(name)
+}
+
+// requestSourceType returns the source type that the emissions of a
+// request are treated as: the custom surrogate or source type blend if
+// either is set, and otherwise the source type with the given name.
+func (c *CityAQ) requestSourceType(name string, surrogate *customSurrogate, blend *sourceTypeBlend) (*sourceType, error) {
+	switch {
+	case surrogate != nil && blend != nil:
+		return nil, fmt.Errorf("cityaq: a custom surrogate and a source type mix can't be used together")
+	case surrogate != nil:
+		return defaultSourceType(surrogate.name()), nil
+	case blend != nil:
+		return c.blendSourceType(blend)
+	}
+	return c.sourceType(name)
+}
+
+// egugrid returns whether the emissions of the receiver are allocated
+// to the country or electric grid buffer rather than the city.
+func (st *sourceType) egugrid() bool {
+	return st.Domain == egugridDomain
+}
+
+// SourceTypes returns the source types in the source type catalog.
+func (c *CityAQ) SourceTypes(ctx context.Context, req *rpc.SourceTypesRequest) (*rpc.SourceTypesResponse, error) {
+	sourceTypes, err := c.sourceTypes()
+	if err != nil {
+		return nil, err
+	}
+	o := &rpc.SourceTypesResponse{
+		SourceTypes: make([]*rpc.SourceType, len(sourceTypes)),
+	}
+	for i, st := range sourceTypes {
+		domain := rpc.AllocationDomain_City
+		if st.egugrid() {
+			domain = rpc.AllocationDomain_EGUGrid
+		}
+		o.SourceTypes[i] = &rpc.SourceType{
+			Name:             st.Name,
+			Label:            st.Label,
+			Description:      st.Description,
+			Domain:           domain,
+			Resolution:       st.Resolution,
+			CellSize:         st.CellSize,
+			StackHeight:      st.StackHeight,
+			StackDiameter:    st.StackDiameter,
+			StackTemperature: st.StackTemperature,
+			StackVelocity:    st.StackVelocity,
+			Speciation:       speciationToRPC(st.Speciation),
+		}
+	}
+	return o, nil
+}
@@ -0,0 +1,119 @@
+package cityaq
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	rpc "github.com/ctessum/cityaq/cityaqrpc"
+)
+
+func TestLoadSourceTypes(t *testing.T) {
+	want, err := loadSourceTypes("")
+	if err != nil {
+		t.Fatal(err)
+	}
+	have, err := loadSourceTypes("cmd/sourcetypes.toml")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(have, want) {
+		t.Errorf("cmd/sourcetypes.toml does not match the built-in source types")
+	}
+
+	dir, err := ioutil.TempDir("", "cityaq_sourcetypes")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	for _, test := range []struct {
+		name, data string
+		err        bool
+	}{
+		{name: "valid", data: `{"SourceType": [{"Name": "roadways"}, {"Name": "power", "Domain": "egugrid", "StackHeight": 50, "StackDiameter": 3, "StackTemperature": 400}]}`},
+		{name: "no name", data: `{"SourceType": [{"Label": "Roads"}]}`, err: true},
+		{name: "duplicate", data: `{"SourceType": [{"Name": "roadways"}, {"Name": "roadways"}]}`, err: true},
+		{name: "domain", data: `{"SourceType": [{"Name": "roadways", "Domain": "country"}]}`, err: true},
+		{name: "resolution", data: `{"SourceType": [{"Name": "roadways", "Resolution": -1}]}`, err: true},
+		{name: "cell size", data: `{"SourceType": [{"Name": "roadways", "CellSize": -1}]}`, err: true},
+		{name: "stack", data: `{"SourceType": [{"Name": "power", "StackHeight": 50}]}`, err: true},
+		{name: "malformed", data: `{"SourceType": [`, err: true},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			file := filepath.Join(dir, test.name+".json")
+			if err := ioutil.WriteFile(file, []byte(test.data), 0644); err != nil {
+				t.Fatal(err)
+			}
+			sourceTypes, err := loadSourceTypes(file)
+			if test.err {
+				if err == nil {
+					t.Error("expected an error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			want := []*sourceType{
+				{Name: "roadways", Label: "roadways", Domain: cityDomain, Resolution: 0.005, CellSize: 500},
+				{
+					Name: "power", Label: "power", Domain: egugridDomain, Resolution: 0.1, CellSize: 10000,
+					StackHeight: 50, StackDiameter: 3, StackTemperature: 400,
+				},
+			}
+			if !reflect.DeepEqual(sourceTypes, want) {
+				t.Errorf("%+v != %+v", sourceTypes, want)
+			}
+		})
+	}
+}
+
+func TestCityAQ_SourceTypes(t *testing.T) {
+	c := new(CityAQ)
+	r, err := c.SourceTypes(context.Background(), &rpc.SourceTypesRequest{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(r.SourceTypes) != len(defaultSourceTypes) {
+		t.Fatalf("have %d source types, want %d", len(r.SourceTypes), len(defaultSourceTypes))
+	}
+	egu := r.SourceTypes[0]
+	if egu.Name != "electric_gen_egugrid" || egu.Domain != rpc.AllocationDomain_EGUGrid || egu.StackHeight != 63.5 {
+		t.Errorf("unexpected source type %+v", egu)
+	}
+	if len(egu.Speciation) != 5 || egu.Speciation[0].Emission != rpc.Emission_PM2_5 {
+		t.Errorf("unexpected speciation %v", egu.Speciation)
+	}
+
+	if _, err := c.sourceType("roadway"); err == nil {
+		t.Error("expected an error for an unknown source type")
+	}
+
+	// Emissions inventory sectors that are not in the catalog are
+	// released at ground level and allocated within the city, or within
+	// the electric grid region if the name has the suffix "_egugrid".
+	st, err := c.sectorSourceType("all")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if st.egugrid() || st.Resolution != 0.005 || st.StackHeight != 0 {
+		t.Errorf("unexpected source type %+v", st)
+	}
+	st, err = c.sectorSourceType("power_egugrid")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !st.egugrid() || st.Resolution != 0.1 || st.StackHeight != 0 {
+		t.Errorf("unexpected source type %+v", st)
+	}
+	st, err = c.sectorSourceType("electric_gen_egugrid")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if st.StackHeight != 63.5 {
+		t.Errorf("catalog source type should be used for sector: %+v", st)
+	}
+}
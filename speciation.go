@@ -0,0 +1,124 @@
+package cityaq
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	rpc "github.com/ctessum/cityaq/cityaqrpc"
+)
+
+// speciationPollutants are the pollutants that speciation
+// profiles can include, in the order they are reported.
+var speciationPollutants = []rpc.Emission{
+	rpc.Emission_PM2_5,
+	rpc.Emission_NH3,
+	rpc.Emission_NOx,
+	rpc.Emission_SOx,
+	rpc.Emission_VOC,
+}
+
+// checkSpeciation checks the speciation profile of the source type with
+// the given name and returns a copy of it with the pollutant names
+// spelled as in the Emission enum and fractions that add up to one.
+// It returns nil if the profile is empty.
+func checkSpeciation(name string, speciation map[string]float64) (map[string]float64, error) {
+	if len(speciation) == 0 {
+		return nil, nil
+	}
+	o := make(map[string]float64)
+	var sum float64
+	for pol, frac := range speciation {
+		var e rpc.Emission
+		for _, p := range speciationPollutants {
+			if strings.EqualFold(p.String(), pol) {
+				e = p
+			}
+		}
+		if e == rpc.Emission_UNKNOWN_EMISSION {
+			return nil, fmt.Errorf("cityaq: source type %s speciation has invalid pollutant %q", name, pol)
+		}
+		if _, ok := o[e.String()]; ok {
+			return nil, fmt.Errorf("cityaq: source type %s speciation includes %s more than once", name, e)
+		}
+		if frac < 0 || invalidFloat(frac) {
+			return nil, fmt.Errorf("cityaq: source type %s speciation has invalid fraction %g for %s", name, frac, e)
+		}
+		o[e.String()] = frac
+	}
+	// Add the fractions in a fixed order so that the same
+	// profile is always normalized in the same way.
+	for _, p := range speciationPollutants {
+		sum += o[p.String()]
+	}
+	if sum == 0 {
+		return nil, fmt.Errorf("cityaq: source type %s speciation fractions are all zero", name)
+	}
+	for pol := range o {
+		o[pol] /= sum
+	}
+	return o, nil
+}
+
+// speciate splits the given total amount of emissions among pollutants
+// using the speciation profile of the receiver. Pollutants that are not
+// in the profile are not included.
+func (st *sourceType) speciate(total *emissionsAmount) ([]pollutantAmount, error) {
+	if len(st.Speciation) == 0 {
+		return nil, fmt.Errorf("cityaq: source type %s does not have a speciation profile", st.Name)
+	}
+	var o []pollutantAmount
+	for _, p := range speciationPollutants {
+		frac := st.Speciation[p.String()]
+		if frac == 0 {
+			continue
+		}
+		o = append(o, pollutantAmount{
+			pollutant: p,
+			emissionsAmount: &emissionsAmount{
+				mass:  total.mass * frac,
+				begin: total.begin,
+				end:   total.end,
+			},
+		})
+	}
+	return o, nil
+}
+
+// errSpeciatePollutants is returned when both Speciate and
+// Pollutants are set in a request.
+var errSpeciatePollutants = errors.New("cityaq: Pollutants can't be used with Speciate, which splits Amount among the pollutants")
+
+// requestSpeciatedAmounts returns the amounts of the pollutants specified
+// by the given request fields. If speciate is true, amount is the total
+// mass of all pollutants, which is split among them using the speciation
+// profile of st, and pollutants must be empty; otherwise the amounts are
+// as in requestPollutantAmounts.
+func requestSpeciatedAmounts(st *sourceType, speciate bool, emission rpc.Emission, amount float64, units rpc.EmissionUnits, pollutants []*rpc.PollutantAmount, begin, end int64) ([]pollutantAmount, error) {
+	if !speciate {
+		return requestPollutantAmounts(emission, amount, units, pollutants, begin, end)
+	}
+	if len(pollutants) > 0 {
+		return nil, errSpeciatePollutants
+	}
+	total, err := requestAmount(amount, units, begin, end)
+	if err != nil {
+		return nil, err
+	}
+	return st.speciate(total)
+}
+
+// speciationToRPC converts a speciation profile to its
+// RPC representation, in a fixed order.
+func speciationToRPC(speciation map[string]float64) []*rpc.PollutantFraction {
+	var o []*rpc.PollutantFraction
+	for pol, frac := range speciation {
+		o = append(o, &rpc.PollutantFraction{
+			Emission: rpc.Emission(rpc.Emission_value[pol]),
+			Fraction: frac,
+		})
+	}
+	sort.Slice(o, func(i, j int) bool { return o[i].Emission < o[j].Emission })
+	return o
+}
@@ -0,0 +1,140 @@
+package cityaq
+
+import (
+	"context"
+	"testing"
+
+	rpc "github.com/ctessum/cityaq/cityaqrpc"
+	"github.com/spatialmodel/inmap/emissions/aep/aeputil"
+	"gonum.org/v1/gonum/floats"
+)
+
+func TestCheckSpeciation(t *testing.T) {
+	s, err := checkSpeciation("test", map[string]float64{"nox": 3, "PM2_5": 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(s) != 2 || s["NOx"] != 0.75 || s["PM2_5"] != 0.25 {
+		t.Errorf("wrong speciation: %v", s)
+	}
+	if s, err := checkSpeciation("test", nil); s != nil || err != nil {
+		t.Errorf("empty speciation: got %v, %v", s, err)
+	}
+	for name, spec := range map[string]map[string]float64{
+		"invalid pollutant": {"CO": 1},
+		"negative":          {"NOx": 1, "VOC": -1},
+		"zero":              {"NOx": 0},
+		"duplicate":         {"NOx": 1, "NOX": 1},
+	} {
+		if _, err := checkSpeciation("test", spec); err == nil {
+			t.Errorf("%s: expected an error", name)
+		}
+	}
+}
+
+func TestSourceType_speciate(t *testing.T) {
+	c := new(CityAQ)
+	st, err := c.sourceType("roadways")
+	if err != nil {
+		t.Fatal(err)
+	}
+	amounts, err := st.speciate(referenceAmount)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var sum float64
+	for _, a := range amounts {
+		sum += a.mass
+		if a.pollutant == rpc.Emission_NOx && !similar(a.mass, 0.6e6, 1e-10) {
+			t.Errorf("NOx: %g != %g", a.mass, 0.6e6)
+		}
+	}
+	if len(amounts) != 5 || !similar(sum, referenceAmount.mass, 1e-10) {
+		t.Errorf("speciated amounts don't add up: %d pollutants, %g kg", len(amounts), sum)
+	}
+
+	st, err = c.sourceType("builtup")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := st.speciate(referenceAmount); err == nil {
+		t.Error("expected an error for a source type without a speciation profile")
+	}
+
+	b, err := requestSourceTypeBlend([]*rpc.SourceTypeWeight{
+		{SourceType: "roadways", Weight: 1},
+		{SourceType: "agricultural", Weight: 1},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	st, err = c.blendSourceType(b)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := (0.015 + 0.8) / 2; !similar(st.Speciation["NH3"], want, 1e-10) {
+		t.Errorf("blended NH3 fraction: %g != %g", st.Speciation["NH3"], want)
+	}
+}
+
+func TestRequestSpeciatedAmounts(t *testing.T) {
+	c := new(CityAQ)
+	st, err := c.sourceType("roadways")
+	if err != nil {
+		t.Fatal(err)
+	}
+	pollutants := []*rpc.PollutantAmount{{Emission: rpc.Emission_NOx, Amount: 1, AmountUnits: rpc.EmissionUnits_Tonnes}}
+	if _, err := requestSpeciatedAmounts(st, true, 0, 1, rpc.EmissionUnits_Tonnes, pollutants, 0, 0); err == nil {
+		t.Error("expected an error for Pollutants with Speciate")
+	}
+	amounts, err := requestSpeciatedAmounts(st, false, 0, 0, 0, pollutants, 0, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(amounts) != 1 || amounts[0].pollutant != rpc.Emission_NOx {
+		t.Errorf("unspeciated amounts: %+v", amounts)
+	}
+	amounts, err = requestSpeciatedAmounts(st, true, 0, 1, rpc.EmissionUnits_Tonnes, nil, 0, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(amounts) != 5 {
+		t.Errorf("speciated amounts: %d pollutants, want 5", len(amounts))
+	}
+}
+
+func TestCityAQ_griddedEmissionsSpeciate(t *testing.T) {
+	c := &CityAQ{
+		CityGeomDir: "testdata/cities",
+		SpatialConfig: aeputil.SpatialConfig{
+			SrgSpecOSM:    "testdata/srgspec_osm.json",
+			SCCExactMatch: true,
+			GridRef:       []string{"testdata/gridref.txt"},
+			OutputSR:      "+proj=longlat",
+			InputSR:       "+proj=longlat",
+		},
+	}
+	emis, err := c.GriddedEmissions(context.Background(), &rpc.GriddedEmissionsRequest{
+		CityName:    "Accra Metropolitan",
+		SourceType:  "railways",
+		Amount:      2,
+		AmountUnits: rpc.EmissionUnits_Kilotonnes,
+		Speciate:    true,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(emis.PollutantEmissions) != 4 {
+		t.Fatalf("wrong number of pollutants: %d", len(emis.PollutantEmissions))
+	}
+	if sum := floats.Sum(emis.Emissions); !similar(sum, 2.0e6, 1e-8) {
+		t.Errorf("total emissions: %g != %g", sum, 2.0e6)
+	}
+	for _, pe := range emis.PollutantEmissions {
+		if pe.Emission == rpc.Emission_NOx {
+			if sum := floats.Sum(pe.Emissions); !similar(sum, 1.8e6, 1e-8) {
+				t.Errorf("NOx emissions: %g != %g", sum, 1.8e6)
+			}
+		}
+	}
+}
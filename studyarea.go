@@ -0,0 +1,442 @@
+package cityaq
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	rpc "github.com/ctessum/cityaq/cityaqrpc"
+	"github.com/ctessum/geom"
+	"github.com/ctessum/geom/encoding/geojson"
+	"gocloud.dev/blob"
+	_ "gocloud.dev/blob/gcsblob" // Register the Google Cloud Storage blob driver.
+)
+
+// studyAreaPrefix is the location within CacheLoc where registered
+// study areas are stored.
+const studyAreaPrefix = "study_areas"
+
+// studyAreaProperty is the GeoJSON feature property that
+// marks a boundary as a registered study area.
+const studyAreaProperty = "cityaq_study_area"
+
+// maxStudyAreaVertices is the largest number of points that
+// a study area boundary can have, which limits the time it
+// takes to validate it.
+const maxStudyAreaVertices = 100000
+
+// RegisterStudyArea validates and stores a study area boundary so that it
+// can be used in the same way as a city in CityGeomDir. Study areas are
+// stored under CacheLoc, so they are available after the server restarts.
+func (c *CityAQ) RegisterStudyArea(ctx context.Context, req *rpc.RegisterStudyAreaRequest) (*rpc.RegisterStudyAreaResponse, error) {
+	dir := c.studyAreaDir()
+	if dir == "" {
+		return nil, fmt.Errorf("cityaq: CacheLoc must be set to register study areas")
+	}
+	name := strings.TrimSpace(req.Name)
+	if name == "" {
+		return nil, fmt.Errorf("cityaq: study area name must be specified")
+	}
+
+	var g geom.Geom
+	var err error
+	switch {
+	case req.GeoJSON != "" && req.WKT != "":
+		return nil, fmt.Errorf("cityaq: only one of GeoJSON and WKT can be specified")
+	case req.GeoJSON != "":
+		g, err = parseGeoJSONBoundary(req.GeoJSON)
+	case req.WKT != "":
+		g, err = parseWKTBoundary(req.WKT)
+	default:
+		return nil, fmt.Errorf("cityaq: study area boundary must be specified as GeoJSON or WKT")
+	}
+	if err != nil {
+		return nil, fmt.Errorf("cityaq: invalid study area boundary: %v", err)
+	}
+	polygons := boundaryPolygons(g)
+	var vertices int
+	for _, p := range polygons {
+		for _, ring := range p {
+			vertices += len(ring)
+		}
+	}
+	if vertices > maxStudyAreaVertices {
+		return nil, fmt.Errorf("cityaq: invalid study area boundary: it has %d points, but the maximum is %d", vertices, maxStudyAreaVertices)
+	}
+	var problems []string
+	for _, p := range polygons {
+		problems = append(problems, boundaryProblems(p)...)
+	}
+	if len(problems) > 0 {
+		return nil, fmt.Errorf("cityaq: invalid study area boundary: %s", strings.Join(problems, "; "))
+	}
+
+	c.studyAreaMu.Lock()
+	defer c.studyAreaMu.Unlock()
+
+	cat := c.catalog()
+	id := citySlug(name)
+	for _, existing := range []string{id, name} {
+		if _, err := cat.id(existing); err == nil {
+			return nil, fmt.Errorf("cityaq: a city or study area named %q already exists", existing)
+		}
+	}
+
+	b, err := studyAreaGeoJSON(id, name, g)
+	if err != nil {
+		return nil, err
+	}
+	file := id + ".geojson"
+	if strings.HasPrefix(c.CacheLoc, "gs://") {
+		bucket, err := c.studyAreaBucket(ctx)
+		if err != nil {
+			return nil, err
+		}
+		defer bucket.Close()
+		if err := bucket.WriteAll(ctx, file, b, nil); err != nil {
+			return nil, fmt.Errorf("cityaq: saving study area: %w", err)
+		}
+	}
+	if err := writeFileAtomic(filepath.Join(dir, file), b); err != nil {
+		return nil, fmt.Errorf("cityaq: saving study area: %w", err)
+	}
+
+	// Problems with other boundary files are logged by refresh, so
+	// here we only need to check that the new study area was loaded.
+	if err := cat.refresh(); err != nil {
+		return nil, err
+	}
+	if _, err := cat.id(id); err != nil {
+		return nil, err
+	}
+	return &rpc.RegisterStudyAreaResponse{ID: id}, nil
+}
+
+// studyAreaDir returns the local directory where registered study areas
+// are stored, or an empty string if study areas can't be stored because
+// CacheLoc is not set. If CacheLoc is a Google Cloud Storage location,
+// the directory holds local copies of the stored study areas.
+func (c *CityAQ) studyAreaDir() string {
+	switch {
+	case c.CacheLoc == "":
+		return ""
+	case strings.HasPrefix(c.CacheLoc, "gs://"):
+		h := sha256.Sum256([]byte(c.CacheLoc))
+		return filepath.Join(os.TempDir(), "cityaq_"+studyAreaPrefix, hex.EncodeToString(h[:8]))
+	default:
+		return filepath.Join(strings.TrimPrefix(c.CacheLoc, "file://"), studyAreaPrefix)
+	}
+}
+
+// studyAreaBucket opens the Google Cloud Storage location
+// where study areas are stored.
+func (c *CityAQ) studyAreaBucket(ctx context.Context) (*blob.Bucket, error) {
+	loc, err := url.Parse(c.CacheLoc)
+	if err != nil {
+		return nil, err
+	}
+	bucket, err := blob.OpenBucket(ctx, "gs://"+loc.Host)
+	if err != nil {
+		return nil, fmt.Errorf("cityaq: opening study area storage: %w", err)
+	}
+	prefix := strings.Trim(loc.Path, "/")
+	if prefix != "" {
+		prefix += "/"
+	}
+	return blob.PrefixedBucket(bucket, prefix+studyAreaPrefix+"/"), nil
+}
+
+// syncStudyAreas copies study areas that have been stored in Google Cloud
+// Storage, possibly by another server, to the local study area directory.
+func (c *CityAQ) syncStudyAreas() error {
+	if !strings.HasPrefix(c.CacheLoc, "gs://") {
+		return nil
+	}
+	ctx := context.Background()
+	bucket, err := c.studyAreaBucket(ctx)
+	if err != nil {
+		return err
+	}
+	defer bucket.Close()
+	dir := c.studyAreaDir()
+	iter := bucket.List(nil)
+	for {
+		obj, err := iter.Next(ctx)
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return fmt.Errorf("cityaq: listing study areas: %w", err)
+		}
+		if obj.IsDir || filepath.Ext(obj.Key) != ".geojson" {
+			continue
+		}
+		local := filepath.Join(dir, filepath.Base(obj.Key))
+		if _, err := os.Stat(local); err == nil {
+			continue
+		}
+		b, err := bucket.ReadAll(ctx, obj.Key)
+		if err != nil {
+			return fmt.Errorf("cityaq: reading study area %s: %w", obj.Key, err)
+		}
+		if err := writeFileAtomic(local, b); err != nil {
+			return fmt.Errorf("cityaq: saving study area %s: %w", obj.Key, err)
+		}
+	}
+	return nil
+}
+
+// writeFileAtomic writes b to the given file, creating its directory
+// if necessary. The file is written to a temporary location first so
+// that it is never observed partially written.
+func writeFileAtomic(file string, b []byte) error {
+	dir := filepath.Dir(file)
+	if err := os.MkdirAll(dir, os.ModePerm); err != nil {
+		return err
+	}
+	f, err := ioutil.TempFile(dir, ".tmp_")
+	if err != nil {
+		return err
+	}
+	if _, err := f.Write(b); err != nil {
+		f.Close()
+		os.Remove(f.Name())
+		return err
+	}
+	if err := f.Close(); err != nil {
+		os.Remove(f.Name())
+		return err
+	}
+	return os.Rename(f.Name(), file)
+}
+
+// studyAreaGeoJSON returns a GeoJSON FeatureCollection
+// representing a study area.
+func studyAreaGeoJSON(id, name string, g geom.Geom) ([]byte, error) {
+	gj, err := geojson.ToGeoJSON(g)
+	if err != nil {
+		return nil, err
+	}
+	fc := map[string]interface{}{
+		"type": "FeatureCollection",
+		"features": []interface{}{
+			map[string]interface{}{
+				"type": "Feature",
+				"properties": map[string]interface{}{
+					"name":            name,
+					cityIDProperty:    id,
+					studyAreaProperty: true,
+				},
+				"geometry": gj,
+			},
+		},
+	}
+	return json.MarshalIndent(fc, "", "  ")
+}
+
+// boundaryPolygons returns the polygons in the given
+// Polygon or MultiPolygon.
+func boundaryPolygons(g geom.Geom) []geom.Polygon {
+	switch t := g.(type) {
+	case geom.Polygon:
+		return []geom.Polygon{t}
+	case geom.MultiPolygon:
+		return t
+	default:
+		return nil
+	}
+}
+
+// parseGeoJSONBoundary parses a GeoJSON Polygon or MultiPolygon geometry,
+// or a Feature or FeatureCollection containing such geometries.
+// If there are multiple features, they are combined into a MultiPolygon.
+func parseGeoJSONBoundary(s string) (geom.Geom, error) {
+	var obj struct {
+		Type     string            `json:"type"`
+		Geometry *geojson.Geometry `json:"geometry"`
+		Features []struct {
+			Geometry *geojson.Geometry `json:"geometry"`
+		} `json:"features"`
+	}
+	if err := json.Unmarshal([]byte(s), &obj); err != nil {
+		return nil, err
+	}
+	var geoms []*geojson.Geometry
+	switch obj.Type {
+	case "Polygon", "MultiPolygon":
+		g := new(geojson.Geometry)
+		if err := json.Unmarshal([]byte(s), g); err != nil {
+			return nil, err
+		}
+		geoms = append(geoms, g)
+	case "Feature":
+		geoms = append(geoms, obj.Geometry)
+	case "FeatureCollection":
+		for _, f := range obj.Features {
+			geoms = append(geoms, f.Geometry)
+		}
+	default:
+		return nil, fmt.Errorf("unsupported GeoJSON type %q", obj.Type)
+	}
+
+	var polys geom.MultiPolygon
+	for _, gj := range geoms {
+		if gj == nil {
+			return nil, fmt.Errorf("missing geometry")
+		}
+		g, err := geojson.FromGeoJSON(gj)
+		if err != nil {
+			return nil, err
+		}
+		switch t := g.(type) {
+		case geom.Polygon:
+			polys = append(polys, t)
+		case geom.MultiPolygon:
+			polys = append(polys, t...)
+		default:
+			return nil, fmt.Errorf("unsupported geometry type %T; it must be a Polygon or MultiPolygon", g)
+		}
+	}
+	if len(polys) == 0 {
+		return nil, fmt.Errorf("no geometry")
+	}
+	if len(polys) == 1 {
+		return polys[0], nil
+	}
+	return polys, nil
+}
+
+// parseWKTBoundary parses a Well-Known Text POLYGON or MULTIPOLYGON.
+func parseWKTBoundary(s string) (geom.Geom, error) {
+	p := &wktParser{s: strings.TrimSpace(s)}
+	word := strings.ToUpper(p.word())
+	var g geom.Geom
+	var err error
+	switch word {
+	case "POLYGON":
+		g, err = p.polygon()
+	case "MULTIPOLYGON":
+		g, err = p.multiPolygon()
+	default:
+		return nil, fmt.Errorf("unsupported WKT geometry type %q; it must be POLYGON or MULTIPOLYGON", word)
+	}
+	if err != nil {
+		return nil, err
+	}
+	p.skipSpace()
+	if p.pos != len(p.s) {
+		return nil, fmt.Errorf("unexpected WKT text %q", p.s[p.pos:])
+	}
+	return g, nil
+}
+
+// wktParser parses Well-Known Text polygons.
+type wktParser struct {
+	s   string
+	pos int
+}
+
+func (p *wktParser) skipSpace() {
+	for p.pos < len(p.s) && strings.ContainsRune(" \t\r\n", rune(p.s[p.pos])) {
+		p.pos++
+	}
+}
+
+// word returns the next sequence of letters.
+func (p *wktParser) word() string {
+	p.skipSpace()
+	start := p.pos
+	for p.pos < len(p.s) && (('a' <= p.s[p.pos] && p.s[p.pos] <= 'z') || ('A' <= p.s[p.pos] && p.s[p.pos] <= 'Z')) {
+		p.pos++
+	}
+	return p.s[start:p.pos]
+}
+
+// expect consumes the given character or returns an error.
+func (p *wktParser) expect(c byte) error {
+	p.skipSpace()
+	if p.pos >= len(p.s) || p.s[p.pos] != c {
+		return fmt.Errorf("expected %q at position %d of WKT", c, p.pos)
+	}
+	p.pos++
+	return nil
+}
+
+// list parses a parenthesized, comma-separated list,
+// calling item for each element.
+func (p *wktParser) list(item func() error) error {
+	if err := p.expect('('); err != nil {
+		return err
+	}
+	for {
+		if err := item(); err != nil {
+			return err
+		}
+		p.skipSpace()
+		if p.pos < len(p.s) && p.s[p.pos] == ',' {
+			p.pos++
+			continue
+		}
+		return p.expect(')')
+	}
+}
+
+func (p *wktParser) point() (geom.Point, error) {
+	p.skipSpace()
+	start := p.pos
+	for p.pos < len(p.s) && p.s[p.pos] != ',' && p.s[p.pos] != ')' {
+		p.pos++
+	}
+	fields := strings.Fields(p.s[start:p.pos])
+	if len(fields) != 2 {
+		return geom.Point{}, fmt.Errorf("invalid WKT point %q", p.s[start:p.pos])
+	}
+	x, err := strconv.ParseFloat(fields[0], 64)
+	if err != nil {
+		return geom.Point{}, fmt.Errorf("invalid WKT point %q", p.s[start:p.pos])
+	}
+	y, err := strconv.ParseFloat(fields[1], 64)
+	if err != nil {
+		return geom.Point{}, fmt.Errorf("invalid WKT point %q", p.s[start:p.pos])
+	}
+	return geom.Point{X: x, Y: y}, nil
+}
+
+func (p *wktParser) ring() (geom.Path, error) {
+	var ring geom.Path
+	err := p.list(func() error {
+		pt, err := p.point()
+		ring = append(ring, pt)
+		return err
+	})
+	return ring, err
+}
+
+func (p *wktParser) polygon() (geom.Polygon, error) {
+	var poly geom.Polygon
+	err := p.list(func() error {
+		ring, err := p.ring()
+		poly = append(poly, ring)
+		return err
+	})
+	return poly, err
+}
+
+func (p *wktParser) multiPolygon() (geom.MultiPolygon, error) {
+	var mp geom.MultiPolygon
+	err := p.list(func() error {
+		poly, err := p.polygon()
+		mp = append(mp, poly)
+		return err
+	})
+	return mp, err
+}
@@ -0,0 +1,235 @@
+package cityaq
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"math"
+	"os"
+	"reflect"
+	"strings"
+	"testing"
+
+	rpc "github.com/ctessum/cityaq/cityaqrpc"
+	"github.com/ctessum/geom"
+)
+
+func TestCityAQ_RegisterStudyArea(t *testing.T) {
+	dir, err := ioutil.TempDir("", "cityaq_study_area")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	c := &CityAQ{
+		CityGeomDir: "testdata/cities",
+		CacheLoc:    "file://" + dir,
+	}
+	ctx := context.Background()
+
+	t.Run("geojson", func(t *testing.T) {
+		resp, err := c.RegisterStudyArea(ctx, &rpc.RegisterStudyAreaRequest{
+			Name:    "Accra Port",
+			GeoJSON: `{"type":"Feature","properties":{},"geometry":{"type":"Polygon","coordinates":[[[-0.3,5.5],[-0.2,5.5],[-0.2,5.6],[-0.3,5.6],[-0.3,5.5]]]}}`,
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if resp.ID != "accra-port" {
+			t.Errorf("ID: %s != accra-port", resp.ID)
+		}
+		cities, err := c.Cities(ctx, &rpc.CitiesRequest{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		want := []string{"Accra Metropolitan", "Accra Port", "ڪراچي Karachi"}
+		if !reflect.DeepEqual(cities.Names, want) {
+			t.Errorf("%v != %v", cities.Names, want)
+		}
+		bounds, err := c.EmissionsGridBounds(ctx, &rpc.EmissionsGridBoundsRequest{CityName: resp.ID})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if bounds.Min.X > -0.3 || bounds.Max.X < -0.2 || bounds.Min.Y > 5.5 || bounds.Max.Y < 5.6 {
+			t.Errorf("grid bounds %v do not contain the study area", bounds)
+		}
+	})
+
+	t.Run("wkt", func(t *testing.T) {
+		resp, err := c.RegisterStudyArea(ctx, &rpc.RegisterStudyAreaRequest{
+			Name: "Karachi Harbour",
+			WKT:  "POLYGON ((66.9 24.8, 67.0 24.8, 67.0 24.9, 66.9 24.9, 66.9 24.8))",
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if resp.ID != "karachi-harbour" {
+			t.Errorf("ID: %s != karachi-harbour", resp.ID)
+		}
+	})
+
+	t.Run("persisted", func(t *testing.T) {
+		c2 := &CityAQ{
+			CityGeomDir: "testdata/cities",
+			CacheLoc:    "file://" + dir,
+		}
+		if _, err := c2.CityGeometry(ctx, &rpc.CityGeometryRequest{CityName: "karachi-harbour"}); err != nil {
+			t.Fatal(err)
+		}
+	})
+
+	t.Run("duplicate", func(t *testing.T) {
+		_, err := c.RegisterStudyArea(ctx, &rpc.RegisterStudyAreaRequest{
+			Name: "Accra Metropolitan",
+			WKT:  "POLYGON ((0 0, 1 0, 1 1, 0 1, 0 0))",
+		})
+		if err == nil || !strings.Contains(err.Error(), "already exists") {
+			t.Errorf("expected duplicate error, got %v", err)
+		}
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		_, err := c.RegisterStudyArea(ctx, &rpc.RegisterStudyAreaRequest{
+			Name: "Bowtie",
+			WKT:  "POLYGON ((0 0, 1 1, 1 0, 0 1, 0 0))",
+		})
+		if err == nil || !strings.Contains(err.Error(), "intersects itself") {
+			t.Errorf("expected self-intersection error, got %v", err)
+		}
+	})
+
+	t.Run("too many points", func(t *testing.T) {
+		var b strings.Builder
+		b.WriteString("POLYGON ((")
+		for i := 0; i < maxStudyAreaVertices; i++ {
+			a := 2 * math.Pi * float64(i) / maxStudyAreaVertices
+			fmt.Fprintf(&b, "%g %g, ", math.Cos(a), math.Sin(a))
+		}
+		b.WriteString("1 0))")
+		_, err := c.RegisterStudyArea(ctx, &rpc.RegisterStudyAreaRequest{Name: "Circle", WKT: b.String()})
+		if err == nil || !strings.Contains(err.Error(), "maximum") {
+			t.Errorf("expected an error for too many points, got %v", err)
+		}
+	})
+
+	t.Run("no cache", func(t *testing.T) {
+		c := &CityAQ{CityGeomDir: "testdata/cities"}
+		_, err := c.RegisterStudyArea(ctx, &rpc.RegisterStudyAreaRequest{
+			Name: "Square",
+			WKT:  "POLYGON ((0 0, 1 0, 1 1, 0 1, 0 0))",
+		})
+		if err == nil {
+			t.Error("expected an error")
+		}
+	})
+}
+
+func TestParseWKTBoundary(t *testing.T) {
+	g, err := parseWKTBoundary("MULTIPOLYGON (((0 0, 1 0, 1 1, 0 0)), ((2 2, 3 2, 3 3, 2 2), (2.1 2.05, 2.9 2.05, 2.9 2.8, 2.1 2.05)))")
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := geom.MultiPolygon{
+		{{{X: 0, Y: 0}, {X: 1, Y: 0}, {X: 1, Y: 1}, {X: 0, Y: 0}}},
+		{
+			{{X: 2, Y: 2}, {X: 3, Y: 2}, {X: 3, Y: 3}, {X: 2, Y: 2}},
+			{{X: 2.1, Y: 2.05}, {X: 2.9, Y: 2.05}, {X: 2.9, Y: 2.8}, {X: 2.1, Y: 2.05}},
+		},
+	}
+	if !reflect.DeepEqual(g, want) {
+		t.Errorf("%v != %v", g, want)
+	}
+
+	for _, s := range []string{
+		"POINT (0 0)",
+		"POLYGON ((0 0, 1 0, 1 1, 0 0)",
+		"POLYGON ((0 0, 1 0, 1 1, 0 0)) extra",
+		"POLYGON ((0 0 0, 1 0 0, 1 1 0, 0 0 0))",
+	} {
+		if _, err := parseWKTBoundary(s); err == nil {
+			t.Errorf("%s: expected an error", s)
+		}
+	}
+}
+
+func TestBoundaryProblems(t *testing.T) {
+	square := geom.Path{{X: 0, Y: 0}, {X: 1, Y: 0}, {X: 1, Y: 1}, {X: 0, Y: 1}, {X: 0, Y: 0}}
+	tests := []struct {
+		name string
+		p    geom.Polygon
+		want string
+	}{
+		{name: "valid", p: geom.Polygon{square}},
+		{name: "empty", p: geom.Polygon{}, want: "empty"},
+		{name: "short", p: geom.Polygon{square[:3]}, want: "at least 4"},
+		{name: "open", p: geom.Polygon{square[:4]}, want: "not closed"},
+		{
+			name: "lonlat",
+			p:    geom.Polygon{{{X: 0, Y: 0}, {X: 200, Y: 0}, {X: 200, Y: 1}, {X: 0, Y: 0}}},
+			want: "not a valid longitude and latitude",
+		},
+		{
+			name: "bowtie",
+			p:    geom.Polygon{{{X: 0, Y: 0}, {X: 1, Y: 1}, {X: 1, Y: 0}, {X: 0, Y: 1}, {X: 0, Y: 0}}},
+			want: "intersects itself",
+		},
+		{
+			name: "zero area",
+			p:    geom.Polygon{{{X: 0, Y: 0}, {X: 1, Y: 0}, {X: 2, Y: 0}, {X: 0, Y: 0}}},
+			want: "zero area",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			problems := boundaryProblems(test.p)
+			if test.want == "" {
+				if len(problems) != 0 {
+					t.Errorf("unexpected problems: %v", problems)
+				}
+				return
+			}
+			if len(problems) == 0 || !strings.Contains(strings.Join(problems, "; "), test.want) {
+				t.Errorf("problems %v should contain %q", problems, test.want)
+			}
+		})
+	}
+}
+
+func TestSelfIntersection(t *testing.T) {
+	// A large ring without intersections.
+	const n = 50000
+	circle := make(geom.Path, n+1)
+	for i := range circle[:n] {
+		a := 2 * math.Pi * float64(i) / n
+		circle[i] = geom.Point{X: math.Cos(a), Y: math.Sin(a)}
+	}
+	circle[n] = circle[0]
+	if i, j, ok := selfIntersection(circle); ok {
+		t.Errorf("circle: unexpected intersection of segments %d and %d", i, j)
+	}
+
+	for _, test := range []struct {
+		name string
+		ring geom.Path
+		i, j int
+	}{
+		{
+			name: "bowtie",
+			ring: geom.Path{{X: 0, Y: 0}, {X: 1, Y: 1}, {X: 1, Y: 0}, {X: 0, Y: 1}, {X: 0, Y: 0}},
+			i:    0, j: 2,
+		},
+		{
+			// A vertex touches a horizontal segment.
+			name: "touching",
+			ring: geom.Path{{X: 0, Y: 0}, {X: 2, Y: 0}, {X: 2, Y: 2}, {X: 1, Y: 0}, {X: 0, Y: 2}, {X: 0, Y: 0}},
+			i:    0, j: 2,
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			i, j, ok := selfIntersection(test.ring)
+			if !ok || i != test.i || j != test.j {
+				t.Errorf("got segments %d and %d (%v), want %d and %d", i, j, ok, test.i, test.j)
+			}
+		})
+	}
+}
@@ -0,0 +1,448 @@
+package cityaq
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"runtime"
+	"sort"
+
+	rpc "github.com/ctessum/cityaq/cityaqrpc"
+	"github.com/ctessum/geom"
+	"github.com/ctessum/geom/index/rtree"
+	"github.com/ctessum/requestcache/v4"
+	"github.com/paulmach/osm"
+	"github.com/paulmach/osm/osmpbf"
+)
+
+// customSurrogate is a spatial surrogate that is built at request time
+// from the OpenStreetMap features that match a set of tags.
+type customSurrogate struct {
+	// tags holds the values of each tag that features can match,
+	// or nil if any value matches.
+	tags     map[string][]string
+	weightBy rpc.SurrogateWeight
+
+	// id uniquely identifies the surrogate.
+	id string
+
+	// req is the request that the surrogate was created from.
+	req *rpc.CustomSurrogate
+}
+
+// requestCustomSurrogate checks the given surrogate specification and
+// converts it to a customSurrogate. It returns nil if s is nil.
+func requestCustomSurrogate(s *rpc.CustomSurrogate) (*customSurrogate, error) {
+	if s == nil {
+		return nil, nil
+	}
+	if _, ok := rpc.SurrogateWeight_name[int32(s.WeightBy)]; !ok || s.WeightBy == rpc.SurrogateWeight_UNKNOWN_SURROGATEWEIGHT {
+		return nil, fmt.Errorf("cityaq: invalid custom surrogate weight %s", s.WeightBy)
+	}
+	if len(s.Tags) == 0 {
+		return nil, fmt.Errorf("cityaq: custom surrogate has no tags")
+	}
+	o := &customSurrogate{
+		tags:     make(map[string][]string),
+		weightBy: s.WeightBy,
+		req:      s,
+	}
+	anyValue := make(map[string]bool)
+	for i, t := range s.Tags {
+		if t == nil || t.Key == "" {
+			return nil, fmt.Errorf("cityaq: custom surrogate tag %d is missing a key", i)
+		}
+		if len(t.Values) == 0 || anyValue[t.Key] {
+			anyValue[t.Key] = true
+			o.tags[t.Key] = nil
+			continue
+		}
+		o.tags[t.Key] = append(o.tags[t.Key], t.Values...)
+	}
+
+	// Create an ID from the tags in a consistent order.
+	keys := make([]string, 0, len(o.tags))
+	for k, v := range o.tags {
+		sort.Strings(v)
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	h := sha256.New()
+	fmt.Fprintf(h, "%s\n", o.weightBy)
+	for _, k := range keys {
+		fmt.Fprintf(h, "%q=%q\n", k, o.tags[k])
+	}
+	o.id = hex.EncodeToString(h.Sum(nil)[:8])
+	return o, nil
+}
+
+// name returns the name of the receiver when it is used as a source type.
+func (s *customSurrogate) name() string {
+	return "custom-" + s.id
+}
+
+// matches returns whether a feature with the given tags
+// should be included in the surrogate.
+func (s *customSurrogate) matches(tags osm.Tags) bool {
+	for _, t := range tags {
+		values, ok := s.tags[t.Key]
+		if !ok {
+			continue
+		}
+		if values == nil {
+			return true
+		}
+		i := sort.SearchStrings(values, t.Value)
+		if i < len(values) && values[i] == t.Value {
+			return true
+		}
+	}
+	return false
+}
+
+// osmExtract holds the tagged OpenStreetMap features within an area.
+type osmExtract struct {
+	Features []osmFeature
+}
+
+// osmFeature is a tagged OpenStreetMap node or way.
+type osmFeature struct {
+	Tags osm.Tags
+
+	// Path holds the location of a node, or the locations of the
+	// nodes of a way that were found.
+	Path []geom.Point
+
+	// Way is whether the feature is a way, and Closed is whether
+	// it is a closed way whose nodes were all found.
+	Way, Closed bool
+}
+
+// osmExtractJob reads the tagged OpenStreetMap features within bounds
+// from a PBF file, so that the file is only scanned once for each
+// allocation domain rather than for each custom surrogate.
+type osmExtractJob struct {
+	file   string
+	bounds *geom.Bounds
+}
+
+func (j *osmExtractJob) Key() string {
+	b := j.bounds
+	return cacheKey("osm", j.file, fmt.Sprintf("%g_%g_%g_%g", b.Min.X, b.Min.Y, b.Max.X, b.Max.Y))
+}
+
+func (j *osmExtractJob) Run(ctx context.Context, result requestcache.Result) error {
+	features, err := readOSMFeatures(ctx, j.file, j.bounds)
+	if err != nil {
+		return err
+	}
+	result.(*osmExtract).Features = features
+	return nil
+}
+
+// surrogateResult holds the fraction of a custom surrogate in each
+// grid cell, or no weights if the surrogate is zero throughout
+// the allocation domain.
+type surrogateResult struct {
+	Weights []float64
+}
+
+// surrogateJob calculates the weights of a custom surrogate on a grid.
+type surrogateJob struct {
+	c        *CityAQ
+	s        *customSurrogate
+	polygon  geom.Polygonal
+	grid     []geom.Polygonal
+	gridName string
+}
+
+func (j *surrogateJob) Key() string {
+	return cacheKey("surrogate", j.gridName, j.s.id)
+}
+
+func (j *surrogateJob) Run(ctx context.Context, result requestcache.Result) error {
+	file, err := j.c.osmFile()
+	if err != nil {
+		return err
+	}
+	var extract osmExtract
+	if err := j.c.cache.NewRequest(ctx, &osmExtractJob{file: file, bounds: osmBounds(j.polygon)}).Result(&extract); err != nil {
+		return err
+	}
+	w, err := surrogateWeights(j.s.features(extract.Features), j.polygon, j.grid)
+	if err == errZeroSurrogate {
+		return nil
+	} else if err != nil {
+		return err
+	}
+	result.(*surrogateResult).Weights = w
+	return nil
+}
+
+// osmBounds returns the area that OpenStreetMap features are read within
+// for the given allocation domain: the bounds of the domain with a
+// margin of 10% on each side, so that the nodes of ways that cross the
+// edge of the domain are usually found.
+func osmBounds(polygon geom.Polygonal) *geom.Bounds {
+	b := polygon.Bounds()
+	dx, dy := (b.Max.X-b.Min.X)*0.1, (b.Max.Y-b.Min.Y)*0.1
+	return &geom.Bounds{
+		Min: geom.Point{X: b.Min.X - dx, Y: b.Min.Y - dy},
+		Max: geom.Point{X: b.Max.X + dx, Y: b.Max.Y + dy},
+	}
+}
+
+// customSurrogateWeights returns the fraction of surrogate s within
+// polygon that is in each cell of the grid called gridName, or nil if
+// the surrogate is zero throughout polygon. The weights are cached,
+// along with the OpenStreetMap features of the allocation domain that
+// they are calculated from.
+func (c *CityAQ) customSurrogateWeights(ctx context.Context, s *customSurrogate, polygon geom.Polygonal, gridName string, grid []geom.Polygonal) ([]float64, error) {
+	c.setupCache()
+	var r surrogateResult
+	job := &surrogateJob{c: c, s: s, polygon: polygon, grid: grid, gridName: gridName}
+	if err := c.cache.NewRequest(ctx, job).Result(&r); err != nil {
+		return nil, err
+	}
+	return r.Weights, nil
+}
+
+// features returns the features of the receiver in the given
+// OpenStreetMap features. Ways are returned as LineStrings when the
+// surrogate is weighted by length, closed ways are returned as Polygons
+// when it is weighted by area, and nodes and the centers of ways are
+// returned as Points when it is weighted by count.
+func (s *customSurrogate) features(features []osmFeature) []geom.Geom {
+	var o []geom.Geom
+	for _, f := range features {
+		if !s.matches(f.Tags) {
+			continue
+		}
+		switch s.weightBy {
+		case rpc.SurrogateWeight_Length:
+			if f.Way && len(f.Path) > 1 {
+				o = append(o, geom.LineString(f.Path))
+			}
+		case rpc.SurrogateWeight_Area:
+			if f.Closed {
+				o = append(o, geom.Polygon{f.Path})
+			}
+		case rpc.SurrogateWeight_Count:
+			b := geom.Path(f.Path).Bounds()
+			o = append(o, geom.Point{X: (b.Min.X + b.Max.X) / 2, Y: (b.Min.Y + b.Max.Y) / 2})
+		}
+	}
+	return o
+}
+
+// osmFile returns the path to the OpenStreetMap extract that custom
+// surrogates are built from: OSMFile if it is set, and otherwise the
+// file used by the first surrogate in SrgSpecOSM.
+func (c *CityAQ) osmFile() (string, error) {
+	if c.OSMFile != "" {
+		return os.ExpandEnv(c.OSMFile), nil
+	}
+	if c.SpatialConfig.SrgSpecOSM == "" {
+		return "", fmt.Errorf("cityaq: custom surrogates require OSMFile or SrgSpecOSM to be set")
+	}
+	f, err := os.Open(os.ExpandEnv(c.SpatialConfig.SrgSpecOSM))
+	if err != nil {
+		return "", fmt.Errorf("cityaq: finding OSM file: %v", err)
+	}
+	defer f.Close()
+	var specs []struct {
+		OSMFile string `json:"osm_file"`
+	}
+	if err := json.NewDecoder(f).Decode(&specs); err != nil {
+		return "", fmt.Errorf("cityaq: finding OSM file: %v", err)
+	}
+	if len(specs) == 0 || specs[0].OSMFile == "" {
+		return "", fmt.Errorf("cityaq: finding OSM file: no OSM file in %s", c.SpatialConfig.SrgSpecOSM)
+	}
+	return os.ExpandEnv(specs[0].OSMFile), nil
+}
+
+// readOSMFeatures reads the tagged nodes and ways within bounds from
+// an OpenStreetMap PBF file. The file is read in a single pass, which
+// relies on nodes being stored before ways, as they are in standard
+// extracts. Only the locations of nodes within bounds are kept, so ways
+// that cross the edge of bounds only include their nodes within it.
+// Relations are not included.
+func readOSMFeatures(ctx context.Context, file string, bounds *geom.Bounds) ([]osmFeature, error) {
+	f, err := os.Open(file)
+	if err != nil {
+		return nil, fmt.Errorf("cityaq: reading OSM file: %v", err)
+	}
+	defer f.Close()
+
+	var o []osmFeature
+	nodes := make(map[osm.NodeID]geom.Point)
+	scanner := osmpbf.New(ctx, f, runtime.GOMAXPROCS(-1))
+	defer scanner.Close()
+	scanner.SkipRelations = true
+	for scanner.Scan() {
+		switch obj := scanner.Object().(type) {
+		case *osm.Node:
+			pt := geom.Point{X: obj.Lon, Y: obj.Lat}
+			if pt.X < bounds.Min.X || pt.X > bounds.Max.X || pt.Y < bounds.Min.Y || pt.Y > bounds.Max.Y {
+				continue
+			}
+			nodes[obj.ID] = pt
+			if len(obj.Tags) > 0 {
+				o = append(o, osmFeature{Tags: obj.Tags, Path: []geom.Point{pt}})
+			}
+		case *osm.Way:
+			if len(obj.Tags) == 0 {
+				continue
+			}
+			path := make([]geom.Point, 0, len(obj.Nodes))
+			for _, n := range obj.Nodes {
+				if pt, ok := nodes[n.ID]; ok {
+					path = append(path, pt)
+				}
+			}
+			if len(path) == 0 {
+				continue
+			}
+			closed := len(path) == len(obj.Nodes) && len(path) > 3 && obj.Nodes[0].ID == obj.Nodes[len(obj.Nodes)-1].ID
+			o = append(o, osmFeature{Tags: obj.Tags, Path: path, Way: true, Closed: closed})
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("cityaq: reading OSM file: %v", err)
+	}
+	return o, nil
+}
+
+// gridCell is a grid cell and its index in the grid.
+type gridCell struct {
+	geom.Polygonal
+	i int
+}
+
+// errZeroSurrogate is returned when none of the features of a spatial
+// surrogate are within the area that emissions are allocated within.
+var errZeroSurrogate = errors.New("cityaq: no surrogate features are within the emissions area")
+
+// surrogateWeights returns the fraction of the total weight of the given
+// features within polygon that is in each of the grid cells.
+func surrogateWeights(features []geom.Geom, polygon geom.Polygonal, grid []geom.Polygonal) ([]float64, error) {
+	index := rtree.NewTree(25, 50)
+	for i, cell := range grid {
+		index.Insert(&gridCell{Polygonal: cell, i: i})
+	}
+	bounds := polygon.Bounds()
+	w := make([]float64, len(grid))
+	for _, f := range features {
+		if !boundsOverlap(f.Bounds(), bounds) {
+			continue
+		}
+		switch t := f.(type) {
+		case geom.LineString:
+			l := t.Clip(polygon)
+			if l == nil {
+				continue
+			}
+			for _, cI := range index.SearchIntersect(l.Bounds()) {
+				cell := cI.(*gridCell)
+				if lc := l.Clip(cell.Polygonal); lc != nil {
+					w[cell.i] += lc.Length()
+				}
+			}
+		case geom.Polygon:
+			p := t.Intersection(polygon)
+			if p == nil {
+				continue
+			}
+			for _, cI := range index.SearchIntersect(p.Bounds()) {
+				cell := cI.(*gridCell)
+				if pc := p.Intersection(cell.Polygonal); pc != nil {
+					w[cell.i] += pc.Area()
+				}
+			}
+		case geom.Point:
+			if t.Within(polygon) == geom.Outside {
+				continue
+			}
+			for _, cI := range index.SearchIntersect(t.Bounds()) {
+				cell := cI.(*gridCell)
+				if t.Within(cell.Polygonal) != geom.Outside {
+					w[cell.i]++
+					break
+				}
+			}
+		default:
+			return nil, fmt.Errorf("cityaq: invalid surrogate feature type %T", f)
+		}
+	}
+	var sum float64
+	for _, v := range w {
+		sum += v
+	}
+	if sum == 0 {
+		return nil, errZeroSurrogate
+	}
+	for i := range w {
+		w[i] /= sum
+	}
+	return w, nil
+}
+
+// areaEmissions allocates the given amounts of emissions to the
+// given grid in proportion to the area of each grid cell that is
+// within polygon. It returns the gridded emissions of each pollutant.
+func areaEmissions(polygon geom.Polygonal, grid []geom.Polygonal, amounts []pollutantAmount) (map[rpc.Emission][]float64, error) {
+	var features []geom.Geom
+	for _, p := range appendPolygons(nil, polygon) {
+		features = append(features, p)
+	}
+	w, err := surrogateWeights(features, polygon, grid)
+	if err != nil {
+		return nil, err
+	}
+	o := make(map[rpc.Emission][]float64)
+	for _, a := range amounts {
+		v := make([]float64, len(grid))
+		for i, wi := range w {
+			v[i] = wi * a.mass
+		}
+		o[a.pollutant] = v
+	}
+	return o, nil
+}
+
+// customSurrogateEmissions allocates the given amounts of emissions
+// within polygon to the grid called gridName using the given surrogate.
+// If the surrogate is zero throughout polygon, it returns an error, or if
+// areaFallback is true, allocates the emissions in proportion to area
+// within polygon instead. It returns the gridded emissions of each
+// pollutant and whether they were allocated in proportion to area.
+func (c *CityAQ) customSurrogateEmissions(ctx context.Context, s *customSurrogate, polygon geom.Polygonal, gridName string, grid []geom.Polygonal, amounts []pollutantAmount, areaFallback bool) (map[rpc.Emission][]float64, bool, error) {
+	w, err := c.customSurrogateWeights(ctx, s, polygon, gridName, grid)
+	if err != nil {
+		return nil, false, err
+	}
+	if w == nil {
+		if !areaFallback {
+			return nil, false, fmt.Errorf("%v for custom surrogate %s", errZeroSurrogate, s.id)
+		}
+		o, err := areaEmissions(polygon, grid, amounts)
+		if err != nil {
+			return nil, false, fmt.Errorf("%v for custom surrogate %s", err, s.id)
+		}
+		return o, true, nil
+	}
+	o := make(map[rpc.Emission][]float64)
+	for _, a := range amounts {
+		v := make([]float64, len(grid))
+		for i, wi := range w {
+			v[i] = wi * a.mass
+		}
+		o[a.pollutant] = v
+	}
+	return o, false, nil
+}
@@ -0,0 +1,216 @@
+package cityaq
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	rpc "github.com/ctessum/cityaq/cityaqrpc"
+	"github.com/ctessum/geom"
+	"github.com/paulmach/osm"
+)
+
+func TestRequestCustomSurrogate(t *testing.T) {
+	s1, err := requestCustomSurrogate(&rpc.CustomSurrogate{
+		Tags: []*rpc.OSMTag{
+			{Key: "amenity", Values: []string{"school", "hospital"}},
+			{Key: "building"},
+		},
+		WeightBy: rpc.SurrogateWeight_Area,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	s2, err := requestCustomSurrogate(&rpc.CustomSurrogate{
+		Tags: []*rpc.OSMTag{
+			{Key: "building"},
+			{Key: "amenity", Values: []string{"hospital", "school"}},
+		},
+		WeightBy: rpc.SurrogateWeight_Area,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if s1.name() != s2.name() {
+		t.Errorf("equivalent surrogates have different names: %s != %s", s1.name(), s2.name())
+	}
+	s3, err := requestCustomSurrogate(&rpc.CustomSurrogate{
+		Tags:     s1.req.Tags,
+		WeightBy: rpc.SurrogateWeight_Count,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if s1.name() == s3.name() {
+		t.Error("surrogates with different weights have the same name")
+	}
+
+	for _, test := range []struct {
+		name string
+		tags osm.Tags
+		want bool
+	}{
+		{name: "value", tags: osm.Tags{{Key: "amenity", Value: "school"}}, want: true},
+		{name: "any value", tags: osm.Tags{{Key: "building", Value: "yes"}}, want: true},
+		{name: "other value", tags: osm.Tags{{Key: "amenity", Value: "bank"}}, want: false},
+		{name: "other key", tags: osm.Tags{{Key: "highway", Value: "school"}}, want: false},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			if got := s1.matches(test.tags); got != test.want {
+				t.Errorf("matches(%v) = %v, want %v", test.tags, got, test.want)
+			}
+		})
+	}
+
+	if s, err := requestCustomSurrogate(nil); s != nil || err != nil {
+		t.Errorf("nil surrogate: got %v, %v", s, err)
+	}
+	for name, s := range map[string]*rpc.CustomSurrogate{
+		"no tags":   {WeightBy: rpc.SurrogateWeight_Length},
+		"no key":    {Tags: []*rpc.OSMTag{{Values: []string{"primary"}}}, WeightBy: rpc.SurrogateWeight_Length},
+		"no weight": {Tags: []*rpc.OSMTag{{Key: "highway"}}},
+	} {
+		if _, err := requestCustomSurrogate(s); err == nil {
+			t.Errorf("%s: expected an error", name)
+		}
+	}
+}
+
+func TestSurrogateWeights(t *testing.T) {
+	square := func(x0, y0, x1, y1 float64) geom.Polygon {
+		return geom.Polygon{{{X: x0, Y: y0}, {X: x1, Y: y0}, {X: x1, Y: y1}, {X: x0, Y: y1}, {X: x0, Y: y0}}}
+	}
+	grid := []geom.Polygonal{square(0, 0, 1, 1), square(1, 0, 2, 1)}
+	domain := square(0, 0, 2, 1)
+
+	tests := []struct {
+		name     string
+		features []geom.Geom
+		want     []float64
+	}{
+		{
+			name: "length",
+			features: []geom.Geom{
+				geom.LineString{{X: 0.5, Y: 0.5}, {X: 1.5, Y: 0.5}},
+				geom.LineString{{X: 1.2, Y: 0.2}, {X: 1.8, Y: 0.2}},
+				geom.LineString{{X: 3, Y: 3}, {X: 4, Y: 4}},
+			},
+			want: []float64{0.5 / 1.6, 1.1 / 1.6},
+		},
+		{
+			name:     "area",
+			features: []geom.Geom{square(0.5, 0, 1.5, 1), square(1.5, 0, 2.5, 1)},
+			want:     []float64{0.5 / 1.5, 1 / 1.5},
+		},
+		{
+			name: "count",
+			features: []geom.Geom{
+				geom.Point{X: 0.5, Y: 0.5},
+				geom.Point{X: 1.5, Y: 0.5},
+				geom.Point{X: 1.6, Y: 0.6},
+				geom.Point{X: 5, Y: 5},
+			},
+			want: []float64{1.0 / 3, 2.0 / 3},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			w, err := surrogateWeights(test.features, domain, grid)
+			if err != nil {
+				t.Fatal(err)
+			}
+			for i, v := range w {
+				if !similar(v, test.want[i], 1e-10) {
+					t.Errorf("cell %d: %g != %g", i, v, test.want[i])
+				}
+			}
+		})
+	}
+
+	if _, err := surrogateWeights([]geom.Geom{geom.Point{X: 5, Y: 5}}, domain, grid); err == nil {
+		t.Error("expected an error for features outside of the domain")
+	}
+}
+
+func TestReadOSMFeatures(t *testing.T) {
+	bounds := &geom.Bounds{Min: geom.Point{X: -0.3, Y: 5.5}, Max: geom.Point{X: -0.1, Y: 5.7}}
+	features, err := readOSMFeatures(context.Background(), "testdata/ghana-latest.osm.pbf", bounds)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(features) == 0 {
+		t.Fatal("no features")
+	}
+	for _, f := range features {
+		if len(f.Tags) == 0 {
+			t.Fatalf("feature without tags: %+v", f)
+		}
+		for _, p := range f.Path {
+			if p.X < bounds.Min.X || p.X > bounds.Max.X || p.Y < bounds.Min.Y || p.Y > bounds.Max.Y {
+				t.Fatalf("node %+v is outside of %+v", p, bounds)
+			}
+		}
+	}
+	if _, err := readOSMFeatures(context.Background(), "testdata/missing.osm.pbf", bounds); err == nil {
+		t.Error("expected an error for a missing OSM file")
+	}
+}
+
+func TestCityAQ_customSurrogateWeights(t *testing.T) {
+	ctx := context.Background()
+	dir, err := ioutil.TempDir("", "cityaq_osm")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	file := filepath.Join(dir, "osm.pbf")
+	abs, err := filepath.Abs("testdata/ghana-latest.osm.pbf")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Symlink(abs, file); err != nil {
+		t.Fatal(err)
+	}
+
+	square := func(x0, y0, x1, y1 float64) geom.Polygon {
+		return geom.Polygon{{{X: x0, Y: y0}, {X: x1, Y: y0}, {X: x1, Y: y1}, {X: x0, Y: y1}, {X: x0, Y: y0}}}
+	}
+	domain := square(-0.3, 5.5, -0.1, 5.7)
+	grid := []geom.Polygonal{square(-0.3, 5.5, -0.2, 5.7), square(-0.2, 5.5, -0.1, 5.7)}
+	c := &CityAQ{OSMFile: file}
+	weights := func(key string, weightBy rpc.SurrogateWeight) ([]float64, error) {
+		s, err := requestCustomSurrogate(&rpc.CustomSurrogate{
+			Tags:     []*rpc.OSMTag{{Key: key}},
+			WeightBy: weightBy,
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		return c.customSurrogateWeights(ctx, s, domain, "test", grid)
+	}
+	w, err := weights("highway", rpc.SurrogateWeight_Length)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(w) != len(grid) || !similar(w[0]+w[1], 1, 1e-10) {
+		t.Errorf("weights %v should sum to one", w)
+	}
+
+	// The features of the domain are cached, so the OSM file isn't
+	// read again for surrogates with other tags.
+	if err := os.Remove(file); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := weights("building", rpc.SurrogateWeight_Area); err != nil {
+		t.Fatal(err)
+	}
+	w, err = weights("no_such_key", rpc.SurrogateWeight_Count)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if w != nil {
+		t.Errorf("surrogate without features should have no weights: %v", w)
+	}
+}
@@ -0,0 +1,506 @@
+package cityaq
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	rpc "github.com/ctessum/cityaq/cityaqrpc"
+)
+
+// maxTimeSteps is the maximum number of time steps of time-resolved
+// emissions, which is enough for a leap year of hourly time steps.
+const maxTimeSteps = 366 * 24
+
+// maxTimeStepValues is the maximum number of gridded values, summed over
+// time steps, grid cells, and pollutants, of time-resolved emissions, so
+// that responses take at most about 8 MB.
+const maxTimeStepValues = 1000000
+
+// temporalProfile describes how emissions vary over time.
+type temporalProfile struct {
+	// monthly holds the relative emissions in each month, starting
+	// with January, and weekly holds the relative emissions on
+	// each day of the week, starting with Monday.
+	monthly [12]float64
+	weekly  [7]float64
+
+	// weekday and weekend hold the relative emissions in each
+	// hour of weekdays and weekend days, in local time.
+	weekday, weekend [24]float64
+}
+
+// temporalKey identifies the temporal profile of a pollutant
+// emitted by a source type.
+type temporalKey struct {
+	sourceType, pollutant string
+}
+
+// temporalProfiles holds the temporal profiles of source types.
+type temporalProfiles struct {
+	// profiles holds the profile of each source type and pollutant.
+	// Keys with an empty source type apply to all source types, and
+	// keys with an empty pollutant apply to all pollutants.
+	profiles map[temporalKey]*temporalProfile
+}
+
+// loadTemporalProfiles reads the temporal cross-reference and profile
+// files in SMOKE format. In the cross-reference file, each line has a
+// source type, the codes of its monthly, weekly, and diurnal profiles,
+// and optionally a pollutant, FIPS code, and facility ID, separated by
+// semicolons, commas, or spaces, and text after "!" or "#" is a comment.
+// Source type names are used in place of SCCs, and source type
+// 0000000000 applies to all source types that are not listed. Lines for
+// a particular FIPS code or facility are skipped, because cities are not
+// identified by them. In the profile file, the profiles are in /MONTHLY/,
+// /WEEKLY/, /DIURNAL WEEKDAY/, and /DIURNAL WEEKEND/ sections, with a
+// profile code, the relative emissions in each period, and optionally
+// their total on each line, in columns separated by spaces or in the
+// fixed-width columns of SMOKE. If both file names are empty, there are
+// no profiles and emissions are constant over time.
+//
+// The files aren't read with aep's TemporalProcessor because it doesn't
+// expose the profiles it reads: it only allocates aep.Records, which need
+// an SCC, a FIPS code, and a time zone from a spatial processor, over the
+// fixed time steps of a simulation. Here the profiles are looked up by
+// source type name and integrated over the period of each request.
+func loadTemporalProfiles(refFile, proFile string) (*temporalProfiles, error) {
+	o := &temporalProfiles{profiles: make(map[temporalKey]*temporalProfile)}
+	if refFile == "" && proFile == "" {
+		return o, nil
+	}
+	if refFile == "" || proFile == "" {
+		return nil, fmt.Errorf("cityaq: both a temporal cross-reference file and a temporal profile file must be specified")
+	}
+	monthly, weekly, weekday, weekend, err := readTemporalProfiles(os.ExpandEnv(proFile))
+	if err != nil {
+		return nil, err
+	}
+
+	refFile = os.ExpandEnv(refFile)
+	f, err := os.Open(refFile)
+	if err != nil {
+		return nil, fmt.Errorf("cityaq: opening temporal cross-reference file: %v", err)
+	}
+	defer f.Close()
+	s := bufio.NewScanner(f)
+	for lineNum := 1; s.Scan(); lineNum++ {
+		fields := temporalRefFields(s.Text())
+		if len(fields) == 0 {
+			continue
+		}
+		if len(fields) < 4 {
+			return nil, fmt.Errorf("cityaq: temporal cross-reference file %s line %d: expected at least 4 fields but found %d", refFile, lineNum, len(fields))
+		}
+		if (len(fields) > 5 && strings.Trim(fields[5], "0") != "") || (len(fields) > 6 && fields[6] != "") {
+			continue
+		}
+		key := temporalKey{sourceType: fields[0]}
+		if strings.Trim(key.sourceType, "0") == "" {
+			// An SCC of zero applies to all source types.
+			key.sourceType = ""
+		}
+		if len(fields) > 4 {
+			key.pollutant = strings.ToUpper(fields[4])
+			if p, ok := smokePollutants[key.pollutant]; ok {
+				key.pollutant = p
+			}
+		}
+		if _, ok := o.profiles[key]; ok {
+			return nil, fmt.Errorf("cityaq: temporal cross-reference file %s line %d: duplicate entry for %s", refFile, lineNum, fields[0])
+		}
+		p := new(temporalProfile)
+		var ok bool
+		if p.monthly, ok = monthly[profileCode(fields[1])]; !ok {
+			return nil, fmt.Errorf("cityaq: temporal cross-reference file %s line %d: monthly profile %s is not in %s", refFile, lineNum, fields[1], proFile)
+		}
+		if p.weekly, ok = weekly[profileCode(fields[2])]; !ok {
+			return nil, fmt.Errorf("cityaq: temporal cross-reference file %s line %d: weekly profile %s is not in %s", refFile, lineNum, fields[2], proFile)
+		}
+		if p.weekday, ok = weekday[profileCode(fields[3])]; !ok {
+			return nil, fmt.Errorf("cityaq: temporal cross-reference file %s line %d: diurnal profile %s is not in %s", refFile, lineNum, fields[3], proFile)
+		}
+		if p.weekend, ok = weekend[profileCode(fields[3])]; !ok {
+			// Use the weekday profile on weekends if there
+			// isn't a separate weekend profile.
+			p.weekend = p.weekday
+		}
+		o.profiles[key] = p
+	}
+	if err := s.Err(); err != nil {
+		return nil, fmt.Errorf("cityaq: reading temporal cross-reference file %s: %v", refFile, err)
+	}
+	return o, nil
+}
+
+// readTemporalProfiles reads the monthly, weekly, weekday diurnal, and
+// weekend diurnal profiles in the given SMOKE temporal profile file,
+// keyed by profile code. The profiles are normalized to sum to one.
+func readTemporalProfiles(file string) (monthly map[string][12]float64, weekly map[string][7]float64,
+	weekday, weekend map[string][24]float64, err error) {
+	f, err := os.Open(file)
+	if err != nil {
+		return nil, nil, nil, nil, fmt.Errorf("cityaq: opening temporal profile file: %v", err)
+	}
+	defer f.Close()
+	monthly = make(map[string][12]float64)
+	weekly = make(map[string][7]float64)
+	weekday = make(map[string][24]float64)
+	weekend = make(map[string][24]float64)
+
+	var section string
+	s := bufio.NewScanner(f)
+	for lineNum := 1; s.Scan(); lineNum++ {
+		raw := s.Text()
+		line := strings.TrimSpace(raw)
+		if strings.HasPrefix(line, "/") {
+			section = strings.ToUpper(strings.Trim(line, "/ "))
+			if section == "END" {
+				section = ""
+			}
+			continue
+		}
+		fields := temporalFields(line)
+		if len(fields) == 0 {
+			continue
+		}
+		var n int
+		switch section {
+		case "MONTHLY":
+			n = 12
+		case "WEEKLY":
+			n = 7
+		case "DIURNAL WEEKDAY", "DIURNAL WEEKEND":
+			n = 24
+		case "":
+			return nil, nil, nil, nil, fmt.Errorf("cityaq: temporal profile file %s line %d: profile is not in a section", file, lineNum)
+		default:
+			// Skip sections that aren't used, such as
+			// day-of-month profiles.
+			continue
+		}
+		if len(fields) < n+1 || (len(fields) == n+1 && len(strings.TrimRight(raw, " \t")) > 5+4*n) {
+			// Values that fill their fixed-width columns aren't
+			// separated by spaces, which leaves too few fields,
+			// or too few to include the total if the line is
+			// long enough to have one.
+			fields = fixedWidthFields(raw, n)
+		}
+		if len(fields) < n+1 {
+			return nil, nil, nil, nil, fmt.Errorf("cityaq: temporal profile file %s line %d: expected %d values but found %d", file, lineNum, n, len(fields)-1)
+		}
+		v := make([]float64, n)
+		var sum float64
+		for i := range v {
+			v[i], err = strconv.ParseFloat(fields[i+1], 64)
+			if err != nil || v[i] < 0 || invalidFloat(v[i]) {
+				return nil, nil, nil, nil, fmt.Errorf("cityaq: temporal profile file %s line %d: invalid value %q", file, lineNum, fields[i+1])
+			}
+			sum += v[i]
+		}
+		if sum == 0 {
+			return nil, nil, nil, nil, fmt.Errorf("cityaq: temporal profile file %s line %d: all values are zero", file, lineNum)
+		}
+		for i := range v {
+			v[i] /= sum
+		}
+		code := profileCode(fields[0])
+		switch section {
+		case "MONTHLY":
+			var p [12]float64
+			copy(p[:], v)
+			monthly[code] = p
+		case "WEEKLY":
+			var p [7]float64
+			copy(p[:], v)
+			weekly[code] = p
+		case "DIURNAL WEEKDAY":
+			var p [24]float64
+			copy(p[:], v)
+			weekday[code] = p
+		case "DIURNAL WEEKEND":
+			var p [24]float64
+			copy(p[:], v)
+			weekend[code] = p
+		}
+	}
+	if err := s.Err(); err != nil {
+		return nil, nil, nil, nil, fmt.Errorf("cityaq: reading temporal profile file %s: %v", file, err)
+	}
+	return monthly, weekly, weekday, weekend, nil
+}
+
+// smokePollutants holds the emission types, as in temporalKey, of
+// SMOKE pollutant names that differ from the names of the emission types.
+var smokePollutants = map[string]string{
+	"SO2":      "SOX",
+	"PM25":     "PM2_5",
+	"PM2.5":    "PM2_5",
+	"PM25-PRI": "PM2_5",
+	"PM25_PRI": "PM2_5",
+}
+
+// temporalFields splits a line of a SMOKE temporal file into fields,
+// ignoring comments.
+func temporalFields(line string) []string {
+	if i := strings.Index(line, "#"); i >= 0 {
+		line = line[:i]
+	}
+	return strings.FieldsFunc(line, func(r rune) bool {
+		return r == ';' || r == ',' || r == ' ' || r == '\t'
+	})
+}
+
+// temporalRefFields splits a line of a SMOKE temporal cross-reference
+// file into fields, ignoring comments. If the fields are separated by
+// semicolons, empty fields are kept so that the later fields stay in
+// their columns.
+func temporalRefFields(line string) []string {
+	if i := strings.IndexAny(line, "!#"); i >= 0 {
+		line = line[:i]
+	}
+	if !strings.Contains(line, ";") {
+		return temporalFields(line)
+	}
+	fields := strings.Split(line, ";")
+	for i, f := range fields {
+		fields[i] = strings.TrimSpace(f)
+	}
+	// Remove empty trailing fields.
+	for len(fields) > 0 && fields[len(fields)-1] == "" {
+		fields = fields[:len(fields)-1]
+	}
+	return fields
+}
+
+// fixedWidthFields splits a line of a SMOKE temporal profile file with
+// n values into fields using the fixed-width columns of SMOKE: five
+// characters for the profile code, four for each value, and the rest
+// for the total.
+func fixedWidthFields(line string, n int) []string {
+	line = strings.TrimRight(line, " \t")
+	if len(line) < 5+4*n {
+		return nil
+	}
+	fields := []string{strings.TrimSpace(line[:5])}
+	for i := 0; i < n; i++ {
+		fields = append(fields, strings.TrimSpace(line[5+4*i:9+4*i]))
+	}
+	return fields
+}
+
+// profileCode normalizes a temporal profile code so that codes with
+// leading zeros match.
+func profileCode(code string) string {
+	code = strings.TrimLeft(code, "0")
+	if code == "" {
+		return "0"
+	}
+	return code
+}
+
+// profile returns the temporal profile of the given pollutant emitted
+// by the given source type, or nil if emissions are constant over time.
+func (tp *temporalProfiles) profile(sourceType string, pollutant rpc.Emission) *temporalProfile {
+	pol := strings.ToUpper(pollutant.String())
+	for _, k := range []temporalKey{
+		{sourceType: sourceType, pollutant: pol},
+		{sourceType: sourceType},
+		{pollutant: pol},
+		{},
+	} {
+		if p, ok := tp.profiles[k]; ok {
+			return p
+		}
+	}
+	return nil
+}
+
+// temporalProfiles returns the temporal profiles, loading them from
+// TemporalRefFile and TemporalProFile the first time they are needed.
+func (c *CityAQ) temporalProfiles() (*temporalProfiles, error) {
+	c.temporalProfilesOnce.Do(func() {
+		c.temporalProfileSet, c.temporalProfilesErr = loadTemporalProfiles(c.TemporalRefFile, c.TemporalProFile)
+	})
+	return c.temporalProfileSet, c.temporalProfilesErr
+}
+
+// rate returns the relative emissions rate of the receiver at the given
+// local time. The monthly emissions are spread evenly over the days of
+// the month, so a profile whose monthly values are proportional to the
+// number of days in each month and whose other values are uniform has
+// a rate of about one. A nil profile has a constant rate of one.
+func (p *temporalProfile) rate(t time.Time) float64 {
+	if p == nil {
+		return 1
+	}
+	daysInMonth := float64(time.Date(t.Year(), t.Month()+1, 0, 0, 0, 0, 0, time.UTC).Day())
+	day := (int(t.Weekday()) + 6) % 7 // Monday is zero.
+	diurnal := p.weekday
+	if t.Weekday() == time.Saturday || t.Weekday() == time.Sunday {
+		diurnal = p.weekend
+	}
+	return p.monthly[t.Month()-1] * 365.25 / daysInMonth * p.weekly[day] * 7 * diurnal[t.Hour()] * 24
+}
+
+// integral returns the integral of the emissions rate of the receiver
+// over the given time range, in hours. loc is the local time zone.
+func (p *temporalProfile) integral(begin, end time.Time, loc *time.Location) float64 {
+	var o float64
+	for t := begin; t.Before(end); {
+		next := t.Truncate(time.Hour).Add(time.Hour)
+		if next.After(end) {
+			next = end
+		}
+		o += p.rate(t.In(loc)) * next.Sub(t).Hours()
+		t = next
+	}
+	return o
+}
+
+// timeStep is a period of time.
+type timeStep struct {
+	begin, end time.Time
+}
+
+// timeSteps divides the time between begin and end into steps of the
+// given resolution, whose boundaries are aligned to hours, days, or
+// months in the given time zone. The first and last steps are shorter
+// if begin and end are not aligned.
+func timeSteps(begin, end time.Time, res rpc.TemporalResolution, loc *time.Location) ([]timeStep, error) {
+	var next func(t time.Time) time.Time
+	switch res {
+	case rpc.TemporalResolution_Hourly:
+		next = func(t time.Time) time.Time { return t.Truncate(time.Hour).Add(time.Hour) }
+	case rpc.TemporalResolution_Daily:
+		next = func(t time.Time) time.Time {
+			y, m, d := t.In(loc).Date()
+			return time.Date(y, m, d+1, 0, 0, 0, 0, loc)
+		}
+	case rpc.TemporalResolution_Monthly:
+		next = func(t time.Time) time.Time {
+			y, m, _ := t.In(loc).Date()
+			return time.Date(y, m+1, 1, 0, 0, 0, 0, loc)
+		}
+	default:
+		return nil, fmt.Errorf("cityaq: invalid temporal resolution %s", res)
+	}
+	var o []timeStep
+	for t := begin; t.Before(end); {
+		if len(o) == maxTimeSteps {
+			return nil, fmt.Errorf("cityaq: the time window has more than the maximum of %d %s time steps", maxTimeSteps, strings.ToLower(res.String()))
+		}
+		n := next(t)
+		if n.After(end) {
+			n = end
+		}
+		o = append(o, timeStep{begin: t, end: n})
+		t = n
+	}
+	return o, nil
+}
+
+// cityTimeZoneProperty is the boundary property that holds the IANA
+// time zone of a city, such as "Africa/Accra", as in the OpenStreetMap
+// timezone tag.
+const cityTimeZoneProperty = "timezone"
+
+// cityTimeZone returns the local time zone of the given city, which is
+// used for temporal profiles, from the cityTimeZoneProperty of its
+// boundary.
+func (c *CityAQ) cityTimeZone(city string) (*time.Location, error) {
+	_, f, err := c.catalog().lookup(city)
+	if err != nil {
+		return nil, err
+	}
+	name, ok := f.props[cityTimeZoneProperty].(string)
+	if !ok || name == "" {
+		return nil, fmt.Errorf("cityaq: the boundary of city %s needs a %s property with its IANA time zone for time-resolved emissions", city, cityTimeZoneProperty)
+	}
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		return nil, fmt.Errorf("cityaq: time zone of city %s: %v", city, err)
+	}
+	return loc, nil
+}
+
+// emissionsTimeSteps allocates the given gridded emissions, which are
+// emitted over the emissions period, to time steps within the time
+// window of req using the temporal profiles of the requested source type,
+// or of each of the source types in blend if it is not nil.
+func (c *CityAQ) emissionsTimeSteps(req *rpc.GriddedEmissionsRequest, cityID string, blend *sourceTypeBlend,
+	amounts []pollutantAmount, emis []*rpc.PollutantEmissions) ([]*rpc.EmissionsTimeStep, error) {
+	period := amounts[0].emissionsAmount
+	begin, end := period.begin, period.end
+	if req.WindowBegin != 0 || req.WindowEnd != 0 {
+		begin, end = time.Unix(req.WindowBegin, 0).UTC(), time.Unix(req.WindowEnd, 0).UTC()
+		if !begin.Before(end) {
+			return nil, fmt.Errorf("cityaq: time window end %v is not after begin %v", end, begin)
+		}
+		if begin.Before(period.begin) || end.After(period.end) {
+			return nil, fmt.Errorf("cityaq: time window %v to %v is not within the emissions period %v to %v",
+				begin, end, period.begin, period.end)
+		}
+	}
+
+	tp, err := c.temporalProfiles()
+	if err != nil {
+		return nil, err
+	}
+	loc, err := c.cityTimeZone(cityID)
+	if err != nil {
+		return nil, err
+	}
+	steps, err := timeSteps(begin, end, req.TemporalResolution, loc)
+	if err != nil {
+		return nil, err
+	}
+	if n := len(steps) * len(emis[0].Emissions) * len(emis); n > maxTimeStepValues {
+		return nil, fmt.Errorf("cityaq: %d %s time steps of %d grid cells and %d pollutants have %d values, more than the maximum of %d; use a shorter time window or a coarser temporal resolution",
+			len(steps), strings.ToLower(req.TemporalResolution.String()), len(emis[0].Emissions), len(emis), n, maxTimeStepValues)
+	}
+
+	// fractions returns the fraction of emissions over the emissions
+	// period that are emitted in each time step.
+	fractions := func(p *temporalProfile) []float64 {
+		total := p.integral(period.begin, period.end, loc)
+		o := make([]float64, len(steps))
+		for i, s := range steps {
+			o[i] = p.integral(s.begin, s.end, loc) / total
+		}
+		return o
+	}
+
+	o := make([]*rpc.EmissionsTimeStep, len(steps))
+	for i, s := range steps {
+		o[i] = &rpc.EmissionsTimeStep{
+			Begin:              s.begin.Unix(),
+			End:                s.end.Unix(),
+			PollutantEmissions: make([]*rpc.PollutantEmissions, len(emis)),
+		}
+	}
+	for j, pe := range emis {
+		var f []float64
+		if blend != nil {
+			f = make([]float64, len(steps))
+			for _, comp := range blend.components {
+				for i, v := range fractions(tp.profile(comp.sourceType, pe.Emission)) {
+					f[i] += v * comp.weight
+				}
+			}
+		} else {
+			f = fractions(tp.profile(req.SourceType, pe.Emission))
+		}
+		for i := range steps {
+			v := make([]float64, len(pe.Emissions))
+			for k, e := range pe.Emissions {
+				v[k] = e * f[i]
+			}
+			o[i].PollutantEmissions[j] = &rpc.PollutantEmissions{Emission: pe.Emission, Emissions: v}
+		}
+	}
+	return o, nil
+}
@@ -0,0 +1,264 @@
+package cityaq
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	rpc "github.com/ctessum/cityaq/cityaqrpc"
+	"github.com/spatialmodel/inmap/emissions/aep/aeputil"
+	"gonum.org/v1/gonum/floats"
+)
+
+func TestLoadTemporalProfiles(t *testing.T) {
+	tp, err := loadTemporalProfiles("testdata/temporal_ref.txt", "testdata/temporal_pro.txt")
+	if err != nil {
+		t.Fatal(err)
+	}
+	road := tp.profile("roadways", rpc.Emission_NOx)
+	if road == nil {
+		t.Fatal("missing roadways profile")
+	}
+	if road.weekday[8] <= road.weekday[3] {
+		t.Errorf("roadways should have a morning rush hour: %v", road.weekday)
+	}
+	if road.weekday == road.weekend {
+		t.Error("roadways should have a separate weekend profile")
+	}
+	if !similar(floats.Sum(road.weekday[:]), 1, 1e-10) {
+		t.Errorf("diurnal profile should sum to one: %v", road.weekday)
+	}
+	res := tp.profile("residential", rpc.Emission_PM2_5)
+	if res.monthly[0] <= res.monthly[6] {
+		t.Errorf("residential should have a heating season: %v", res.monthly)
+	}
+	if p := tp.profile("airports", rpc.Emission_PM2_5); p == nil || p == road || p == res {
+		t.Errorf("airports should use the default profile")
+	}
+
+	tp, err = loadTemporalProfiles("", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if p := tp.profile("roadways", rpc.Emission_NOx); p != nil {
+		t.Error("there should be no profiles when no files are specified")
+	}
+
+	dir, err := ioutil.TempDir("", "cityaq_temporal")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	ref := filepath.Join(dir, "ref.txt")
+	if err := ioutil.WriteFile(ref, []byte("roadways;1;1;9\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := loadTemporalProfiles(ref, "testdata/temporal_pro.txt"); err == nil {
+		t.Error("expected an error for a missing diurnal profile")
+	}
+	if _, err := loadTemporalProfiles("testdata/temporal_ref.txt", ""); err == nil {
+		t.Error("expected an error for a missing profile file")
+	}
+}
+
+func TestLoadTemporalProfiles_smoke(t *testing.T) {
+	tp, err := loadTemporalProfiles("testdata/smoke_tref.txt", "testdata/smoke_tpro.txt")
+	if err != nil {
+		t.Fatal(err)
+	}
+	def := tp.profile("airports", rpc.Emission_PM2_5)
+	if def == nil || !similar(def.weekday[0], 42.0/1000, 1e-10) {
+		t.Fatalf("airports should use the default profile: %+v", def)
+	}
+	road := tp.profile("roadways", rpc.Emission_NOx)
+	if !similar(road.weekly[4], 160.0/1000, 1e-10) {
+		t.Errorf("roadways NOx weekly profile: %v", road.weekly)
+	}
+	// Values that fill their fixed-width columns are read.
+	wood := tp.profile("residential", rpc.Emission_PM2_5)
+	if wood.weekly != [7]float64{4: 1} {
+		t.Errorf("residential PM2.5 weekly profile: %v", wood.weekly)
+	}
+	if wood.weekday != [24]float64{17: 1} || wood.weekend != wood.weekday {
+		t.Errorf("residential PM2.5 diurnal profiles: %v, %v", wood.weekday, wood.weekend)
+	}
+	// The county- and facility-specific lines are skipped.
+	res := tp.profile("residential", rpc.Emission_SOx)
+	if res == wood || res.monthly[0] <= res.monthly[6] || !similar(res.weekday[0], 42.0/1000, 1e-10) {
+		t.Errorf("residential SO2 should use the residential profile: %+v", res)
+	}
+}
+
+func TestTimeSteps(t *testing.T) {
+	loc, err := time.LoadLocation("Africa/Accra")
+	if err != nil {
+		t.Fatal(err)
+	}
+	begin := time.Date(2016, time.January, 1, 0, 0, 0, 0, time.UTC)
+	end := time.Date(2017, time.January, 1, 0, 0, 0, 0, time.UTC)
+	steps, err := timeSteps(begin, end, rpc.TemporalResolution_Monthly, loc)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(steps) != 12 || !steps[1].begin.Equal(time.Date(2016, time.February, 1, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("wrong monthly time steps: %v", steps)
+	}
+
+	loc, err = time.LoadLocation("Asia/Karachi")
+	if err != nil {
+		t.Fatal(err)
+	}
+	steps, err = timeSteps(begin, begin.Add(72*time.Hour), rpc.TemporalResolution_Daily, loc)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(steps) != 4 || steps[0].end.Sub(steps[0].begin) != 19*time.Hour {
+		t.Errorf("daily time steps should be aligned to local midnight: %v", steps)
+	}
+
+	if _, err := timeSteps(begin, end.AddDate(1, 0, 0), rpc.TemporalResolution_Hourly, loc); err == nil {
+		t.Error("expected an error for too many time steps")
+	}
+}
+
+func TestTemporalProfile_integral(t *testing.T) {
+	loc := time.UTC
+	begin := time.Date(2016, time.January, 1, 0, 0, 0, 0, time.UTC)
+	end := time.Date(2017, time.January, 1, 0, 0, 0, 0, time.UTC)
+	var flat *temporalProfile
+	if v := flat.integral(begin, end, loc); v != 366*24 {
+		t.Errorf("flat integral: %g != %d", v, 366*24)
+	}
+	if v := flat.integral(begin, begin.Add(90*time.Minute), loc); v != 1.5 {
+		t.Errorf("partial hour integral: %g != 1.5", v)
+	}
+
+	tp, err := loadTemporalProfiles("testdata/temporal_ref.txt", "testdata/temporal_pro.txt")
+	if err != nil {
+		t.Fatal(err)
+	}
+	road := tp.profile("roadways", rpc.Emission_NOx)
+	// Friday, January 8, 2016.
+	day := time.Date(2016, time.January, 8, 0, 0, 0, 0, time.UTC)
+	rush := road.integral(day.Add(17*time.Hour), day.Add(18*time.Hour), loc)
+	night := road.integral(day.Add(3*time.Hour), day.Add(4*time.Hour), loc)
+	if rush <= 5*night {
+		t.Errorf("rush hour emissions %g should be much greater than night emissions %g", rush, night)
+	}
+
+	// In Oslo, 17:00 local time is 16:00 UTC in winter and
+	// 15:00 UTC in summer, when daylight saving time is in effect.
+	oslo, err := time.LoadLocation("Europe/Oslo")
+	if err != nil {
+		t.Fatal(err)
+	}
+	summer := day.AddDate(0, 6, 0) // Friday, July 8, 2016.
+	winterRush := road.integral(day.Add(16*time.Hour), day.Add(17*time.Hour), oslo)
+	summerRush := road.integral(summer.Add(15*time.Hour), summer.Add(16*time.Hour), oslo)
+	if !similar(winterRush, summerRush, 1e-10) {
+		t.Errorf("rush hour should follow daylight saving time: %g != %g", winterRush, summerRush)
+	}
+}
+
+func TestCityAQ_griddedEmissionsTimeSteps(t *testing.T) {
+	c := &CityAQ{
+		CityGeomDir: "testdata/cities",
+		SpatialConfig: aeputil.SpatialConfig{
+			SrgSpecOSM:    "testdata/srgspec_osm.json",
+			SCCExactMatch: true,
+			GridRef:       []string{"testdata/gridref.txt"},
+			OutputSR:      "+proj=longlat",
+			InputSR:       "+proj=longlat",
+		},
+		TemporalRefFile: "testdata/temporal_ref.txt",
+		TemporalProFile: "testdata/temporal_pro.txt",
+	}
+	req := &rpc.GriddedEmissionsRequest{
+		CityName:           "Accra Metropolitan",
+		SourceType:         "roadways",
+		Emission:           rpc.Emission_NOx,
+		TemporalResolution: rpc.TemporalResolution_Monthly,
+	}
+	emis, err := c.GriddedEmissions(context.Background(), req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(emis.TimeSteps) != 12 {
+		t.Fatalf("wrong number of time steps: %d", len(emis.TimeSteps))
+	}
+	var sum float64
+	for _, ts := range emis.TimeSteps {
+		if len(ts.PollutantEmissions) != 1 || len(ts.PollutantEmissions[0].Emissions) != len(emis.Emissions) {
+			t.Fatalf("wrong time step emissions size")
+		}
+		sum += floats.Sum(ts.PollutantEmissions[0].Emissions)
+	}
+	if total := floats.Sum(emis.Emissions); !similar(sum, total, 1e-8) {
+		t.Errorf("time steps should add up to the total emissions: %g != %g", sum, total)
+	}
+
+	// One weekday in hourly time steps.
+	req.TemporalResolution = rpc.TemporalResolution_Hourly
+	day := time.Date(2016, time.March, 2, 0, 0, 0, 0, time.UTC)
+	req.WindowBegin, req.WindowEnd = day.Unix(), day.Add(24*time.Hour).Unix()
+	emis, err = c.GriddedEmissions(context.Background(), req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(emis.TimeSteps) != 24 {
+		t.Fatalf("wrong number of time steps: %d", len(emis.TimeSteps))
+	}
+	if rush, night := floats.Sum(emis.TimeSteps[17].PollutantEmissions[0].Emissions),
+		floats.Sum(emis.TimeSteps[3].PollutantEmissions[0].Emissions); rush <= night {
+		t.Errorf("rush hour emissions %g should be greater than night emissions %g", rush, night)
+	}
+
+	// Hourly time steps over the whole emissions period are too large.
+	req.WindowBegin, req.WindowEnd = 0, 0
+	if len(emis.Emissions)*366*24 <= maxTimeStepValues {
+		t.Fatalf("the grid is too small to test the limit: %d cells", len(emis.Emissions))
+	}
+	if _, err := c.GriddedEmissions(context.Background(), req); err == nil || !strings.Contains(err.Error(), "maximum") {
+		t.Errorf("expected an error for too many values, got %v", err)
+	}
+
+	req.WindowBegin, req.WindowEnd = day.AddDate(1, 0, 0).Unix(), day.AddDate(1, 0, 1).Unix()
+	if _, err := c.GriddedEmissions(context.Background(), req); err == nil {
+		t.Error("expected an error for a time window outside of the emissions period")
+	}
+}
+
+func TestCityAQ_cityTimeZone(t *testing.T) {
+	c := &CityAQ{CityGeomDir: "testdata/cities"}
+	loc, err := c.cityTimeZone("Accra Metropolitan")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if loc.String() != "Africa/Accra" {
+		t.Errorf("time zone: %s != Africa/Accra", loc)
+	}
+
+	dir, err := ioutil.TempDir("", "cityaq_cities")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	b, err := ioutil.ReadFile("testdata/cities/accra_jurisdiction.geojson")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for tz, want := range map[string]bool{`"Africa/Accra"`: true, `"Mars/Olympus_Mons"`: false, `""`: false} {
+		s := strings.Replace(string(b), `"Africa/Accra"`, tz, 1)
+		if err := ioutil.WriteFile(filepath.Join(dir, "accra.geojson"), []byte(s), 0644); err != nil {
+			t.Fatal(err)
+		}
+		c := &CityAQ{CityGeomDir: dir}
+		if _, err := c.cityTimeZone("Accra Metropolitan"); (err == nil) != want {
+			t.Errorf("time zone %s: error %v", tz, err)
+		}
+	}
+}
@@ -0,0 +1,259 @@
+package cityaq
+
+import (
+	"fmt"
+	"math"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/ctessum/geom"
+	"github.com/ctessum/geom/index/rtree"
+)
+
+// BoundaryProblem describes a problem with a city boundary file.
+type BoundaryProblem struct {
+	// File is the path to the boundary file.
+	File string
+
+	// Problem is a description of the problem.
+	Problem string
+}
+
+func (p BoundaryProblem) String() string {
+	return p.File + ": " + p.Problem
+}
+
+// ValidateCities checks the boundary files in the CityGeomDir directory
+// for problems that would prevent the cities from being loaded or cause
+// errors when they are used: files that can't be read, missing or
+// duplicate names and IDs, invalid geometry, and GeoJSON rings that
+// don't follow the RFC 7946 winding order. If SrgShapefileDirectory is
+// set, it also checks that each boundary can be matched to a country.
+// The returned error is only non-nil if the checks could not be run.
+func (c *CityAQ) ValidateCities() ([]BoundaryProblem, error) {
+	dir := os.ExpandEnv(c.CityGeomDir)
+	var files []string
+	err := filepath.Walk(dir, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if _, ok := boundaryFormatOf(path); ok && !info.IsDir() {
+			files = append(files, path)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("cityaq: reading city directory: %w", err)
+	}
+	sort.Strings(files)
+
+	var problems []BoundaryProblem
+	add := func(file string, err interface{}) {
+		// Remove the file name from error messages
+		// because it is already included in the problem.
+		msg := strings.TrimPrefix(fmt.Sprint(err), "file "+file+": ")
+		problems = append(problems, BoundaryProblem{File: file, Problem: msg})
+	}
+	names := make(map[string]string)
+	ids := make(map[string]string)
+	for _, path := range files {
+		props, err := boundaryProperties(path)
+		if err != nil {
+			add(path, err)
+			continue
+		}
+		if name, err := defaultCityName(props); err != nil {
+			add(path, err)
+		} else {
+			if other, ok := names[name]; ok {
+				add(path, fmt.Sprintf("city %q is also defined in %s", name, other))
+			} else {
+				names[name] = path
+			}
+			if id, err := cityID(props, name); err != nil {
+				add(path, err)
+			} else if other, ok := ids[id]; ok {
+				add(path, fmt.Sprintf("city ID %q is also used by %s", id, other))
+			} else {
+				ids[id] = path
+			}
+		}
+
+		f, _ := boundaryFormatOf(path)
+		g, err := f.geometry(path)
+		if err != nil {
+			add(path, err)
+			continue
+		}
+		if len(g) == 0 {
+			add(path, "boundary is empty")
+			continue
+		}
+		valid := true
+		for i, p := range g {
+			var prefix string
+			if len(g) > 1 {
+				prefix = fmt.Sprintf("part %d: ", i)
+			}
+			pp := boundaryProblems(p)
+			for _, problem := range pp {
+				add(path, prefix+problem)
+			}
+			if len(pp) > 0 {
+				valid = false
+				continue
+			}
+			// RFC 7946 requires exterior rings to be counterclockwise
+			// and holes to be clockwise. Other formats have their own
+			// conventions, which are handled when they are read.
+			if strings.EqualFold(filepath.Ext(path), ".geojson") {
+				for j, ring := range p {
+					if ccw := planarArea(ring) > 0; ccw != (j == 0) {
+						add(path, fmt.Sprintf("%sring %d has the wrong winding order", prefix, j))
+					}
+				}
+			}
+		}
+		if valid && c.SpatialConfig.SrgShapefileDirectory != "" {
+			ctry, err := c.countryOf(g)
+			if err != nil {
+				return nil, err
+			}
+			if ctry == nil {
+				add(path, "boundary can't be matched to a country")
+			}
+		}
+	}
+	return problems, nil
+}
+
+// planarArea returns the signed area of the given closed ring,
+// which is positive if the ring is counterclockwise.
+func planarArea(ring geom.Path) float64 {
+	var a float64
+	for i := 0; i < len(ring)-1; i++ {
+		a += ring[i].X*ring[i+1].Y - ring[i+1].X*ring[i].Y
+	}
+	return a / 2
+}
+
+// boundaryProblems returns descriptions of any problems with
+// a boundary polygon whose coordinates are longitude and latitude
+// in degrees.
+func boundaryProblems(p geom.Polygon) []string {
+	var problems []string
+	if len(p) == 0 {
+		return []string{"boundary is empty"}
+	}
+	for i, ring := range p {
+		if len(ring) < 4 {
+			problems = append(problems, fmt.Sprintf("ring %d has %d points but must have at least 4", i, len(ring)))
+			continue
+		}
+		if ring[0] != ring[len(ring)-1] {
+			problems = append(problems, fmt.Sprintf("ring %d is not closed", i))
+		}
+		for j, pt := range ring {
+			if math.IsNaN(pt.X) || math.IsNaN(pt.Y) || pt.X < -180 || pt.X > 180 || pt.Y < -90 || pt.Y > 90 {
+				problems = append(problems, fmt.Sprintf("ring %d point %d (%g, %g) is not a valid longitude and latitude", i, j, pt.X, pt.Y))
+				break
+			}
+		}
+		if k, l, ok := selfIntersection(ring); ok {
+			problems = append(problems, fmt.Sprintf("ring %d intersects itself at segments %d and %d", i, k, l))
+		}
+	}
+	if len(problems) == 0 && sphericalArea(p) <= 0 {
+		problems = append(problems, "boundary has zero area")
+	}
+	return problems
+}
+
+// selfIntersection returns the indices of the first two
+// non-adjacent segments of the given closed ring that intersect,
+// and whether any such segments were found. The segments are
+// indexed by their bounds, so that only segments whose bounds
+// overlap are compared.
+func selfIntersection(ring geom.Path) (int, int, bool) {
+	n := len(ring) - 1 // Number of segments in a closed ring.
+	index := rtree.NewTree(25, 50)
+	segments := make([]*ringSegment, n)
+	for i := range segments {
+		segments[i] = &ringSegment{i: i, a: ring[i], b: ring[i+1]}
+		index.Insert(segments[i])
+	}
+	for i, s := range segments {
+		first := -1
+		for _, oI := range index.SearchIntersect(s.searchBounds()) {
+			j := oI.(*ringSegment).i
+			if j <= i || j == i+1 || (i == 0 && j == n-1) {
+				continue // Adjacent segments share an end point.
+			}
+			if (first < 0 || j < first) && segmentsIntersect(s.a, s.b, ring[j], ring[j+1]) {
+				first = j
+			}
+		}
+		if first >= 0 {
+			return i, first, true
+		}
+	}
+	return -1, -1, false
+}
+
+// ringSegment is a segment of a ring, for use in a spatial index.
+type ringSegment struct {
+	i    int
+	a, b geom.Point
+}
+
+// Bounds returns the bounds of the segment.
+func (s *ringSegment) Bounds() *geom.Bounds {
+	return &geom.Bounds{
+		Min: geom.Point{X: math.Min(s.a.X, s.b.X), Y: math.Min(s.a.Y, s.b.Y)},
+		Max: geom.Point{X: math.Max(s.a.X, s.b.X), Y: math.Max(s.a.Y, s.b.Y)},
+	}
+}
+
+// searchBounds returns the bounds of the segment, expanded slightly
+// so that segments that only touch it are found by index searches.
+func (s *ringSegment) searchBounds() *geom.Bounds {
+	const pad = 1.0e-9 // degrees
+	b := s.Bounds()
+	b.Min.X, b.Min.Y = b.Min.X-pad, b.Min.Y-pad
+	b.Max.X, b.Max.Y = b.Max.X+pad, b.Max.Y+pad
+	return b
+}
+
+// segmentsIntersect returns whether line segment p1-p2 intersects
+// line segment p3-p4.
+func segmentsIntersect(p1, p2, p3, p4 geom.Point) bool {
+	d1 := orientation(p3, p4, p1)
+	d2 := orientation(p3, p4, p2)
+	d3 := orientation(p1, p2, p3)
+	d4 := orientation(p1, p2, p4)
+	if ((d1 > 0 && d2 < 0) || (d1 < 0 && d2 > 0)) &&
+		((d3 > 0 && d4 < 0) || (d3 < 0 && d4 > 0)) {
+		return true
+	}
+	return (d1 == 0 && onSegment(p3, p4, p1)) ||
+		(d2 == 0 && onSegment(p3, p4, p2)) ||
+		(d3 == 0 && onSegment(p1, p2, p3)) ||
+		(d4 == 0 && onSegment(p1, p2, p4))
+}
+
+// orientation returns the cross product of (b-a) and (c-a), which is
+// positive if a, b, and c are in counter-clockwise order,
+// negative if they are in clockwise order, and zero if they are
+// collinear.
+func orientation(a, b, c geom.Point) float64 {
+	return (b.X-a.X)*(c.Y-a.Y) - (b.Y-a.Y)*(c.X-a.X)
+}
+
+// onSegment returns whether point c, which is collinear with
+// segment a-b, lies on the segment.
+func onSegment(a, b, c geom.Point) bool {
+	return math.Min(a.X, b.X) <= c.X && c.X <= math.Max(a.X, b.X) &&
+		math.Min(a.Y, b.Y) <= c.Y && c.Y <= math.Max(a.Y, b.Y)
+}
@@ -0,0 +1,90 @@
+package cityaq
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/spatialmodel/inmap/emissions/aep/aeputil"
+)
+
+func TestCityAQ_ValidateCities(t *testing.T) {
+	for _, dir := range []string{"testdata/cities", "testdata/boundaries"} {
+		t.Run(dir, func(t *testing.T) {
+			c := &CityAQ{
+				CityGeomDir: dir,
+				SpatialConfig: aeputil.SpatialConfig{
+					SrgShapefileDirectory: "testdata",
+				},
+			}
+			problems, err := c.ValidateCities()
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(problems) != 0 {
+				t.Errorf("unexpected problems: %v", problems)
+			}
+		})
+	}
+
+	t.Run("problems", func(t *testing.T) {
+		dir, err := ioutil.TempDir("", "cityaq_validate")
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer os.RemoveAll(dir)
+
+		feature := func(props, coords string) string {
+			return `{"type":"FeatureCollection","features":[{"type":"Feature","properties":` +
+				props + `,"geometry":{"type":"Polygon","coordinates":` + coords + `}}]}`
+		}
+		const (
+			accra  = `[[[-0.3,5.5],[-0.1,5.5],[-0.1,5.7],[-0.3,5.7],[-0.3,5.5]]]`
+			cw     = `[[[-0.3,5.5],[-0.3,5.7],[-0.1,5.7],[-0.1,5.5],[-0.3,5.5]]]`
+			bowtie = `[[[-0.3,5.5],[-0.1,5.7],[-0.1,5.5],[-0.3,5.7],[-0.3,5.5]]]`
+			ocean  = `[[[-30,-30],[-29,-30],[-29,-29],[-30,-29],[-30,-30]]]`
+		)
+		files := map[string]string{
+			"a_valid.geojson":     feature(`{"name":"Valid"}`, accra),
+			"b_duplicate.geojson": feature(`{"name":"Valid"}`, accra),
+			"c_unnamed.geojson":   feature(`{"population":1}`, accra),
+			"d_clockwise.geojson": feature(`{"name":"Clockwise"}`, cw),
+			"e_bowtie.geojson":    feature(`{"name":"Bowtie"}`, bowtie),
+			"f_ocean.geojson":     feature(`{"name":"Ocean"}`, ocean),
+			"g_malformed.geojson": `{"type":"FeatureCollection","features":[`,
+		}
+		for name, data := range files {
+			if err := ioutil.WriteFile(filepath.Join(dir, name), []byte(data), 0644); err != nil {
+				t.Fatal(err)
+			}
+		}
+		c := &CityAQ{
+			CityGeomDir: dir,
+			SpatialConfig: aeputil.SpatialConfig{
+				SrgShapefileDirectory: "testdata",
+			},
+		}
+		problems, err := c.ValidateCities()
+		if err != nil {
+			t.Fatal(err)
+		}
+		have := make([]string, len(problems))
+		for i, p := range problems {
+			have[i] = filepath.Base(p.File)
+		}
+		want := []string{
+			"b_duplicate.geojson",
+			"b_duplicate.geojson",
+			"c_unnamed.geojson",
+			"d_clockwise.geojson",
+			"e_bowtie.geojson",
+			"f_ocean.geojson",
+			"g_malformed.geojson",
+		}
+		if !reflect.DeepEqual(have, want) {
+			t.Errorf("%v != %v", problems, want)
+		}
+	})
+}